@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// blockClient is the part of the client used to find who to block
+type blockClient interface {
+	FindContactByEmail(ctx context.Context, email string) (*client.Contact, error)
+	ListContactRequests(ctx context.Context) (*client.ContactRequestList, error)
+	ListBlocked(ctx context.Context) (*client.BlockedList, error)
+}
+
+// resolveBlockTarget returns the user ID for a block. An email is looked up
+// among contacts, then pending requests in either direction, since the
+// server only blocks by ID.
+func resolveBlockTarget(ctx context.Context, c blockClient, idOrEmail string) (string, error) {
+	if !strings.Contains(idOrEmail, "@") {
+		return idOrEmail, nil
+	}
+
+	contact, err := c.FindContactByEmail(ctx, idOrEmail)
+	if err == nil {
+		return contact.Id, nil
+	}
+	if !errors.Is(err, client.ErrNotFound) {
+		return "", fmt.Errorf("looking up contact: %w", err)
+	}
+
+	requests, err := c.ListContactRequests(ctx)
+	if err != nil {
+		return "", fmt.Errorf("listing requests: %w", err)
+	}
+	for _, req := range append(requests.Incoming, requests.Outgoing...) {
+		if strings.EqualFold(string(req.Email), idOrEmail) && req.UserId != nil {
+			return *req.UserId, nil
+		}
+	}
+	return "", fmt.Errorf("no contact or pending request with %s", idOrEmail)
+}
+
+// resolveUnblockTarget returns the user ID of a blocked user given their
+// ID or email
+func resolveUnblockTarget(ctx context.Context, c blockClient, idOrEmail string) (string, error) {
+	if !strings.Contains(idOrEmail, "@") {
+		return idOrEmail, nil
+	}
+
+	blocked, err := c.ListBlocked(ctx)
+	if err != nil {
+		return "", fmt.Errorf("listing blocked users: %w", err)
+	}
+	for _, u := range blocked.Users {
+		if strings.EqualFold(string(u.Email), idOrEmail) {
+			return u.UserId, nil
+		}
+	}
+	return "", fmt.Errorf("%s isn't blocked", idOrEmail)
+}
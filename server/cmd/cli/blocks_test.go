@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// fakeBlocks is a blockClient with one contact, one incoming request and
+// one blocked user
+type fakeBlocks struct{}
+
+func (fakeBlocks) FindContactByEmail(ctx context.Context, email string) (*client.Contact, error) {
+	if email == "carol@example.com" {
+		return &client.Contact{Id: "carol-id", Email: client.Email(email)}, nil
+	}
+	return nil, client.ErrNotFound
+}
+
+func (fakeBlocks) ListContactRequests(ctx context.Context) (*client.ContactRequestList, error) {
+	bob := "bob-id"
+	return &client.ContactRequestList{
+		Incoming: []client.ContactRequest{{Id: "req-1", UserId: &bob, Email: "bob@example.com"}},
+	}, nil
+}
+
+func (fakeBlocks) ListBlocked(ctx context.Context) (*client.BlockedList, error) {
+	return &client.BlockedList{Users: []client.BlockedUser{{UserId: "dave-id", Email: "dave@example.com"}}}, nil
+}
+
+func TestResolveBlockTarget(t *testing.T) {
+	ctx := context.Background()
+	for arg, want := range map[string]string{
+		"some-id":           "some-id",
+		"carol@example.com": "carol-id",
+		"Bob@Example.com":   "bob-id",
+	} {
+		if got, err := resolveBlockTarget(ctx, fakeBlocks{}, arg); err != nil || got != want {
+			t.Errorf("resolveBlockTarget(%s) = %q, %v; want %q", arg, got, err, want)
+		}
+	}
+	if _, err := resolveBlockTarget(ctx, fakeBlocks{}, "eve@example.com"); err == nil {
+		t.Error("resolveBlockTarget for a stranger succeeded, want error")
+	}
+
+	if got, err := resolveUnblockTarget(ctx, fakeBlocks{}, "dave@example.com"); err != nil || got != "dave-id" {
+		t.Errorf("resolveUnblockTarget = %q, %v; want dave-id", got, err)
+	}
+	if _, err := resolveUnblockTarget(ctx, fakeBlocks{}, "bob@example.com"); err == nil {
+		t.Error("resolveUnblockTarget for someone not blocked succeeded, want error")
+	}
+}
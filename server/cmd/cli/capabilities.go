@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// capabilitySource is the part of the client used to check server features
+type capabilitySource interface {
+	Capabilities(ctx context.Context) (*client.Capabilities, error)
+}
+
+// requireFeature returns a readable error when the server doesn't support
+// a feature a command needs, instead of letting the command fail on a 404.
+// what names the feature for the user.
+func requireFeature(ctx context.Context, c capabilitySource, feature, what string) error {
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return fmt.Errorf("checking server features: %w", err)
+	}
+	if !caps.Has(feature) {
+		return fmt.Errorf("your server doesn't support %s; upgrade it", what)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+func TestRequireFeature(t *testing.T) {
+	ctx := context.Background()
+
+	// Supported
+	c := statsServer(t, map[string]string{"/capabilities": `{"features":["events","presence"]}`})
+	if err := requireFeature(ctx, c, client.FeatureEvents, "event streams"); err != nil {
+		t.Errorf("requireFeature = %v, want nil", err)
+	}
+
+	// Reported off
+	c = statsServer(t, map[string]string{"/capabilities": `{"features":["presence"]}`})
+	err := requireFeature(ctx, c, client.FeatureEvents, "event streams")
+	if err == nil || !strings.Contains(err.Error(), "your server doesn't support event streams; upgrade it") {
+		t.Errorf("requireFeature = %v, want upgrade message", err)
+	}
+
+	// Too old to report capabilities at all
+	c = statsServer(t, map[string]string{})
+	err = requireFeature(ctx, c, client.FeatureEvents, "event streams")
+	if err == nil || !strings.Contains(err.Error(), "upgrade it") {
+		t.Errorf("requireFeature on an older server = %v, want upgrade message", err)
+	}
+}
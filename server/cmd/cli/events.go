@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// errSessionRejected is returned when the server ends the event stream by
+// rejecting the session
+var errSessionRejected = errors.New("session rejected, log in again")
+
+// eventSubscriber is the part of the client used by the events tailer
+type eventSubscriber interface {
+	Subscribe(ctx context.Context, opts client.SubscribeOptions) (<-chan client.Event, error)
+}
+
+// eventTailer prints server events. The client reconnects with exponential
+// backoff when the stream drops and skips events replayed on reconnect.
+type eventTailer struct {
+	events     eventSubscriber
+	filter     string // only print events of this type (empty prints all)
+	out        io.Writer
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// run tails events until ctx is cancelled or the server rejects the session
+func (t *eventTailer) run(ctx context.Context) error {
+	events, err := t.events.Subscribe(ctx, client.SubscribeOptions{
+		MinBackoff: t.minBackoff,
+		MaxBackoff: t.maxBackoff,
+	})
+	if err != nil {
+		return err
+	}
+
+	for ev := range events {
+		if t.filter != "" && ev.Type != t.filter {
+			continue
+		}
+		fmt.Fprintln(t.out, formatEventLine(time.Now(), ev))
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return errSessionRejected
+}
+
+// formatEventLine renders one event as "time  type  data"
+func formatEventLine(at time.Time, ev client.Event) string {
+	return fmt.Sprintf("%s  %s  %s", at.Format(time.RFC3339), ev.Type, ev.Data)
+}
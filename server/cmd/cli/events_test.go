@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+func TestEventTailer_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var lastEventIDs []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		conn := len(lastEventIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch conn {
+		case 1:
+			// Send one event, then drop the connection
+			fmt.Fprint(w, "id: 1\nevent: contact_request.received\ndata: {\"from\":\"alice@example.com\"}\n\n")
+		case 2:
+			// Replay the first event before the new one
+			fmt.Fprint(w, ": keepalive\n\n")
+			fmt.Fprint(w, "id: 1\nevent: contact_request.received\ndata: {\"from\":\"alice@example.com\"}\n\n")
+			fmt.Fprint(w, "id: 2\nevent: location.shared\ndata: {\"from\":\"bob@example.com\"}\n\n")
+		default:
+			cancel()
+		}
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	tailer := &eventTailer{
+		events:     client.NewWhereishClient(client.ClientConfig{BaseURL: ts.URL, Token: "test-token"}),
+		out:        &out,
+		minBackoff: time.Millisecond,
+		maxBackoff: 10 * time.Millisecond,
+	}
+
+	if err := tailer.run(ctx); err != context.Canceled {
+		t.Fatalf("run = %v, want context.Canceled", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("printed %d lines, want 2:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], "contact_request.received") || !strings.Contains(lines[1], "location.shared") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	// Reconnects resume after the last event seen
+	if len(lastEventIDs) < 2 || lastEventIDs[1] != "1" {
+		t.Errorf("Last-Event-ID headers = %q, want second to be %q", lastEventIDs, "1")
+	}
+}
+
+func TestEventTailer_SessionRejected(t *testing.T) {
+	var conns atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conns.Add(1) > 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":{"code":"unauthorized","message":"session expired"}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\nevent: location.shared\ndata: {}\n\n")
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	tailer := &eventTailer{
+		events:     client.NewWhereishClient(client.ClientConfig{BaseURL: ts.URL, Token: "test-token"}),
+		out:        &out,
+		minBackoff: time.Millisecond,
+		maxBackoff: 10 * time.Millisecond,
+	}
+
+	if err := tailer.run(context.Background()); err != errSessionRejected {
+		t.Fatalf("run = %v, want errSessionRejected", err)
+	}
+	if !strings.Contains(out.String(), "location.shared") {
+		t.Errorf("output = %q, want the event sent before the session was rejected", out.String())
+	}
+}
+
+func TestEventTailer_Filter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := subscribeFunc(func(ctx context.Context, opts client.SubscribeOptions) (<-chan client.Event, error) {
+		events := make(chan client.Event, 2)
+		events <- client.Event{ID: "1", Type: "contact_request.received", Data: "{}"}
+		events <- client.Event{ID: "2", Type: "location.shared", Data: "{}"}
+		close(events)
+		cancel()
+		return events, nil
+	})
+
+	var out bytes.Buffer
+	tailer := &eventTailer{events: sub, filter: "location.shared", out: &out}
+	tailer.run(ctx)
+
+	if got := strings.Count(out.String(), "\n"); got != 1 || !strings.Contains(out.String(), "location.shared") {
+		t.Errorf("output = %q, want only the location.shared event", out.String())
+	}
+}
+
+// subscribeFunc adapts a function to eventSubscriber
+type subscribeFunc func(ctx context.Context, opts client.SubscribeOptions) (<-chan client.Event, error)
+
+func (f subscribeFunc) Subscribe(ctx context.Context, opts client.SubscribeOptions) (<-chan client.Event, error) {
+	return f(ctx, opts)
+}
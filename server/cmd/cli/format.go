@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// mapURL returns an OpenStreetMap link centered on the given coordinates
+func mapURL(c *crypto.Coordinates) string {
+	return fmt.Sprintf("https://www.openstreetmap.org/?mlat=%.6f&mlon=%.6f#map=15/%.6f/%.6f",
+		c.Latitude, c.Longitude, c.Latitude, c.Longitude)
+}
+
+// placePath joins hierarchy values from most to least specific.
+// Levels the CLI doesn't know about are appended in key order.
+func placePath(hierarchy map[string]string) string {
+	var parts []string
+	known := make(map[string]bool)
+	for _, level := range crypto.HierarchyLevels {
+		known[level] = true
+		if v := hierarchy[level]; v != "" {
+			parts = append(parts, v)
+		}
+	}
+
+	var extra []string
+	for k := range hierarchy {
+		if !known[k] && hierarchy[k] != "" {
+			extra = append(extra, k)
+		}
+	}
+	sort.Strings(extra)
+	for _, k := range extra {
+		parts = append(parts, hierarchy[k])
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatMapLine describes where a contact is: a map link when coordinates
+// were shared, otherwise the place path
+func formatMapLine(name string, loc *crypto.LocationData) string {
+	where := placePath(loc.Hierarchy)
+	if loc.Coordinates != nil {
+		where = mapURL(loc.Coordinates)
+	}
+	if where == "" {
+		where = "(unknown)"
+	}
+	if loc.NamedLocation != "" {
+		return fmt.Sprintf("%s [%s]: %s", name, loc.NamedLocation, where)
+	}
+	return fmt.Sprintf("%s: %s", name, where)
+}
+
+// describeSkip explains why a contact didn't receive a location share
+func describeSkip(skipped client.SkippedContact) string {
+	switch skipped.Reason {
+	case client.SkipNoPublicKey:
+		return "no public key"
+	case client.SkipEncryptFailed:
+		return fmt.Sprintf("encryption failed: %v", skipped.Err)
+	case client.SkipRejected:
+		return fmt.Sprintf("rejected by server: %v", skipped.Err)
+	case client.SkipNotContact:
+		return "no longer a contact"
+	}
+	return string(skipped.Reason)
+}
+
+// sharePrecision picks the precision label for a shared location from its
+// most specific part: coordinates or anything below city level is exact
+func sharePrecision(loc *crypto.LocationData) client.LocationPrecision {
+	if loc.Coordinates != nil {
+		return client.Exact
+	}
+	for _, level := range []string{"address", "street", "neighborhood"} {
+		if loc.Hierarchy[level] != "" {
+			return client.Exact
+		}
+	}
+	for _, level := range []string{"city", "county"} {
+		if loc.Hierarchy[level] != "" {
+			return client.City
+		}
+	}
+	if len(loc.Hierarchy) > 0 {
+		return client.Country
+	}
+	return client.Exact
+}
+
+// locationTime formats when a location was recorded: the sender's
+// timestamp when it parses, otherwise when the server last stored it
+func locationTime(loc *crypto.LocationData, updatedAt time.Time) string {
+	if t, err := time.Parse(time.RFC3339, loc.Timestamp); err == nil {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return updatedAt.Local().Format("2006-01-02 15:04")
+}
+
+// parsePrecision splits a --precision value, <id|email>=<level>, checking
+// the level is one the hierarchy knows
+func parsePrecision(arg string) (who, level string, err error) {
+	who, level, ok := strings.Cut(arg, "=")
+	if !ok || who == "" {
+		return "", "", fmt.Errorf("invalid --precision %q (use <id|email>=<level>)", arg)
+	}
+	if crypto.LevelsFrom(level) == nil {
+		return "", "", fmt.Errorf("unknown level %q (use one of %s)", level, strings.Join(crypto.HierarchyLevels, ", "))
+	}
+	return who, level, nil
+}
+
+// contactShareOptions limits each contact ID in maxLevels to that level and
+// coarser, labelling their share with the precision of what's left
+func contactShareOptions(loc *crypto.LocationData, maxLevels map[string]string) map[string]client.ContactShareOptions {
+	opts := make(map[string]client.ContactShareOptions)
+	for id, level := range maxLevels {
+		levels := crypto.LevelsFrom(level)
+		opts[id] = client.ContactShareOptions{
+			Levels:    levels,
+			Precision: sharePrecision(loc.Restrict(levels)),
+		}
+	}
+	return opts
+}
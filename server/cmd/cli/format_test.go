@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+func TestFormatMapLine_Coordinates(t *testing.T) {
+	loc := &crypto.LocationData{
+		Hierarchy:   map[string]string{"city": "Seattle"},
+		Coordinates: &crypto.Coordinates{Latitude: 47.6062, Longitude: -122.3321},
+	}
+
+	got := formatMapLine("Alice", loc)
+	want := "Alice: https://www.openstreetmap.org/?mlat=47.606200&mlon=-122.332100#map=15/47.606200/-122.332100"
+	if got != want {
+		t.Errorf("formatMapLine = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMapLine_HierarchyOnly(t *testing.T) {
+	loc := &crypto.LocationData{
+		Hierarchy: map[string]string{
+			"country":      "USA",
+			"city":         "Seattle",
+			"state":        "Washington",
+			"neighborhood": "Fremont",
+		},
+		NamedLocation: "Work",
+	}
+
+	got := formatMapLine("Bob", loc)
+	want := "Bob [Work]: Fremont, Seattle, Washington, USA"
+	if got != want {
+		t.Errorf("formatMapLine = %q, want %q", got, want)
+	}
+}
+
+func TestSharePrecision(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  *crypto.LocationData
+		want client.LocationPrecision
+	}{
+		{"coordinates", &crypto.LocationData{Hierarchy: map[string]string{"country": "USA"}, Coordinates: &crypto.Coordinates{}}, client.Exact},
+		{"street", &crypto.LocationData{Hierarchy: map[string]string{"street": "Main St", "city": "Seattle"}}, client.Exact},
+		{"city", &crypto.LocationData{Hierarchy: map[string]string{"city": "Seattle", "state": "Washington"}}, client.City},
+		{"state", &crypto.LocationData{Hierarchy: map[string]string{"state": "Washington", "country": "USA"}}, client.Country},
+		{"empty", &crypto.LocationData{}, client.Exact},
+	}
+	for _, tt := range tests {
+		if got := sharePrecision(tt.loc); got != tt.want {
+			t.Errorf("%s: sharePrecision = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLocationTime(t *testing.T) {
+	updated := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	sent := time.Date(2025, 3, 1, 11, 30, 0, 0, time.UTC)
+
+	got := locationTime(&crypto.LocationData{Timestamp: sent.Format(time.RFC3339)}, updated)
+	if want := sent.Local().Format("2006-01-02 15:04"); got != want {
+		t.Errorf("locationTime = %q, want the sender's timestamp %q", got, want)
+	}
+
+	got = locationTime(&crypto.LocationData{Timestamp: "garbage"}, updated)
+	if want := updated.Local().Format("2006-01-02 15:04"); got != want {
+		t.Errorf("locationTime = %q, want the server's time %q", got, want)
+	}
+}
+
+func TestContactShareOptions(t *testing.T) {
+	loc := &crypto.LocationData{
+		Hierarchy:   map[string]string{"street": "Main St", "city": "Seattle", "state": "Washington"},
+		Coordinates: &crypto.Coordinates{},
+	}
+
+	who, level, err := parsePrecision("bob@example.com=city")
+	if err != nil || who != "bob@example.com" || level != "city" {
+		t.Fatalf("parsePrecision = %q, %q, %v", who, level, err)
+	}
+	for _, bad := range []string{"city", "=city", "bob-id=galaxy"} {
+		if _, _, err := parsePrecision(bad); err == nil {
+			t.Errorf("parsePrecision(%q) succeeded, want error", bad)
+		}
+	}
+
+	opts := contactShareOptions(loc, map[string]string{"bob-id": "city", "carol-id": "state"})
+	if got := opts["bob-id"]; got.Precision != client.City || got.Levels[0] != "city" {
+		t.Errorf("bob-id options = %+v, want city precision from city up", got)
+	}
+	if got := opts["carol-id"]; got.Precision != client.Country {
+		t.Errorf("carol-id precision = %q, want %q", got.Precision, client.Country)
+	}
+}
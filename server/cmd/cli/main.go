@@ -5,9 +5,13 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/tabwriter"
@@ -21,6 +25,10 @@ import (
 // Config file location - can be overridden with CONFIG env var
 var configPath = getConfigPath()
 
+// defaultLocationSkew is how far a shared location's timestamp may be from
+// now before "locations map" flags it
+const defaultLocationSkew = 7 * 24 * time.Hour
+
 func getConfigPath() string {
 	if path := os.Getenv("CONFIG"); path != "" {
 		return path
@@ -30,8 +38,9 @@ func getConfigPath() string {
 
 // Config holds CLI configuration
 type config struct {
-	ServerURL string `json:"server_url"`
-	Token     string `json:"token"`
+	ServerURL    string `json:"server_url"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func main() {
@@ -58,6 +67,18 @@ func main() {
 		handleWhoami()
 	case "logout":
 		handleLogout()
+	case "recover":
+		handleRecover(args)
+	case "recovery-codes":
+		handleRecoveryCodes(args)
+	case "usage":
+		handleUsage()
+	case "stats":
+		handleStats()
+	case "settings":
+		handleSettings(args)
+	case "profile":
+		handleProfile(args)
 	case "contacts":
 		handleContacts(args)
 	case "requests":
@@ -66,10 +87,14 @@ func main() {
 		handleLocations(args)
 	case "devices":
 		handleDevices(args)
+	case "sessions":
+		handleSessions(args)
 	case "identity":
 		handleIdentity(args)
 	case "data":
 		handleData(args)
+	case "events":
+		handleEvents(args)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", cmd)
 		printUsage()
@@ -90,35 +115,82 @@ Commands:
   health                     Check server health
   whoami                     Show current user
   logout                     End session
+  recover <code>             Login with a one-time recovery code
+  recovery-codes status      Show how many recovery codes are left
+  recovery-codes generate    Replace recovery codes with a new set
+  usage                      Show storage usage
+  stats                      Show an account overview
+
+  settings show              Show account settings
+  settings set <k>=<v> ...   Update settings (discoverable, sharingEnabled,
+                             autoAcceptRequests, lastKnownMode, acceptRequests)
+
+  profile set-name <name>    Change the display name contacts see
 
   contacts list              List contacts
   contacts add <email>       Send contact request
-  contacts remove <id>       Remove contact
+  contacts remove <id|email> Remove contact
+  contacts pin <id|email>    Pin contact to the top of the list
+  contacts unpin <id|email>  Unpin contact
+  contacts note <id|email> [text]
+                             Set a private note on a contact (no text removes it)
+  contacts block <id|email>  Block a contact or someone with a pending request
+  contacts unblock <id|email>
+                             Unblock a user
+  contacts blocked           List blocked users
 
   requests list              List pending requests
   requests accept <id>       Accept contact request
   requests decline <id>      Decline contact request
   requests cancel <id>       Cancel outgoing request
+  requests resend <id>       Replace an expired outgoing request with a new one
 
-  locations get              Get locations from contacts
+  locations get              Get and decrypt locations from contacts
   locations share [k=v ...]  Share location with contacts (encrypts with NaCl)
+                             --to <id|email> shares with a single contact
+                             --precision <id|email>=<level> shares no finer than
+                             level (e.g. city) with that contact; repeatable
+                             --coords <lat,lng> includes a precise position
+                             --ttl <dur> makes the share expire, e.g. 1h
+  locations send-file --to <id|email> --blob-file <path>
+                             Upload a blob already encrypted elsewhere, as is
+  locations map              Show a map link or place for each contact
+                             --max-skew <dur> flags timestamps further from now (default 168h)
 
   devices list               List devices
   devices register <name>    Register new device
+  devices rename <id> <name> Rename device
   devices revoke <id>        Revoke device
+  devices pause <id> <dur>   Block device for a duration, e.g. 24h
+  devices unpause <id>       End a device's pause
+
+  sessions list              List active login sessions
+  sessions revoke <token>    End a session, by the token prefix shown in the list
 
   identity get               Get identity backup info
   identity backup            Generate keypair, encrypt with PIN, and upload
   identity restore           Decrypt identity backup with PIN
+  identity change-pin        Re-encrypt identity (and PIN-encrypted user data) under a new PIN
+  identity export --file <path>
+                             Write identity to a PIN-encrypted file for offline transfer
+  identity import --file <path>
+                             Read an exported identity file and upload it as your backup
   identity reset             Reset identity (with confirmation)
 
-  data get                   Get user data info
-  data set                   Set user data (not implemented - needs encryption)
+  data get [--decrypt]       Get user data info, or decrypt and print it
+  data set [--file <path>]   Encrypt a JSON object from a file or stdin and save it
+
+  events tail [--filter <type>]
+                             Print server events as they happen, e.g.
+                             contact_request.received, contact_request.accepted,
+                             location.shared
 
 Environment:
   CONFIG         Config file path (default: ~/.whereish/config.json)
   WHEREISH_URL   Server URL (overrides config)
-  WHEREISH_TOKEN Auth token (overrides config)`)
+  WHEREISH_TOKEN Auth token (overrides config)
+  WHEREISH_PIN   PIN to use when stdin isn't a terminal, for scripts and CI.
+                 Anyone who can read the environment can read the PIN.`)
 }
 
 func loadConfig() *config {
@@ -134,7 +206,9 @@ func loadConfig() *config {
 		cfg.ServerURL = url
 	}
 	if token := os.Getenv("WHEREISH_TOKEN"); token != "" {
+		// The saved refresh token belongs to a different session
 		cfg.Token = token
+		cfg.RefreshToken = ""
 	}
 
 	return cfg
@@ -157,8 +231,18 @@ func saveConfig(cfg *config) error {
 func getClient() *client.WhereishClient {
 	cfg := loadConfig()
 	return client.NewWhereishClient(client.ClientConfig{
-		BaseURL: cfg.ServerURL,
-		Token:   cfg.Token,
+		BaseURL:      cfg.ServerURL,
+		Token:        cfg.Token,
+		RefreshToken: cfg.RefreshToken,
+		UserAgent:    "whereish-cli",
+		Retries:      3,
+		OnRefresh: func(token, refreshToken string) {
+			cfg := loadConfig()
+			cfg.Token, cfg.RefreshToken = token, refreshToken
+			if err := saveConfig(cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save refreshed token: %v\n", err)
+			}
+		},
 	})
 }
 
@@ -197,6 +281,7 @@ func handleConfig(args []string) {
 		}
 		cfg := loadConfig()
 		cfg.Token = args[1]
+		cfg.RefreshToken = ""
 		if err := saveConfig(cfg); err != nil {
 			fatal("Failed to save config: %v", err)
 		}
@@ -238,17 +323,123 @@ func handleWhoami() {
 	fmt.Printf("Email: %s\n", user.Email)
 	fmt.Printf("Name: %s\n", user.Name)
 	fmt.Printf("Created: %s\n", user.CreatedAt.Format(time.RFC3339))
+	if user.LastLoginAt != nil {
+		fmt.Printf("Last Login: %s\n", user.LastLoginAt.Format(time.RFC3339))
+	}
 	if user.PublicKey != nil && *user.PublicKey != "" {
 		fmt.Printf("Public Key: %s...%s\n", (*user.PublicKey)[:8], (*user.PublicKey)[len(*user.PublicKey)-4:])
 	}
 	if user.HasIdentityBackup != nil {
 		fmt.Printf("Has Identity Backup: %v\n", *user.HasIdentityBackup)
+		if *user.HasIdentityBackup {
+			if meta, err := c.GetIdentityBackupMeta(ctx); err == nil {
+				if meta.Memory != nil && meta.Parallelism != nil {
+					fmt.Printf("Backup Encryption: %s, %s (time %d, %d KiB, %d threads)\n",
+						meta.Algorithm, meta.Kdf, meta.Iterations, *meta.Memory, *meta.Parallelism)
+				} else {
+					fmt.Printf("Backup Encryption: %s, %s (%d iterations)\n", meta.Algorithm, meta.Kdf, meta.Iterations)
+				}
+			}
+		}
 	}
 	if user.HasUserData != nil {
 		fmt.Printf("Has User Data: %v\n", *user.HasUserData)
 	}
 }
 
+func handleUsage() {
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	usage, err := c.GetStorageUsage(ctx)
+	if err != nil {
+		fatal("Failed to get usage: %v", err)
+	}
+
+	fmt.Printf("Identity Backup: %d bytes\n", usage.IdentityBackup)
+	fmt.Printf("User Data: %d bytes\n", usage.UserData)
+	fmt.Printf("Shared Locations: %d bytes\n", usage.Locations)
+	if usage.Quota > 0 {
+		fmt.Printf("Total: %d of %d bytes (%.1f%%)\n", usage.Total, usage.Quota, 100*float64(usage.Total)/float64(usage.Quota))
+	} else {
+		fmt.Printf("Total: %d bytes (no quota)\n", usage.Total)
+	}
+}
+
+func handleRecover(args []string) {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: whereish recover <code>")
+		os.Exit(1)
+	}
+
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	login, err := c.LoginWithRecoveryCode(ctx, args[0])
+	if err != nil {
+		fatal("Recovery login failed: %v", err)
+	}
+
+	cfg := loadConfig()
+	cfg.Token = login.Token
+	cfg.RefreshToken = c.RefreshToken()
+	if err := saveConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save token: %v\n", err)
+	}
+
+	fmt.Printf("Logged in as %s (%s). That recovery code is now used up.\n", login.User.Name, login.User.Email)
+}
+
+func handleRecoveryCodes(args []string) {
+	if len(args) == 0 {
+		args = []string{"status"}
+	}
+
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch args[0] {
+	case "status":
+		status, err := c.GetRecoveryCodeStatus(ctx)
+		if err != nil {
+			fatal("Failed to get recovery codes: %v", err)
+		}
+		fmt.Printf("%d unused recovery codes\n", status.Remaining)
+
+	case "generate":
+		codes, err := c.GenerateRecoveryCodes(ctx)
+		if err != nil {
+			fatal("Failed to generate recovery codes: %v", err)
+		}
+		fmt.Println("Recovery codes (each works once; any older codes no longer work):")
+		fmt.Println()
+		for _, code := range codes.Codes {
+			fmt.Printf("  %s\n", code)
+		}
+		fmt.Println()
+		fmt.Println("Store these somewhere safe. They won't be shown again.")
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown recovery-codes command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func handleStats() {
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stats, err := collectStats(ctx, c)
+	if err != nil {
+		fatal("Failed to get stats: %v", err)
+	}
+	printStats(os.Stdout, stats, time.Now())
+}
+
 func handleLogout() {
 	c := getClient()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -261,11 +452,95 @@ func handleLogout() {
 	// Clear token from config
 	cfg := loadConfig()
 	cfg.Token = ""
+	cfg.RefreshToken = ""
 	saveConfig(cfg)
 
 	fmt.Println("Logged out successfully")
 }
 
+func handleProfile(args []string) {
+	if len(args) < 2 || args[0] != "set-name" {
+		fmt.Fprintln(os.Stderr, "Usage: whereish profile set-name <name>")
+		os.Exit(1)
+	}
+
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user, err := c.UpdateProfile(ctx, strings.Join(args[1:], " "))
+	if err != nil {
+		fatal("Failed to update name: %v", err)
+	}
+	fmt.Printf("Name changed to %s\n", user.Name)
+}
+
+func handleSettings(args []string) {
+	if len(args) == 0 {
+		args = []string{"show"}
+	}
+
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var settings *client.UserSettings
+	var err error
+
+	switch args[0] {
+	case "show":
+		settings, err = c.GetSettings(ctx)
+		if err != nil {
+			fatal("Failed to get settings: %v", err)
+		}
+
+	case "set":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish settings set <name>=<true|false> ...")
+			os.Exit(1)
+		}
+		update := &client.UserSettingsUpdate{}
+		for _, arg := range args[1:] {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 {
+				fatal("Invalid setting %q (use name=true|false)", arg)
+			}
+			value, err := strconv.ParseBool(parts[1])
+			if err != nil {
+				fatal("Invalid value for %s: %s", parts[0], parts[1])
+			}
+			switch parts[0] {
+			case "discoverable":
+				update.Discoverable = &value
+			case "sharingEnabled":
+				update.SharingEnabled = &value
+			case "autoAcceptRequests":
+				update.AutoAcceptRequests = &value
+			case "lastKnownMode":
+				update.LastKnownMode = &value
+			case "acceptRequests":
+				update.AcceptRequests = &value
+			default:
+				fatal("Unknown setting: %s", parts[0])
+			}
+		}
+		settings, err = c.UpdateSettings(ctx, update)
+		if err != nil {
+			fatal("Failed to update settings: %v", err)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown settings command: %s\n", args[0])
+		os.Exit(1)
+	}
+
+	fmt.Printf("Discoverable: %v\n", settings.Discoverable)
+	fmt.Printf("Sharing Enabled: %v\n", settings.SharingEnabled)
+	fmt.Printf("Auto-Accept Requests: %v\n", settings.AutoAcceptRequests)
+	fmt.Printf("Last-Known Mode: %v\n", settings.LastKnownMode)
+	fmt.Printf("Accept Requests: %v\n", settings.AcceptRequests)
+}
+
 func handleContacts(args []string) {
 	if len(args) == 0 {
 		args = []string{"list"}
@@ -288,13 +563,18 @@ func handleContacts(args []string) {
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tSINCE")
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tSINCE\tNOTE")
 		for _, contact := range contacts.Contacts {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			note := ""
+			if contact.Note != nil {
+				note = truncate(*contact.Note, 30)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 				truncate(contact.Id, 8),
 				contact.Name,
 				contact.Email,
 				contact.CreatedAt.Format("2006-01-02"),
+				note,
 			)
 		}
 		w.Flush()
@@ -304,6 +584,20 @@ func handleContacts(args []string) {
 			fmt.Fprintln(os.Stderr, "Usage: whereish contacts add <email>")
 			os.Exit(1)
 		}
+		check, err := c.CheckContact(ctx, args[1])
+		if err != nil {
+			fatal("Failed to check contact: %v", err)
+		}
+		switch check.Status {
+		case client.AlreadyContact:
+			fatal("%s is already a contact", args[1])
+		case client.RequestPending:
+			fatal("A request with %s is already pending", args[1])
+		case client.Blocked:
+			fatal("You have blocked %s; unblock them first", args[1])
+		case client.NotAccepting:
+			fatal("%s is not accepting contact requests", args[1])
+		}
 		req, err := c.SendContactRequest(ctx, args[1])
 		if err != nil {
 			fatal("Failed to send request: %v", err)
@@ -312,14 +606,112 @@ func handleContacts(args []string) {
 
 	case "remove":
 		if len(args) < 2 {
-			fmt.Fprintln(os.Stderr, "Usage: whereish contacts remove <id>")
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts remove <id|email>")
 			os.Exit(1)
 		}
-		if err := c.RemoveContact(ctx, args[1]); err != nil {
+		if err := c.RemoveContact(ctx, resolveContactID(ctx, c, args[1])); err != nil {
 			fatal("Failed to remove contact: %v", err)
 		}
 		fmt.Println("Contact removed")
 
+	case "pin":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts pin <id|email>")
+			os.Exit(1)
+		}
+		contacts, err := c.ListContacts(ctx)
+		if err != nil {
+			fatal("Failed to list contacts: %v", err)
+		}
+		// Place newly pinned contacts after any already pinned
+		next := 0
+		for _, contact := range contacts.Contacts {
+			if contact.SortOrder != nil && *contact.SortOrder >= next {
+				next = *contact.SortOrder + 1
+			}
+		}
+		if err := c.SetContactOrder(ctx, resolveContactID(ctx, c, args[1]), &next); err != nil {
+			fatal("Failed to pin contact: %v", err)
+		}
+		fmt.Println("Contact pinned")
+
+	case "unpin":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts unpin <id|email>")
+			os.Exit(1)
+		}
+		if err := c.SetContactOrder(ctx, resolveContactID(ctx, c, args[1]), nil); err != nil {
+			fatal("Failed to unpin contact: %v", err)
+		}
+		fmt.Println("Contact unpinned")
+
+	case "note":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts note <id|email> [text]")
+			os.Exit(1)
+		}
+		note := strings.Join(args[2:], " ")
+		if err := c.SetContactNote(ctx, resolveContactID(ctx, c, args[1]), note); err != nil {
+			fatal("Failed to set note: %v", err)
+		}
+		if note == "" {
+			fmt.Println("Note removed")
+		} else {
+			fmt.Println("Note saved")
+		}
+
+	case "block":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts block <id|email>")
+			os.Exit(1)
+		}
+		if err := requireFeature(ctx, c, client.FeatureBlocking, "blocking"); err != nil {
+			fatal("%v", err)
+		}
+		userID, err := resolveBlockTarget(ctx, c, args[1])
+		if err != nil {
+			fatal("Failed to block: %v", err)
+		}
+		if err := c.BlockUser(ctx, userID); err != nil {
+			fatal("Failed to block: %v", err)
+		}
+		fmt.Println("Blocked")
+
+	case "unblock":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish contacts unblock <id|email>")
+			os.Exit(1)
+		}
+		userID, err := resolveUnblockTarget(ctx, c, args[1])
+		if err != nil {
+			fatal("Failed to unblock: %v", err)
+		}
+		if err := c.UnblockUser(ctx, userID); err != nil {
+			fatal("Failed to unblock: %v", err)
+		}
+		fmt.Println("Unblocked")
+
+	case "blocked":
+		blocked, err := c.ListBlocked(ctx)
+		if err != nil {
+			fatal("Failed to list blocked users: %v", err)
+		}
+		if len(blocked.Users) == 0 {
+			fmt.Println("No blocked users")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tBLOCKED")
+		for _, u := range blocked.Users {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				truncate(u.UserId, 8),
+				u.Name,
+				u.Email,
+				u.BlockedAt.Format("2006-01-02"),
+			)
+		}
+		w.Flush()
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown contacts command: %s\n", args[0])
 		os.Exit(1)
@@ -342,7 +734,13 @@ func handleRequests(args []string) {
 			fatal("Failed to list requests: %v", err)
 		}
 
-		if len(requests.Incoming) == 0 && len(requests.Outgoing) == 0 {
+		expired, err := expiredOutgoing(ctx, c)
+		if err != nil {
+			fatal("Failed to list requests: %v", err)
+		}
+		outgoing := append(requests.Outgoing, expired...)
+
+		if len(requests.Incoming) == 0 && len(outgoing) == 0 {
 			fmt.Println("No pending requests")
 			return
 		}
@@ -365,19 +763,21 @@ func handleRequests(args []string) {
 			w.Flush()
 		}
 
-		if len(requests.Outgoing) > 0 {
+		if len(outgoing) > 0 {
 			fmt.Println("Outgoing requests:")
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "  ID\tTO\tDATE")
-			for _, req := range requests.Outgoing {
+			fmt.Fprintln(w, "  ID\tTO\tDATE\t")
+			now := time.Now()
+			for _, req := range outgoing {
 				name := string(req.Email)
 				if req.Name != nil {
 					name = *req.Name
 				}
-				fmt.Fprintf(w, "  %s\t%s\t%s\n",
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n",
 					truncate(req.Id, 8),
 					name,
 					req.CreatedAt.Format("2006-01-02"),
+					requestNote(req, now),
 				)
 			}
 			w.Flush()
@@ -414,6 +814,17 @@ func handleRequests(args []string) {
 		}
 		fmt.Println("Request cancelled")
 
+	case "resend":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish requests resend <id>")
+			os.Exit(1)
+		}
+		req, err := resendRequest(ctx, c, args[1])
+		if err != nil {
+			fatal("Failed to resend request: %v", err)
+		}
+		fmt.Printf("Request resent to %s\n", req.Email)
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown requests command: %s\n", args[0])
 		os.Exit(1)
@@ -441,53 +852,88 @@ func handleLocations(args []string) {
 			return
 		}
 
+		contacts, err := c.ListContacts(ctx)
+		if err != nil {
+			fatal("Failed to list contacts: %v", err)
+		}
+		byID := make(map[string]client.Contact)
+		for _, contact := range contacts.Contacts {
+			byID[contact.Id] = contact
+		}
+
+		identity := unlockIdentity(ctx, c)
+
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "FROM\tUPDATED\tBLOB (truncated)")
+		fmt.Fprintln(w, "FROM\tLOCATION\tNAMED\tTIME\tPRECISION")
 		for _, loc := range locations.Locations {
-			fmt.Fprintf(w, "%s\t%s\t%s...\n",
-				truncate(loc.FromUserId, 8),
-				loc.UpdatedAt.Format("2006-01-02 15:04"),
-				truncate(loc.Blob, 20),
+			contact, ok := byID[loc.FromUserId]
+			if !ok || contact.PublicKey == "" {
+				fmt.Fprintf(os.Stderr, "Warning: skipping location from %s (no public key)\n", truncate(loc.FromUserId, 8))
+				continue
+			}
+			data, err := crypto.DecryptLocation(loc.Blob, identity, contact.PublicKey)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping location from %s (unable to decrypt: %v)\n", contact.Name, err)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				contact.Name,
+				placePath(data.Hierarchy),
+				data.NamedLocation,
+				locationTime(data, loc.UpdatedAt),
+				loc.Precision,
 			)
 		}
 		w.Flush()
-		fmt.Println("\nNote: Locations are encrypted. Decryption requires your identity key.")
 
 	case "share":
-		// First, we need the user's identity for encryption
-		// Fetch identity backup
-		backup, err := c.GetIdentityBackup(ctx)
-		if err != nil {
-			if strings.Contains(err.Error(), "not_found") {
-				fmt.Println("No identity found. Create one first:")
-				fmt.Println("  whereish identity backup")
-				return
+		// Optional --to restricts sharing to a single contact;
+		// optional --coords adds a precise position;
+		// --precision <id|email>=<level> limits what one contact sees;
+		// --ttl makes the share expire
+		var to string
+		var coords *crypto.Coordinates
+		var levels []string
+		precisions := make(map[string]string)
+		var ttl time.Duration
+		for i := 1; i < len(args); i++ {
+			if args[i] == "--to" && i+1 < len(args) {
+				to = args[i+1]
+				i++
+				continue
 			}
-			fatal("Failed to get identity backup: %v", err)
-		}
-
-		// Prompt for PIN
-		fmt.Print("Enter PIN to decrypt identity: ")
-		pin, err := readPassword()
-		if err != nil {
-			fatal("Failed to read PIN: %v", err)
-		}
-		fmt.Println()
-
-		// Convert and decrypt identity
-		cryptoBackup := &crypto.IdentityBackup{
-			Algorithm:  string(backup.Algorithm),
-			KDF:        string(backup.Kdf),
-			Iterations: backup.Iterations,
-			Salt:       backup.Salt,
-			IV:         backup.Iv,
-			Payload:    backup.Payload,
+			if args[i] == "--ttl" && i+1 < len(args) {
+				d, err := time.ParseDuration(args[i+1])
+				if err != nil || d <= 0 {
+					fatal("Invalid --ttl %q (use a duration such as 1h)", args[i+1])
+				}
+				ttl = d
+				i++
+				continue
+			}
+			if args[i] == "--precision" && i+1 < len(args) {
+				who, level, err := parsePrecision(args[i+1])
+				if err != nil {
+					fatal("%v", err)
+				}
+				precisions[who] = level
+				i++
+				continue
+			}
+			if args[i] == "--coords" && i+1 < len(args) {
+				var lat, lng float64
+				if _, err := fmt.Sscanf(args[i+1], "%g,%g", &lat, &lng); err != nil {
+					fatal("Invalid --coords %q (use lat,lng)", args[i+1])
+				}
+				coords = &crypto.Coordinates{Latitude: lat, Longitude: lng}
+				i++
+				continue
+			}
+			levels = append(levels, args[i])
 		}
 
-		identity, err := crypto.DecryptIdentity(cryptoBackup, pin)
-		if err != nil {
-			fatal("Failed to decrypt identity: %v", err)
-		}
+		// First, we need the user's identity for encryption
+		identity := unlockIdentity(ctx, c)
 
 		// Get contacts to share with
 		contacts, err := c.ListContacts(ctx)
@@ -495,17 +941,36 @@ func handleLocations(args []string) {
 			fatal("Failed to list contacts: %v", err)
 		}
 
+		if to != "" {
+			toID := resolveContactID(ctx, c, to)
+			var selected []client.Contact
+			for _, contact := range contacts.Contacts {
+				if contact.Id == toID {
+					selected = append(selected, contact)
+				}
+			}
+			if len(selected) == 0 {
+				fatal("Not a contact: %s", to)
+			}
+			contacts.Contacts = selected
+		}
+
 		if len(contacts.Contacts) == 0 {
 			fmt.Println("No contacts to share with")
 			return
 		}
 
+		maxLevels := make(map[string]string)
+		for who, level := range precisions {
+			maxLevels[resolveContactID(ctx, c, who)] = level
+		}
+
 		// Get location data from args or prompt
 		var locationData *crypto.LocationData
-		if len(args) > 1 {
+		if len(levels) > 0 {
 			// Parse from args: share <level>=<value> ...
 			hierarchy := make(map[string]string)
-			for _, arg := range args[1:] {
+			for _, arg := range levels {
 				parts := strings.SplitN(arg, "=", 2)
 				if len(parts) == 2 {
 					hierarchy[parts[0]] = parts[1]
@@ -553,37 +1018,111 @@ func handleLocations(args []string) {
 			}
 		}
 
-		// Encrypt for each contact
-		var shares []client.LocationShare
-		for _, contact := range contacts.Contacts {
-			if contact.PublicKey == "" {
-				fmt.Printf("Skipping %s (no public key)\n", contact.Name)
-				continue
+		locationData.Coordinates = coords
+
+		// Encrypt for each contact and upload
+		opts := client.DefaultShareOptions()
+		opts.Precision = sharePrecision(locationData)
+		opts.Contacts = contactShareOptions(locationData, maxLevels)
+		opts.TTL = ttl
+		report, err := c.ShareLocationEncrypted(ctx, identity, locationData, contacts.Contacts, &opts)
+		if err != nil {
+			fatal("Failed to share locations: %v", err)
+		}
+
+		for _, skipped := range report.Skipped {
+			fmt.Printf("Skipping %s (%s)\n", skipped.Contact.Name, describeSkip(skipped))
+		}
+
+		if len(report.Shared) == 0 {
+			fmt.Println("Location not shared with anyone")
+			return
+		}
+
+		fmt.Printf("Location shared with %d contact(s) at %s precision\n", len(report.Shared), opts.Precision)
+		if ttl > 0 {
+			fmt.Printf("Expires at %s\n", time.Now().Add(ttl).Local().Format("2006-01-02 15:04"))
+		}
+
+	case "send-file":
+		var to, blobFile string
+		for i := 1; i+1 < len(args); i++ {
+			switch args[i] {
+			case "--to":
+				to = args[i+1]
+				i++
+			case "--blob-file":
+				blobFile = args[i+1]
+				i++
 			}
+		}
+		if to == "" || blobFile == "" {
+			fmt.Fprintln(os.Stderr, "Usage: whereish locations send-file --to <id|email> --blob-file <path>")
+			os.Exit(1)
+		}
 
-			encrypted, err := crypto.EncryptLocation(locationData, identity, contact.PublicKey)
-			if err != nil {
-				fmt.Printf("Warning: failed to encrypt for %s: %v\n", contact.Name, err)
-				continue
+		data, err := os.ReadFile(blobFile)
+		if err != nil {
+			fatal("Failed to read blob file: %v", err)
+		}
+		contact, err := sendBlobFile(ctx, c, to, data)
+		if err != nil {
+			fatal("Failed to send blob: %v", err)
+		}
+		fmt.Printf("Blob sent to %s\n", contact.Name)
+
+	case "map":
+		// Optional --max-skew sets how far from now a sender's timestamp
+		// may be before the entry is flagged
+		maxSkew := defaultLocationSkew
+		for i := 1; i < len(args); i++ {
+			if args[i] == "--max-skew" && i+1 < len(args) {
+				d, err := time.ParseDuration(args[i+1])
+				if err != nil {
+					fatal("Invalid --max-skew %q: %v", args[i+1], err)
+				}
+				maxSkew = d
+				i++
 			}
+		}
 
-			shares = append(shares, client.LocationShare{
-				ToUserId: contact.Id,
-				Blob:     encrypted,
-			})
+		locations, err := c.GetLocations(ctx)
+		if err != nil {
+			fatal("Failed to get locations: %v", err)
 		}
 
-		if len(shares) == 0 {
-			fmt.Println("No locations to share (no contacts have public keys)")
+		if len(locations.Locations) == 0 {
+			fmt.Println("No locations shared with you")
 			return
 		}
 
-		// Upload to server
-		if err := c.ShareLocations(ctx, shares); err != nil {
-			fatal("Failed to share locations: %v", err)
+		contacts, err := c.ListContacts(ctx)
+		if err != nil {
+			fatal("Failed to list contacts: %v", err)
+		}
+		byID := make(map[string]client.Contact)
+		for _, contact := range contacts.Contacts {
+			byID[contact.Id] = contact
 		}
 
-		fmt.Printf("Location shared with %d contact(s)\n", len(shares))
+		identity := unlockIdentity(ctx, c)
+
+		for _, loc := range locations.Locations {
+			contact, ok := byID[loc.FromUserId]
+			if !ok || contact.PublicKey == "" {
+				continue
+			}
+			data, err := crypto.DecryptLocation(loc.Blob, identity, contact.PublicKey)
+			if err != nil {
+				fmt.Printf("%s: (unable to decrypt)\n", contact.Name)
+				continue
+			}
+			line := formatMapLine(contact.Name, data)
+			if err := crypto.ValidateLocationTimestamp(data, maxSkew); err != nil {
+				line += fmt.Sprintf(" [suspicious timestamp: %v]", err)
+			}
+			fmt.Println(line)
+		}
 
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown locations command: %s\n", args[0])
@@ -613,20 +1152,33 @@ func handleDevices(args []string) {
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tPLATFORM\tLAST SEEN\tSTATUS")
+		fmt.Fprintln(w, "ID\tNAME\tPLATFORM\tAPP\tLAST SEEN\tSESSIONS\tSTATUS")
 		for _, d := range devices.Devices {
 			status := "active"
+			if d.PausedUntil != nil {
+				status = "paused until " + d.PausedUntil.Local().Format("2006-01-02 15:04")
+			}
 			if d.IsRevoked != nil && *d.IsRevoked {
 				status = "revoked"
 			}
 			if d.IsCurrent != nil && *d.IsCurrent {
 				status = "current"
 			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			app := "-"
+			if d.UserAgent != nil && *d.UserAgent != "" {
+				app = truncate(*d.UserAgent, 30)
+			}
+			sessions := "-"
+			if d.ActiveSessions != nil && *d.ActiveSessions > 0 && d.SessionExpiresAt != nil {
+				sessions = fmt.Sprintf("%d, expires %s", *d.ActiveSessions, d.SessionExpiresAt.Local().Format("2006-01-02"))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 				truncate(d.Id, 8),
 				d.Name,
 				d.Platform,
+				app,
 				d.LastSeen.Format("2006-01-02 15:04"),
+				sessions,
 				status,
 			)
 		}
@@ -655,12 +1207,107 @@ func handleDevices(args []string) {
 		}
 		fmt.Println("Device revoked")
 
+	case "rename":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish devices rename <id> <name>")
+			os.Exit(1)
+		}
+		name := strings.Join(args[2:], " ")
+		if err := c.RenameDevice(ctx, args[1], name); err != nil {
+			fatal("Failed to rename device: %v", err)
+		}
+		fmt.Printf("Device renamed to %s\n", name)
+
+	case "pause":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish devices pause <id> <duration>")
+			os.Exit(1)
+		}
+		d, err := time.ParseDuration(args[2])
+		if err != nil || d <= 0 {
+			fatal("Invalid duration %q", args[2])
+		}
+		until := time.Now().Add(d)
+		if err := c.PauseDevice(ctx, args[1], until); err != nil {
+			fatal("Failed to pause device: %v", err)
+		}
+		fmt.Printf("Device paused until %s\n", until.Format("2006-01-02 15:04"))
+
+	case "unpause":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish devices unpause <id>")
+			os.Exit(1)
+		}
+		if err := c.UnpauseDevice(ctx, args[1]); err != nil {
+			fatal("Failed to unpause device: %v", err)
+		}
+		fmt.Println("Device unpaused")
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown devices command: %s\n", args[0])
 		os.Exit(1)
 	}
 }
 
+func handleSessions(args []string) {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	c := getClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := requireFeature(ctx, c, client.FeatureSessions, "session management"); err != nil {
+		fatal("%v", err)
+	}
+
+	switch args[0] {
+	case "list":
+		sessions, err := c.ListSessions(ctx)
+		if err != nil {
+			fatal("Failed to list sessions: %v", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TOKEN\tDEVICE\tCREATED\tEXPIRES\tSTATUS")
+		for _, sess := range sessions.Sessions {
+			device := "-"
+			if sess.DeviceName != nil {
+				device = *sess.DeviceName
+			} else if sess.DeviceId != nil {
+				device = truncate(*sess.DeviceId, 8)
+			}
+			status := ""
+			if sess.IsCurrent != nil && *sess.IsCurrent {
+				status = "current"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				sess.Token,
+				device,
+				sess.CreatedAt.Local().Format("2006-01-02 15:04"),
+				sess.ExpiresAt.Local().Format("2006-01-02 15:04"),
+				status,
+			)
+		}
+		w.Flush()
+
+	case "revoke":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: whereish sessions revoke <token>")
+			os.Exit(1)
+		}
+		if err := c.RevokeSession(ctx, args[1]); err != nil {
+			fatal("Failed to revoke session: %v", err)
+		}
+		fmt.Println("Session revoked")
+
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown sessions command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
 func handleIdentity(args []string) {
 	if len(args) == 0 {
 		args = []string{"get"}
@@ -686,6 +1333,9 @@ func handleIdentity(args []string) {
 		fmt.Printf("Algorithm: %s\n", backup.Algorithm)
 		fmt.Printf("KDF: %s\n", backup.Kdf)
 		fmt.Printf("Iterations: %d\n", backup.Iterations)
+		if backup.Memory != nil && backup.Parallelism != nil {
+			fmt.Printf("Memory: %d KiB, parallelism %d\n", *backup.Memory, *backup.Parallelism)
+		}
 		fmt.Printf("Salt: %s\n", truncate(backup.Salt, 20))
 		fmt.Printf("IV: %s\n", truncate(backup.Iv, 20))
 		fmt.Printf("Payload: %s...\n", truncate(backup.Payload, 40))
@@ -726,22 +1376,17 @@ func handleIdentity(args []string) {
 		}
 
 		// Encrypt identity
-		backup, err := crypto.EncryptIdentity(identity, pin)
+		backup, err := encryptBackup(identity, pin)
 		if err != nil {
 			fatal("Failed to encrypt identity: %v", err)
 		}
 
 		// Upload backup to server
-		apiBackup := &client.IdentityBackup{
-			Algorithm:  client.IdentityBackupAlgorithm(backup.Algorithm),
-			Kdf:        client.IdentityBackupKdf(backup.KDF),
-			Iterations: backup.Iterations,
-			Salt:       backup.Salt,
-			Iv:         backup.IV,
-			Payload:    backup.Payload,
-		}
-
-		if err := c.SetIdentityBackup(ctx, apiBackup); err != nil {
+		if err := c.SetIdentityBackup(ctx, fromCryptoBackup(backup)); err != nil {
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && (apiErr.Code == "backup_exists" || apiErr.Code == "version_conflict") {
+				fatal("An identity backup already exists (possibly created on another device).\nUse 'whereish identity restore' to use it instead.")
+			}
 			fatal("Failed to upload identity backup: %v", err)
 		}
 
@@ -775,18 +1420,8 @@ func handleIdentity(args []string) {
 		}
 		fmt.Println()
 
-		// Convert API backup to crypto backup
-		cryptoBackup := &crypto.IdentityBackup{
-			Algorithm:  string(backup.Algorithm),
-			KDF:        string(backup.Kdf),
-			Iterations: backup.Iterations,
-			Salt:       backup.Salt,
-			IV:         backup.Iv,
-			Payload:    backup.Payload,
-		}
-
 		// Decrypt identity
-		identity, err := crypto.DecryptIdentity(cryptoBackup, pin)
+		identity, err := crypto.DecryptIdentity(toCryptoBackup(backup), pin)
 		if err != nil {
 			fatal("Failed to decrypt identity: %v", err)
 		}
@@ -799,6 +1434,136 @@ func handleIdentity(args []string) {
 		fmt.Println("\nNote: Identity is in memory only. For persistent storage,")
 		fmt.Println("the web client stores it in secure browser storage.")
 
+	case "change-pin":
+		user, err := c.GetCurrentUser(ctx)
+		if err != nil {
+			fatal("Failed to get user: %v", err)
+		}
+
+		fmt.Print("Enter current PIN: ")
+		oldPIN, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		fmt.Print("Enter new PIN: ")
+		newPIN, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		if len(newPIN) < 4 {
+			fatal("PIN must be at least 4 characters")
+		}
+
+		fmt.Print("Confirm new PIN: ")
+		newPIN2, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		if newPIN != newPIN2 {
+			fatal("PINs do not match")
+		}
+
+		if err := changePIN(ctx, c, user.Id, oldPIN, newPIN); err != nil {
+			fatal("Failed to change PIN: %v", err)
+		}
+
+		fmt.Println("PIN changed.")
+
+	case "export":
+		path := fileFlag(args[1:], "identity export --file <path>")
+		identity := unlockIdentity(ctx, c)
+
+		fmt.Print("Enter PIN to protect the file: ")
+		pin, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		if len(pin) < 4 {
+			fatal("PIN must be at least 4 characters")
+		}
+
+		fmt.Print("Confirm PIN: ")
+		pin2, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		if pin != pin2 {
+			fatal("PINs do not match")
+		}
+
+		data, err := crypto.ExportIdentityFile(identity, pin)
+		if err != nil {
+			fatal("Failed to encrypt identity: %v", err)
+		}
+
+		// Never overwrite: the existing file may be the only other copy
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			fatal("Failed to create %s: %v", path, err)
+		}
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			fatal("Failed to write %s: %v", path, err)
+		}
+		if err := f.Close(); err != nil {
+			fatal("Failed to write %s: %v", path, err)
+		}
+
+		fmt.Printf("Identity exported to %s\n", path)
+		fmt.Println("Anyone with this file and its PIN can read locations shared with you.")
+
+	case "import":
+		path := fileFlag(args[1:], "identity import --file <path>")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fatal("Failed to read %s: %v", path, err)
+		}
+
+		fmt.Print("Enter the file's PIN: ")
+		pin, err := readPassword()
+		if err != nil {
+			fatal("Failed to read PIN: %v", err)
+		}
+		fmt.Println()
+
+		identity, err := crypto.ImportIdentityFile(data, pin)
+		if errors.Is(err, crypto.ErrInvalidIdentityFile) {
+			fatal("%s: %v", path, err)
+		}
+		if err != nil {
+			fatal("Failed to decrypt %s: %v", path, err)
+		}
+
+		// The CLI keeps no local key store, so the identity becomes this
+		// account's server backup, protected by the same PIN
+		backup, err := encryptBackup(identity, pin)
+		if err != nil {
+			fatal("Failed to encrypt identity: %v", err)
+		}
+		if err := c.SetIdentityBackup(ctx, fromCryptoBackup(backup)); err != nil {
+			var apiErr *client.APIError
+			if errors.As(err, &apiErr) && apiErr.Code == "backup_exists" {
+				fatal("This account already has an identity backup.\nUse 'whereish identity restore' to use it instead.")
+			}
+			fatal("Failed to upload identity backup: %v", err)
+		}
+		if err := c.SetPublicKey(ctx, identity.PublicKeyBase64()); err != nil {
+			fatal("Failed to register public key: %v", err)
+		}
+
+		fmt.Println("Identity imported and uploaded.")
+		fmt.Printf("Public key: %s\n", identity.PublicKeyBase64())
+
 	case "reset":
 		fmt.Println("WARNING: This will delete your encrypted identity backup from the server.")
 		fmt.Println("You will lose access to any data encrypted with your current identity.")
@@ -820,11 +1585,51 @@ func handleIdentity(args []string) {
 
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown identity command: %s\n", args[0])
-		fmt.Fprintln(os.Stderr, "Available: get, backup, restore, reset")
+		fmt.Fprintln(os.Stderr, "Available: get, backup, restore, change-pin, export, import, reset")
 		os.Exit(1)
 	}
 }
 
+func handleEvents(args []string) {
+	if len(args) == 0 || args[0] != "tail" {
+		fmt.Fprintln(os.Stderr, "Usage: whereish events tail [--filter <type>]")
+		os.Exit(1)
+	}
+
+	filter := ""
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--filter":
+			if i+1 >= len(args) {
+				fatal("--filter requires an event type")
+			}
+			filter = args[i+1]
+			i++
+		default:
+			fatal("Unknown option: %s", args[i])
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := getClient()
+	if err := requireFeature(ctx, c, client.FeatureEvents, "event streams"); err != nil {
+		fatal("%v", err)
+	}
+
+	tailer := &eventTailer{
+		events:     c,
+		filter:     filter,
+		out:        os.Stdout,
+		minBackoff: time.Second,
+		maxBackoff: time.Minute,
+	}
+	if err := tailer.run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		fatal("Event stream failed: %v", err)
+	}
+}
+
 func handleData(args []string) {
 	if len(args) == 0 {
 		args = []string{"get"}
@@ -836,6 +1641,8 @@ func handleData(args []string) {
 
 	switch args[0] {
 	case "get":
+		decrypt := len(args) > 1 && args[1] == "--decrypt"
+
 		data, err := c.GetUserData(ctx)
 		if err != nil {
 			if strings.Contains(err.Error(), "not_found") {
@@ -845,16 +1652,66 @@ func handleData(args []string) {
 			fatal("Failed to get user data: %v", err)
 		}
 
-		fmt.Printf("Version: %d\n", data.Version)
-		fmt.Printf("Updated: %s\n", data.UpdatedAt.Format(time.RFC3339))
-		if data.Blob != nil {
-			fmt.Printf("Blob: %s...\n", truncate(*data.Blob, 40))
+		if !decrypt {
+			fmt.Printf("Version: %d\n", data.Version)
+			fmt.Printf("Updated: %s\n", data.UpdatedAt.Format(time.RFC3339))
+			if data.Blob != nil {
+				fmt.Printf("Blob: %s...\n", truncate(*data.Blob, 40))
+			}
+			fmt.Println("\nNote: Use 'whereish data get --decrypt' to decrypt with your identity key.")
+			return
 		}
-		fmt.Println("\nNote: Decryption requires your identity key.")
+
+		if data.Blob == nil || *data.Blob == "" {
+			fmt.Println("User data is empty")
+			return
+		}
+		user, err := c.GetCurrentUser(ctx)
+		if err != nil {
+			fatal("Failed to get current user: %v", err)
+		}
+		identity, pin := unlockIdentityPIN(ctx, c)
+		plaintext, err := openUserData(*data.Blob, identity, pin, user.Id)
+		if err != nil {
+			fatal("Failed to decrypt user data: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Version %d, updated %s\n", data.Version, data.UpdatedAt.Format(time.RFC3339))
+		fmt.Println(string(plaintext))
 
 	case "set":
-		fmt.Println("User data update not implemented in CLI.")
-		fmt.Println("Updating user data requires client-side encryption.")
+		// The JSON object comes from --file, or stdin if not given
+		var file string
+		for i := 1; i+1 < len(args); i++ {
+			if args[i] == "--file" {
+				file = args[i+1]
+				i++
+			}
+		}
+
+		var plaintext []byte
+		var err error
+		if file != "" {
+			plaintext, err = os.ReadFile(file)
+		} else {
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				fmt.Println("Enter a JSON object, then Ctrl-D:")
+			}
+			plaintext, err = io.ReadAll(os.Stdin)
+		}
+		if err != nil {
+			fatal("Failed to read user data: %v", err)
+		}
+
+		identity := unlockIdentity(ctx, c)
+
+		data, err := setUserData(ctx, c, identity, plaintext)
+		if errors.Is(err, errUserDataConflict) {
+			fatal("Failed to set user data: %v; run the command again to overwrite it", err)
+		}
+		if err != nil {
+			fatal("Failed to set user data: %v", err)
+		}
+		fmt.Printf("User data saved (version %d)\n", data.Version)
 
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown data command: %s\n", args[0])
@@ -886,6 +1743,7 @@ func handleDevLogin(args []string) {
 	// Save token to config
 	cfg := loadConfig()
 	cfg.Token = login.Token
+	cfg.RefreshToken = c.RefreshToken()
 	if err := saveConfig(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to save token: %v\n", err)
 	}
@@ -915,12 +1773,87 @@ func handleLogin(args []string) {
 	fmt.Println("This requires the server to be running with DEV_MODE=true")
 }
 
-func readPassword() (string, error) {
-	bytes, err := term.ReadPassword(int(syscall.Stdin))
+// unlockIdentity fetches the identity backup and decrypts it with a PIN
+// read from the terminal. Exits if there is no identity or the PIN is wrong.
+func unlockIdentity(ctx context.Context, c *client.WhereishClient) *crypto.Identity {
+	identity, _ := unlockIdentityPIN(ctx, c)
+	return identity
+}
+
+// unlockIdentityPIN is unlockIdentity for callers that also need the PIN
+func unlockIdentityPIN(ctx context.Context, c *client.WhereishClient) (*crypto.Identity, string) {
+	backup, err := c.GetIdentityBackup(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "not_found") {
+			fmt.Println("No identity found. Create one first:")
+			fmt.Println("  whereish identity backup")
+			os.Exit(0)
+		}
+		fatal("Failed to get identity backup: %v", err)
+	}
+
+	// Prompt for PIN
+	fmt.Print("Enter PIN to decrypt identity: ")
+	pin, err := readPassword()
+	if err != nil {
+		fatal("Failed to read PIN: %v", err)
+	}
+	fmt.Println()
+
+	identity, err := crypto.DecryptIdentity(toCryptoBackup(backup), pin)
+	if err != nil {
+		fatal("Failed to decrypt identity: %v", err)
+	}
+	return identity, pin
+}
+
+// resolveContactID accepts either a contact ID or a contact's email and
+// returns the ID, exiting if an email doesn't match any contact
+func resolveContactID(ctx context.Context, c *client.WhereishClient, idOrEmail string) string {
+	if !strings.Contains(idOrEmail, "@") {
+		return idOrEmail
+	}
+
+	contact, err := c.FindContactByEmail(ctx, idOrEmail)
+	if errors.Is(err, client.ErrNotFound) {
+		fatal("No contact with email %s", idOrEmail)
+	}
 	if err != nil {
-		return "", err
+		fatal("Failed to look up contact: %v", err)
 	}
-	return string(bytes), nil
+	return contact.Id
+}
+
+// stdinPINs reads PINs from the terminal, or from WHEREISH_PIN when stdin
+// isn't one
+var stdinPINs = &pinReader{
+	isTerminal: term.IsTerminal(int(syscall.Stdin)),
+	readTerminal: func() (string, error) {
+		bytes, err := term.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return "", err
+		}
+		return string(bytes), nil
+	},
+	getenv: os.Getenv,
+	warn:   os.Stderr,
+}
+
+// fileFlag returns the value of --file in args, exiting with usage if it's
+// missing
+func fileFlag(args []string, usage string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--file" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Usage: whereish %s\n", usage)
+	os.Exit(1)
+	return ""
+}
+
+func readPassword() (string, error) {
+	return stdinPINs.read()
 }
 
 func truncate(s string, max int) string {
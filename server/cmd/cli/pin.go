@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// pinStore is the part of the client that holds PIN-encrypted data
+type pinStore interface {
+	GetIdentityBackup(ctx context.Context) (*client.IdentityBackup, error)
+	SetIdentityBackup(ctx context.Context, backup *client.IdentityBackup) error
+	GetUserData(ctx context.Context) (*client.UserData, error)
+	SetUserData(ctx context.Context, version int, blob string) (*client.UserData, error)
+}
+
+// errWrongPIN is returned when the current PIN doesn't unlock the backup
+var errWrongPIN = errors.New("wrong PIN")
+
+// changePIN re-encrypts the identity backup under newPIN, and the user data
+// too if it uses the PIN-derived secretbox scheme. Everything is decrypted
+// before anything is written, and the backup is restored if the user data
+// upload fails, so an error leaves the old PIN working.
+func changePIN(ctx context.Context, c pinStore, userID, oldPIN, newPIN string) error {
+	backup, err := c.GetIdentityBackup(ctx)
+	if err != nil {
+		return fmt.Errorf("get identity backup: %w", err)
+	}
+	if !crypto.VerifyPIN(toCryptoBackup(backup), oldPIN) {
+		return errWrongPIN
+	}
+	identity, err := crypto.DecryptIdentity(toCryptoBackup(backup), oldPIN)
+	if err != nil {
+		return fmt.Errorf("decrypt identity: %w", err)
+	}
+
+	// Re-encrypt user data in memory first
+	var newBlob string
+	data, err := c.GetUserData(ctx)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		data = nil
+	} else if err != nil {
+		return fmt.Errorf("get user data: %w", err)
+	}
+	if data != nil && data.Blob != nil && crypto.UserDataScheme(*data.Blob) == crypto.UserDataSchemeSecretbox {
+		salt := crypto.UserDataKeySalt(userID)
+		plaintext, err := crypto.DecryptUserDataSecretbox(*data.Blob, crypto.DeriveUserDataKey(oldPIN, salt))
+		if err != nil {
+			return fmt.Errorf("decrypt user data: %w", err)
+		}
+		if newBlob, err = crypto.EncryptUserDataSecretbox(plaintext, crypto.DeriveUserDataKey(newPIN, salt)); err != nil {
+			return fmt.Errorf("encrypt user data: %w", err)
+		}
+	}
+
+	newBackup, err := encryptBackup(identity, newPIN)
+	if err != nil {
+		return fmt.Errorf("encrypt identity: %w", err)
+	}
+
+	generation := 0
+	if backup.Generation != nil {
+		generation = *backup.Generation
+	}
+	replacement := fromCryptoBackup(newBackup)
+	replacement.Generation = &generation
+	if err := c.SetIdentityBackup(ctx, replacement); err != nil {
+		return fmt.Errorf("upload identity backup: %w", err)
+	}
+
+	if newBlob == "" {
+		return nil
+	}
+
+	if _, err := c.SetUserData(ctx, data.Version, newBlob); err != nil {
+		// Put the old backup back so the old PIN still unlocks everything
+		restore := *backup
+		next := generation + 1
+		restore.Generation = &next
+		if restoreErr := c.SetIdentityBackup(ctx, &restore); restoreErr != nil {
+			return fmt.Errorf("upload user data: %v; restoring identity backup also failed: %w", err, restoreErr)
+		}
+		return fmt.Errorf("upload user data: %w", err)
+	}
+	return nil
+}
+
+// encryptBackup encrypts the identity for upload. New backups use
+// Argon2id, which makes offline guessing of the PIN far costlier than
+// PBKDF2; older PBKDF2 backups still decrypt.
+func encryptBackup(identity *crypto.Identity, pin string) (*crypto.IdentityBackup, error) {
+	return crypto.EncryptIdentityKDF(identity, pin, crypto.KDFArgon2id)
+}
+
+// toCryptoBackup converts an API identity backup for decryption
+func toCryptoBackup(backup *client.IdentityBackup) *crypto.IdentityBackup {
+	converted := &crypto.IdentityBackup{
+		Algorithm:  string(backup.Algorithm),
+		KDF:        string(backup.Kdf),
+		Iterations: backup.Iterations,
+		Salt:       backup.Salt,
+		IV:         backup.Iv,
+		Payload:    backup.Payload,
+	}
+	if backup.Memory != nil {
+		converted.Memory = *backup.Memory
+	}
+	if backup.Parallelism != nil {
+		converted.Parallelism = *backup.Parallelism
+	}
+	return converted
+}
+
+// fromCryptoBackup converts an encrypted identity for upload
+func fromCryptoBackup(backup *crypto.IdentityBackup) *client.IdentityBackup {
+	converted := &client.IdentityBackup{
+		Algorithm:  client.IdentityBackupAlgorithm(backup.Algorithm),
+		Kdf:        client.IdentityBackupKdf(backup.KDF),
+		Iterations: backup.Iterations,
+		Salt:       backup.Salt,
+		Iv:         backup.IV,
+		Payload:    backup.Payload,
+	}
+	if backup.KDF == crypto.KDFArgon2id {
+		converted.Memory = &backup.Memory
+		converted.Parallelism = &backup.Parallelism
+	}
+	return converted
+}
+
+// pinEnvVar supplies the PIN when stdin isn't a terminal
+const pinEnvVar = "WHEREISH_PIN"
+
+// errNoPIN is returned when there's no terminal to prompt on and no PIN in
+// the environment
+var errNoPIN = errors.New("stdin is not a terminal and " + pinEnvVar + " is not set")
+
+// pinReader prompts for PINs on the terminal. When stdin isn't a terminal,
+// as in pipelines, it uses the PIN from the environment instead, warning
+// once since anything that can read the environment can read the PIN.
+type pinReader struct {
+	isTerminal   bool
+	readTerminal func() (string, error)
+	getenv       func(string) string
+	warn         io.Writer
+	warned       bool
+}
+
+func (p *pinReader) read() (string, error) {
+	if p.isTerminal {
+		return p.readTerminal()
+	}
+
+	pin := p.getenv(pinEnvVar)
+	if pin == "" {
+		return "", errNoPIN
+	}
+	if !p.warned {
+		fmt.Fprintf(p.warn, "\nWarning: using the PIN from %s. Anyone who can read this process's environment can read it.\n", pinEnvVar)
+		p.warned = true
+	}
+	return pin, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// fakePinStore keeps an identity backup and user data in memory
+type fakePinStore struct {
+	backup             client.IdentityBackup
+	generation         int
+	data               *client.UserData
+	failUserDataUpload bool
+}
+
+func (f *fakePinStore) GetIdentityBackup(ctx context.Context) (*client.IdentityBackup, error) {
+	backup := f.backup
+	generation := f.generation
+	backup.Generation = &generation
+	return &backup, nil
+}
+
+func (f *fakePinStore) SetIdentityBackup(ctx context.Context, backup *client.IdentityBackup) error {
+	if backup.Generation == nil || *backup.Generation != f.generation {
+		return &client.APIError{StatusCode: http.StatusConflict, Code: "version_conflict"}
+	}
+	f.backup = *backup
+	f.backup.Generation = nil
+	f.generation++
+	return nil
+}
+
+func (f *fakePinStore) GetUserData(ctx context.Context) (*client.UserData, error) {
+	if f.data == nil {
+		return nil, &client.APIError{StatusCode: http.StatusNotFound, Code: "not_found"}
+	}
+	data := *f.data
+	return &data, nil
+}
+
+func (f *fakePinStore) SetUserData(ctx context.Context, version int, blob string) (*client.UserData, error) {
+	if f.failUserDataUpload {
+		return nil, errors.New("connection reset")
+	}
+	if version != f.data.Version {
+		return nil, &client.APIError{StatusCode: http.StatusConflict, Code: "version_conflict"}
+	}
+	f.data = &client.UserData{Version: version + 1, Blob: &blob}
+	return f.data, nil
+}
+
+// newFakePinStore returns a store holding an identity backup and secretbox
+// user data, both encrypted under pin
+func newFakePinStore(t *testing.T, userID, pin string) *fakePinStore {
+	t.Helper()
+
+	identity, err := crypto.GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+	backup, err := crypto.EncryptIdentity(identity, pin)
+	if err != nil {
+		t.Fatalf("EncryptIdentity failed: %v", err)
+	}
+	blob, err := crypto.EncryptUserDataSecretbox([]byte("places"), crypto.DeriveUserDataKey(pin, crypto.UserDataKeySalt(userID)))
+	if err != nil {
+		t.Fatalf("EncryptUserDataSecretbox failed: %v", err)
+	}
+
+	return &fakePinStore{
+		backup:     *fromCryptoBackup(backup),
+		generation: 1,
+		data:       &client.UserData{Version: 1, Blob: &blob},
+	}
+}
+
+// unlocks reports whether pin decrypts both the identity and user data
+func (f *fakePinStore) unlocks(userID, pin string) bool {
+	if _, err := crypto.DecryptIdentity(toCryptoBackup(&f.backup), pin); err != nil {
+		return false
+	}
+	key := crypto.DeriveUserDataKey(pin, crypto.UserDataKeySalt(userID))
+	plaintext, err := crypto.DecryptUserDataSecretbox(*f.data.Blob, key)
+	return err == nil && string(plaintext) == "places"
+}
+
+func TestChangePIN(t *testing.T) {
+	st := newFakePinStore(t, "user-1", "1234")
+
+	if err := changePIN(context.Background(), st, "user-1", "1234", "5678"); err != nil {
+		t.Fatalf("changePIN failed: %v", err)
+	}
+
+	if !st.unlocks("user-1", "5678") {
+		t.Error("new PIN doesn't unlock identity and user data")
+	}
+	if _, err := crypto.DecryptIdentity(toCryptoBackup(&st.backup), "1234"); err == nil {
+		t.Error("old PIN still unlocks identity")
+	}
+
+	// The PBKDF2 backup is replaced with an Argon2id one
+	if st.backup.Kdf != crypto.KDFArgon2id || st.backup.Memory == nil || st.backup.Parallelism == nil {
+		t.Errorf("new backup KDF = %s, memory %v, parallelism %v; want argon2id with parameters",
+			st.backup.Kdf, st.backup.Memory, st.backup.Parallelism)
+	}
+}
+
+func TestChangePIN_WrongPIN(t *testing.T) {
+	st := newFakePinStore(t, "user-1", "1234")
+
+	if err := changePIN(context.Background(), st, "user-1", "0000", "5678"); !errors.Is(err, errWrongPIN) {
+		t.Fatalf("err = %v, want errWrongPIN", err)
+	}
+	if st.generation != 1 {
+		t.Error("identity backup was written despite wrong PIN")
+	}
+}
+
+func TestChangePIN_UserDataFailureRestoresBackup(t *testing.T) {
+	st := newFakePinStore(t, "user-1", "1234")
+	st.failUserDataUpload = true
+
+	if err := changePIN(context.Background(), st, "user-1", "1234", "5678"); err == nil {
+		t.Fatal("expected error when the user data upload fails")
+	}
+
+	if !st.unlocks("user-1", "1234") {
+		t.Error("old PIN no longer unlocks identity and user data")
+	}
+}
+
+func TestPinReader_EnvWithoutTerminal(t *testing.T) {
+	var warn bytes.Buffer
+	p := &pinReader{
+		readTerminal: func() (string, error) {
+			t.Fatal("read from terminal when stdin isn't one")
+			return "", nil
+		},
+		getenv: func(key string) string {
+			if key == pinEnvVar {
+				return "1234"
+			}
+			return ""
+		},
+		warn: &warn,
+	}
+
+	// Backup prompts twice (PIN and confirmation); both get the env PIN
+	for i := 0; i < 2; i++ {
+		pin, err := p.read()
+		if err != nil {
+			t.Fatalf("read failed: %v", err)
+		}
+		if pin != "1234" {
+			t.Errorf("pin = %q, want %q", pin, "1234")
+		}
+	}
+	if strings.Count(warn.String(), "Warning") != 1 {
+		t.Errorf("warning = %q, want exactly one warning", warn.String())
+	}
+
+	// The env PIN unlocks a backup made with it
+	st := newFakePinStore(t, "user-1", "1234")
+	pin, _ := p.read()
+	if !st.unlocks("user-1", pin) {
+		t.Error("env PIN doesn't unlock identity")
+	}
+}
+
+func TestPinReader_NoTerminalNoEnv(t *testing.T) {
+	p := &pinReader{
+		getenv: func(string) string { return "" },
+		warn:   &bytes.Buffer{},
+	}
+	if _, err := p.read(); !errors.Is(err, errNoPIN) {
+		t.Errorf("err = %v, want errNoPIN", err)
+	}
+}
+
+func TestPinReader_TerminalIgnoresEnv(t *testing.T) {
+	p := &pinReader{
+		isTerminal:   true,
+		readTerminal: func() (string, error) { return "5678", nil },
+		getenv:       func(string) string { return "1234" },
+		warn:         &bytes.Buffer{},
+	}
+	pin, err := p.read()
+	if err != nil || pin != "5678" {
+		t.Errorf("read = %q, %v; want terminal PIN %q", pin, err, "5678")
+	}
+}
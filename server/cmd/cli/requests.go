@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+const (
+	// requestExpiry is how long the server keeps a contact request pending
+	requestExpiry = 30 * 24 * time.Hour
+
+	// requestExpiryWarning is how close to expiring an outgoing request is
+	// before the list points it out
+	requestExpiryWarning = 3 * 24 * time.Hour
+)
+
+// requestClient is the part of the client used to resend requests
+type requestClient interface {
+	GetContactRequestHistory(ctx context.Context, cursor string, limit int) (*client.ContactRequestHistory, error)
+	CancelContactRequest(ctx context.Context, requestID string) error
+	SendContactRequest(ctx context.Context, email string) (*client.ContactRequest, error)
+}
+
+// requestNote describes when an outgoing request expires, or that it has.
+// It's empty for requests with plenty of time left.
+func requestNote(req client.ContactRequest, now time.Time) string {
+	if req.Status == client.Expired {
+		return "expired; resend with: whereish requests resend " + truncate(req.Id, 8)
+	}
+	left := req.CreatedAt.Add(requestExpiry).Sub(now)
+	switch {
+	case left <= 0:
+		return "expiring"
+	case left < 24*time.Hour:
+		return fmt.Sprintf("expires in %dh", int(left.Hours())+1)
+	case left < requestExpiryWarning:
+		return fmt.Sprintf("expires in %dd", int(left.Hours()/24)+1)
+	}
+	return ""
+}
+
+// expiredOutgoing returns the user's outgoing requests that expired
+// without an answer, reading the whole request history
+func expiredOutgoing(ctx context.Context, c requestClient) ([]client.ContactRequest, error) {
+	var expired []client.ContactRequest
+	cursor := ""
+	for {
+		page, err := c.GetContactRequestHistory(ctx, cursor, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range page.Requests {
+			if req.Status == client.Expired && req.Direction != nil && *req.Direction == client.Outgoing {
+				expired = append(expired, req)
+			}
+		}
+		if page.NextCursor == nil {
+			return expired, nil
+		}
+		cursor = *page.NextCursor
+	}
+}
+
+// resendRequest replaces an expired outgoing request with a fresh one to
+// the same person. id may be the full request ID or a prefix of it, as
+// shown by requests list.
+func resendRequest(ctx context.Context, c requestClient, id string) (*client.ContactRequest, error) {
+	expired, err := expiredOutgoing(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("reading request history: %w", err)
+	}
+
+	var match *client.ContactRequest
+	for i := range expired {
+		if strings.HasPrefix(expired[i].Id, id) {
+			if match != nil {
+				return nil, fmt.Errorf("%q matches more than one request; use more of the ID", id)
+			}
+			match = &expired[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no expired outgoing request %q", id)
+	}
+
+	if err := c.CancelContactRequest(ctx, match.Id); err != nil {
+		return nil, fmt.Errorf("cancelling expired request: %w", err)
+	}
+	req, err := c.SendContactRequest(ctx, string(match.Email))
+	if err != nil {
+		return nil, fmt.Errorf("sending new request: %w", err)
+	}
+	return req, nil
+}
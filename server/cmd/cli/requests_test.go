@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// fakeRequests is a requestClient over an in-memory request history
+type fakeRequests struct {
+	history   []client.ContactRequest
+	cancelled []string
+	sent      []string
+}
+
+func (f *fakeRequests) GetContactRequestHistory(ctx context.Context, cursor string, limit int) (*client.ContactRequestHistory, error) {
+	// One request per page, to exercise paging
+	i := 0
+	if cursor != "" {
+		fmt.Sscan(cursor, &i)
+	}
+	page := &client.ContactRequestHistory{Requests: f.history[i : i+1]}
+	if i+1 < len(f.history) {
+		next := fmt.Sprint(i + 1)
+		page.NextCursor = &next
+	}
+	return page, nil
+}
+
+func (f *fakeRequests) CancelContactRequest(ctx context.Context, requestID string) error {
+	f.cancelled = append(f.cancelled, requestID)
+	return nil
+}
+
+func (f *fakeRequests) SendContactRequest(ctx context.Context, email string) (*client.ContactRequest, error) {
+	f.sent = append(f.sent, email)
+	return &client.ContactRequest{Id: "new-request", Email: client.Email(email), Status: client.Pending}, nil
+}
+
+func historyRequest(id, email string, status client.ContactRequestStatus, direction client.ContactRequestDirection) client.ContactRequest {
+	return client.ContactRequest{Id: id, Email: client.Email(email), Status: status, Direction: &direction}
+}
+
+func TestResendRequest(t *testing.T) {
+	f := &fakeRequests{history: []client.ContactRequest{
+		historyRequest("aaaa1111-declined", "carol@example.com", client.Declined, client.Outgoing),
+		historyRequest("bbbb2222-incoming", "dave@example.com", client.Expired, client.Incoming),
+		historyRequest("cccc3333-expired", "bob@example.com", client.Expired, client.Outgoing),
+	}}
+
+	req, err := resendRequest(context.Background(), f, "cccc3333")
+	if err != nil {
+		t.Fatalf("resendRequest failed: %v", err)
+	}
+	if req.Email != "bob@example.com" {
+		t.Errorf("new request to %s, want bob@example.com", req.Email)
+	}
+	if fmt.Sprint(f.cancelled) != "[cccc3333-expired]" || fmt.Sprint(f.sent) != "[bob@example.com]" {
+		t.Errorf("cancelled %v and sent %v, want the expired request replaced", f.cancelled, f.sent)
+	}
+
+	// Only expired outgoing requests can be resent
+	for _, id := range []string{"aaaa1111", "bbbb2222", "zzzz"} {
+		if _, err := resendRequest(context.Background(), f, id); err == nil {
+			t.Errorf("resendRequest(%s) succeeded, want error", id)
+		}
+	}
+	if len(f.cancelled) != 1 {
+		t.Errorf("cancelled %v, want nothing more", f.cancelled)
+	}
+}
+
+func TestRequestNote(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		req  client.ContactRequest
+		want string
+	}{
+		{client.ContactRequest{Status: client.Pending, CreatedAt: now}, ""},
+		{client.ContactRequest{Status: client.Pending, CreatedAt: now.Add(-requestExpiry + 36*time.Hour)}, "expires in 2d"},
+		{client.ContactRequest{Status: client.Pending, CreatedAt: now.Add(-requestExpiry + 90*time.Minute)}, "expires in 2h"},
+		{client.ContactRequest{Status: client.Expired, Id: "cccc3333-expired"}, "expired; resend with: whereish requests resend cccc3333"},
+	}
+	for _, tt := range tests {
+		if got := requestNote(tt.req, now); got != tt.want {
+			t.Errorf("requestNote(%s, created %s) = %q, want %q", tt.req.Status, now.Sub(tt.req.CreatedAt), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// blobSender is the part of the client `locations send-file` uses
+type blobSender interface {
+	ListContacts(ctx context.Context) (*client.ContactList, error)
+	ShareLocations(ctx context.Context, locations []client.LocationShare) error
+}
+
+// sendBlobFile uploads a blob encrypted elsewhere to one contact, named by
+// ID or email. The blob must be base64 and is sent as read, minus
+// surrounding whitespace; the CLI doesn't encrypt or inspect it.
+func sendBlobFile(ctx context.Context, c blobSender, to string, data []byte) (*client.Contact, error) {
+	blob := strings.TrimSpace(string(data))
+	if blob == "" {
+		return nil, errors.New("blob file is empty")
+	}
+	if _, err := base64.StdEncoding.DecodeString(blob); err != nil {
+		return nil, fmt.Errorf("blob isn't base64: %w", err)
+	}
+
+	contacts, err := c.ListContacts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list contacts: %w", err)
+	}
+	var recipient *client.Contact
+	for i, contact := range contacts.Contacts {
+		if contact.Id == to || strings.EqualFold(string(contact.Email), strings.TrimSpace(to)) {
+			recipient = &contacts.Contacts[i]
+			break
+		}
+	}
+	if recipient == nil {
+		return nil, fmt.Errorf("not a contact: %s", to)
+	}
+
+	share := client.LocationShare{ToUserId: recipient.Id, Blob: blob}
+	if err := c.ShareLocations(ctx, []client.LocationShare{share}); err != nil {
+		return nil, err
+	}
+	return recipient, nil
+}
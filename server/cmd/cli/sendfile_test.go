@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// fakeBlobSender records shares made through it
+type fakeBlobSender struct {
+	contacts []client.Contact
+	shared   []client.LocationShare
+}
+
+func (f *fakeBlobSender) ListContacts(ctx context.Context) (*client.ContactList, error) {
+	return &client.ContactList{Contacts: f.contacts}, nil
+}
+
+func (f *fakeBlobSender) ShareLocations(ctx context.Context, locations []client.LocationShare) error {
+	f.shared = append(f.shared, locations...)
+	return nil
+}
+
+func newFakeBlobSender() *fakeBlobSender {
+	return &fakeBlobSender{contacts: []client.Contact{
+		{Id: "alice-id", Name: "Alice", Email: "alice@example.com"},
+		{Id: "bob-id", Name: "Bob", Email: "bob@example.com"},
+	}}
+}
+
+func TestSendBlobFile(t *testing.T) {
+	const blob = "c2VhbGVkIGJ5IGFub3RoZXIgY2xpZW50"
+
+	for _, to := range []string{"bob-id", "Bob@example.com"} {
+		f := newFakeBlobSender()
+		contact, err := sendBlobFile(context.Background(), f, to, []byte(blob+"\n"))
+		if err != nil {
+			t.Fatalf("sendBlobFile(%q) failed: %v", to, err)
+		}
+		if contact.Id != "bob-id" {
+			t.Errorf("recipient = %s, want bob-id", contact.Id)
+		}
+		if len(f.shared) != 1 || f.shared[0].ToUserId != "bob-id" || f.shared[0].Blob != blob {
+			t.Errorf("shared = %+v, want the blob to bob-id only", f.shared)
+		}
+		if f.shared[0].Precision != nil {
+			t.Errorf("precision = %v, want unset", *f.shared[0].Precision)
+		}
+	}
+}
+
+func TestSendBlobFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name, to, blob, want string
+	}{
+		{"not base64", "bob-id", "not base64!", "base64"},
+		{"empty", "bob-id", "\n", "empty"},
+		{"not a contact", "carol@example.com", "YmxvYg==", "not a contact"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeBlobSender()
+			_, err := sendBlobFile(context.Background(), f, tt.to, []byte(tt.blob))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.want)
+			}
+			if len(f.shared) != 0 {
+				t.Errorf("shared = %+v, want nothing", f.shared)
+			}
+		})
+	}
+}
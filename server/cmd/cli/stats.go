@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// statsSource is the part of the client the stats dashboard reads from
+type statsSource interface {
+	GetContactSummary(ctx context.Context) (*client.ContactSummary, error)
+	ListDevices(ctx context.Context) (*client.DeviceList, error)
+	GetStorageUsage(ctx context.Context) (*client.StorageUsage, error)
+	GetSettings(ctx context.Context) (*client.UserSettings, error)
+}
+
+// accountStats is the data behind the stats dashboard. A nil field means
+// the server doesn't offer that endpoint.
+type accountStats struct {
+	Summary  *client.ContactSummary
+	Devices  *client.DeviceList
+	Usage    *client.StorageUsage
+	Settings *client.UserSettings
+}
+
+// collectStats fetches everything the dashboard shows. Endpoints an older
+// server doesn't have are left out rather than failing the whole command.
+func collectStats(ctx context.Context, c statsSource) (*accountStats, error) {
+	var stats accountStats
+	var err error
+	if stats.Summary, err = optional(c.GetContactSummary(ctx)); err != nil {
+		return nil, fmt.Errorf("contact summary: %w", err)
+	}
+	if stats.Devices, err = optional(c.ListDevices(ctx)); err != nil {
+		return nil, fmt.Errorf("devices: %w", err)
+	}
+	if stats.Usage, err = optional(c.GetStorageUsage(ctx)); err != nil {
+		return nil, fmt.Errorf("storage usage: %w", err)
+	}
+	if stats.Settings, err = optional(c.GetSettings(ctx)); err != nil {
+		return nil, fmt.Errorf("settings: %w", err)
+	}
+	return &stats, nil
+}
+
+// optional turns "endpoint not found" into a nil result
+func optional[T any](v *T, err error) (*T, error) {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusNotImplemented) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// printStats writes the dashboard
+func printStats(w io.Writer, stats *accountStats, now time.Time) {
+	const unavailable = "not supported by this server"
+
+	if s := stats.Summary; s != nil {
+		fmt.Fprintf(w, "Contacts:  %d (%d with keys)\n", s.Contacts, s.WithPublicKey)
+		fmt.Fprintf(w, "Requests:  %d incoming, %d outgoing\n", s.PendingIncoming, s.PendingOutgoing)
+	} else {
+		fmt.Fprintf(w, "Contacts:  %s\n", unavailable)
+	}
+
+	if stats.Devices != nil {
+		active, paused, revoked := 0, 0, 0
+		for _, d := range stats.Devices.Devices {
+			switch {
+			case d.IsRevoked != nil && *d.IsRevoked:
+				revoked++
+			case d.PausedUntil != nil && d.PausedUntil.After(now):
+				paused++
+			default:
+				active++
+			}
+		}
+		fmt.Fprintf(w, "Devices:   %d active, %d paused, %d revoked\n", active, paused, revoked)
+	} else {
+		fmt.Fprintf(w, "Devices:   %s\n", unavailable)
+	}
+
+	if u := stats.Usage; u != nil {
+		if u.Quota > 0 {
+			fmt.Fprintf(w, "Storage:   %d of %d bytes (%.1f%%)\n", u.Total, u.Quota, 100*float64(u.Total)/float64(u.Quota))
+		} else {
+			fmt.Fprintf(w, "Storage:   %d bytes\n", u.Total)
+		}
+	} else {
+		fmt.Fprintf(w, "Storage:   %s\n", unavailable)
+	}
+
+	fmt.Fprintf(w, "Sharing:   %s\n", sharingStatus(stats))
+}
+
+// sharingStatus summarizes whether the user is sharing and with how many
+// contacts
+func sharingStatus(stats *accountStats) string {
+	if stats.Settings != nil && !stats.Settings.SharingEnabled {
+		return "off"
+	}
+	if stats.Summary == nil {
+		if stats.Settings == nil {
+			return "unknown"
+		}
+		return "on"
+	}
+	s := stats.Summary
+	if s.SharingTo == 0 {
+		return fmt.Sprintf("not sharing with anyone, %d sharing with you", s.SharingFrom)
+	}
+	return fmt.Sprintf("sharing with %d, %d sharing with you", s.SharingTo, s.SharingFrom)
+}
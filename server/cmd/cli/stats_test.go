@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whereish/server/pkg/client"
+)
+
+// statsServer serves the aggregate endpoints from payloads keyed by path.
+// Paths without a payload return 404, like an older server.
+func statsServer(t *testing.T, payloads map[string]string) *client.WhereishClient {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := payloads[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"Not found"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+	return client.NewWhereishClient(client.ClientConfig{BaseURL: ts.URL, Token: "test-token"})
+}
+
+func TestStats(t *testing.T) {
+	now := time.Now()
+	c := statsServer(t, map[string]string{
+		"/contacts/summary": `{"contacts":3,"withPublicKey":2,"sharingTo":2,"sharingFrom":1,"pendingIncoming":1,"pendingOutgoing":0}`,
+		"/devices": `{"devices":[
+			{"id":"d1","name":"Phone","platform":"ios","createdAt":"2026-01-01T00:00:00Z","lastSeen":"2026-01-01T00:00:00Z"},
+			{"id":"d2","name":"Laptop","platform":"cli","createdAt":"2026-01-01T00:00:00Z","lastSeen":"2026-01-01T00:00:00Z","pausedUntil":"` + now.Add(time.Hour).Format(time.RFC3339) + `"},
+			{"id":"d3","name":"Old","platform":"web","createdAt":"2026-01-01T00:00:00Z","lastSeen":"2026-01-01T00:00:00Z","isRevoked":true}]}`,
+		"/me/usage":    `{"identityBackup":100,"userData":300,"locations":100,"total":500,"quota":1000}`,
+		"/me/settings": `{"discoverable":true,"sharingEnabled":true,"autoAcceptRequests":false,"lastKnownMode":false,"acceptRequests":true}`,
+	})
+
+	stats, err := collectStats(context.Background(), c)
+	if err != nil {
+		t.Fatalf("collectStats failed: %v", err)
+	}
+	var out bytes.Buffer
+	printStats(&out, stats, now)
+
+	for _, want := range []string{
+		"Contacts:  3 (2 with keys)",
+		"Requests:  1 incoming, 0 outgoing",
+		"Devices:   1 active, 1 paused, 1 revoked",
+		"Storage:   500 of 1000 bytes (50.0%)",
+		"Sharing:   sharing with 2, 1 sharing with you",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestStats_OlderServer(t *testing.T) {
+	// Only devices and settings exist; sharing is turned off
+	c := statsServer(t, map[string]string{
+		"/devices":     `{"devices":[]}`,
+		"/me/settings": `{"discoverable":true,"sharingEnabled":false,"autoAcceptRequests":false,"lastKnownMode":false,"acceptRequests":true}`,
+	})
+
+	stats, err := collectStats(context.Background(), c)
+	if err != nil {
+		t.Fatalf("collectStats failed: %v", err)
+	}
+	var out bytes.Buffer
+	printStats(&out, stats, time.Now())
+
+	for _, want := range []string{
+		"Contacts:  not supported by this server",
+		"Devices:   0 active, 0 paused, 0 revoked",
+		"Storage:   not supported by this server",
+		"Sharing:   off",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestStats_OtherErrorsFail(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"unauthorized","message":"Invalid session"}}`))
+	}))
+	defer ts.Close()
+	c := client.NewWhereishClient(client.ClientConfig{BaseURL: ts.URL, Token: "expired"})
+
+	if _, err := collectStats(context.Background(), c); err == nil {
+		t.Error("expected error for an expired session")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// userDataClient is the part of the client that reads and writes user data
+type userDataClient interface {
+	GetUserData(ctx context.Context) (*client.UserData, error)
+	SetUserData(ctx context.Context, version int, blob string) (*client.UserData, error)
+}
+
+// errUserDataConflict is returned when another device updated the user data
+// between reading its version and uploading
+var errUserDataConflict = errors.New("user data was modified by another device")
+
+// currentUserData returns the stored user data, or nil if there is none
+func currentUserData(ctx context.Context, c userDataClient) (*client.UserData, error) {
+	data, err := c.GetUserData(ctx)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return data, err
+}
+
+// setUserData encrypts a JSON object to the identity's own key and uploads
+// it over the current version, or as version 0 if there's no user data yet.
+// On a version conflict the error reports the version now on the server.
+func setUserData(ctx context.Context, c userDataClient, identity *crypto.Identity, plaintext []byte) (*client.UserData, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(plaintext, &obj); err != nil {
+		return nil, fmt.Errorf("user data must be a JSON object: %w", err)
+	}
+
+	current, err := currentUserData(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("get user data: %w", err)
+	}
+	version := 0
+	if current != nil {
+		version = current.Version
+	}
+
+	blob, err := crypto.EncryptUserData(plaintext, identity)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt user data: %w", err)
+	}
+
+	data, err := c.SetUserData(ctx, version, blob)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		latest, getErr := currentUserData(ctx, c)
+		if getErr != nil || latest == nil {
+			return nil, errUserDataConflict
+		}
+		return nil, fmt.Errorf("%w (now version %d)", errUserDataConflict, latest.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("upload user data: %w", err)
+	}
+	return data, nil
+}
+
+// openUserData decrypts a user data blob with whichever scheme it uses:
+// box to the identity's own key, or secretbox under a key derived from the
+// PIN and user ID
+func openUserData(blob string, identity *crypto.Identity, pin, userID string) ([]byte, error) {
+	if crypto.UserDataScheme(blob) == crypto.UserDataSchemeSecretbox {
+		return crypto.DecryptUserDataSecretbox(blob, crypto.DeriveUserDataKey(pin, crypto.UserDataKeySalt(userID)))
+	}
+	return crypto.DecryptUserData(blob, identity)
+}
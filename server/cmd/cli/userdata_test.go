@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/whereish/server/pkg/client"
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// fakeUserData is a userDataClient holding one versioned blob. bumpOnSet
+// simulates another device saving just before each upload.
+type fakeUserData struct {
+	data      *client.UserData
+	bumpOnSet bool
+}
+
+func (f *fakeUserData) GetUserData(ctx context.Context) (*client.UserData, error) {
+	if f.data == nil {
+		return nil, &client.APIError{StatusCode: http.StatusNotFound, Code: "not_found"}
+	}
+	data := *f.data
+	return &data, nil
+}
+
+func (f *fakeUserData) SetUserData(ctx context.Context, version int, blob string) (*client.UserData, error) {
+	current := 0
+	if f.data != nil {
+		current = f.data.Version
+	}
+	if f.bumpOnSet {
+		current++
+		f.data = &client.UserData{Version: current}
+	}
+	if version != current {
+		return nil, &client.APIError{StatusCode: http.StatusConflict, Code: "version_conflict"}
+	}
+	f.data = &client.UserData{Version: version + 1, Blob: &blob}
+	return f.data, nil
+}
+
+func TestSetUserData(t *testing.T) {
+	identity, err := crypto.GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+	ctx := context.Background()
+	f := &fakeUserData{}
+
+	// First save goes in as version 0, later ones over the current version
+	for i, want := range []int{1, 2} {
+		data, err := setUserData(ctx, f, identity, []byte(`{"places":["home"]}`))
+		if err != nil {
+			t.Fatalf("save %d failed: %v", i+1, err)
+		}
+		if data.Version != want {
+			t.Errorf("save %d: version = %d, want %d", i+1, data.Version, want)
+		}
+	}
+
+	plaintext, err := openUserData(*f.data.Blob, identity, "", "")
+	if err != nil {
+		t.Fatalf("openUserData failed: %v", err)
+	}
+	if string(plaintext) != `{"places":["home"]}` {
+		t.Errorf("decrypted %q", plaintext)
+	}
+
+	if _, err := setUserData(ctx, f, identity, []byte(`["not", "an", "object"]`)); err == nil {
+		t.Error("setUserData accepted a JSON array")
+	}
+
+	f.bumpOnSet = true
+	_, err = setUserData(ctx, f, identity, []byte(`{}`))
+	if !errors.Is(err, errUserDataConflict) || !strings.Contains(err.Error(), "version 3") {
+		t.Errorf("setUserData error = %v, want a conflict reporting version 3", err)
+	}
+}
+
+func TestOpenUserData_Secretbox(t *testing.T) {
+	blob, err := crypto.EncryptUserDataSecretbox([]byte(`{}`), crypto.DeriveUserDataKey("1234", crypto.UserDataKeySalt("user-1")))
+	if err != nil {
+		t.Fatalf("EncryptUserDataSecretbox failed: %v", err)
+	}
+	if got, err := openUserData(blob, nil, "1234", "user-1"); err != nil || string(got) != `{}` {
+		t.Errorf("openUserData = %q, %v", got, err)
+	}
+	if _, err := openUserData(blob, nil, "9999", "user-1"); err == nil {
+		t.Error("openUserData with the wrong PIN succeeded")
+	}
+}
@@ -2,12 +2,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,15 +25,28 @@ import (
 
 func main() {
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Log structured lines; the standard logger is routed through it too
+	logger, err := newLogger(cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("LOG_FORMAT: %v", err)
+	}
+	slog.SetDefault(logger)
 
 	// Initialize store
 	var st store.Store
-	var err error
 
 	switch cfg.DatabaseType {
 	case "sqlite":
-		st, err = sqlite.New(cfg.DatabaseURL)
+		st, err = sqlite.New(cfg.DatabaseURL,
+			sqlite.WithEncryptionKey(cfg.DatabaseEncryptionKey),
+			sqlite.WithDedupeIdenticalShares(cfg.DedupeIdenticalShares),
+			sqlite.WithKeyChangeCleanup(cfg.ClearSharesOnKeyChange),
+			sqlite.WithEmailNormalization(cfg.EmailNormalizePlus, cfg.EmailNormalizeDots))
 	case "postgres":
 		log.Fatal("Postgres not yet implemented")
 	case "firestore":
@@ -46,25 +65,63 @@ func main() {
 		log.Fatal("GOOGLE_CLIENT_ID is required (or set DEV_MODE=true)")
 	}
 
+	contactRequestRate, err := api.ParseRate(cfg.ContactRequestRate)
+	if err != nil {
+		log.Fatalf("CONTACT_REQUEST_RATE: %v", err)
+	}
+	var contactRequestLimiter api.RateLimiter
+	if contactRequestRate.Count > 0 {
+		contactRequestLimiter = api.NewTokenBucketLimiter(contactRequestRate)
+	}
+
 	// Create API server
-	server := api.NewServer(st, cfg.GoogleClientID, cfg.SessionDuration)
+	server := api.NewServer(st, cfg.GoogleClientID, cfg.SessionDuration,
+		api.WithVerifyTimeout(cfg.OAuthVerifyTimeout),
+		api.WithAppleClientID(cfg.AppleClientID),
+		api.WithRefreshTokenDuration(cfg.RefreshTokenDuration),
+		api.WithStorageQuota(int64(cfg.StorageQuotaBytes)),
+		api.WithRequireDevice(cfg.RequireDevice),
+		api.WithMaxShareBatchBytes(int64(cfg.MaxShareBatchBytes)),
+		api.WithBackupIterations(cfg.BackupMinIterations, cfg.BackupMaxIterations),
+		api.WithKeyChangeNotify(cfg.ClearSharesOnKeyChange),
+		api.WithCursorKey([]byte(cfg.CursorKey)),
+		api.WithContactRequestLimiter(contactRequestLimiter),
+		api.WithDevMode(cfg.DevMode),
+	)
+
+	trustedProxies, err := api.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("TRUSTED_PROXIES: %v", err)
+	}
+
+	corsOrigins, err := api.ParseCORSOrigins(cfg.AllowedOrigins)
+	if err != nil {
+		log.Fatalf("ALLOWED_ORIGINS: %v", err)
+	}
+	if corsOrigins.Any() && !cfg.DevMode {
+		log.Fatal("ALLOWED_ORIGINS=* is only allowed with DEV_MODE=true; list the origins instead")
+	}
 
 	// Setup router
 	r := chi.NewRouter()
 
 	// Middleware
-	r.Use(middleware.Logger)
+	r.Use(middleware.RequestID)
+	r.Use(api.RequestLogger(logger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.RealIP)
-	r.Use(corsMiddleware)
+	r.Use(api.RequireHTTPS(cfg.RequireHTTPS, trustedProxies)) // before RealIP rewrites RemoteAddr
+	r.Use(api.RealIP(trustedProxies))
+	r.Use(api.CORS(corsOrigins, cfg.CORSAllowCredentials, cfg.CORSDebug))
+	r.Use(api.MinClientVersion(cfg.MinClientVersion, cfg.StrictClientVersion))
+	r.Use(api.ConcurrencyLimit(cfg.MaxConcurrentRequests))
 	r.Use(server.AuthMiddleware)
+	r.Use(server.DeviceMiddleware)
 
 	// Mount API routes with /api prefix
 	api.HandlerFromMuxWithBaseURL(server, r, "/api")
 
-	// Dev mode: add test login endpoint
+	// Dev mode: the test login endpoint answers instead of returning 404
 	if cfg.DevMode {
-		r.Post("/api/dev/login", server.DevLogin)
 		log.Println("DEV MODE: /api/dev/login endpoint enabled")
 	}
 
@@ -73,33 +130,113 @@ func main() {
 	log.Printf("Starting server on %s", addr)
 	log.Printf("Database: %s (%s)", cfg.DatabaseType, cfg.DatabaseURL)
 
-	// Graceful shutdown
-	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-		<-sigChan
-		log.Println("Shutting down...")
-		st.Close()
-		os.Exit(0)
-	}()
+	jobs := newJobs(st, cfg)
+	jobs.start()
+
+	// Run until signalled, then drain requests, stop jobs and let the
+	// deferred st.Close run
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if err := http.ListenAndServe(addr, r); err != nil {
+	httpServer := &http.Server{Handler: r}
+	httpServer.RegisterOnShutdown(server.CloseStreams)
+	ln, err := net.Listen("tcp", addr)
+	if err == nil {
+		err = serve(ctx, httpServer, ln, cfg.ShutdownTimeout)
+	}
+	jobs.stop()
+	if err != nil {
+		st.Close()
 		log.Fatalf("Server error: %v", err)
 	}
+	log.Println("Server stopped")
 }
 
-// corsMiddleware adds CORS headers
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+// serve runs srv on ln until ctx is done, then shuts it down: it stops
+// accepting connections and gives in-flight requests up to timeout to
+// finish before closing what's left. Returns an error only if the server
+// failed.
+func serve(ctx context.Context, srv *http.Server, ln net.Listener, timeout time.Duration) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	log.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Requests still running after %s, closing connections: %v", timeout, err)
+		srv.Close()
+	}
+
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// newLogger returns a logger writing format ("text" or "json") to stderr
+func newLogger(format string) (*slog.Logger, error) {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, nil)), nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want text or json)", format)
+}
+
+// loginAttemptRetention is how long login attempts are kept
+const loginAttemptRetention = 30 * 24 * time.Hour
 
-		next.ServeHTTP(w, r)
+// contactRequestExpiry is how long a contact request stays pending
+const contactRequestExpiry = 30 * 24 * time.Hour
+
+// locationCleanupInterval is how often expired location shares are
+// deleted. Readers already skip them, so this only bounds how long they
+// stay on disk.
+const locationCleanupInterval = 10 * time.Minute
+
+// newJobs registers the server's background jobs. Jobs named in
+// DISABLED_JOBS are skipped.
+func newJobs(st store.Store, cfg *config.Config) *scheduler {
+	disabled := make(map[string]bool)
+	for _, name := range strings.Split(cfg.DisabledJobs, ",") {
+		disabled[strings.TrimSpace(name)] = true
+	}
+
+	jobs := &scheduler{}
+	jobs.register("expire-sessions", cfg.SessionCleanupInterval, !disabled["expire-sessions"], func(ctx context.Context) error {
+		n, err := st.Sessions().DeleteExpired(ctx)
+		if n > 0 {
+			log.Printf("Deleted %d expired sessions", n)
+		}
+		return err
+	})
+	jobs.register("expire-locations", locationCleanupInterval, !disabled["expire-locations"], func(ctx context.Context) error {
+		n, err := st.Locations().DeleteExpired(ctx)
+		if n > 0 {
+			log.Printf("Deleted %d expired location shares", n)
+		}
+		return err
+	})
+	jobs.register("prune-login-attempts", time.Hour, !disabled["prune-login-attempts"], func(ctx context.Context) error {
+		return st.Users().PruneLoginAttempts(ctx, time.Now().Add(-loginAttemptRetention))
+	})
+	jobs.register("expire-requests", time.Hour, !disabled["expire-requests"], func(ctx context.Context) error {
+		_, err := st.Contacts().ExpireRequests(ctx, time.Now().Add(-contactRequestExpiry))
+		return err
 	})
+	jobs.register("inactive-accounts", 24*time.Hour, cfg.InactiveAccountSweep && !disabled["inactive-accounts"],
+		retentionJob(st, retentionPolicy{
+			TTL:    cfg.InactiveAccountTTL,
+			Grace:  cfg.InactiveAccountGrace,
+			DryRun: cfg.InactiveAccountDryRun,
+		}))
+	return jobs
 }
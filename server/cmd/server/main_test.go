@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// startServe runs serve on a local port with a handler that holds each
+// request until release is closed. It returns the server's URL and a
+// channel receiving serve's result.
+func startServe(t *testing.T, ctx context.Context, release <-chan struct{}, timeout time.Duration) (string, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		io.WriteString(w, "done")
+	})}
+
+	done := make(chan error, 1)
+	go func() { done <- serve(ctx, srv, ln, timeout) }()
+	return "http://" + ln.Addr().String(), done
+}
+
+func TestServe_DrainsRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	url, done := startServe(t, ctx, release, 5*time.Second)
+
+	resp := make(chan string, 1)
+	go func() {
+		r, err := http.Get(url)
+		if err != nil {
+			resp <- err.Error()
+			return
+		}
+		defer r.Body.Close()
+		body, _ := io.ReadAll(r.Body)
+		resp <- string(body)
+	}()
+	time.Sleep(50 * time.Millisecond) // let the request arrive
+
+	// Shutdown waits for the in-flight request...
+	cancel()
+	select {
+	case err := <-done:
+		t.Fatalf("serve returned %v with a request in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// ...which completes normally, and new connections are refused
+	if _, err := http.Get(url); err == nil {
+		t.Error("server accepted a request after shutdown began")
+	}
+	close(release)
+	if body := <-resp; body != "done" {
+		t.Errorf("in-flight request got %q, want done", body)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("serve = %v, want nil", err)
+	}
+}
+
+func TestServe_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	url, done := startServe(t, ctx, release, 50*time.Millisecond)
+
+	go http.Get(url)
+	time.Sleep(50 * time.Millisecond)
+
+	// A request that never finishes is cut off after the timeout
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("serve = %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("serve didn't return after the shutdown timeout")
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/whereish/server/internal/store"
+)
+
+// retentionPolicy controls the inactive account sweep
+type retentionPolicy struct {
+	TTL    time.Duration // no login for this long flags an account
+	Grace  time.Duration // flagged for this long deletes it
+	DryRun bool          // only log what would happen
+}
+
+// retentionJob returns the inactive account sweep as a scheduler job
+func retentionJob(st store.Store, policy retentionPolicy) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		flagged, deleted, err := sweepInactiveAccounts(ctx, st, policy, time.Now())
+		log.Printf("Retention sweep: %d flagged, %d deleted (dry run: %t)", flagged, deleted, policy.DryRun)
+		return err
+	}
+}
+
+// sweepInactiveAccounts flags accounts with no login within the policy's
+// TTL, and soft-deletes accounts flagged for longer than its grace period.
+// Each step is audited in the same transaction. It returns how many
+// accounts were flagged and deleted, or in a dry run would have been.
+func sweepInactiveAccounts(ctx context.Context, st store.Store, policy retentionPolicy, now time.Time) (flagged, deleted int, err error) {
+	accounts, err := st.Users().ListInactive(ctx, now.Add(-policy.TTL))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, a := range accounts {
+		switch {
+		case a.FlaggedAt == nil:
+			if policy.DryRun {
+				flagged++
+				log.Printf("Retention dry run: would flag account %s, last active %s", a.UserID, a.LastActive.Format(time.RFC3339))
+				continue
+			}
+			err = st.WithTx(ctx, func(tx store.Store) error {
+				if err := tx.Audit().Record(ctx, &store.AuditEvent{UserID: a.UserID, Action: store.AuditAccountFlagged, TargetID: a.UserID}); err != nil {
+					return err
+				}
+				return tx.Users().FlagInactive(ctx, a.UserID, now)
+			})
+			if err != nil {
+				return flagged, deleted, err
+			}
+			flagged++
+			log.Printf("Retention: flagged account %s, last active %s", a.UserID, a.LastActive.Format(time.RFC3339))
+
+		case a.FlaggedAt.Before(now.Add(-policy.Grace)):
+			if policy.DryRun {
+				deleted++
+				log.Printf("Retention dry run: would delete account %s, flagged %s", a.UserID, a.FlaggedAt.Format(time.RFC3339))
+				continue
+			}
+			err = st.WithTx(ctx, func(tx store.Store) error {
+				if err := tx.Audit().Record(ctx, &store.AuditEvent{UserID: a.UserID, Action: store.AuditAccountExpired, TargetID: a.UserID}); err != nil {
+					return err
+				}
+				return tx.Users().SoftDelete(ctx, a.UserID)
+			})
+			if err != nil {
+				return flagged, deleted, err
+			}
+			deleted++
+			log.Printf("Retention: deleted account %s, flagged %s", a.UserID, a.FlaggedAt.Format(time.RFC3339))
+		}
+	}
+	return flagged, deleted, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/whereish/server/internal/store"
+	"github.com/whereish/server/internal/store/sqlite"
+)
+
+// retentionStore returns a store with an account last active long ago and
+// one created now
+func retentionStore(t *testing.T) (st store.Store, stale, active *store.User) {
+	t.Helper()
+	st, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+
+	ctx := context.Background()
+	stale = &store.User{Email: "stale@example.com", Name: "Stale", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	active = &store.User{Email: "active@example.com", Name: "Active"}
+	for _, u := range []*store.User{stale, active} {
+		if err := st.Users().Create(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return st, stale, active
+}
+
+func TestSweepInactiveAccounts_DryRun(t *testing.T) {
+	st, stale, _ := retentionStore(t)
+	ctx := context.Background()
+	policy := retentionPolicy{TTL: 30 * 24 * time.Hour, Grace: 7 * 24 * time.Hour, DryRun: true}
+
+	flagged, deleted, err := sweepInactiveAccounts(ctx, st, policy, time.Now())
+	if err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if flagged != 1 || deleted != 0 {
+		t.Errorf("sweep = %d flagged, %d deleted; want 1, 0", flagged, deleted)
+	}
+
+	// Nothing changed
+	accounts, _ := st.Users().ListInactive(ctx, time.Now().Add(-policy.TTL))
+	if len(accounts) != 1 || accounts[0].FlaggedAt != nil {
+		t.Errorf("inactive accounts = %+v, want one unflagged", accounts)
+	}
+	if events, _ := st.Audit().ListForUser(ctx, stale.ID); len(events) != 0 {
+		t.Errorf("dry run recorded %d audit events", len(events))
+	}
+}
+
+func TestSweepInactiveAccounts(t *testing.T) {
+	st, stale, active := retentionStore(t)
+	ctx := context.Background()
+	policy := retentionPolicy{TTL: 30 * 24 * time.Hour, Grace: 7 * 24 * time.Hour}
+	now := time.Now()
+
+	flagged, deleted, err := sweepInactiveAccounts(ctx, st, policy, now)
+	if err != nil || flagged != 1 || deleted != 0 {
+		t.Fatalf("first sweep = %d flagged, %d deleted, %v; want 1, 0", flagged, deleted, err)
+	}
+
+	// Still within the grace period
+	flagged, deleted, err = sweepInactiveAccounts(ctx, st, policy, now.Add(24*time.Hour))
+	if err != nil || flagged != 0 || deleted != 0 {
+		t.Fatalf("sweep in grace = %d flagged, %d deleted, %v; want 0, 0", flagged, deleted, err)
+	}
+	if _, err := st.Users().GetByID(ctx, stale.ID); err != nil {
+		t.Fatalf("account deleted during grace: %v", err)
+	}
+
+	// Past it
+	flagged, deleted, err = sweepInactiveAccounts(ctx, st, policy, now.Add(policy.Grace+time.Hour))
+	if err != nil || deleted != 1 {
+		t.Fatalf("sweep after grace = %d flagged, %d deleted, %v; want 1 deleted", flagged, deleted, err)
+	}
+	if _, err := st.Users().GetByID(ctx, stale.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByID after sweep = %v, want ErrNotFound", err)
+	}
+	if _, err := st.Users().GetByID(ctx, active.ID); err != nil {
+		t.Errorf("active account: %v", err)
+	}
+
+	events, _ := st.Audit().ListForUser(ctx, stale.ID)
+	var actions []string
+	for _, e := range events {
+		actions = append(actions, e.Action)
+	}
+	if len(actions) != 2 {
+		t.Errorf("audit actions = %v, want flag and delete", actions)
+	}
+}
+
+func TestSweepInactiveAccounts_LoginClearsFlag(t *testing.T) {
+	st, stale, _ := retentionStore(t)
+	ctx := context.Background()
+	policy := retentionPolicy{TTL: 30 * 24 * time.Hour, Grace: 7 * 24 * time.Hour}
+	now := time.Now()
+
+	if _, _, err := sweepInactiveAccounts(ctx, st, policy, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Users().TouchLastLogin(ctx, stale.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Inactive again by the time the grace period ends, but the clock
+	// restarted at the login
+	_, deleted, err := sweepInactiveAccounts(ctx, st, retentionPolicy{TTL: time.Hour, Grace: policy.Grace}, now.Add(policy.Grace+time.Hour))
+	if err != nil || deleted != 0 {
+		t.Errorf("sweep after login = %d deleted, %v; want 0", deleted, err)
+	}
+}
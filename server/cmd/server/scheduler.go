@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// job is a named task the scheduler runs periodically
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// scheduler runs background jobs, each on its own interval, between start
+// and stop. Each job runs once at start, then every interval; a run that
+// overlaps the next tick delays it rather than running twice at once.
+type scheduler struct {
+	jobs []job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// register adds a job. A disabled job, or one with no interval, is logged
+// and never runs, so the list of jobs at startup shows what's switched
+// off. Register all jobs before calling start.
+func (s *scheduler) register(name string, interval time.Duration, enabled bool, run func(ctx context.Context) error) {
+	if !enabled || interval <= 0 {
+		log.Printf("Job %s disabled", name)
+		return
+	}
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// start runs each registered job in its own goroutine
+func (s *scheduler) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}()
+	}
+}
+
+// stop cancels running jobs and waits for them to return
+func (s *scheduler) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop runs j until ctx is cancelled
+func (s *scheduler) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Error running job %s: %v", j.name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs, disabledRuns atomic.Int32
+	s := &scheduler{}
+	s.register("count", 10*time.Millisecond, true, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("logged, not fatal")
+	})
+	s.register("off", time.Millisecond, false, func(ctx context.Context) error {
+		disabledRuns.Add(1)
+		return nil
+	})
+
+	s.start()
+	time.Sleep(55 * time.Millisecond)
+	s.stop()
+
+	// Once at start, then every interval
+	n := runs.Load()
+	if n < 3 || n > 7 {
+		t.Errorf("job ran %d times in 55ms at a 10ms interval, want about 6", n)
+	}
+	if got := disabledRuns.Load(); got != 0 {
+		t.Errorf("disabled job ran %d times", got)
+	}
+
+	// Nothing runs after stop returns
+	time.Sleep(30 * time.Millisecond)
+	if got := runs.Load(); got != n {
+		t.Errorf("job ran %d more times after stop", got-n)
+	}
+}
+
+func TestScheduler_StopCancelsRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	s := &scheduler{}
+	s.register("slow", time.Hour, true, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	s.start()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		s.stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop didn't return while a job was running")
+	}
+}
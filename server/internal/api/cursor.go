@@ -0,0 +1,79 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errInvalidCursor is returned for cursors that are malformed, were signed
+// with a different key, or belong to another user
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursorMACSize is how much of the HMAC-SHA256 tag a cursor carries
+const cursorMACSize = 16
+
+// cursorCodec encodes opaque page cursors holding the last sort key and ID
+// returned. Cursors are signed and bound to the user they were issued to,
+// so a client can't forge one or replay another user's.
+type cursorCodec struct {
+	key []byte
+}
+
+// newCursorCodec returns a codec signing with key. An empty key uses a
+// random one, so cursors stop working when the server restarts.
+func newCursorCodec(key []byte) cursorCodec {
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic("cursor key: " + err.Error())
+		}
+	}
+	return cursorCodec{key: key}
+}
+
+// encode packs the sort key and last ID returned to userID into a cursor
+func (c cursorCodec) encode(userID string, at time.Time, lastID string) string {
+	payload := []byte(strconv.FormatInt(at.UnixNano(), 10) + ":" + lastID)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(c.mac(userID, payload))
+}
+
+// decode verifies a cursor issued to userID and returns its sort key and ID
+func (c cursorCodec) decode(userID, cursor string) (time.Time, string, error) {
+	encPayload, encMAC, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return time.Time{}, "", errInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encMAC)
+	if err != nil || !hmac.Equal(mac, c.mac(userID, payload)) {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	nanos, lastID, ok := strings.Cut(string(payload), ":")
+	if !ok || lastID == "" {
+		return time.Time{}, "", errInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+	return time.Unix(0, n), lastID, nil
+}
+
+func (c cursorCodec) mac(userID string, payload []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write([]byte(userID))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)[:cursorMACSize]
+}
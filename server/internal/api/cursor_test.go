@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	c := newCursorCodec([]byte("test-key"))
+	at := time.Unix(0, 1700000000123456789)
+
+	cursor := c.encode("user-1", at, "last:id")
+	gotAt, gotID, err := c.decode("user-1", cursor)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !gotAt.Equal(at) || gotID != "last:id" {
+		t.Errorf("decode = %v, %q; want %v, %q", gotAt, gotID, at, "last:id")
+	}
+}
+
+func TestCursorCodec_Rejects(t *testing.T) {
+	c := newCursorCodec([]byte("test-key"))
+	cursor := c.encode("user-1", time.Now(), "id-5")
+	payload, mac, _ := strings.Cut(cursor, ".")
+
+	// Point the cursor at a different row, keeping the old signature
+	forged := base64.RawURLEncoding.EncodeToString([]byte("0:id-1")) + "." + mac
+
+	tests := []struct {
+		name   string
+		codec  cursorCodec
+		userID string
+		cursor string
+	}{
+		{"tampered payload", c, "user-1", forged},
+		{"missing signature", c, "user-1", payload},
+		{"other user", c, "user-2", cursor},
+		{"other key", newCursorCodec([]byte("other-key")), "user-1", cursor},
+		{"garbage", c, "user-1", "not-a-cursor"},
+		{"unsigned old format", c, "user-1", base64.RawURLEncoding.EncodeToString([]byte("0:id-1"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.codec.decode(tt.userID, tt.cursor); err != errInvalidCursor {
+				t.Errorf("decode err = %v, want errInvalidCursor", err)
+			}
+		})
+	}
+}
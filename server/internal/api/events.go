@@ -0,0 +1,303 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event types sent on the /events stream
+const (
+	EventContactRequestReceived = "contact_request.received"
+	EventLocationShared         = "location.shared"
+	EventLocationRequested      = "location.requested"
+	EventPresenceUpdated        = "presence.updated"
+	EventContactKeyChanged      = "contact.key_changed"
+)
+
+// EventLocation is the type of each event on the /locations/stream stream
+const EventLocation = "location"
+
+const (
+	// eventBufferSize is how many recent events are kept per user for
+	// streams resuming with Last-Event-ID
+	eventBufferSize = 100
+
+	// eventResumeWindow is how long buffered events are kept. A user with
+	// no open stream and nothing newer is dropped from the hub.
+	eventResumeWindow = 10 * time.Minute
+
+	// eventSubscriberBuffer is how many events can queue for a stream
+	// before it's considered stalled and closed
+	eventSubscriberBuffer = 16
+
+	// eventKeepalive is how often an idle stream gets a comment line, so
+	// proxies don't time it out
+	eventKeepalive = 30 * time.Second
+)
+
+// event is one message on a user's stream
+type event struct {
+	ID   uint64
+	Type string
+	From string // user whose action caused the event
+	Data []byte
+	At   time.Time
+}
+
+// eventFilter limits a stream to events from some users. A nil filter
+// passes everything.
+type eventFilter map[string]bool
+
+// newEventFilter returns a filter for events from the given users, or nil
+// for none
+func newEventFilter(from []string) eventFilter {
+	if len(from) == 0 {
+		return nil
+	}
+	f := make(eventFilter, len(from))
+	for _, userID := range from {
+		f[userID] = true
+	}
+	return f
+}
+
+// allows reports whether ev passes the filter
+func (f eventFilter) allows(ev event) bool {
+	return f == nil || f[ev.From]
+}
+
+// eventHub fans events out to each user's open streams and keeps a short
+// rolling buffer per user so a reconnecting stream can catch up on what it
+// missed. Events are held in memory only and are lost on restart.
+type eventHub struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	lastID    uint64
+	users     map[string]*userEvents
+	lastSweep time.Time
+}
+
+// userEvents is one user's buffer and open streams
+type userEvents struct {
+	recent []event // oldest first, at most eventBufferSize
+	subs   map[chan event]eventFilter
+}
+
+// newEventHub creates a hub. IDs start from the current time in
+// microseconds so they keep increasing across restarts and a stale
+// Last-Event-ID can't hide new events.
+func newEventHub() *eventHub {
+	return &eventHub{
+		now:    time.Now,
+		lastID: uint64(time.Now().UnixMicro()),
+		users:  make(map[string]*userEvents),
+	}
+}
+
+// user returns the user's entry, creating it if needed. Callers hold mu.
+func (h *eventHub) user(userID string) *userEvents {
+	u, ok := h.users[userID]
+	if !ok {
+		u = &userEvents{subs: make(map[chan event]eventFilter)}
+		h.users[userID] = u
+	}
+	return u
+}
+
+// publish sends an event caused by from to the user's open streams and
+// buffers it. A stream that has fallen too far behind is closed; its
+// client reconnects and catches up from the buffer.
+func (h *eventHub) publish(userID, from, eventType string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("Error encoding event", "type", eventType, "error", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.sweep(now)
+
+	h.lastID++
+	ev := event{ID: h.lastID, Type: eventType, From: from, Data: payload, At: now}
+
+	u := h.user(userID)
+	u.recent = append(u.recent, ev)
+	if len(u.recent) > eventBufferSize {
+		u.recent = append(u.recent[:0], u.recent[len(u.recent)-eventBufferSize:]...)
+	}
+
+	for ch, filter := range u.subs {
+		if !filter.allows(ev) {
+			continue
+		}
+		select {
+		case ch <- ev:
+		default:
+			delete(u.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe opens a stream of the user's events that pass filter.
+// Buffered events after lastID are returned to be sent first; pass 0 to
+// skip them. The channel is closed if the stream falls behind. Call cancel
+// when done.
+func (h *eventHub) subscribe(userID string, lastID uint64, filter eventFilter) (missed []event, events <-chan event, cancel func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sweep(h.now())
+	u := h.user(userID)
+	if lastID > 0 {
+		for _, ev := range u.recent {
+			if ev.ID > lastID && filter.allows(ev) {
+				missed = append(missed, ev)
+			}
+		}
+	}
+
+	ch := make(chan event, eventSubscriberBuffer)
+	u.subs[ch] = filter
+	cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := u.subs[ch]; ok {
+			delete(u.subs, ch)
+			close(ch)
+		}
+		// Keep the entry while a reconnect could still resume from it
+		if len(u.subs) == 0 && h.users[userID] == u && !u.resumable(h.now()) {
+			delete(h.users, userID)
+		}
+	}
+	return missed, ch, cancel
+}
+
+// sweep drops buffered events older than eventResumeWindow, and users left
+// with no events and no open streams, at most once a window so users who
+// stop connecting don't accumulate. Callers hold mu.
+func (h *eventHub) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < eventResumeWindow {
+		return
+	}
+	h.lastSweep = now
+	for userID, u := range h.users {
+		i := 0
+		for i < len(u.recent) && now.Sub(u.recent[i].At) >= eventResumeWindow {
+			i++
+		}
+		u.recent = append(u.recent[:0], u.recent[i:]...)
+		if len(u.recent) == 0 && len(u.subs) == 0 {
+			delete(h.users, userID)
+		}
+	}
+}
+
+// resumable reports whether the user's newest event is recent enough for a
+// reconnecting stream to resume from
+func (u *userEvents) resumable(now time.Time) bool {
+	return len(u.recent) > 0 && now.Sub(u.recent[len(u.recent)-1].At) < eventResumeWindow
+}
+
+// closeAll ends every open stream
+func (h *eventHub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, u := range h.users {
+		for ch := range u.subs {
+			delete(u.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// CloseStreams ends all open event and location streams, so they don't
+// hold up a graceful shutdown. Clients reconnect and resume with
+// Last-Event-ID.
+func (s *Server) CloseStreams() {
+	s.events.closeAll()
+	s.locationEvents.closeAll()
+}
+
+// StreamEvents streams the user's events as server-sent events. A client
+// reconnecting with Last-Event-ID first receives buffered events it missed.
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	s.stream(w, r, s.events, params.LastEventID, params.From)
+}
+
+// StreamLocations streams locations shared with the user as server-sent
+// events, each carrying the EncryptedLocation
+func (s *Server) StreamLocations(w http.ResponseWriter, r *http.Request, params StreamLocationsParams) {
+	s.stream(w, r, s.locationEvents, params.LastEventID, params.From)
+}
+
+// stream serves the user's events from hub until the client disconnects,
+// starting with buffered events after lastEventID if one is given. With
+// from, only events caused by those users are sent.
+func (s *Server) stream(w http.ResponseWriter, r *http.Request, hub *eventHub, lastEventID *string, from *EventFrom) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "internal_error", "Streaming not supported")
+		return
+	}
+
+	var lastID uint64
+	if lastEventID != nil {
+		id, err := strconv.ParseUint(*lastEventID, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Invalid Last-Event-ID")
+			return
+		}
+		lastID = id
+	}
+
+	var filter eventFilter
+	if from != nil {
+		filter = newEventFilter(*from)
+	}
+	missed, events, cancel := hub.subscribe(userID, lastID, filter)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, ev := range missed {
+		writeEvent(w, ev)
+	}
+	flusher.Flush()
+
+	keepalive := time.NewTicker(eventKeepalive)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			writeEvent(w, ev)
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes one event in server-sent event framing
+func writeEvent(w http.ResponseWriter, ev event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, ev.Data)
+}
@@ -0,0 +1,342 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventHub_Resume(t *testing.T) {
+	hub := newEventHub()
+	for i := 0; i < 5; i++ {
+		hub.publish("bob", "zoe", EventLocationShared, map[string]int{"n": i})
+	}
+	hub.publish("alice", "zoe", EventLocationShared, nil)
+
+	_, events, cancel := hub.subscribe("bob", 0, nil)
+	cancel()
+	if _, ok := <-events; ok {
+		t.Error("channel open after cancel")
+	}
+
+	// Resuming after the second event returns the rest
+	first := hub.users["bob"].recent[0].ID
+	missed, _, cancel := hub.subscribe("bob", first+1, nil)
+	defer cancel()
+	if len(missed) != 3 || missed[0].ID != first+2 || string(missed[2].Data) != `{"n":4}` {
+		t.Errorf("missed = %+v, want events 3-5", missed)
+	}
+}
+
+func TestEventHub_BufferLimit(t *testing.T) {
+	hub := newEventHub()
+	for i := 0; i < eventBufferSize+10; i++ {
+		hub.publish("bob", "zoe", EventLocationShared, nil)
+	}
+	if got := len(hub.users["bob"].recent); got != eventBufferSize {
+		t.Errorf("buffered %d events, want %d", got, eventBufferSize)
+	}
+}
+
+func TestEventHub_Shrinks(t *testing.T) {
+	hub := newEventHub()
+	now := time.Now()
+	hub.now = func() time.Time { return now }
+
+	// Bob never connects; Carol's stream closes while her event is fresh
+	hub.publish("bob", "zoe", EventLocationShared, nil)
+	_, _, cancel := hub.subscribe("carol", 0, nil)
+	hub.publish("carol", "zoe", EventLocationShared, nil)
+	cancel()
+	if _, ok := hub.users["carol"]; !ok {
+		t.Fatal("carol dropped while her event could still be resumed")
+	}
+
+	// Dave's stream closes with nothing buffered
+	_, _, cancel = hub.subscribe("dave", 0, nil)
+	cancel()
+	if _, ok := hub.users["dave"]; ok {
+		t.Error("dave kept after his only stream closed")
+	}
+
+	// Alice stays connected through the window
+	_, _, cancelAlice := hub.subscribe("alice", 0, nil)
+	defer cancelAlice()
+	hub.publish("alice", "zoe", EventLocationShared, nil)
+
+	now = now.Add(eventResumeWindow)
+	hub.publish("erin", "zoe", EventLocationShared, nil)
+	for _, userID := range []string{"bob", "carol"} {
+		if _, ok := hub.users[userID]; ok {
+			t.Errorf("%s kept after the resume window", userID)
+		}
+	}
+	if u := hub.users["alice"]; u == nil || len(u.recent) != 0 {
+		t.Errorf("alice = %+v, want kept with her stream but no expired events", u)
+	}
+	if len(hub.users) != 2 {
+		t.Errorf("hub has %d users, want alice and erin", len(hub.users))
+	}
+}
+
+func TestEventHub_Filter(t *testing.T) {
+	hub := newEventHub()
+	hub.publish("bob", "alice", EventLocationShared, nil)
+	hub.publish("bob", "carol", EventLocationShared, nil)
+
+	// Buffered and live events from unchosen users are both skipped
+	missed, events, cancel := hub.subscribe("bob", 1, newEventFilter([]string{"carol"}))
+	defer cancel()
+	if len(missed) != 1 || missed[0].From != "carol" {
+		t.Errorf("missed = %+v, want only carol's event", missed)
+	}
+	hub.publish("bob", "alice", EventLocationShared, nil)
+	hub.publish("bob", "carol", EventPresenceUpdated, nil)
+	if ev := <-events; ev.From != "carol" || ev.Type != EventPresenceUpdated {
+		t.Errorf("live event = %+v, want carol's presence.updated", ev)
+	}
+}
+
+func TestEventHub_SlowSubscriberClosed(t *testing.T) {
+	hub := newEventHub()
+	_, events, cancel := hub.subscribe("bob", 0, nil)
+	defer cancel()
+
+	// Nobody reads, so the stream falls behind and is dropped rather than
+	// blocking the publisher
+	for i := 0; i < eventSubscriberBuffer+1; i++ {
+		hub.publish("bob", "zoe", EventLocationShared, nil)
+	}
+	for range events {
+	}
+}
+
+func TestEventHub_CloseAll(t *testing.T) {
+	hub := newEventHub()
+	_, alice, cancelAlice := hub.subscribe("alice", 0, nil)
+	defer cancelAlice()
+	_, bob, cancelBob := hub.subscribe("bob", 0, nil)
+	defer cancelBob()
+
+	hub.closeAll()
+	for range alice {
+	}
+	for range bob {
+	}
+}
+
+// sseEvent is an event read back from the stream
+type sseEvent struct {
+	id, typ, data string
+}
+
+// openEvents connects to the event stream, resuming after lastEventID if
+// set. Cancel ctx to disconnect.
+func openEvents(t *testing.T, ctx context.Context, url, token, lastEventID string) *bufio.Scanner {
+	t.Helper()
+	return openStream(t, ctx, url+"/api/events", token, lastEventID)
+}
+
+// openStream connects to the server-sent event stream at url
+func openStream(t *testing.T, ctx context.Context, url, token, lastEventID string) *bufio.Scanner {
+	t.Helper()
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	return bufio.NewScanner(resp.Body)
+}
+
+// readEvent reads the next event from the stream
+func readEvent(t *testing.T, sc *bufio.Scanner) sseEvent {
+	t.Helper()
+	var ev sseEvent
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" && ev.id != "" {
+			return ev
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		switch field {
+		case "id":
+			ev.id = value
+		case "event":
+			ev.typ = value
+		case "data":
+			ev.data = value
+		}
+	}
+	t.Fatalf("stream ended: %v", sc.Err())
+	return ev
+}
+
+func TestStreamEvents_Resume(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	share := func() {
+		t.Helper()
+		body := LocationShareRequest{Locations: []LocationShare{{ToUserId: userB.ID, Blob: "blob"}}}
+		if rec := doRequest(t, r, "POST", "/api/locations", body, tokenA); rec.Code != http.StatusNoContent {
+			t.Fatalf("share status = %d", rec.Code)
+		}
+	}
+
+	// Bob receives an event, then disconnects
+	ctx, disconnect := context.WithCancel(context.Background())
+	stream := openEvents(t, ctx, ts.URL, tokenB, "")
+	share()
+	first := readEvent(t, stream)
+	if first.typ != EventLocationShared || !strings.Contains(first.data, userA.ID) {
+		t.Fatalf("first event = %+v, want location.shared from Alice", first)
+	}
+	disconnect()
+
+	// Two more shares while Bob is away
+	share()
+	share()
+
+	ctx, disconnect = context.WithCancel(context.Background())
+	defer disconnect()
+	stream = openEvents(t, ctx, ts.URL, tokenB, first.id)
+	firstID, _ := strconv.ParseUint(first.id, 10, 64)
+	for i := uint64(1); i <= 2; i++ {
+		ev := readEvent(t, stream)
+		if ev.id != strconv.FormatUint(firstID+i, 10) || ev.typ != EventLocationShared {
+			t.Errorf("event %d after resume = %+v, want id %d", i, ev, firstID+i)
+		}
+	}
+
+	// Events published after the catch-up arrive live
+	share()
+	if ev := readEvent(t, stream); ev.id != strconv.FormatUint(firstID+3, 10) {
+		t.Errorf("live event id = %s, want %d", ev.id, firstID+3)
+	}
+}
+
+func TestStreamLocations_From(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	tokenC, userC := createTestUser(t, st, "carol@example.com", "Carol")
+	for _, token := range []string{tokenA, tokenC} {
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, token)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+	}
+	share := func(token, blob string) {
+		t.Helper()
+		body := LocationShareRequest{Locations: []LocationShare{{ToUserId: userB.ID, Blob: blob}}}
+		if rec := doRequest(t, r, "POST", "/api/locations", body, token); rec.Code != http.StatusNoContent {
+			t.Fatalf("share status = %d", rec.Code)
+		}
+	}
+
+	// Bob only follows Carol, so Alice's share is never delivered
+	ctx, disconnect := context.WithCancel(context.Background())
+	defer disconnect()
+	stream := openStream(t, ctx, ts.URL+"/api/locations/stream?from="+userC.ID, tokenB, "")
+	share(tokenA, "from_alice")
+	share(tokenC, "from_carol")
+
+	ev := readEvent(t, stream)
+	var loc EncryptedLocation
+	json.Unmarshal([]byte(ev.data), &loc)
+	if loc.FromUserId != userC.ID || loc.Blob != "from_carol" {
+		t.Errorf("first location = %+v, want Carol's", loc)
+	}
+}
+
+func TestStreamEvents_InvalidLastEventID(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	token, _ := createTestUser(t, st, "alice@example.com", "Alice")
+
+	req := httptest.NewRequest("GET", "/api/events", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Last-Event-ID", "not-a-number")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestStreamLocations(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	stream := openStream(t, ctx, ts.URL+"/api/locations/stream", tokenB, "")
+
+	body := LocationShareRequest{Locations: []LocationShare{{ToUserId: userB.ID, Blob: "blob", Precision: ptr(City)}}}
+	if rec := doRequest(t, r, "POST", "/api/locations", body, tokenA); rec.Code != http.StatusNoContent {
+		t.Fatalf("share status = %d", rec.Code)
+	}
+
+	ev := readEvent(t, stream)
+	if ev.typ != EventLocation {
+		t.Fatalf("event type = %q, want %q", ev.typ, EventLocation)
+	}
+	var loc EncryptedLocation
+	if err := json.Unmarshal([]byte(ev.data), &loc); err != nil {
+		t.Fatalf("decode location: %v", err)
+	}
+	if loc.FromUserId != userA.ID || loc.Blob != "blob" || loc.Precision != City || loc.UpdatedAt.IsZero() {
+		t.Errorf("location = %+v, want Alice's city-level blob", loc)
+	}
+
+	// Disconnecting removes the subscriber
+	disconnect()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		server.locationEvents.mu.Lock()
+		n := len(server.locationEvents.users[userB.ID].subs)
+		server.locationEvents.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d subscribers left after disconnect", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
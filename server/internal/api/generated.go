@@ -25,6 +25,15 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ContactCheckStatus.
+const (
+	AlreadyContact ContactCheckStatus = "already_contact"
+	Blocked        ContactCheckStatus = "blocked"
+	CanRequest     ContactCheckStatus = "can_request"
+	NotAccepting   ContactCheckStatus = "not_accepting"
+	RequestPending ContactCheckStatus = "request_pending"
+)
+
 // Defines values for ContactRequestDirection.
 const (
 	Incoming ContactRequestDirection = "incoming"
@@ -35,6 +44,7 @@ const (
 const (
 	Accepted ContactRequestStatus = "accepted"
 	Declined ContactRequestStatus = "declined"
+	Expired  ContactRequestStatus = "expired"
 	Pending  ContactRequestStatus = "pending"
 )
 
@@ -74,9 +84,68 @@ const (
 
 // Defines values for IdentityBackupKdf.
 const (
+	Argon2id     IdentityBackupKdf = "argon2id"
 	PBKDF2SHA256 IdentityBackupKdf = "PBKDF2-SHA256"
 )
 
+// Defines values for LocationPrecision.
+const (
+	City    LocationPrecision = "city"
+	Country LocationPrecision = "country"
+	Exact   LocationPrecision = "exact"
+)
+
+// Defines values for ReadinessResponseComponents.
+const (
+	Fail ReadinessResponseComponents = "fail"
+	Ok   ReadinessResponseComponents = "ok"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	Degraded ReadinessResponseStatus = "degraded"
+	Ready    ReadinessResponseStatus = "ready"
+)
+
+// AppleLoginRequest defines model for AppleLoginRequest.
+type AppleLoginRequest struct {
+	// IdToken Sign in with Apple identity token
+	IdToken string `json:"idToken"`
+
+	// Name User's name from Apple's authorization response, which is only
+	// included the first time the user signs in to the app
+	Name *string `json:"name,omitempty"`
+
+	// Nonce Nonce the app generated for this sign in. The app passes Apple
+	// its SHA-256 in hex and sends the nonce itself here; the token's
+	// nonce claim is not accepted.
+	Nonce string `json:"nonce"`
+}
+
+// BlockCreate defines model for BlockCreate.
+type BlockCreate struct {
+	// UserId User to block, from a contact or contact request
+	UserId string `json:"userId"`
+}
+
+// BlockedList defines model for BlockedList.
+type BlockedList struct {
+	Users []BlockedUser `json:"users"`
+}
+
+// BlockedUser defines model for BlockedUser.
+type BlockedUser struct {
+	BlockedAt time.Time           `json:"blockedAt"`
+	Email     openapi_types.Email `json:"email"`
+	Name      string              `json:"name"`
+	UserId    string              `json:"userId"`
+}
+
+// Capabilities defines model for Capabilities.
+type Capabilities struct {
+	Features []string `json:"features"`
+}
+
 // ConflictError defines model for ConflictError.
 type ConflictError struct {
 	// CurrentVersion Current version on server
@@ -99,24 +168,71 @@ type Contact struct {
 	// Name Contact's display name
 	Name string `json:"name"`
 
+	// Note Private note visible only to the owner; absent if none
+	Note *string `json:"note,omitempty"`
+
 	// PublicKey Base64-encoded X25519 public key for encrypting locations
 	PublicKey string `json:"publicKey"`
+
+	// SortOrder Pin position (lower first); absent if not pinned
+	SortOrder *int `json:"sortOrder,omitempty"`
+
+	// UpdatedAt When the contact's name, key, pin or note last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ContactCheck defines model for ContactCheck.
+type ContactCheck struct {
+	// Status can_request: a request can be sent.
+	// already_contact: the user is already a contact.
+	// request_pending: a request between the users is pending in either direction.
+	// blocked: requests to this user are blocked.
+	// not_accepting: the user has turned off incoming requests.
+	Status ContactCheckStatus `json:"status"`
 }
 
+// ContactCheckStatus can_request: a request can be sent.
+// already_contact: the user is already a contact.
+// request_pending: a request between the users is pending in either direction.
+// blocked: requests to this user are blocked.
+// not_accepting: the user has turned off incoming requests.
+type ContactCheckStatus string
+
 // ContactList defines model for ContactList.
 type ContactList struct {
 	Contacts []Contact `json:"contacts"`
+
+	// NextOffset Offset of the next page; absent on the last page
+	NextOffset *int `json:"nextOffset,omitempty"`
+
+	// Total Total number of contacts; present when paging
+	Total *int `json:"total,omitempty"`
+}
+
+// ContactNoteUpdate defines model for ContactNoteUpdate.
+type ContactNoteUpdate struct {
+	// Note Note text; empty to remove
+	Note string `json:"note"`
+}
+
+// ContactOrderUpdate defines model for ContactOrderUpdate.
+type ContactOrderUpdate struct {
+	// SortOrder Pin position (lower first); null to unpin
+	SortOrder *int `json:"sortOrder"`
 }
 
 // ContactRequest defines model for ContactRequest.
 type ContactRequest struct {
-	CreatedAt time.Time `json:"createdAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	DeclinedAt *time.Time `json:"declinedAt,omitempty"`
 
 	// Direction Whether this is an incoming or outgoing request
 	Direction *ContactRequestDirection `json:"direction,omitempty"`
 
 	// Email Email of the other user
-	Email openapi_types.Email `json:"email"`
+	Email     openapi_types.Email `json:"email"`
+	ExpiredAt *time.Time          `json:"expiredAt,omitempty"`
 
 	// Id Request ID
 	Id string `json:"id"`
@@ -124,6 +240,9 @@ type ContactRequest struct {
 	// Name Name of the other user (if available)
 	Name   *string              `json:"name,omitempty"`
 	Status ContactRequestStatus `json:"status"`
+
+	// UserId ID of the other user
+	UserId *string `json:"userId,omitempty"`
 }
 
 // ContactRequestDirection Whether this is an incoming or outgoing request
@@ -138,15 +257,54 @@ type ContactRequestCreate struct {
 	Email openapi_types.Email `json:"email"`
 }
 
+// ContactRequestHistory defines model for ContactRequestHistory.
+type ContactRequestHistory struct {
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string          `json:"nextCursor,omitempty"`
+	Requests   []ContactRequest `json:"requests"`
+}
+
 // ContactRequestList defines model for ContactRequestList.
 type ContactRequestList struct {
 	Incoming []ContactRequest `json:"incoming"`
 	Outgoing []ContactRequest `json:"outgoing"`
 }
 
+// ContactSummary defines model for ContactSummary.
+type ContactSummary struct {
+	// Contacts Accepted contacts
+	Contacts int `json:"contacts"`
+
+	// PendingIncoming Pending requests received
+	PendingIncoming int `json:"pendingIncoming"`
+
+	// PendingOutgoing Pending requests sent
+	PendingOutgoing int `json:"pendingOutgoing"`
+
+	// SharingFrom Contacts who have shared a location with the user
+	SharingFrom int `json:"sharingFrom"`
+
+	// SharingTo Contacts the user has shared a location with
+	SharingTo int `json:"sharingTo"`
+
+	// WithPublicKey Contacts who have published a public key
+	WithPublicKey int `json:"withPublicKey"`
+}
+
+// DevLoginRequest defines model for DevLoginRequest.
+type DevLoginRequest struct {
+	// Email Email to sign in as
+	Email string `json:"email"`
+
+	// Name Name for a new user (defaults to the email's local part)
+	Name *string `json:"name,omitempty"`
+}
+
 // Device defines model for Device.
 type Device struct {
-	CreatedAt time.Time `json:"createdAt"`
+	// ActiveSessions Number of unexpired sessions bound to the device
+	ActiveSessions *int      `json:"activeSessions,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
 
 	// Id Device ID
 	Id string `json:"id"`
@@ -159,8 +317,17 @@ type Device struct {
 	LastSeen  time.Time `json:"lastSeen"`
 
 	// Name User-friendly device name
-	Name     string         `json:"name"`
-	Platform DevicePlatform `json:"platform"`
+	Name string `json:"name"`
+
+	// PausedUntil When the device's pause ends; absent if it isn't paused
+	PausedUntil *time.Time     `json:"pausedUntil,omitempty"`
+	Platform    DevicePlatform `json:"platform"`
+
+	// SessionExpiresAt When the device's last active session expires; absent without one
+	SessionExpiresAt *time.Time `json:"sessionExpiresAt,omitempty"`
+
+	// UserAgent App and version the device registered with
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DevicePlatform defines model for Device.Platform.
@@ -171,6 +338,9 @@ type DeviceCreate struct {
 	// Name User-friendly device name
 	Name     string               `json:"name"`
 	Platform DeviceCreatePlatform `json:"platform"`
+
+	// UserAgent App and version, e.g. "Whereish iOS/2.3.1". Defaults to the User-Agent header.
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DeviceCreatePlatform defines model for DeviceCreate.Platform.
@@ -181,9 +351,23 @@ type DeviceList struct {
 	Devices []Device `json:"devices"`
 }
 
+// DevicePause defines model for DevicePause.
+type DevicePause struct {
+	// Until When the pause ends; must be in the future
+	Until time.Time `json:"until"`
+}
+
+// DeviceUpdate defines model for DeviceUpdate.
+type DeviceUpdate struct {
+	// Name New device name
+	Name string `json:"name"`
+}
+
 // DeviceWithToken defines model for DeviceWithToken.
 type DeviceWithToken struct {
-	CreatedAt time.Time `json:"createdAt"`
+	// ActiveSessions Number of unexpired sessions bound to the device
+	ActiveSessions *int      `json:"activeSessions,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
 
 	// Id Device ID
 	Id string `json:"id"`
@@ -196,11 +380,21 @@ type DeviceWithToken struct {
 	LastSeen  time.Time `json:"lastSeen"`
 
 	// Name User-friendly device name
-	Name     string                  `json:"name"`
-	Platform DeviceWithTokenPlatform `json:"platform"`
+	Name string `json:"name"`
+
+	// PausedUntil When the device's pause ends; absent if it isn't paused
+	PausedUntil *time.Time              `json:"pausedUntil,omitempty"`
+	Platform    DeviceWithTokenPlatform `json:"platform"`
 
-	// Token Device token for API authentication
+	// SessionExpiresAt When the device's last active session expires; absent without one
+	SessionExpiresAt *time.Time `json:"sessionExpiresAt,omitempty"`
+
+	// Token Device token. Not sent with requests: the device is
+	// identified by the session bound to it.
 	Token string `json:"token"`
+
+	// UserAgent App and version the device registered with
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DeviceWithTokenPlatform defines model for DeviceWithToken.Platform.
@@ -213,9 +407,18 @@ type EncryptedLocation struct {
 	// Contains location hierarchy, optional named location, and timestamp.
 	Blob string `json:"blob"`
 
+	// ExpiresAt When the share expires; absent if it doesn't
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// FromUserId User ID who shared this location
 	FromUserId string `json:"fromUserId"`
 
+	// Precision Sender-declared, unencrypted label for how precise the encrypted
+	// location is, so recipients can decide how to render it and senders
+	// can review what they share. It's a hint; the server can't check it
+	// against the blob. Defaults to exact.
+	Precision LocationPrecision `json:"precision"`
+
 	// UpdatedAt When the location was last updated
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -253,7 +456,12 @@ type IdentityBackup struct {
 	// Algorithm Encryption algorithm
 	Algorithm IdentityBackupAlgorithm `json:"algorithm"`
 
-	// Iterations KDF iterations
+	// Generation Current generation when read. When writing, the generation being
+	// replaced (omit or 0 to create).
+	Generation *int `json:"generation,omitempty"`
+
+	// Iterations PBKDF2 iterations (the accepted range is server-configurable), or
+	// the Argon2id time cost (1-64)
 	Iterations int `json:"iterations"`
 
 	// Iv Base64-encoded IV (12 bytes)
@@ -262,6 +470,12 @@ type IdentityBackup struct {
 	// Kdf Key derivation function
 	Kdf IdentityBackupKdf `json:"kdf"`
 
+	// Memory Argon2id memory in KiB, at least 8 per thread (argon2id only)
+	Memory *int `json:"memory,omitempty"`
+
+	// Parallelism Argon2id threads (argon2id only)
+	Parallelism *int `json:"parallelism,omitempty"`
+
 	// Payload Base64-encoded ciphertext of encrypted keypair
 	Payload string `json:"payload"`
 
@@ -275,16 +489,57 @@ type IdentityBackupAlgorithm string
 // IdentityBackupKdf Key derivation function
 type IdentityBackupKdf string
 
+// IdentityBackupMeta defines model for IdentityBackupMeta.
+type IdentityBackupMeta struct {
+	// Algorithm Encryption algorithm
+	Algorithm string `json:"algorithm"`
+
+	// Iterations KDF iterations, or the Argon2id time cost
+	Iterations int `json:"iterations"`
+
+	// Kdf Key derivation function
+	Kdf string `json:"kdf"`
+
+	// Memory Argon2id memory in KiB (argon2id only)
+	Memory *int `json:"memory,omitempty"`
+
+	// Parallelism Argon2id threads (argon2id only)
+	Parallelism *int `json:"parallelism,omitempty"`
+}
+
 // LocationList defines model for LocationList.
 type LocationList struct {
 	Locations []EncryptedLocation `json:"locations"`
+
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Total Total number of locations; present when paging
+	Total *int `json:"total,omitempty"`
 }
 
+// LocationPrecision Sender-declared, unencrypted label for how precise the encrypted
+// location is, so recipients can decide how to render it and senders
+// can review what they share. It's a hint; the server can't check it
+// against the blob. Defaults to exact.
+type LocationPrecision string
+
 // LocationShare defines model for LocationShare.
 type LocationShare struct {
 	// Blob Base64-encoded NaCl box ciphertext for this recipient
 	Blob string `json:"blob"`
 
+	// ExpiresAt When the share should disappear; must be in the future. From
+	// then on the recipient no longer sees it, and it is deleted soon
+	// after. Omit to keep the share until it's replaced.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Precision Sender-declared, unencrypted label for how precise the encrypted
+	// location is, so recipients can decide how to render it and senders
+	// can review what they share. It's a hint; the server can't check it
+	// against the blob. Defaults to exact.
+	Precision *LocationPrecision `json:"precision,omitempty"`
+
 	// ToUserId User ID to share location with
 	ToUserId string `json:"toUserId"`
 }
@@ -294,22 +549,197 @@ type LocationShareRequest struct {
 	Locations []LocationShare `json:"locations"`
 }
 
+// LocationShareResult defines model for LocationShareResult.
+type LocationShareResult struct {
+	// Error Error code when ok is false
+	Error *string `json:"error,omitempty"`
+
+	// Ok Whether the location was written for this recipient
+	Ok       bool   `json:"ok"`
+	ToUserId string `json:"toUserId"`
+}
+
+// LocationShareResults defines model for LocationShareResults.
+type LocationShareResults struct {
+	Results []LocationShareResult `json:"results"`
+}
+
+// LocationSnapshot defines model for LocationSnapshot.
+type LocationSnapshot struct {
+	Locations []EncryptedLocation `json:"locations"`
+
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// SnapshotAt When the snapshot was started; the same on every page
+	SnapshotAt time.Time `json:"snapshotAt"`
+}
+
 // LoginResponse defines model for LoginResponse.
 type LoginResponse struct {
 	// IsNewUser True if this is the user's first login
 	IsNewUser *bool `json:"isNewUser,omitempty"`
 
+	// RefreshToken Long-lived token for POST /auth/refresh, omitted if the server
+	// doesn't issue them
+	RefreshToken *string `json:"refreshToken,omitempty"`
+
 	// Token Session token for API authentication
 	Token string `json:"token"`
 	User  User   `json:"user"`
 }
 
+// Nudge defines model for Nudge.
+type Nudge struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// FromUserId Contact asking for a location
+	FromUserId string `json:"fromUserId"`
+}
+
+// NudgeList defines model for NudgeList.
+type NudgeList struct {
+	Nudges []Nudge `json:"nudges"`
+}
+
+// OnboardRequest defines model for OnboardRequest.
+type OnboardRequest struct {
+	IdentityBackup IdentityBackup `json:"identityBackup"`
+
+	// PublicKey Base64-encoded X25519 public key (32 bytes)
+	PublicKey string `json:"publicKey"`
+}
+
+// Presence defines model for Presence.
+type Presence struct {
+	// Blob Base64-encoded NaCl box ciphertext
+	Blob       string    `json:"blob"`
+	FromUserId string    `json:"fromUserId"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// PresenceList defines model for PresenceList.
+type PresenceList struct {
+	Presence []Presence `json:"presence"`
+}
+
+// PresenceShare defines model for PresenceShare.
+type PresenceShare struct {
+	// Blob Base64-encoded NaCl box ciphertext for this recipient
+	Blob     string `json:"blob"`
+	ToUserId string `json:"toUserId"`
+}
+
+// PresenceUpdate defines model for PresenceUpdate.
+type PresenceUpdate struct {
+	Statuses []PresenceShare `json:"statuses"`
+}
+
+// ProfileUpdate defines model for ProfileUpdate.
+type ProfileUpdate struct {
+	// Name New display name
+	Name string `json:"name"`
+}
+
 // PublicKeyRequest defines model for PublicKeyRequest.
 type PublicKeyRequest struct {
 	// PublicKey Base64-encoded X25519 public key (32 bytes)
 	PublicKey string `json:"publicKey"`
 }
 
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	// Components Status of each checked component
+	Components map[string]ReadinessResponseComponents `json:"components"`
+	Status     ReadinessResponseStatus                `json:"status"`
+}
+
+// ReadinessResponseComponents defines model for ReadinessResponse.Components.
+type ReadinessResponseComponents string
+
+// ReadinessResponseStatus defines model for ReadinessResponse.Status.
+type ReadinessResponseStatus string
+
+// RecoveryCodeStatus defines model for RecoveryCodeStatus.
+type RecoveryCodeStatus struct {
+	// Remaining Unused recovery codes
+	Remaining int `json:"remaining"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// Codes One-time recovery codes. Store them somewhere safe.
+	Codes []string `json:"codes"`
+}
+
+// RecoveryLoginRequest defines model for RecoveryLoginRequest.
+type RecoveryLoginRequest struct {
+	// Code Recovery code; case, spaces and dashes are ignored
+	Code string `json:"code"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshResponse defines model for RefreshResponse.
+type RefreshResponse struct {
+	// ExpiresAt When the new session expires
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// RefreshToken Replaces the refresh token that was used
+	RefreshToken string `json:"refreshToken"`
+
+	// Token New session token
+	Token string `json:"token"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeviceId Device the session is bound to; absent if none
+	DeviceId *string `json:"deviceId,omitempty"`
+
+	// DeviceName Name of the bound device
+	DeviceName *string   `json:"deviceName,omitempty"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+
+	// IsCurrent Whether this is the session making the request
+	IsCurrent *bool `json:"isCurrent,omitempty"`
+
+	// Token Masked token, the first few characters followed by an ellipsis
+	Token string `json:"token"`
+}
+
+// SessionList defines model for SessionList.
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// StorageUsage defines model for StorageUsage.
+type StorageUsage struct {
+	// IdentityBackup Bytes used by the identity backup
+	IdentityBackup int64 `json:"identityBackup"`
+
+	// Locations Bytes used by locations shared with contacts
+	Locations int64 `json:"locations"`
+
+	// Quota Per-user storage quota in bytes (0 means unlimited)
+	Quota int64 `json:"quota"`
+	Total int64 `json:"total"`
+
+	// UserData Bytes used by the encrypted user data blob
+	UserData int64 `json:"userData"`
+}
+
+// TokenValidation defines model for TokenValidation.
+type TokenValidation struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserId    string    `json:"userId"`
+}
+
 // User defines model for User.
 type User struct {
 	// CreatedAt Account creation timestamp
@@ -321,12 +751,21 @@ type User struct {
 	// HasIdentityBackup Whether user has stored an identity backup
 	HasIdentityBackup *bool `json:"hasIdentityBackup,omitempty"`
 
+	// HasIncomingLocations Whether anyone is sharing a location with the user (GET /me only)
+	HasIncomingLocations *bool `json:"hasIncomingLocations,omitempty"`
+
 	// HasUserData Whether user has stored encrypted user data
 	HasUserData *bool `json:"hasUserData,omitempty"`
 
 	// Id Unique user identifier
 	Id string `json:"id"`
 
+	// LastLoginAt Most recent successful login
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+
+	// LatestLocationAt When a location was most recently shared with the user (GET /me only)
+	LatestLocationAt *time.Time `json:"latestLocationAt,omitempty"`
+
 	// Name Display name
 	Name string `json:"name"`
 
@@ -357,6 +796,41 @@ type UserDataUpdate struct {
 	Version int `json:"version"`
 }
 
+// UserSettings defines model for UserSettings.
+type UserSettings struct {
+	// AcceptRequests Whether other users can send new contact requests
+	AcceptRequests bool `json:"acceptRequests"`
+
+	// AutoAcceptRequests Whether incoming contact requests are accepted as soon as they arrive
+	AutoAcceptRequests bool `json:"autoAcceptRequests"`
+
+	// Discoverable Whether other users can send contact requests by email
+	Discoverable bool `json:"discoverable"`
+
+	// LastKnownMode Whether contacts keep seeing the last shared location when sharing
+	// stops. When off, turning sharing off deletes the user's shared
+	// locations.
+	LastKnownMode bool `json:"lastKnownMode"`
+
+	// SharingEnabled Whether the user can share locations; when off, shares are rejected
+	SharingEnabled bool `json:"sharingEnabled"`
+}
+
+// UserSettingsUpdate defines model for UserSettingsUpdate.
+type UserSettingsUpdate struct {
+	AcceptRequests     *bool `json:"acceptRequests,omitempty"`
+	AutoAcceptRequests *bool `json:"autoAcceptRequests,omitempty"`
+	Discoverable       *bool `json:"discoverable,omitempty"`
+	LastKnownMode      *bool `json:"lastKnownMode,omitempty"`
+	SharingEnabled     *bool `json:"sharingEnabled,omitempty"`
+}
+
+// EventFrom defines model for EventFrom.
+type EventFrom = []string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
 // ContactId defines model for contactId.
 type ContactId = string
 
@@ -366,6 +840,12 @@ type DeviceId = string
 // RequestId defines model for requestId.
 type RequestId = string
 
+// SessionToken defines model for sessionToken.
+type SessionToken = string
+
+// UserId defines model for userId.
+type UserId = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
@@ -375,15 +855,129 @@ type NotFound = Error
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// ListContactsParams defines parameters for ListContacts.
+type ListContactsParams struct {
+	// Since Only return contacts updated after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Maximum contacts per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of contacts to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// CheckContactParams defines parameters for CheckContact.
+type CheckContactParams struct {
+	// Email Email of the user to check
+	Email string `form:"email" json:"email"`
+}
+
+// GetContactRequestHistoryParams defines parameters for GetContactRequestHistory.
+type GetContactRequestHistoryParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum requests per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// From Contact user IDs to receive events from; repeat for several (default all)
+	From *EventFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// LastEventID ID of the last event received, to resume after it
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetIdentityBackupParams defines parameters for GetIdentityBackup.
+type GetIdentityBackupParams struct {
+	// IfNoneMatch ETag from an earlier response, to get a 304 if unchanged
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// SetIdentityBackupParams defines parameters for SetIdentityBackup.
+type SetIdentityBackupParams struct {
+	// Overwrite Replace an existing backup without passing its generation
+	Overwrite *bool `form:"overwrite,omitempty" json:"overwrite,omitempty"`
+}
+
+// GetLocationsParams defines parameters for GetLocations.
+type GetLocationsParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum locations per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ShareLocationsParams defines parameters for ShareLocations.
+type ShareLocationsParams struct {
+	// Partial Write recipients independently and report per-recipient results
+	Partial *bool `form:"partial,omitempty" json:"partial,omitempty"`
+}
+
+// GetLocationSnapshotParams defines parameters for GetLocationSnapshot.
+type GetLocationSnapshotParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum locations per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// StreamLocationsParams defines parameters for StreamLocations.
+type StreamLocationsParams struct {
+	// From Contact user IDs to receive events from; repeat for several (default all)
+	From *EventFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// LastEventID ID of the last event received, to resume after it
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetUserDataParams defines parameters for GetUserData.
+type GetUserDataParams struct {
+	// IfNoneMatch ETag from an earlier response, to get a 304 if unchanged
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// LoginWithAppleJSONRequestBody defines body for LoginWithApple for application/json ContentType.
+type LoginWithAppleJSONRequestBody = AppleLoginRequest
+
 // LoginWithGoogleJSONRequestBody defines body for LoginWithGoogle for application/json ContentType.
 type LoginWithGoogleJSONRequestBody = GoogleLoginRequest
 
+// LoginWithRecoveryCodeJSONRequestBody defines body for LoginWithRecoveryCode for application/json ContentType.
+type LoginWithRecoveryCodeJSONRequestBody = RecoveryLoginRequest
+
+// RefreshSessionJSONRequestBody defines body for RefreshSession for application/json ContentType.
+type RefreshSessionJSONRequestBody = RefreshRequest
+
+// BlockUserJSONRequestBody defines body for BlockUser for application/json ContentType.
+type BlockUserJSONRequestBody = BlockCreate
+
 // SendContactRequestJSONRequestBody defines body for SendContactRequest for application/json ContentType.
 type SendContactRequestJSONRequestBody = ContactRequestCreate
 
+// SetContactNoteJSONRequestBody defines body for SetContactNote for application/json ContentType.
+type SetContactNoteJSONRequestBody = ContactNoteUpdate
+
+// SetContactOrderJSONRequestBody defines body for SetContactOrder for application/json ContentType.
+type SetContactOrderJSONRequestBody = ContactOrderUpdate
+
+// DevLoginJSONRequestBody defines body for DevLogin for application/json ContentType.
+type DevLoginJSONRequestBody = DevLoginRequest
+
 // RegisterDeviceJSONRequestBody defines body for RegisterDevice for application/json ContentType.
 type RegisterDeviceJSONRequestBody = DeviceCreate
 
+// RenameDeviceJSONRequestBody defines body for RenameDevice for application/json ContentType.
+type RenameDeviceJSONRequestBody = DeviceUpdate
+
+// PauseDeviceJSONRequestBody defines body for PauseDevice for application/json ContentType.
+type PauseDeviceJSONRequestBody = DevicePause
+
 // SetIdentityBackupJSONRequestBody defines body for SetIdentityBackup for application/json ContentType.
 type SetIdentityBackupJSONRequestBody = IdentityBackup
 
@@ -393,6 +987,18 @@ type SetPublicKeyJSONRequestBody = PublicKeyRequest
 // ShareLocationsJSONRequestBody defines body for ShareLocations for application/json ContentType.
 type ShareLocationsJSONRequestBody = LocationShareRequest
 
+// UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
+type UpdateProfileJSONRequestBody = ProfileUpdate
+
+// UpdateSettingsJSONRequestBody defines body for UpdateSettings for application/json ContentType.
+type UpdateSettingsJSONRequestBody = UserSettingsUpdate
+
+// OnboardJSONRequestBody defines body for Onboard for application/json ContentType.
+type OnboardJSONRequestBody = OnboardRequest
+
+// SetPresenceJSONRequestBody defines body for SetPresence for application/json ContentType.
+type SetPresenceJSONRequestBody = PresenceUpdate
+
 // SetUserDataJSONRequestBody defines body for SetUserData for application/json ContentType.
 type SetUserDataJSONRequestBody = UserDataUpdate
 
@@ -401,21 +1007,60 @@ type ServerInterface interface {
 	// Delete user account
 	// (DELETE /auth/account)
 	DeleteAccount(w http.ResponseWriter, r *http.Request)
+	// Login with Sign in with Apple
+	// (POST /auth/apple)
+	LoginWithApple(w http.ResponseWriter, r *http.Request)
 	// Login with Google OAuth
 	// (POST /auth/google)
 	LoginWithGoogle(w http.ResponseWriter, r *http.Request)
 	// End current session
 	// (POST /auth/logout)
 	Logout(w http.ResponseWriter, r *http.Request)
+	// Login with a recovery code
+	// (POST /auth/recovery)
+	LoginWithRecoveryCode(w http.ResponseWriter, r *http.Request)
+	// Count unused recovery codes
+	// (GET /auth/recovery-codes)
+	GetRecoveryCodeStatus(w http.ResponseWriter, r *http.Request)
+	// Generate new recovery codes
+	// (POST /auth/recovery-codes)
+	GenerateRecoveryCodes(w http.ResponseWriter, r *http.Request)
+	// Refresh a session
+	// (POST /auth/refresh)
+	RefreshSession(w http.ResponseWriter, r *http.Request)
+	// Check the current session
+	// (GET /auth/validate)
+	ValidateToken(w http.ResponseWriter, r *http.Request)
+	// List server features
+	// (GET /capabilities)
+	GetCapabilities(w http.ResponseWriter, r *http.Request)
 	// List contacts
 	// (GET /contacts)
-	ListContacts(w http.ResponseWriter, r *http.Request)
+	ListContacts(w http.ResponseWriter, r *http.Request, params ListContactsParams)
+	// Block a user
+	// (POST /contacts/block)
+	BlockUser(w http.ResponseWriter, r *http.Request)
+	// Unblock a user
+	// (DELETE /contacts/block/{userId})
+	UnblockUser(w http.ResponseWriter, r *http.Request, userId UserId)
+	// List blocked users
+	// (GET /contacts/blocked)
+	ListBlocked(w http.ResponseWriter, r *http.Request)
+	// Check whether a contact request can be sent
+	// (GET /contacts/check)
+	CheckContact(w http.ResponseWriter, r *http.Request, params CheckContactParams)
+	// List nudges from contacts
+	// (GET /contacts/nudges)
+	ListNudges(w http.ResponseWriter, r *http.Request)
 	// Send contact request
 	// (POST /contacts/request)
 	SendContactRequest(w http.ResponseWriter, r *http.Request)
 	// List contact requests
 	// (GET /contacts/requests)
 	ListContactRequests(w http.ResponseWriter, r *http.Request)
+	// List past contact requests
+	// (GET /contacts/requests/history)
+	GetContactRequestHistory(w http.ResponseWriter, r *http.Request, params GetContactRequestHistoryParams)
 	// Cancel contact request
 	// (DELETE /contacts/requests/{requestId})
 	CancelContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId)
@@ -425,9 +1070,24 @@ type ServerInterface interface {
 	// Decline contact request
 	// (POST /contacts/requests/{requestId}/decline)
 	DeclineContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId)
+	// Get contact and sharing counts
+	// (GET /contacts/summary)
+	GetContactSummary(w http.ResponseWriter, r *http.Request)
 	// Remove contact
 	// (DELETE /contacts/{contactId})
 	RemoveContact(w http.ResponseWriter, r *http.Request, contactId ContactId)
+	// Set private contact note
+	// (PUT /contacts/{contactId}/note)
+	SetContactNote(w http.ResponseWriter, r *http.Request, contactId ContactId)
+	// Ask a contact to share their location
+	// (POST /contacts/{contactId}/nudge)
+	NudgeContact(w http.ResponseWriter, r *http.Request, contactId ContactId)
+	// Set contact sort order
+	// (PUT /contacts/{contactId}/order)
+	SetContactOrder(w http.ResponseWriter, r *http.Request, contactId ContactId)
+	// Login with an email only (dev mode)
+	// (POST /dev/login)
+	DevLogin(w http.ResponseWriter, r *http.Request)
 	// List devices
 	// (GET /devices)
 	ListDevices(w http.ResponseWriter, r *http.Request)
@@ -437,30 +1097,84 @@ type ServerInterface interface {
 	// Revoke device
 	// (DELETE /devices/{deviceId})
 	RevokeDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId)
+	// Rename device
+	// (PATCH /devices/{deviceId})
+	RenameDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId)
+	// Pause device
+	// (POST /devices/{deviceId}/pause)
+	PauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId)
+	// Unpause device
+	// (POST /devices/{deviceId}/unpause)
+	UnpauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId)
+	// Stream events
+	// (GET /events)
+	StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams)
 	// Health check
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
 	// Get encrypted identity backup
 	// (GET /identity/backup)
-	GetIdentityBackup(w http.ResponseWriter, r *http.Request)
+	GetIdentityBackup(w http.ResponseWriter, r *http.Request, params GetIdentityBackupParams)
 	// Store encrypted identity backup
 	// (PUT /identity/backup)
-	SetIdentityBackup(w http.ResponseWriter, r *http.Request)
+	SetIdentityBackup(w http.ResponseWriter, r *http.Request, params SetIdentityBackupParams)
+	// Get identity backup parameters
+	// (GET /identity/backup/meta)
+	GetIdentityBackupMeta(w http.ResponseWriter, r *http.Request)
 	// Register public key
 	// (POST /identity/public-key)
 	SetPublicKey(w http.ResponseWriter, r *http.Request)
 	// Get locations from contacts
 	// (GET /locations)
-	GetLocations(w http.ResponseWriter, r *http.Request)
+	GetLocations(w http.ResponseWriter, r *http.Request, params GetLocationsParams)
 	// Share locations with contacts
 	// (POST /locations)
-	ShareLocations(w http.ResponseWriter, r *http.Request)
+	ShareLocations(w http.ResponseWriter, r *http.Request, params ShareLocationsParams)
+	// Page through all locations shared with the user
+	// (GET /locations/all)
+	GetLocationSnapshot(w http.ResponseWriter, r *http.Request, params GetLocationSnapshotParams)
+	// Stream incoming locations
+	// (GET /locations/stream)
+	StreamLocations(w http.ResponseWriter, r *http.Request, params StreamLocationsParams)
 	// Get current user info
 	// (GET /me)
 	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+	// Update profile
+	// (PATCH /me)
+	UpdateProfile(w http.ResponseWriter, r *http.Request)
+	// Get user settings
+	// (GET /me/settings)
+	GetSettings(w http.ResponseWriter, r *http.Request)
+	// Update user settings
+	// (PUT /me/settings)
+	UpdateSettings(w http.ResponseWriter, r *http.Request)
+	// Get storage usage
+	// (GET /me/usage)
+	GetStorageUsage(w http.ResponseWriter, r *http.Request)
+	// Set up a new account's keys
+	// (POST /onboard)
+	Onboard(w http.ResponseWriter, r *http.Request)
+	// OpenAPI specification
+	// (GET /openapi.json)
+	GetOpenAPISpec(w http.ResponseWriter, r *http.Request)
+	// Get check-ins from contacts
+	// (GET /presence)
+	GetPresence(w http.ResponseWriter, r *http.Request)
+	// Check in with contacts
+	// (POST /presence)
+	SetPresence(w http.ResponseWriter, r *http.Request)
+	// Readiness check
+	// (GET /ready)
+	GetReadiness(w http.ResponseWriter, r *http.Request)
+	// List sessions
+	// (GET /sessions)
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke session
+	// (DELETE /sessions/{token})
+	RevokeSession(w http.ResponseWriter, r *http.Request, token SessionToken)
 	// Get encrypted user data
 	// (GET /user-data)
-	GetUserData(w http.ResponseWriter, r *http.Request)
+	GetUserData(w http.ResponseWriter, r *http.Request, params GetUserDataParams)
 	// Update encrypted user data
 	// (PUT /user-data)
 	SetUserData(w http.ResponseWriter, r *http.Request)
@@ -476,6 +1190,12 @@ func (_ Unimplemented) DeleteAccount(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Login with Sign in with Apple
+// (POST /auth/apple)
+func (_ Unimplemented) LoginWithApple(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Login with Google OAuth
 // (POST /auth/google)
 func (_ Unimplemented) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
@@ -488,81 +1208,213 @@ func (_ Unimplemented) Logout(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List contacts
-// (GET /contacts)
-func (_ Unimplemented) ListContacts(w http.ResponseWriter, r *http.Request) {
+// Login with a recovery code
+// (POST /auth/recovery)
+func (_ Unimplemented) LoginWithRecoveryCode(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Send contact request
-// (POST /contacts/request)
-func (_ Unimplemented) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+// Count unused recovery codes
+// (GET /auth/recovery-codes)
+func (_ Unimplemented) GetRecoveryCodeStatus(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List contact requests
-// (GET /contacts/requests)
-func (_ Unimplemented) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+// Generate new recovery codes
+// (POST /auth/recovery-codes)
+func (_ Unimplemented) GenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Cancel contact request
-// (DELETE /contacts/requests/{requestId})
-func (_ Unimplemented) CancelContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+// Refresh a session
+// (POST /auth/refresh)
+func (_ Unimplemented) RefreshSession(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Accept contact request
-// (POST /contacts/requests/{requestId}/accept)
-func (_ Unimplemented) AcceptContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+// Check the current session
+// (GET /auth/validate)
+func (_ Unimplemented) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Decline contact request
-// (POST /contacts/requests/{requestId}/decline)
-func (_ Unimplemented) DeclineContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+// List server features
+// (GET /capabilities)
+func (_ Unimplemented) GetCapabilities(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Remove contact
-// (DELETE /contacts/{contactId})
-func (_ Unimplemented) RemoveContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+// List contacts
+// (GET /contacts)
+func (_ Unimplemented) ListContacts(w http.ResponseWriter, r *http.Request, params ListContactsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// List devices
-// (GET /devices)
-func (_ Unimplemented) ListDevices(w http.ResponseWriter, r *http.Request) {
+// Block a user
+// (POST /contacts/block)
+func (_ Unimplemented) BlockUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Register device
-// (POST /devices)
-func (_ Unimplemented) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+// Unblock a user
+// (DELETE /contacts/block/{userId})
+func (_ Unimplemented) UnblockUser(w http.ResponseWriter, r *http.Request, userId UserId) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Revoke device
-// (DELETE /devices/{deviceId})
-func (_ Unimplemented) RevokeDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+// List blocked users
+// (GET /contacts/blocked)
+func (_ Unimplemented) ListBlocked(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Health check
-// (GET /health)
-func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
+// Check whether a contact request can be sent
+// (GET /contacts/check)
+func (_ Unimplemented) CheckContact(w http.ResponseWriter, r *http.Request, params CheckContactParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get encrypted identity backup
+// List nudges from contacts
+// (GET /contacts/nudges)
+func (_ Unimplemented) ListNudges(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send contact request
+// (POST /contacts/request)
+func (_ Unimplemented) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List contact requests
+// (GET /contacts/requests)
+func (_ Unimplemented) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List past contact requests
+// (GET /contacts/requests/history)
+func (_ Unimplemented) GetContactRequestHistory(w http.ResponseWriter, r *http.Request, params GetContactRequestHistoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel contact request
+// (DELETE /contacts/requests/{requestId})
+func (_ Unimplemented) CancelContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Accept contact request
+// (POST /contacts/requests/{requestId}/accept)
+func (_ Unimplemented) AcceptContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Decline contact request
+// (POST /contacts/requests/{requestId}/decline)
+func (_ Unimplemented) DeclineContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get contact and sharing counts
+// (GET /contacts/summary)
+func (_ Unimplemented) GetContactSummary(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove contact
+// (DELETE /contacts/{contactId})
+func (_ Unimplemented) RemoveContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set private contact note
+// (PUT /contacts/{contactId}/note)
+func (_ Unimplemented) SetContactNote(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Ask a contact to share their location
+// (POST /contacts/{contactId}/nudge)
+func (_ Unimplemented) NudgeContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set contact sort order
+// (PUT /contacts/{contactId}/order)
+func (_ Unimplemented) SetContactOrder(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Login with an email only (dev mode)
+// (POST /dev/login)
+func (_ Unimplemented) DevLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List devices
+// (GET /devices)
+func (_ Unimplemented) ListDevices(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register device
+// (POST /devices)
+func (_ Unimplemented) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke device
+// (DELETE /devices/{deviceId})
+func (_ Unimplemented) RevokeDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename device
+// (PATCH /devices/{deviceId})
+func (_ Unimplemented) RenameDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Pause device
+// (POST /devices/{deviceId}/pause)
+func (_ Unimplemented) PauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unpause device
+// (POST /devices/{deviceId}/unpause)
+func (_ Unimplemented) UnpauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream events
+// (GET /events)
+func (_ Unimplemented) StreamEvents(w http.ResponseWriter, r *http.Request, params StreamEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Health check
+// (GET /health)
+func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get encrypted identity backup
 // (GET /identity/backup)
-func (_ Unimplemented) GetIdentityBackup(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetIdentityBackup(w http.ResponseWriter, r *http.Request, params GetIdentityBackupParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Store encrypted identity backup
 // (PUT /identity/backup)
-func (_ Unimplemented) SetIdentityBackup(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) SetIdentityBackup(w http.ResponseWriter, r *http.Request, params SetIdentityBackupParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get identity backup parameters
+// (GET /identity/backup/meta)
+func (_ Unimplemented) GetIdentityBackupMeta(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -574,13 +1426,25 @@ func (_ Unimplemented) SetPublicKey(w http.ResponseWriter, r *http.Request) {
 
 // Get locations from contacts
 // (GET /locations)
-func (_ Unimplemented) GetLocations(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetLocations(w http.ResponseWriter, r *http.Request, params GetLocationsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // Share locations with contacts
 // (POST /locations)
-func (_ Unimplemented) ShareLocations(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) ShareLocations(w http.ResponseWriter, r *http.Request, params ShareLocationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Page through all locations shared with the user
+// (GET /locations/all)
+func (_ Unimplemented) GetLocationSnapshot(w http.ResponseWriter, r *http.Request, params GetLocationSnapshotParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream incoming locations
+// (GET /locations/stream)
+func (_ Unimplemented) StreamLocations(w http.ResponseWriter, r *http.Request, params StreamLocationsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -590,9 +1454,75 @@ func (_ Unimplemented) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Update profile
+// (PATCH /me)
+func (_ Unimplemented) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get user settings
+// (GET /me/settings)
+func (_ Unimplemented) GetSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update user settings
+// (PUT /me/settings)
+func (_ Unimplemented) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get storage usage
+// (GET /me/usage)
+func (_ Unimplemented) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set up a new account's keys
+// (POST /onboard)
+func (_ Unimplemented) Onboard(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// OpenAPI specification
+// (GET /openapi.json)
+func (_ Unimplemented) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get check-ins from contacts
+// (GET /presence)
+func (_ Unimplemented) GetPresence(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Check in with contacts
+// (POST /presence)
+func (_ Unimplemented) SetPresence(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Readiness check
+// (GET /ready)
+func (_ Unimplemented) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List sessions
+// (GET /sessions)
+func (_ Unimplemented) ListSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke session
+// (DELETE /sessions/{token})
+func (_ Unimplemented) RevokeSession(w http.ResponseWriter, r *http.Request, token SessionToken) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get encrypted user data
 // (GET /user-data)
-func (_ Unimplemented) GetUserData(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetUserData(w http.ResponseWriter, r *http.Request, params GetUserDataParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -631,6 +1561,20 @@ func (siw *ServerInterfaceWrapper) DeleteAccount(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
+// LoginWithApple operation middleware
+func (siw *ServerInterfaceWrapper) LoginWithApple(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LoginWithApple(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // LoginWithGoogle operation middleware
 func (siw *ServerInterfaceWrapper) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
 
@@ -665,8 +1609,933 @@ func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request
 	handler.ServeHTTP(w, r)
 }
 
-// ListContacts operation middleware
-func (siw *ServerInterfaceWrapper) ListContacts(w http.ResponseWriter, r *http.Request) {
+// LoginWithRecoveryCode operation middleware
+func (siw *ServerInterfaceWrapper) LoginWithRecoveryCode(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LoginWithRecoveryCode(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRecoveryCodeStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetRecoveryCodeStatus(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRecoveryCodeStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GenerateRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) GenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GenerateRecoveryCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshSession operation middleware
+func (siw *ServerInterfaceWrapper) RefreshSession(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshSession(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ValidateToken operation middleware
+func (siw *ServerInterfaceWrapper) ValidateToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ValidateToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCapabilities operation middleware
+func (siw *ServerInterfaceWrapper) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCapabilities(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListContacts operation middleware
+func (siw *ServerInterfaceWrapper) ListContacts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListContactsParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListContacts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BlockUser operation middleware
+func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BlockUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnblockUser operation middleware
+func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnblockUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListBlocked operation middleware
+func (siw *ServerInterfaceWrapper) ListBlocked(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBlocked(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckContact operation middleware
+func (siw *ServerInterfaceWrapper) CheckContact(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CheckContactParams
+
+	// ------------- Required query parameter "email" -------------
+
+	if paramValue := r.URL.Query().Get("email"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "email"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "email", r.URL.Query(), &params.Email)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckContact(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListNudges operation middleware
+func (siw *ServerInterfaceWrapper) ListNudges(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListNudges(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SendContactRequest operation middleware
+func (siw *ServerInterfaceWrapper) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendContactRequest(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListContactRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListContactRequests(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetContactRequestHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetContactRequestHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetContactRequestHistoryParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContactRequestHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelContactRequest operation middleware
+func (siw *ServerInterfaceWrapper) CancelContactRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelContactRequest(w, r, requestId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AcceptContactRequest operation middleware
+func (siw *ServerInterfaceWrapper) AcceptContactRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptContactRequest(w, r, requestId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeclineContactRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeclineContactRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "requestId" -------------
+	var requestId RequestId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeclineContactRequest(w, r, requestId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetContactSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetContactSummary(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetContactSummary(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveContact operation middleware
+func (siw *ServerInterfaceWrapper) RemoveContact(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "contactId" -------------
+	var contactId ContactId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "contactId", chi.URLParam(r, "contactId"), &contactId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contactId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveContact(w, r, contactId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetContactNote operation middleware
+func (siw *ServerInterfaceWrapper) SetContactNote(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "contactId" -------------
+	var contactId ContactId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "contactId", chi.URLParam(r, "contactId"), &contactId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contactId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetContactNote(w, r, contactId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// NudgeContact operation middleware
+func (siw *ServerInterfaceWrapper) NudgeContact(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "contactId" -------------
+	var contactId ContactId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "contactId", chi.URLParam(r, "contactId"), &contactId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contactId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.NudgeContact(w, r, contactId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetContactOrder operation middleware
+func (siw *ServerInterfaceWrapper) SetContactOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "contactId" -------------
+	var contactId ContactId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "contactId", chi.URLParam(r, "contactId"), &contactId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contactId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetContactOrder(w, r, contactId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DevLogin operation middleware
+func (siw *ServerInterfaceWrapper) DevLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DevLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDevices operation middleware
+func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDevices(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterDevice operation middleware
+func (siw *ServerInterfaceWrapper) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterDevice(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeDevice operation middleware
+func (siw *ServerInterfaceWrapper) RevokeDevice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deviceId" -------------
+	var deviceId DeviceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceId", chi.URLParam(r, "deviceId"), &deviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeDevice(w, r, deviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameDevice operation middleware
+func (siw *ServerInterfaceWrapper) RenameDevice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deviceId" -------------
+	var deviceId DeviceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceId", chi.URLParam(r, "deviceId"), &deviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameDevice(w, r, deviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PauseDevice operation middleware
+func (siw *ServerInterfaceWrapper) PauseDevice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deviceId" -------------
+	var deviceId DeviceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceId", chi.URLParam(r, "deviceId"), &deviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseDevice(w, r, deviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnpauseDevice operation middleware
+func (siw *ServerInterfaceWrapper) UnpauseDevice(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "deviceId" -------------
+	var deviceId DeviceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceId", chi.URLParam(r, "deviceId"), &deviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnpauseDevice(w, r, deviceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamEventsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetIdentityBackup operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityBackup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIdentityBackupParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIdentityBackup(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetIdentityBackup operation middleware
+func (siw *ServerInterfaceWrapper) SetIdentityBackup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetIdentityBackupParams
+
+	// ------------- Optional query parameter "overwrite" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "overwrite", r.URL.Query(), &params.Overwrite)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "overwrite", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetIdentityBackup(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetIdentityBackupMeta operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentityBackupMeta(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -675,7 +2544,7 @@ func (siw *ServerInterfaceWrapper) ListContacts(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListContacts(w, r)
+		siw.Handler.GetIdentityBackupMeta(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -685,8 +2554,8 @@ func (siw *ServerInterfaceWrapper) ListContacts(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// SendContactRequest operation middleware
-func (siw *ServerInterfaceWrapper) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+// SetPublicKey operation middleware
+func (siw *ServerInterfaceWrapper) SetPublicKey(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -695,7 +2564,7 @@ func (siw *ServerInterfaceWrapper) SendContactRequest(w http.ResponseWriter, r *
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SendContactRequest(w, r)
+		siw.Handler.SetPublicKey(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -705,8 +2574,10 @@ func (siw *ServerInterfaceWrapper) SendContactRequest(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// ListContactRequests operation middleware
-func (siw *ServerInterfaceWrapper) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+// GetLocations operation middleware
+func (siw *ServerInterfaceWrapper) GetLocations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
 
 	ctx := r.Context()
 
@@ -714,8 +2585,27 @@ func (siw *ServerInterfaceWrapper) ListContactRequests(w http.ResponseWriter, r
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLocationsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListContactRequests(w, r)
+		siw.Handler.GetLocations(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -725,28 +2615,30 @@ func (siw *ServerInterfaceWrapper) ListContactRequests(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// CancelContactRequest operation middleware
-func (siw *ServerInterfaceWrapper) CancelContactRequest(w http.ResponseWriter, r *http.Request) {
+// ShareLocations operation middleware
+func (siw *ServerInterfaceWrapper) ShareLocations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "requestId" -------------
-	var requestId RequestId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
-		return
-	}
-
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ShareLocationsParams
+
+	// ------------- Optional query parameter "partial" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "partial", r.URL.Query(), &params.Partial)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "partial", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CancelContactRequest(w, r, requestId)
+		siw.Handler.ShareLocations(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -756,28 +2648,38 @@ func (siw *ServerInterfaceWrapper) CancelContactRequest(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// AcceptContactRequest operation middleware
-func (siw *ServerInterfaceWrapper) AcceptContactRequest(w http.ResponseWriter, r *http.Request) {
+// GetLocationSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetLocationSnapshot(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "requestId" -------------
-	var requestId RequestId
+	ctx := r.Context()
 
-	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLocationSnapshotParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
 		return
 	}
 
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "limit" -------------
 
-	r = r.WithContext(ctx)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AcceptContactRequest(w, r, requestId)
+		siw.Handler.GetLocationSnapshot(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -787,28 +2689,51 @@ func (siw *ServerInterfaceWrapper) AcceptContactRequest(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// DeclineContactRequest operation middleware
-func (siw *ServerInterfaceWrapper) DeclineContactRequest(w http.ResponseWriter, r *http.Request) {
+// StreamLocations operation middleware
+func (siw *ServerInterfaceWrapper) StreamLocations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "requestId" -------------
-	var requestId RequestId
+	ctx := r.Context()
 
-	err = runtime.BindStyledParameterWithOptions("simple", "requestId", chi.URLParam(r, "requestId"), &requestId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamLocationsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "requestId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
 		return
 	}
 
-	ctx := r.Context()
+	headers := r.Header
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
 
-	r = r.WithContext(ctx)
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeclineContactRequest(w, r, requestId)
+		siw.Handler.StreamLocations(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -818,19 +2743,8 @@ func (siw *ServerInterfaceWrapper) DeclineContactRequest(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// RemoveContact operation middleware
-func (siw *ServerInterfaceWrapper) RemoveContact(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "contactId" -------------
-	var contactId ContactId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "contactId", chi.URLParam(r, "contactId"), &contactId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "contactId", Err: err})
-		return
-	}
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -839,7 +2753,7 @@ func (siw *ServerInterfaceWrapper) RemoveContact(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoveContact(w, r, contactId)
+		siw.Handler.GetCurrentUser(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -849,8 +2763,8 @@ func (siw *ServerInterfaceWrapper) RemoveContact(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// ListDevices operation middleware
-func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Request) {
+// UpdateProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -859,7 +2773,7 @@ func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListDevices(w, r)
+		siw.Handler.UpdateProfile(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -869,8 +2783,8 @@ func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// RegisterDevice operation middleware
-func (siw *ServerInterfaceWrapper) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+// GetSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetSettings(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -879,7 +2793,7 @@ func (siw *ServerInterfaceWrapper) RegisterDevice(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterDevice(w, r)
+		siw.Handler.GetSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -889,19 +2803,8 @@ func (siw *ServerInterfaceWrapper) RegisterDevice(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// RevokeDevice operation middleware
-func (siw *ServerInterfaceWrapper) RevokeDevice(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "deviceId" -------------
-	var deviceId DeviceId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "deviceId", chi.URLParam(r, "deviceId"), &deviceId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceId", Err: err})
-		return
-	}
+// UpdateSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSettings(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -910,7 +2813,7 @@ func (siw *ServerInterfaceWrapper) RevokeDevice(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RevokeDevice(w, r, deviceId)
+		siw.Handler.UpdateSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -920,11 +2823,17 @@ func (siw *ServerInterfaceWrapper) RevokeDevice(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// GetStorageUsage operation middleware
+func (siw *ServerInterfaceWrapper) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.GetStorageUsage(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -934,8 +2843,8 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// GetIdentityBackup operation middleware
-func (siw *ServerInterfaceWrapper) GetIdentityBackup(w http.ResponseWriter, r *http.Request) {
+// Onboard operation middleware
+func (siw *ServerInterfaceWrapper) Onboard(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -944,7 +2853,7 @@ func (siw *ServerInterfaceWrapper) GetIdentityBackup(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetIdentityBackup(w, r)
+		siw.Handler.Onboard(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -954,17 +2863,11 @@ func (siw *ServerInterfaceWrapper) GetIdentityBackup(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// SetIdentityBackup operation middleware
-func (siw *ServerInterfaceWrapper) SetIdentityBackup(w http.ResponseWriter, r *http.Request) {
-
-	ctx := r.Context()
-
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
-
-	r = r.WithContext(ctx)
+// GetOpenAPISpec operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SetIdentityBackup(w, r)
+		siw.Handler.GetOpenAPISpec(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -974,8 +2877,8 @@ func (siw *ServerInterfaceWrapper) SetIdentityBackup(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// SetPublicKey operation middleware
-func (siw *ServerInterfaceWrapper) SetPublicKey(w http.ResponseWriter, r *http.Request) {
+// GetPresence operation middleware
+func (siw *ServerInterfaceWrapper) GetPresence(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -984,7 +2887,7 @@ func (siw *ServerInterfaceWrapper) SetPublicKey(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SetPublicKey(w, r)
+		siw.Handler.GetPresence(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -994,8 +2897,8 @@ func (siw *ServerInterfaceWrapper) SetPublicKey(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetLocations operation middleware
-func (siw *ServerInterfaceWrapper) GetLocations(w http.ResponseWriter, r *http.Request) {
+// SetPresence operation middleware
+func (siw *ServerInterfaceWrapper) SetPresence(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -1004,7 +2907,7 @@ func (siw *ServerInterfaceWrapper) GetLocations(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetLocations(w, r)
+		siw.Handler.SetPresence(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1014,8 +2917,22 @@ func (siw *ServerInterfaceWrapper) GetLocations(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// ShareLocations operation middleware
-func (siw *ServerInterfaceWrapper) ShareLocations(w http.ResponseWriter, r *http.Request) {
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadiness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSessions operation middleware
+func (siw *ServerInterfaceWrapper) ListSessions(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -1024,7 +2941,7 @@ func (siw *ServerInterfaceWrapper) ShareLocations(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ShareLocations(w, r)
+		siw.Handler.ListSessions(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1034,8 +2951,19 @@ func (siw *ServerInterfaceWrapper) ShareLocations(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetCurrentUser operation middleware
-func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+// RevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "token" -------------
+	var token SessionToken
+
+	err = runtime.BindStyledParameterWithOptions("simple", "token", chi.URLParam(r, "token"), &token, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -1044,7 +2972,7 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCurrentUser(w, r)
+		siw.Handler.RevokeSession(w, r, token)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1057,14 +2985,40 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 // GetUserData operation middleware
 func (siw *ServerInterfaceWrapper) GetUserData(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserDataParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserData(w, r)
+		siw.Handler.GetUserData(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1210,21 +3164,60 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/auth/account", wrapper.DeleteAccount)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/apple", wrapper.LoginWithApple)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/google", wrapper.LoginWithGoogle)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/recovery", wrapper.LoginWithRecoveryCode)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/recovery-codes", wrapper.GetRecoveryCodeStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/recovery-codes", wrapper.GenerateRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.RefreshSession)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/validate", wrapper.ValidateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/capabilities", wrapper.GetCapabilities)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/contacts", wrapper.ListContacts)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/contacts/block", wrapper.BlockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/contacts/block/{userId}", wrapper.UnblockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/contacts/blocked", wrapper.ListBlocked)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/contacts/check", wrapper.CheckContact)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/contacts/nudges", wrapper.ListNudges)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/contacts/request", wrapper.SendContactRequest)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/contacts/requests", wrapper.ListContactRequests)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/contacts/requests/history", wrapper.GetContactRequestHistory)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/contacts/requests/{requestId}", wrapper.CancelContactRequest)
 	})
@@ -1234,9 +3227,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/contacts/requests/{requestId}/decline", wrapper.DeclineContactRequest)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/contacts/summary", wrapper.GetContactSummary)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/contacts/{contactId}", wrapper.RemoveContact)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/contacts/{contactId}/note", wrapper.SetContactNote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/contacts/{contactId}/nudge", wrapper.NudgeContact)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/contacts/{contactId}/order", wrapper.SetContactOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dev/login", wrapper.DevLogin)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/devices", wrapper.ListDevices)
 	})
@@ -1246,6 +3254,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/devices/{deviceId}", wrapper.RevokeDevice)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/devices/{deviceId}", wrapper.RenameDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/devices/{deviceId}/pause", wrapper.PauseDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/devices/{deviceId}/unpause", wrapper.UnpauseDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/events", wrapper.StreamEvents)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
 	})
@@ -1255,6 +3275,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/identity/backup", wrapper.SetIdentityBackup)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/identity/backup/meta", wrapper.GetIdentityBackupMeta)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/identity/public-key", wrapper.SetPublicKey)
 	})
@@ -1264,9 +3287,48 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/locations", wrapper.ShareLocations)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/locations/all", wrapper.GetLocationSnapshot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/locations/stream", wrapper.StreamLocations)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/me", wrapper.GetCurrentUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/me", wrapper.UpdateProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/me/settings", wrapper.GetSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/me/settings", wrapper.UpdateSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/me/usage", wrapper.GetStorageUsage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/onboard", wrapper.Onboard)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/openapi.json", wrapper.GetOpenAPISpec)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/presence", wrapper.GetPresence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/presence", wrapper.SetPresence)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/ready", wrapper.GetReadiness)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions", wrapper.ListSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions/{token}", wrapper.RevokeSession)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/user-data", wrapper.GetUserData)
 	})
@@ -1280,71 +3342,213 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RcaW/bOJj+K4R2gaZY2Tl6AJNvaZJ2stN2gqSdLtAEMzT12uJEIjUk5dQb+L8veOim",
-	"JCe129l+aWzxeu+Dj/wQEJ5mnAFTMjh+CDIscAoKhPlEOFOYqItIf4hAEkEzRTkLjoNT+wjlEgS6OAvC",
-	"gOqvM6ziIAwYTiE4rs0PAwH/5FRAFBwrkUMYSBJDivXCapXpwVIJyhbBeh0GESwpAd+2Z+ZJ74blxMft",
-	"p8eCHKTTDenduVriMVubvWXGmQTD8Dc4urILFewHZv7EWZZQgvWh9v+W+mQPtWX/U8A8OA7+Y78S5r59",
-	"KvfPheDCbtWk7IItcUKjgrJgHQYfuXrLcxbtfvMrkDwXBBDjCs3Nnusw+MxwrmIu6P/CDzjDSa5iYMqt",
-	"igqpIS4QtbwxyuHW0duccjZPKFF2SW0ugmcgFLXSI7kQwNQfICS1J2zpkn2OlnYA4gxJEEsQQRjAN5xm",
-	"CQTHr8JCSyhTsAChGQM9G/II9P/l5MAt/SdxJw3Cts6FQQpS4kVr4hlWGMVYohkAQymP6JxChGYrhBlX",
-	"MQhkbau74Lqu8F/tmapNbsvxfPY3ENUZb0kL28zrzgsLW/TwQQBWEJ2oLs+/xMCQigGR0pATI28Z0wzd",
-	"Y4lAKjxLqIxB2+6cixQr7UqwgomiKfhYCCmmid6sHG6/8Qyl/U7lmay5z85E61j6p0ZUZgleITPOMz/L",
-	"Zwklv8Gqu8gbLOH1ywkwLawI/c/Rq1eHvyA7Ad3BCs25QMCIWGWKsgVKuLUROSp9qnlY8MKdrDpIWJPU",
-	"gIDfU+kTsn1o/qYKUjlm+IW6rMudsBB45dFYt/DAkWpeeUD1NlOeiAogyushvsRgbE3FVCIqEWaIMsJT",
-	"LQQuEM/Vguu/C58dBsDy1DDeDQvCoBhVo8ejuc2Nz/XXiM+NqVh716pZN4hHafhVPVxuqNofcQrdI6A9",
-	"Okd4iWmCZwk89y0nFVa5kUbBjQxYZJmBCYFMGdOOgCSUQeThy5AWu9U31F1H+KkZ21WXTdhvqFYcSWBl",
-	"dEaKbyCLtms1o8YP67e3UqMeaW9XVT7RNLuaZm5rybbcBo2gIt9mkVsxZjqSpHYnSJcGjFu/CVsuZ7DR",
-	"F6X4Ttu/flL5ALfHjPMEMLObXMGS30E0solbtYz7ws3yrZlgqa4B2Oa88Zv5ZwliMhcUWJSsihO4UFHl",
-	"Ix9WiF7GnPlDW4KVPkLd5CnXNopZJLgx33uYaZtN6IbmXgSrYum6vdeI71enPpP//8KFNgP6KfX7C0vD",
-	"5uHZGeGYTRfL9h/nC1XxJ35nNRMnye/z4Pjrhnu3iVDFOl6LNk9NcnRyeYFwo34Y9cZ26S4Zt+swOLe5",
-	"FkTvXabVZe8s4bPRTO4jPk3QjH9DhGYxCAXf1PSGlauje6piE1dAPJMoE3SJFZiM77+QAEIzCkznllUq",
-	"OL1hxglTJss0EMUUBBYkXoWIm5PgxOhuVA4JEWYR0o5BKpxm0xvm0+C54Kk2BF/h/dlmxug+5kjGWBdm",
-	"xmUVO/jWy7NotA4oidC5vzZr5GZtmPy3pFojIbQyqh/Dp7Q9xeNIidek5gMmMWUwEYAjnRYhMxu52qvy",
-	"H66O/bMTK7zVYHOPX/MUs/YOxej6JjZ5obKsoHdUI/qY+Y7zRQLv+YKy3hSdRp/8Vm0no99PchVrVbMW",
-	"Oh4tPvVYchj8CjhR8ZVr6XSP0s1UYzNj5c3Vl32NhGvTNSj6CA1hHE4PpgejNLhz+Ei4iLRPU6s3mNzl",
-	"WZcEnCy4oCpOPVmsqxg5Q9WoqkQ5Ob+eHL16PXl3+sFLLlUgXJnZWfq3s7eo9rxG8eGB/hcGKWU01RsV",
-	"X3S7KHQ56kAv/kB7h0dotlIgvbXGXTT3nA50CDfeVBM/zxlxLqqg/fLNb2dvjybXv54cvXrtpT7Dq4Tj",
-	"aPSElWfX9QKUrv0OVhmmwlsf4USNrqsHob3D1720t1SoLmHNlIb83J6G5RVpPnUrAp4/p6gaD5tmFd1I",
-	"OpZgVHsMne9ah6DtRWWTRJh4VoZdn+QUHwuPykXHWlyjpi89low0g9Yo6b3e9fEiarL0e8VjPH+fv6Xy",
-	"I9xrSrsM/CRyQHTeKLZ05f1MojkVUqFEL+2thHqSxGuQpq37uCwxDHJ3viGeGRr8GaVbwMedy6Lz1iu9",
-	"72kS7r042tRZVNv4jlkIaOOm7gkhPGcKmSGG5UWi+fgObtesnklkniIcRQKk3KgJFmPZjZz+ytv0d3TJ",
-	"LRXXaS1miLqpaGbn+rQuxlKf7gwrvPnaVXwwTyI92dsw8LkYRv/JXTvKHnBOm3cVQS7Fn3hGDo9ebF7/",
-	"nzX71tVi/81jhs74tpvZT+pZD/f66nJ4QjyohKKH25sJyihbtIooGd6w4toiA5FS41+kra0yAXMQwAjI",
-	"bolXRhzTRkzmaM+5Nn7PikzheU9RNlBEva/KpSeYXG866+58EMvTGQjjOXVZmVKpKNHssU0wsmrkfd0M",
-	"ryXXKj0eLsgKaX42o7Yi00eRf/4tA6Jnktb94F4/J2oud5z8ngivM0MguaBqda3DjKMWsAChSyIPzeaZ",
-	"C3DNwDZFb40SHKO/3KgHaeOhqZbWf92wG/aWF9eIE5kBoXNKkOa003ZEGUnyCIp2mNmnb8HjBzuqXD5w",
-	"V7XGs5kZFY9ipTJ7BUzZnNduk6oGnXahAqiMg85F8aXO7MlqYvMCCSnWZFcZl07AtPWeXF5MNZknSYIk",
-	"MEkVXYLxuWivsmln5FmCCciwbtjPdR5SKRJJdFo4kTSC6Q37FIO7Lba+XbZUTiKqEMGMcYV00R4iTMzl",
-	"HZYIIyNoMJefK2v3CSXgcibHgA8Xn0x2Q1VS54cmK6iprysx12HAM2A4o8Fx8GJ6MH1hMn0VGy3a19qx",
-	"j22ctoqUgPJEg0sQKda5jumC6jFVKobcfOPvcJIgLCUnVJuy4arhCpWGUM4K4meAchZxBpbOUr90Fh2c",
-	"mS1c/hC00BdHBy/7cw17OANTeHlw2JevlevtN7AMxtbyNMViVR6iQaJWVbyQprDSpnerZ1gmLkyXwrgl",
-	"LpXPfZAYswUgjLwNDeNPMSrF6axoesNsx1o2eU3n9fzXx0OTc3+hKrbbBSV+5g2PVluDbXg6O+umi1Mi",
-	"h3VHhAdbO0GztvAASMwAJHNCQMp5nljdOBjXjRrM5+nq5Fx3cPz1tq5c9lAmC6irw4CCJXzBc9WvYA4o",
-	"hAvTLMKUrNc7U5+a6GU3sTE7tMPJ77Oycxa1j+pnQh1YsADlu8xWuWDSuiB3n1zgSaRltYqBilrSqXOy",
-	"y+oT0hU6A9CZguKF564SPf1lsaDX5KhUp8Uxd6jydQCGT+GpNF2nkmNbEJNZk1S0FQKqUBkNIe2LWinr",
-	"VddrYJEOeaSF3FO8hDIZhzdb2TJvesMu5u5G1bViUMRBsmcKxXgJuj5zzjFEjJfrUYlcleCTmD5F6/Z6",
-	"N37SizvYyFMe7ugMfryfZZrUe/0wN6knvdw9kND04hpAxpcHv/wIDKXlKU50vrdC8I1KJZGO9u6byk4b",
-	"Jqd1s20em1veuJ+ccRVXuCWdvJXAJQfMae8up0Mu76rYePeer46I8bC8hQbeugOsFt5cHPsPJfZ4PZRp",
-	"n2JGIDGYslIcrW27UrCTOo6sDhPvuWqvhuxX2Oj17SbpQKHYxGyePDnpLs1/eFKJem7KxZL+HXZSF8y+",
-	"zRv6o9aJed5E/LWlc8PeaMvS0UuiGRCeQpWGaDMjmLVuA7zZhN1rl0Ldul0O+j+XkoVIOAfE4L5gzE9R",
-	"HcvgbamOwy32686ZHTCsPJ5S2Mz6SbZdgjF/hnwc6U8Q0EP5Ssugr72ClC+hmYVWsPMpqtlxwiUYFZam",
-	"CgBMYot+fVZhXVz7p5a8UmkSDsZNU96bgdozFPbzWLlWr+5sJtcqMupdf45YLcWl5fdKs4ZUG6333Fgk",
-	"YEGlAmELuNploSsN/AnMmdtphw6yBskbqNgKkreVr0QlYQWPS5zeOuxxU1eOhdostId2XV57E97h5w0r",
-	"xdDoB5vxMp9JbbNMmTtWgpNE+m3AbnlWvLuyiwqsAf/8wZVXGwHpUQGHXawU+Me2qWrWaQ9Qe5Goozo1",
-	"69x/KF7mG/G0S34HlZKEKBOwBGZa31ShueCpc68FcNq26bVDjQrWWHwjZivjcpNkhQgmcdVm9iiW3rZU",
-	"q8f51vItxc1caylAi83+OZ5V7z0mOYtlG3Wr7hbDjkYWijZFH3nrRqmEE3Zd6ztQFmm3S8fawvJ5LMvB",
-	"8KhEBYpvqDFr10MkBnLnb0QWKID9WYkg6GOkoLCEBmilug1qgQmcrtsPzRsm07rE6PLi48TA1yyMzJYS",
-	"Bei3toW7OPbZwztQLfjDDiXT2skjmfM+Zvy7G0kfefu8rqnTMsd3oPrlXdOt4okNyrmvWWqvEh+vRzLm",
-	"eRKhGfgvLHtUy98p9anO9sO0T2vGArXHG1+0BGSBNj8pphrxPVYVGq7GXllM7iyyZixxq+lJ523RaKL4",
-	"BFgJhbB1y+9lzSI71x72atyk01QOqMdl7QXSXWhGByb3VN2obnz+RelWEwnlV4cGiHMk5lTaVsrR4g/c",
-	"WxqzVf0q61zXswbm1I08JUpp71GvpDzvCUDva28p7/BSuIZXHow81eWeyUG3eWem/X/f8pWMa8jZ3prM",
-	"aKyMh8TavJqs5OmLAE2xFpVdifUp5ek1dK0+TRlu39S9mOanmnt5WKf6PysINDu+Vggj+qCN3oIzB1N1",
-	"yiy0z7zSMeO5MvKs5ekOWOpN0d17rp/te+Q7s0gHje7e1bi7f9svY3O+LcsjnYW92bx+PIkcSvQpebyJ",
-	"jltDiPokVOJYdywes8egs6zQyf/2BL086SapeQN07VSk0overNwiUcfUYnrDPkuQPQhRNKm/JY7SXKoS",
-	"X6kfQIE6dcC+ntyroSLb98ct7O0PRngN6ebnUs7F+6E/FLbwyzZv0Go/WuShtIBfl78Z1NRoK5pHKHWr",
-	"CdKEFH+9Xd/qx2JZNMta96CXF2iGJSD381q5SILjYB9n1HTL3H4Pwz/lpL1fgU5LMcMLSO0LVg7parz0",
-	"OnzYtGtg3WmV3PvWLDPqoXUr52H8+p4HBBzW/fbzav2Kw90NTj2XTPZGuIYnaP4mmxw8ZwfaPAN1D8Dq",
-	"aYVbr8oqugs2Ws+iko2AZfUWd+On22Swvl3/XwAAAP//RLPuqZVOAAA=",
+	"H4sIAAAAAAAC/+x97XIbt7Lgq6C4W2WpLkXJX9kbp+4P2VZOdOIPXcvOuVVhShecAUkcDYE5A4xkbspV",
+	"+zT7YPskW90NYDBDzJCSJTk5dX4lFjH4aHQ3+rt/H2V6VWollDWjF7+PloLnosL/PfnIF/DfXJiskqWV",
+	"Wo1ejN6X/B+1YFeiMlIrpufMLgWrhCm1MoLNdL4es7mu2On84J1W4uAtt9lyNB6ZbClWHCa061KMXoyM",
+	"raRajL58+TIelbziK2H9yldC2R8rvdpc/pVWlmeW1UZU7PS1YVazSmRCXgkm4DPD5pVe/cAqUQpucSdG",
+	"XImKF2wvF3NeF5bxotgfjUfic1noXIxe2KoW45GE+f9Ri2o9Go8UX8EeYa7W3qUVK5M4xNj/gVcVX8O/",
+	"jV0XOIWuVvDv0zmAg6CxcSyANW6cccUErwopqgDUMRxyISzj7OnRMybnrFbZkquFyEdu23Rtzb53B/54",
+	"lBFIT/Ot0Parldwum7Wa78ejSvyjlpXIPUyH1s3FlcxEatnX+EvvguHDm60HY4UZPKcb0rtyM8XNljbC",
+	"ALV81JdCba7+lptLkTM3iFkYxcpKzOXnMeOGVcLWlRI5m63ZX04+skM30qQ3id/fcINwwSnAfBq6ePfR",
+	"TVbCayCsRip6yfMPBFP4F2CSUPi/vCwLmXHYxuHfjUaoNdP+z0rMRy9G/+OwYV6H9Ks5PKkqXdFS7bOc",
+	"qiteyNxfMtDkO21/1LXK73/xD8LousoEUxqYEqz5ZTz6pHhtl7qS/1s8wB6Oa7sUyrpZmb81pismCTaE",
+	"rDQPLHNcloV4oxdSRbdUVroUlZV0gzLvwepzuVBMKnYt7ZLhREzmsLxdM4+iG0yUMCuFhY8Mgx+JSeJ0",
+	"jwzz0PMH8vzyeimzJZOGaVWsp0qqrKhzkeNTNZeVsczKlcB/ImszcqEMbNZq/CMvy2l6f1pliQ2+gz/7",
+	"L9lCKFFxK3J8fexSGlyASTVhH92YkhsjDB1kqqQ17Pyn44Mnz7+DXSzFZ8YVcASVG5wW12XSGlHM2VJU",
+	"4gf8M8LxkZkq+j0ruFzBsQHJeJaJ0op8kjrJl5hofw2X6A/4W/hAz/4uMqSVl4XOLl9VgluxiQWD/MNq",
+	"NoOPx+6FY+7FAMTL2px3607dQr0bFPkbmUJT+M60HvAhEnJTwe43n/bEjszQhnCWjQ3N6Mdj3CvICNyO",
+	"XoxybsUB4GYK+cSKy6I1nP4yQEcbPzQXtROc3UzjsFKz7dSJX/GSz2Qh/SHbR54LbuuK/l985quyELAU",
+	"iW2j8aishBEO/XaVszrbDkskd6fVvJCZJQa5sb2sriqh7C8k2SaEBPq9EX0VM6K6QrErHOd5WFcqKxaE",
+	"P6JnQRQ+I1iM3NQXmdtp6mpXwhi+6Hz4mlvOltywmRCKrXQu55IEBq60XYqKkdC0lb5wT80im2DsjKej",
+	"jbvA6wE/kHoCDshUHCm0Yf63pVDI6Ro+USCzN0tZsmtumDCWzwppligM3zkhyX5p8ZGJ5OIdX7Lm01ya",
+	"suBr5uhr83ttE9+fVfKKW5QiBLuSRs4KgY+cf7n0tRLVD4zPDKCqnMPLkZy/rGeFzH4W681FXnIjvnt2",
+	"IBQgQ87+68nz54+/Z/QBuxRrfNaEyqp1aaVasEKTRGFS6xhd2fdVLqrNdc6kYqU2Ev7J9gp9LSp6nffb",
+	"B7CslEqJvJk+oq26zHdFHidCjOEMY5gTHiCEZMGNZY1KtQsWbbygEZN0d9qAeBzh+ABpvFqK7HKTPozl",
+	"tjab58u4unDv5gvGg/KSccVmggH8JlPFi0rwfH3hYPCiEXqkYe7H5kmeTJWb5qIUKpdqEc88E/ZaCBWm",
+	"MDCHGwdyi5DEamQlMtjjZKrce/HCz2EIUaWjHV4J5oZMQIqxFyS24MJhp8DYnAqk53MmVaZXsKSfk0Qc",
+	"oeoVsrAGLKPxqHP+0XjUOWDzqKH4E+1g9Nu2W3c3M3ClaWnEbWZ3gcTNlrIzKPHZvp/PjUhQAP3dG2lg",
+	"JCv5QgTy0nSXiP7wQ5LCrLa82Jz7I/yZqXo1ExWs4M/0A6N33LJroL+SLwjI3Yk3Hh76fAiY77QVn5De",
+	"N0Ga5pfwBbPis/2BiVWJmgerxEpf4SPHP78RamGXoxePj46Otl02rjCwO+Ryfdu7JRtUdVHAnmtVShTP",
+	"66LgsyLYrBJA7dterwbnFYWbiKKtJ3u3T3KRFVLd8BvPSZLMHZkN8hJgZarhC7piurYLHfGIiD/4YaPx",
+	"yI9KUHokJXRMdfBnT1IkWwGTip+NXmlCfC5ldTMQpASQD7GZakfJ4x0ozxu7ZntyzvgVl4hV+6npmtfH",
+	"A7BhnB51Rs31jsIpk0DtUxRPXydBeoNH1+1zx6fWgbBPn93l7mun2xqhglGJWb0DInSOQaO2b/Ynaayu",
+	"1pu7Bcb+qq6MrpJai9GVs0Tc7A1obs0/tDd9rz40prZBtS3Mvx0I6Qc10PRdbTDiDfd05mE2tHH883q1",
+	"4qnLj2WJjr3PUWd4mpPPu6Pm0wiCnfeJBjQSnPO35EPTvY+gt2U6I5RNTmWWHBBw0BFk2PVSsyW/EgyG",
+	"i5zxoI+Q6dGT6tASH/XAAi0xNL1Icm744axfy9o8QFk7RZbxSNu6gejUXTM+Xxucm9e+eXMpZHwtrobN",
+	"wYOM02pvCGXc3PDpAgbGmRLX7t1yvjzjFV9c+JHBeylYySu7/xWMlzxQKVnJyitx7v0vm1sN4nCt3DPo",
+	"/TqGzcDs7/fbtcdEiHML4Upu8aJtfmCcOWu7ZIUaNA12u2YrfgkkbNHz2zHczrQuBFe0yAdxpS9FvmUR",
+	"N2uwX1Xuq9Sc8E6dC6F2h02/T+FgXkmh8mLtd+CNncGu9nbN5Nmyz4TCayPyT8rKYsD4QFM/MgyHM6Fy",
+	"E5s3pGXSqEeWft7dhlUW3MLIWDCT2ozGI67ySqNodC1mo/EoK2RSFHN4eYJoao7tLmdAKYGIIPgrCc+b",
+	"QwEP0jUIFmLn0wBNHy+S6HhclugM8WbXZj+sEgtprAAic1y4ubm/LUUlpFky+f788Mnk6eTxbuKkt914",
+	"8MbkGGFfP9PoEynvDw2/GhN2h/6YicliwqYJ8E5HE/a6w5TxdDgzowiFSVvtfrKD1t25j37ApyVDAunu",
+	"kivNtVV689P2b+cM6HlzP/UWdhFziVWNRjd0TILnsrZ1JW5nn6Rl+3fba1ZJv8jiuhdX/6arywZbo8v+",
+	"/vud7rp/j3+Tdhmczbwo3s9HL37d8Ta757JppzWNJ7/qhL3TlgWWFgTWFzELkmaqyK3tHS522bDG8OJL",
+	"u4sblja1CYDfvoxHJ2RvF/kbJ3gmXYqzrdb8d/xVwWb6M8tkuRQV2MYmUxVmp6MaoXJ0uZfO3wBW/39j",
+	"lchkKYUCS3ojoE6mCmVZqUwjFS+lqHiVLddjpnEnYCvkK5GHIWPkK4C8xvJVOUk73MUOrxPK5BvPEL2t",
+	"uRbwuu78EIGH+tNwMAwK7E4PQPHFnyjJnAFm3p84hKn+Ws/CBzs6NxpFhLv32X11O0YRAWBMGBVvIz5Q",
+	"ilB7XKtbHKDdcKhsKZU4qATPwSrF8GvmPJMNo3ExKxe9oQMtX2l7jZ/qFVfdFfzoeBFSXKQJ0TL35EFN",
+	"AfMvWi9uHX5DH7P3EPQDSNsTctMTCJLazk+CF3b5wQXZDLmpvBSyxC/WSZnjqs/Nfo4+dS9wtC7j8eRo",
+	"cjT6CqfMqQtAesmzy7rcPAIvFrqSdpmwNzgOCYTWjGqMyscn5xC9c/CXV2+Tx3UxQYOBBc0Ycp0Aek4Y",
+	"Evp1JcEjNUaKj8bNhFQL8NmVBc9Ezvb0SmJczRG8OiS57rdYa6RnSuumSSiyZy9/fv3jE9YMYXuwtDf4",
+	"soqrBbx+LgTiACIW5KKu0Iw8ZrqaKhh/XC20eiKJz7NMG8v2Hh9892x/2rpZcL+AKLiSSq4AoI+T+73a",
+	"+rqd/sL2Hj9hs7UVJmnNvsznm5P8LED0xqcOgDqvVeb4ub9eAsfB+U/HT55/NxqPuDtX8q5XYuXMtB1Z",
+	"2gODBoBY97N8OWbcskIA4/53VqJKDBfP9vwa6N/fj8H13fPnT79D2cpB6+jZvz//X99F8Pv3pGmOV7wo",
+	"RCHNamBztLwZWv9ZtPaT58+33VvJ14Xm+dbLayQSMKCIIJJcinXJZZW6TsMLu3VeGMT2Hn/XixYdBhLT",
+	"N+BLi1LcmoiNzdG2M5u3wvI7YTiBG8YsJ2XhGaDun1//GJE2kCtLU2uKSDcv+KZEFY7QJauvJKUEzt49",
+	"FWwxxg4iTwpNvOCXVl+b2JpdFdhNRaEnaOBePUY7Bg2E490yaqABzxBoz2Ih3BmORy8ADzEgpCt/qFxU",
+	"B+DP5JXIx6xWDSsq+EwUCJqlvmYkC1PgbxgzVUEil2bMjG7UJoOxObnIZC5wAoxGgOVAVfERv6IyUwUD",
+	"K3ElxTW7XnILS6xJ6ZiwU9C/OFtKZX9w6iaKTBkHO2IGQURM2qniC9DJ8FsIspm17TN4+k7kjK6VxfSX",
+	"TNo1UWpmk2+cB+05bOnuVNEmYjoA7eu0QrPUdZGzXBpeloJXPUaVCQO3CMosyuN32AFTmhVaLUTFjBCG",
+	"SUu6K5puWS4KAahhtFZTxedWVBP2HsQwq9mlEGW0G7TCMAn350U2uoEdbb5fpUxavU2xtU6v7XNu9Zot",
+	"2grjECkivvRqMzfndq15t1rtdmMXbo+mLuyAKtt5rYOOSgxMXwJuzHlh2vokBphFwWgbd6wvh5wlHXUf",
+	"1AIr1CDVRL6TGAN2vlJ9uSuwEiHfVfPDza/TXcH24AFaY3CXipdmqf+JHljjTjTMA90gRBZjeWVF7p4M",
+	"DAdSTFyJau0XuYW5KI79jbaUvgs0ZPSZD6R5J659rkRHaqhqAfa82CNZU04QZfMUMHUS5Ssxr4RZ9hhJ",
+	"3mi1OCgglsFl3cGdnL0//8gOIbXo0H09ZqBWA5OX8+jBnSpnXWTSmBrFgFXaitljbT5vZfzB2sdnp5jU",
+	"1KRp9XnLtmEp5a4kDcxugtQdvavzhdgSof/1hlSfbskNupHJtd9vRR2yUA4He+Fx0oK1gp92J3oCzDZW",
+	"5CZNbeW9mmle5QOGvK5pamg7HUPWV8bz7z19sqtu3Cwz7m45deozn9JzZxLidlzbJJcyvxn23sgkPnTs",
+	"NOqVEVB2Qr4AxW34F2dQ9W7qQUT2Vmz1k2dJnngLYaRXvvSH642/RqPwDQi+Da1tgA/Tp/em57K4jYu1",
+	"nSMUmX4KmQl2viLpvB3Hfmsna4gb6+VRD85jUtv8IHgulTCmX5Rol7TgeS7JA3nWGuW1Xn0Jok87EiwO",
+	"W2+92HjPaJ7k2ZJ0bQywdAvGtwTP5rq0evSCljBWV4L+kQrT33SfYOIK2icWFc+TAdVJNByNYwikYZhp",
+	"EPxe6Vych3W7ovuKS5UM4fykaiNyVrlZUO0x2401zYzb9mTSvsKEJfO9Iibe2cyEnQO0USRjRq/E9VJU",
+	"ghk+F5PRrbNLaQ9Dmx921qX9nR/inf/AMm7EmJmSZ8KgmSHnZgn/WwkmF0pXIo+xbHT88tXrg5Mf//LT",
+	"wV9/fvP24N3Zf37YzUeZPgcKvL0n6IrTw8u0Rg8u10fJuxh6ICa0E4u2s0VlWD34QDYa48xBONQJ63bJ",
+	"SatyQXu7ivzvos3u5pL1o1pbjU1gKcA6zeJOJPmtdVriiBfZhLnukoVKc7/bmrFCc/blMHcMgrsd64YR",
+	"sP6AO4a+2sFSL/jrOCpHMRfXkH9a8cyKyrC5LiAPzCVwM1EUsjTStOj+6fwJ/z57LP7f//m/O+NQHMq4",
+	"EwKlBVgTxT/vJEy52baLUX7i5JasrvhCfPIRHdv0p46AsraCqNUHafkv2Iw+iXiGVPa7Z0nvUctcNLRC",
+	"GOhDhTCwKgrV32Gxf9Ta8s2FzkR1QGVLCCIMx4FBGwUstnfEVoIrw2pVyJW0It/fbb3gtNlhLGwAqg7s",
+	"AunGf4LbzrnlzOlRW1faCFBpXXO0j3HLFkVH8SBMoRMy0l8ggqgnnO4WPOXG1TWGiTBdOWSgXMJxhi4c",
+	"CvzAJ8aH1928NkKyBA/+ynieV8KYnVIel9ycbiFNz26bJBurMclGJWh0k9XCCi6L5U0/dfpFuFprRZEr",
+	"lA/TmzDE9rDY1kp0Xb/ttT/1EkLfuRLkkJw8ldTxSUkov4cfhrjTVgWSUW2qCz7LHj95mroPMCqjlJrC",
+	"n7faWEzvUpaZOsuEMfO6CObV3TCo4Bby9BxMe0U33nZlrJqli3WLZ/bfx9fkf7zu063/qpeKvdZ3XTTj",
+	"VhUkhq2bMebdwpbToCEMp6cJ1bNOoK4ZT5Uvv1KKaiXpjSYfaFmJuaiEyoTZDCMO1iLMki3mbM9Z7fW1",
+	"8lE9+z2BvwOhr2+aINdbMLjewENXu8ZHJ4BZS5dWrqSxMgPwUBJUtm4FxWx9sZpAxmGbob/NPjPRLe70",
+	"Rsc/+VyKDL7MOnWO9vohsUM8THP8XtsdnPxcWCvVwvSVJ/gQZSCnuWyTN05hFpiWDbphp8SYSfJaXlt9",
+	"vONKoc5Ad2bU0UOIJDB8rRX8F6M3eFXJK5FcPZcGbQBU2uFGJ9zYw2zNOo9xJ3vuZ6Wv1VudD6yU+dxU",
+	"jF8wQniVB773zLlh38DO3Ws6Vcbq0riwVT2fj7FyC3zvRsAfXdBEy5NHszbRM6bFGKIzuHlOFEArH/aW",
+	"47uBsGrFNZgf2HXYH/5Ed1eJvyMNJNbt5v/EN7axpyQ+daE/7qL2NtpoOEPapInRBuOt5LMr8m9H0x3w",
+	"a5frS4B6M2TeiKyupF2fg0rpOKLglaggwD3BF/E3Z6xpe1Qn7Ed8KF6w/3ajfo/rs37576maKqjTeL3U",
+	"hWBZJVDO4kXXFOCyhMgKcmBKkcm5zKYKwO90v1yDe9jyS8E4M6LkFTxaeZRl1Mkmgn9NlVa9VhV2Jblz",
+	"UdNHjk5Q1UYw4pkaDF5aW1I1UKnmOqoY0PgeQjbfpsEbC45l6wMyUxix4gDHhvY9UR+fnU4AbsdFwYxQ",
+	"RmKKKGp6e40g4SQLsqqNY2liH87YvF5ZIYWyB0bmYkKX4WLcUIQ2nXfOMIllr5S2GDQ/xhRVEO0Ngj2r",
+	"kbYLvsY9viooGM/FhiEbtUshQ9IBJqgr9l8HNPLASwYuh5EdhwAAH/buJR7OXCh0mIqK9xr27Ml3rC7R",
+	"kH8RKq9azXSR40S0J7pL8O44c6i7oLenH1FBl7ad3Hp8djqK3nSXIfFlPNKlULyUYCeaHE2eYqiyXSLZ",
+	"UGgDJ1WRKKcQyWJzolpxRQJ5l2Ez9z0KgbwoGDdGZxKrnsKt461Jgxehlb+cmWC1yrUSdM5AKKAzj17j",
+	"Ek6FHXUKBT85etav7tLmsKLus6PHfcaoMN9hq+wuMhdfXMNtonXE0XhkOUgmvwKjXI5+gy8cEEvn6Sm1",
+	"sSmRimrKMc62VsN1URDhZh3pQ3IdKgGAyS3AQ0x1E/yCMdyFVJdYCUl8loYIgAZPVdCkMPAHBYSJ2weW",
+	"D3SPYPQeO1UfhCi+EpTO0a6duw7VHJqyuRjw6ikqqtgL9vKpatfrpWCaSlChXjFhHzytUJXzKLQVtGel",
+	"p4p2TMQCEYsN/aUwCvVcyBrFz5rSby91vr6zcsubNZK/tIUFW9XiywY6H93ZBtpxVYm6zzgg0ueJTo62",
+	"00lUnft2pAUfPdv+USjDHT/0oxe//hZTJp0C8XiTmAZodIGJcDsRaTJnbjthtshSzmOyHMRKWu6e0DKR",
+	"PPgvvIxZ/nY0i9FhAMEKvdC17UcwV3ee++fT69dtMTKFJjDtLu8gDd2A5Ne9hCcq7251AAje/b4Tnemk",
+	"094RmluLmoiQsgsJ15jcMFWhfpBaO334mq8n7ASjMTD6WleX8Jxk+J6Uguqwc1nUlaDeIFPFFTs9I4WP",
+	"W8Gck2SQVuPohHui2GQMwb9oNnpLnnx//00SPmrNVoBdgDMiZ9xasSqt2ZVp8DZS70AyByG0ZUE1XNso",
+	"+BdhE+E694gDidWSDS1i0nWBR3fAdl7hK1r3RRi1YTnu4TUhcAMuMgjD7dn8dVH4iKXWDPQLr1AHL9ZN",
+	"65Wm54KTSMEyZtjfa8qvkiiiLr0y3r1B6gfRjnB6oBtMXh5EoXRgewdX58/J1Ob0Q2SAcS07PRztGBxU",
+	"LFDMiqrDefEMr1MX+VS5v2BNHao5shHNI5t8LFRgMLgvekmm6o1eLNB4WWN6PVYo80ZRt8CjUMhMRi++",
+	"tCmUcIFP5+FdvZ8HpRXM9cBPSTe2qwcNTRMa8s1lP7flRggZQFt/wxHf7vIgUmbbGEK6gsopUm3t1dyA",
+	"hjgtKvPPjh5PpuoDZiEjL4omQiTFNMJsKTgVDeAKfaNACRiWiuo/muOrkN7CLWUB1WUKKV0whPgYIs7u",
+	"CTW6oRfJZzgGyJ08LACUlACevuSs08okeccQokY3HIoc+Q4kru+Ps1rWZakrS+nAztQHEu1UWVEUTsq1",
+	"2hsI43dGKmMFh8L3KJEgDwLn+bOjZxN2PFVumC+6h6sKlZdaKhvWxfg7F0u3grpWZIpJNINCA+mP7gwv",
+	"XEu9MfOJBWNGKS5jpimfZUyYdXEVbnPsiv5juRIHYjMOFuMLYyvBV2MfvHRBwUt73Ecxmdiquj+mhgSy",
+	"EheOue75OSmT19cAZNxz35mY68rXqJy6Tnlmf+x5/gXu2IBdeiGVb6/WfV1gZcBwcQEqx4VUbC9KDONk",
+	"wTORAWo//fTbVk+ceySq1joJijonXABtyF3vsEgr0d6PyBU+SFNKVIB4kBOimbhbkzjEeMgqipYAOeqs",
+	"+RfqakqInGzmzvofxdhZHSacTFFhY0YiviLrzKJau8a5JQgvcoYZ2xTPizopCn9e7iMtVKqsEiuhLC+Y",
+	"WasMiQQXQdUR681jp4VxMPcDvZV8IeLOCGPXx4QMRC7ZfLZ2TUk4pJgHaDAMYGONjR3+pjfaOUwVLDJh",
+	"Z1gvwVUAmIEcu5pJFcq5SRJiNtVbaeyrJiQx7sL562akfZCHG3i6kIYARGmYi7tIddPEfbS6Ue6WjbUZ",
+	"RYvFZ5ptwAvoclhT6+IttdYNRSAwYSYUs3m+rQjRl3F/xd+wG6uZuZRlz2boEtO7iVdPVFr58tt98o+o",
+	"YUlK8Qd+EJ3yLp5knDMuTu6YS/hTm8Ec4rvSryhgvzXvJ5mwd64dTRMD4N0SZDji3dgJrFpFDArp1zku",
+	"xMqFOnULl0ftcFbENpwjCjyErqcMrW6rNXzpmwU0HXWMECa4ZabKQw0cl6TS4Odg/6IiwaE3TdP7hr10",
+	"r22TjkwdTlqOIN/fJ4zGrBg4pWuQE1r1kHFH5ElVFr/+RNXU70NliZsM7qSvPOupZuEO8wd3dQRKwHO7",
+	"696VEA5/pzDiL20fbvvCPtG1uivrMPjUVpshhzT9KMF1+qBeqxbcHxiI7qw3BKPII8Fl83l0TRzvU3KL",
+	"G1cmOO/LiJOYcSdQ1hMtihR3xpNj5rULY85847Kk/HdWiXkhF0vfjZvY6Ab7nbBP6hJih5Db1iqONpoq",
+	"dEcHYT3q8QUqFWp1qN8FCQjth1azuVQ5WG2m6jpEgDvftjRBe0hb/1FlfBVqtQyKR8nWMASXtBzgg/R2",
+	"b578AK8/njht7nXApgTYkPNNp3hQ401How/XuvGcR43wdsDhpiBEEok323W4VK7ouinIkHQZr5uALIBT",
+	"T1Ve6dJg+CN8VKBA5fsG4xQ+EnEjIYHbqEFfUop/R7u/Rwxpamkk0MM3dfHWASWu4QbulivR5CSW3UBq",
+	"rKJk1qTceE6W4Q38sTp0T8Xr8ZG1k6k6nXdKhHnRizBD+XCAMVM6zAfmAgron0wVlbk1bMXXTouEgnDo",
+	"eQoCJpqhCTNc2wjnjGIao88IjeDbhbDs2ZPvCV8+CFutD46pEtnGTiHY84DkSBO6PZqxG+XcHdGWQ4/I",
+	"ENfspGh7raeKTFeA9pH+vYGhAOFOe6T7kR+T3b12EiQf39MeBpmpUI46HkpCffoQne49prUakafi5SOB",
+	"7373hMJpq/P+s6PvHwIUdM+ehFAbM2Au8n9padMP6t7u3kUQKX9gRoiYh3RY8Xki62B3LrzdSjjTdtmk",
+	"WHCS3qiXo9fAu3ufDBm2ovj7+5ae4hZ1Cdi/SqL/3ZlQWhkuO17H4bJpKjh4LcI5cztPZBBdhHKOUeoN",
+	"50WAqUIZYEwvk7RQsQ3cJE6OhOvFTAwJc/JsKXLwmDInTzTvk6vs4AyNnF0vZSEm7BVXmSiK5sWit8jX",
+	"5OQqB6sIWk6w/CdYSo1hTXU+eOQXgg5CWfeIaGBU7THnJ1syblEO3FreoMTKSlxJXZsho2WG34yGFIJe",
+	"w2gAxu0No8+PWgXGt9lFf3sw6vIgTxDYcdfk3iG1B9dQkDpL/pUk+rv7vw1TTwfJkBYw/jtwzE165di8",
+	"xTFSXTm6ytGXmHWpKapvi65LCrHQSnSajG9qzjjThsh3M/tTOPaOJqgPjd5Hx/gmVig6+le8kPF9H5Lw",
+	"1K+7UNpWu/1x164yVS/hTSVRfSYyvWqkdeTAiQS51KXSWvd5qXfOMwalMqfQNE67KFv0m6AOAfiuUMd1",
+	"ZO7Hndc0YBh5Emk6+NU3ou3QZvpb3I87+i0uyDTde5NS1vFiUYkFt4I8vt77swRSNVklhHoRuZHbvnPy",
+	"mk8VOMrHaJ7qGpRwGIlauvW3DedWI3ANiz++G/H9U7BfaUCe9sC9kxDGhvwALD69kW5lh4v+3f3flrf6",
+	"A3ro2kangjjvUpYTFjHsQhtKKjdUth9sQmiUetR0HnM5kpGtyuneSlNjunQMIuyh17y9hYDDOXck4Eb5",
+	"gVW/Df3SiVlUfX332zxUmi6yrFP0ay0oL3Ch80qIAyyMCl+gEbHxvr7T1qWaX0kjZz77zmoy5gVv73WF",
+	"3yJ5KlC9fIpdmOlYMbEq7RpXAbIlhEqHm54HwoUNfPVd35vdDnbnstxv6/2FKUIHvD+J9/dc2NBp0fMD",
+	"RRd1IwwNxbvToqK5jBlO8Fcgyppl5LH42CBaiFAILwOTimEhno7nJCTh0HdOQcDfcsYt+i0hqj4Tzpbw",
+	"+DkkS9c2HTSP7oaHZk6024fCgQcy+fnDQZUldx1W62Dv68qg5jKFJG2n1g0RU1e5qHp555lUHbyk+LfS",
+	"e1P9L+Axm7AziqFrFJhKTBX85Molmswpt0ZXluHS41ZBxVqVnSlcDN5kqrBvC3z4Hr5je85fzVRdFPuw",
+	"Nfx4mMXip39cHovb+0omex5A+2dktYG1hVP043Murg6p/Fm/+9CnJLp0IwwE8PHYV6KScwz+knbs6vK5",
+	"tBGS0+ZTRYGtE4YRlnNNZFZAOLModLkSyr6II8KrKEu+VoUw0ILYsqpWTqp/ffLLxdv3r0/+A+4zXWnh",
+	"6o0r6XYfiOan/6fPYbyH1PYQnIJy4V4urthK52I/HXodNTffGnntxsZN862OCz44l3XamfLarXSP9xZ1",
+	"cR+IP/VHvivrbB4O5uHr/zKUXkggNM4gSl+Ehj4deJJu1s36jkv6+CrWbh7qXE0lL5Rga2EhF0FB9zmL",
+	"9O+M+01wiJ+UmqpEFYWiQIOqVliGi0IETv7z0+mHk4vXJ7+cvjpxda+c/ugSJlyudMi28Bwt5FngRM+O",
+	"nrp/X0QpJAldkyD22ldwvie+IzPxbZz+3Q71CQymIRH9fSOnhL+LqJz2BuZHzOXwd18AfItF40pfogJM",
+	"wzFn6EooS28f+b7IjOEfQKgZxVwKpJmqqFk+xbMcPQ4eM8W0GmM2ksuYW2OkDTj2vAODyCz3QKZ+9PAg",
+	"42sKSSDkXnTViRIoCgcICHoz6c2DaEd9I6ACLPmtbCGw9hAOAAxslqit9soxCH/VjwyJz6g3wv8Bc7OV",
+	"XK1ctJBvuVhjq8vHR0dRrfNJ4iJgjru5iPtiMl8pP4f7h5P+aWRnupibs43DktdmwDDxUaxKXfFK+njm",
+	"mIuIyWLCOCuXVBMPDAl8TdLRTLCVNAWXOSbKuleRGA3DNfMGQ8M7RqwlvFpuHHbmJCMc/oWyxd2D3lTn",
+	"qxWNhziCGhkZNln12ePOUoJ5JnKVlL3huz84cuMWvxa3CU5/FtTGI98Csx0+DBQvoNDSgIYOuXhVrDc5",
+	"3yea7UHfII/R3yhjo9wF8JSF3KvlkIR7gCFIOJRRjjEGgnhhNpLNX2x4O6M4oz33J59eMGkimih51EUu",
+	"ScOmI+9Cno5QkW+KI0Yxtvvjnl4Q1C4yVMec0G/7PsW6qZs5cVsR+T656yHu/UCCzLTnE7Mnzg6z78yw",
+	"CIdHhlJdJaDgX8/fv2NUUBXzV0+uqIaeofRWbgRbaaWtVhLlJXrOW9VO6hLEM3i/yTI3Vb5WIF4QAvnx",
+	"kbfquogRV6yvEplWSlBdTjj/VEEd7wPcxsHpa1c1zUGbdBg3rbTA55HrntBfsGgmvAaQQQ9vAq8EW2gl",
+	"fmgW5Nd8Hdo2U6XPSsyFzZZN+i68FS5F2K2V8aZxBdx2bGbsjXY5R3SjvW0LBTt97c2aWEuZ8LXBMewI",
+	"buqVj3TD8CwM3aLio03sVgt628LEtrAOnAY6YO8SlAG+LSLJA6Kz9vvSXX1DDzqJSPQbqUB0Ye7OI7bj",
+	"/kBcZyl4YZe9XMfbVnyFShztuAM4+lhfhYWUW/0nWuserSu0wpBZjNgoMAs6y3rQZkXzhQynhHHK1zc9",
+	"nIW+H32ArKS46lQfDQV+Oy1AnJ5H/2gXDXYFnc5O3x3kogJ6ws6D3McrS9VawjUgmPhyzx70jww7+cgX",
+	"xDWhqlNoQkUbYBgBLS3+m0kF2SAH76Cm3VvQl3xEKWdPj57RnpRmM52vKWg1mgqLXdSuQkXeE3Fx2u14",
+	"czOp4HQOW8Od3W/EVWefKcLvu9PR2HE33BQAv28xN+wQx+AST1OyzWl7fpLcHZi/VtC5X2fdO90Fjssa",
+	"SASqiAF4enr0v5A+n3K/nVNF7ZvTnntUZyJdt7uHHNEiadmCaoVR/Utc/IoXtXsUK8FzeglRq2olstPi",
+	"46nSFXblBkK02iVWURnhYxAXVkiLja/ke8wTvdbBDh4KShaUSQspVdBPX7RDbDwIJuyVd95cU/sa2slU",
+	"dbI74iVpyIX7hZw1zUL/YbGvuYEiHiTeuLp1K6GcxMHrXPqcK1emEmO6cyh6eWodCM1U6doi0Bs/EVmg",
+	"KWWrAsx3O2PPjo58hbKLcK8pf+Y2zpOsuZe4rGA6hmOSOdBECNBXJ8ODKSXbNL0C7kkTTnGzWyjDXUZE",
+	"1bgeViv+/i49yPNCZraXe710981NKK+DHQzJseALOLly9ReZm25/TJU32nSEmT+BJq85plfCJ7ICu+Je",
+	"i7b2p+rhU6jcnVYx0fZEVVA32hsy7IQQdbgSlg9JUqHknFsLGGxDtnF++iPT3cVUeUK1WJGksGN2+gtc",
+	"TtTue8I+GQFNwUDZy0Umc8cSfc+Vqdp4Hypx0JHSjK1IM/v59Y/MuP4mOwlAbwEADybG4GoJHOjSdQPi",
+	"fx7xQvafcTu6UlDywaUYqAjduFEjnIxahgOGCZUfWH0gVB5hNATqRL2QuuXAyOiBYoU0TuZIvW+h4fk9",
+	"+SM3Gqrf9hVpKqH9gbyH7dZ2aXRodUvdovw1HCLcI/V2cTaz2TpOMUcjF/yeUAFD27k9I1ROWOWiKwGE",
+	"/9Ykw7fwbT9R1o2S8NJl3aK2NhhEhHucKlpzqJ7bUBLiVLWzEH8A+VYa30pZq8Z01NNG5S/Cvokaof6h",
+	"8hIbQ+jDVWz77V5DjeizvqCVkw2c7pavuKOchb7pG8oMIwbiWpDPYLP5XmK0Ok2FKWWwTYzeAu/7ZDVU",
+	"CAlqa+Yu2JlHQHnD4hPFga4OQICUajFhSJ0lr6zkBalPoKxNVZgLPgKJ0QrFpMpFKVRO+h1luVQHzdBK",
+	"mLrwEaS+9AWoj80QqrwGBhxnSW7iVOdYj4gs2UrbC37hf3F15675ehyKE5FNOuOK5ZUuqWScC0yYTNUn",
+	"anMAh2u3R8NqMd5j6X66yKXBH5PPGjDLnen/byhbh+OaBMgqUerKpiHXQ7zufr6J3uaPjnD4ZgGIrT0Q",
+	"qJJFc1LYuNdCb2p1+2Xc1/ij7Vj6pysm8tF71CAajgh0s/cc0Mhelzj2H6zKR8wvXJXGwCT2Wpxhf8xq",
+	"U2Mw0kygpwlDmXz2Tu6sNwqbzL0jnofttQJLvOaBvU2cyvv4Aa7hpefHBa8Wvtp4y9Tk0odRbtpzhH1h",
+	"tb7AL/a76nA72XijN37q0WrJk4e8KHYsWdFCl25P6Vh0Y15yw9zLqZrXhSv3Sy5V+tkVkoCCFDxDu6HK",
+	"BONZpQ3pMSDVGBLxpmqXQhNbRbwfcThzhjSXrRsKEqNLk559o3hpltoeo0m0lNklA63e+8BXPBfOB1Fi",
+	"veAt4uO5m+5fUuTDSJEB3oOlLTZFs29V3eIMdmSXla4XSwwx741z6Bbk7KfrxqN841CPZnVuGr99SF9a",
+	"OVmtIX0MTyigT+mKl+0KbkA+uigc5U/DhqcjH1kxVSG0QrEg578JOXSneSHc5ozzB2Z6BTTLMIccmdNU",
+	"PT1iRmRaYbndqTp2vPDQF97vj59gifCJqXKRBN4QGQElRFFQwjc1TwECIbbl4AiZqBvXuBEN0R8EsbPo",
+	"+a84iJMO/m4gkYvWMd82SCLEShXR1fYR8mp7PxToQlyt6IB85q3NUbCEqzubjJN4RVkUoQL1PXFknD+V",
+	"SknLu9QtNdd3VnNgY+JUr6vhcOzIiJpLUxbcdxa4XsomMdcwI9qh2lO1EavtevVignnTlhUXcAX/8YYS",
+	"XIACo88qPZf31trSzX6TGOz7R41PEWAi1HhwqqV9sDLcQCIyZyUOvc9lJ1+SwyrjHl+Zi+CzGaOELHIv",
+	"ATc9yX3XegraxleVKhnMhFDwveu477S6qA2IE9L6/EF+4vtmAGGdAUYQwHhHfMDV0gsH3OQBqRAOunOy",
+	"TuuKrXTVXNCEQU4zYKb/C8K9EHPL4tAjX3vbjULmYNwVwSuUNjfRyq0buXt6jy/j2xH9EDr43x4+GzpF",
+	"+1uQyHGA2vDF9tcaixLX2dK3a0rHCaEorLOsLqUwYzarIAcOKi9eY4MbeNQXulpvOEWi9vnY/amP4mnt",
+	"T4ZUvXu75tY6Q1TvYEEgvCPSb0+avDXXc2sgFb2J5ep6b8mWGztcI0+rVMA7QBDgylDL/nHUU9H3tnac",
+	"3DBFJu4JO8H/Us1WNKMbDnYsXfkhTJoJc3363VPg4zyoVLbfn67iQlbO5v29H3zhju6EFOdtcAY0sFrL",
+	"K5nXvJgq3/ss+Xq8dwC8Hz7lZv9ab+9p4uaiu/pzhxD1W1EdjjXYQXXpOsDQseOZ7W2gx36i3kNdtvGY",
+	"Yn8Hohh0KRQv5cQfbYuAJE1wajm1xlC2RbvZH/MNLXzyKyV1L3zXVBJ+gGWev/55OIQ8zSbfl0Idn52e",
+	"lyL7Wi7J81xSQ8OzCtahLojUoMIptqSc9hnt3V5YrrN6BUsOxZL7wS0oplmgz3e5UUCB/8hF50NuTcih",
+	"YQZeN47ZPBVGqU5HGFw6HYGMNB3tpwIQ2JmfEi5fgAnBCt+QNtRkS12S//A+3zG/xna3dIBMy23cajJz",
+	"d00cUNENcP8aR/WHKN0yBA61L3nCGud0OkRkqrxbWqvICTr2zbei+I44idmVsmKPj548m6ookZmFFiEo",
+	"XGOnYN98gSpnNW5kI+Dpo53+gA3pqZgbNgXJCnQw9JZya6HQfajXNP1X5jgHCvkmFYL+WH6/xpsXO/CS",
+	"7Wx8IZgdvGH49PUywl9EJedSOKErqh8ETxHWWsc4bBQnXE8PbhlSiV5UvFyCKFZWYCGWV04VhJbYGIfh",
+	"Hz8ICwgw85Q3VTd+uqDNsVTC3HNbdLfIbllQBN4v49Hzo6cPu4f3kR7fzIE34HoAb2lj7dYYys7y+fA3",
+	"MQDVyhXbcF3Xm+6+cb+fCcMSKGaqOLbhpTZJ4GsxSwwlqcRcfvZmSRK9HKKGBteYYzFVoc267G185OuH",
+	"3KteSGtsK00UQHpXtYlMc7aBGzz8HRsWD1ZnwTT0pgd0Y9HzF7iQWFvFmubCLoVyd+VKAWHVR//JhP0I",
+	"/nEc1rYT+cJARy74ae3sfuQ4nyqpwoNYcrvEKguFBkvVh7irfqJgUtMokxv4xHfkh5gtt1NM+4E/I/Fg",
+	"nAIcO5rEb3Oqwj75aiYXta7NhRvXXx+m6dh/szQ8N7ErDrRTgr5bql0l5s9QJQS2O9xcHdDvAIxGt00K",
+	"hW9JtnOZnd5kGXnFx1PlHlF4psD32QhzgC2iEopM1b35nz5vLYQ2uNSVO8kB9f7jbQmgYHx8zTHr4Q+a",
+	"+hl2OKhroFkyp4PcbbrnJz/znyvRMwBklxTPGHqeohoy2uoaGKYiCkE1TJdWrqSxMgO6Ig6crdlBS4tB",
+	"RUiqrKhdvqH4XBLj99SRVlsiPL4vLwFM/+08BH0k0KDnn1wV2pr/9wshAPOZfWn/xO5I3ZFyfx/NBK9E",
+	"dQwvyYtffwOmRkpNKsIELEozbgRKGaPxqK6K0YvRIS8lckO33sZXbb0Frc3uIV5xxRcYdtdEn+Cjthmq",
+	"1pvq3rXmpub0nwzO2zAPfAb3yCAybj910TO338zfQHhzgVeJdgTGOQ9C5yI3T5TE8PvWxIcQreu7pkcK",
+	"rpsvjmf7fah4YtXcDQhHwV7o5mlKhG7ES0OwV39xICMXSuQHUvl4NTehKwvy5bcv/38A8n/w0C/1AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,16 +2,27 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/whereish/server/internal/auth"
 	"github.com/whereish/server/internal/store"
+	"github.com/whereish/server/pkg/crypto"
 )
 
 const Version = "1.0.0"
@@ -20,37 +31,178 @@ const Version = "1.0.0"
 type contextKey string
 
 const (
-	userIDKey    contextKey = "userID"
-	sessionKey   contextKey = "session"
+	userIDKey  contextKey = "userID"
+	sessionKey contextKey = "session"
+	deviceKey  contextKey = "device"
 )
 
 // Server implements the generated ServerInterface
 type Server struct {
-	store          store.Store
-	googleVerifier *auth.GoogleVerifier
+	store           store.Store
+	googleVerifier  auth.TokenVerifier
+	appleVerifier   auth.NonceVerifier // nil when Sign in with Apple isn't configured
 	sessionDuration time.Duration
+	verifyTimeout   time.Duration
+
+	// refreshTokenDuration is how long refresh tokens last; 0 means none
+	// are issued
+	refreshTokenDuration time.Duration
+
+	storageQuota  int64
+	requireDevice bool
+	maxShareBatch int64
+
+	// contactRequestLimiter throttles contact requests per sender; nil
+	// means unlimited
+	contactRequestLimiter RateLimiter
+
+	minBackupIterations int
+	maxBackupIterations int
+
+	cursorKey []byte
+	cursors   cursorCodec
+
+	events *eventHub
+
+	// locationEvents carries each shared location to the recipient's
+	// /locations/stream
+	locationEvents *eventHub
+
+	// lastSeenInterval is how stale a device's last seen time gets before
+	// a request from it is recorded
+	lastSeenInterval time.Duration
+
+	// keyChangeNotify sends contacts contact.key_changed when a user
+	// replaces or clears their public key
+	keyChangeNotify bool
+
+	// devMode enables /dev/login, which signs in as any email
+	devMode bool
+}
+
+// Option configures optional Server behavior
+type Option func(*Server)
+
+// WithVerifyTimeout bounds how long OAuth token verification may take
+func WithVerifyTimeout(d time.Duration) Option {
+	return func(s *Server) { s.verifyTimeout = d }
+}
+
+// WithRefreshTokenDuration issues refresh tokens at login lasting d, which
+// clients can exchange for new sessions (0 = no refresh tokens)
+func WithRefreshTokenDuration(d time.Duration) Option {
+	return func(s *Server) { s.refreshTokenDuration = d }
+}
+
+// WithStorageQuota sets the per-user storage quota in bytes (0 = unlimited)
+func WithStorageQuota(bytes int64) Option {
+	return func(s *Server) { s.storageQuota = bytes }
+}
+
+// WithMaxShareBatchBytes limits the size of a location share request body
+// (0 = unlimited). Bodies over the limit get 413.
+func WithMaxShareBatchBytes(bytes int64) Option {
+	return func(s *Server) { s.maxShareBatch = bytes }
+}
+
+// WithContactRequestLimiter limits how often each user can send contact
+// requests. Requests over the limit get 429.
+func WithContactRequestLimiter(l RateLimiter) Option {
+	return func(s *Server) { s.contactRequestLimiter = l }
+}
+
+// WithRequireDevice blocks mutating requests from sessions that aren't
+// bound to a registered, unrevoked device
+func WithRequireDevice(required bool) Option {
+	return func(s *Server) { s.requireDevice = required }
+}
+
+// WithBackupIterations sets the PBKDF2 iteration range accepted for identity
+// backups. Too few weakens the PIN; too many makes decrypting the backup
+// hang on the user's devices.
+func WithBackupIterations(min, max int) Option {
+	return func(s *Server) {
+		s.minBackupIterations = min
+		s.maxBackupIterations = max
+	}
+}
+
+// WithCursorKey sets the key page cursors are signed with. Without one a
+// random key is used, and cursors stop working when the server restarts.
+func WithCursorKey(key []byte) Option {
+	return func(s *Server) { s.cursorKey = key }
+}
+
+// WithGoogleVerifier replaces the Google token verifier (used in tests)
+func WithGoogleVerifier(v auth.TokenVerifier) Option {
+	return func(s *Server) { s.googleVerifier = v }
+}
+
+// WithAppleClientID enables Sign in with Apple for tokens issued to the
+// app's bundle ID or Services ID. Without it /auth/apple returns 404.
+func WithAppleClientID(clientID string) Option {
+	return func(s *Server) {
+		if clientID != "" {
+			s.appleVerifier = auth.NewAppleVerifier(clientID)
+		}
+	}
+}
+
+// WithKeyChangeNotify controls whether contacts get a contact.key_changed
+// event when a user replaces or clears their public key. On by default.
+func WithKeyChangeNotify(enabled bool) Option {
+	return func(s *Server) { s.keyChangeNotify = enabled }
+}
+
+// WithDevMode enables /dev/login, which signs in as any email without
+// verifying it. Without it /dev/login returns 404.
+func WithDevMode(enabled bool) Option {
+	return func(s *Server) { s.devMode = enabled }
+}
+
+// WithAppleVerifier replaces the Apple token verifier (used in tests)
+func WithAppleVerifier(v auth.NonceVerifier) Option {
+	return func(s *Server) { s.appleVerifier = v }
 }
 
 // NewServer creates a new API server
-func NewServer(s store.Store, googleClientID string, sessionDuration time.Duration) *Server {
-	return &Server{
-		store:          s,
-		googleVerifier: auth.NewGoogleVerifier(googleClientID),
+func NewServer(s store.Store, googleClientID string, sessionDuration time.Duration, opts ...Option) *Server {
+	server := &Server{
+		store:           s,
+		googleVerifier:  auth.NewGoogleVerifier(googleClientID),
 		sessionDuration: sessionDuration,
+		verifyTimeout:   10 * time.Second,
+
+		minBackupIterations: 100000,
+		maxBackupIterations: 1000000,
+
+		events:         newEventHub(),
+		locationEvents: newEventHub(),
+
+		lastSeenInterval: time.Minute,
+		keyChangeNotify:  true,
+	}
+	for _, opt := range opts {
+		opt(server)
 	}
+	server.cursors = newCursorCodec(server.cursorKey)
+	return server
 }
 
 // AuthMiddleware validates session tokens and adds user ID to context
 func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip auth for health endpoint
-		if r.URL.Path == "/api/health" || r.URL.Path == "/health" {
+		// Skip auth for health endpoints, the API spec and capabilities
+		if isHealthPath(r.URL.Path) || r.URL.Path == "/api/openapi.json" || r.URL.Path == "/openapi.json" || r.URL.Path == "/api/capabilities" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
 		// Skip auth for login endpoints
 		if r.URL.Path == "/api/auth/google" || r.URL.Path == "/auth/google" ||
+			r.URL.Path == "/api/auth/recovery" || r.URL.Path == "/auth/recovery" ||
+			r.URL.Path == "/api/auth/refresh" || r.URL.Path == "/auth/refresh" ||
+			r.URL.Path == "/api/auth/apple" || r.URL.Path == "/auth/apple" ||
 			r.URL.Path == "/api/dev/login" || r.URL.Path == "/dev/login" {
 			next.ServeHTTP(w, r)
 			return
@@ -68,18 +220,117 @@ func (s *Server) AuthMiddleware(next http.Handler) http.Handler {
 				writeError(w, http.StatusUnauthorized, "unauthorized", "Invalid or expired session")
 				return
 			}
-			log.Printf("Error getting session: %v", err)
+			loggerFrom(r.Context()).Error("Error getting session", "error", err)
 			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 			return
 		}
 
 		// Add user ID and session to context
+		setLogUser(r.Context(), session.UserID)
 		ctx := context.WithValue(r.Context(), userIDKey, session.UserID)
 		ctx = context.WithValue(ctx, sessionKey, session)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// needsDevice reports whether a request requires a device-bound session
+// under the require-device policy. Reads are always allowed, as are the
+// calls needed to register a device or log out.
+func needsDevice(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	switch r.URL.Path {
+	case "/api/devices", "/devices", "/api/auth/logout", "/auth/logout":
+		return false
+	}
+	return true
+}
+
+// DeviceMiddleware resolves the authenticated session's device and adds it
+// to the context for DeviceFromContext. It must run after AuthMiddleware.
+// It also blocks paused devices and enforces the require-device policy.
+func (s *Server) DeviceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, ok := r.Context().Value(sessionKey).(*store.Session)
+		if !ok {
+			// Unauthenticated route
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Revoking a device ends its access at once, even though the
+		// session it's bound to hasn't expired
+		device, err := s.sessionDevice(r.Context(), session)
+		if errors.Is(err, errDeviceRevoked) {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "This device has been revoked")
+			return
+		}
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error getting session device", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		}
+
+		if device != nil && device.IsPaused(time.Now()) {
+			writeError(w, http.StatusForbidden, "device_paused", "This device is paused")
+			return
+		}
+
+		if s.requireDevice && device == nil && needsDevice(r) {
+			writeError(w, http.StatusForbidden, "device_required", "Register a device before making changes")
+			return
+		}
+
+		ctx := r.Context()
+		if device != nil {
+			ctx = context.WithValue(ctx, deviceKey, device)
+			if time.Since(device.LastSeen) > s.lastSeenInterval {
+				go s.touchDevice(device.ID)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// touchDevice records that a device was just used. It runs outside the
+// request, so a slow write doesn't hold up the response.
+func (s *Server) touchDevice(deviceID string) {
+	if err := s.store.Devices().UpdateLastSeen(context.Background(), deviceID); err != nil {
+		slog.Error("Error updating device last seen", "device_id", deviceID, "error", err)
+	}
+}
+
+// DeviceFromContext returns the current session's device, or nil if the
+// session isn't bound to a device
+func DeviceFromContext(ctx context.Context) *store.Device {
+	device, _ := ctx.Value(deviceKey).(*store.Device)
+	return device
+}
+
+// errDeviceRevoked is returned by sessionDevice when the session's device
+// has been revoked or no longer exists
+var errDeviceRevoked = errors.New("device revoked")
+
+// sessionDevice returns the session's device, or nil if it has none
+func (s *Server) sessionDevice(ctx context.Context, session *store.Session) (*store.Device, error) {
+	if session.DeviceID == "" {
+		return nil, nil
+	}
+	device, err := s.store.Devices().GetByID(ctx, session.DeviceID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, errDeviceRevoked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if device.RevokedAt != nil {
+		return nil, errDeviceRevoked
+	}
+	return device, nil
+}
+
 // GetHealth implements health check
 func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 	resp := HealthResponse{
@@ -89,6 +340,90 @@ func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// GetReadiness checks that the store is reachable and crypto is working
+func (s *Server) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	resp := ReadinessResponse{
+		Status: Ready,
+		Components: map[string]ReadinessResponseComponents{
+			"store":  Ok,
+			"crypto": Ok,
+		},
+	}
+
+	if err := s.store.Ping(r.Context()); err != nil {
+		loggerFrom(r.Context()).Warn("Readiness: store ping failed", "error", err)
+		resp.Components["store"] = Fail
+		resp.Status = Degraded
+	}
+	if err := crypto.SelfTest(); err != nil {
+		loggerFrom(r.Context()).Warn("Readiness: crypto self-test failed", "error", err)
+		resp.Components["crypto"] = Fail
+		resp.Status = Degraded
+	}
+
+	status := http.StatusOK
+	if resp.Status == Degraded {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, resp)
+}
+
+// openAPISpec renders the spec embedded by the code generator as JSON once,
+// on first request
+var openAPISpec = sync.OnceValues(func() ([]byte, error) {
+	spec, err := GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	return spec.MarshalJSON()
+})
+
+// GetOpenAPISpec serves the API specification the handlers were generated from
+func (s *Server) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	spec, err := openAPISpec()
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error loading OpenAPI spec", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+// Features reported by GetCapabilities
+const (
+	FeatureEvents          = "events"
+	FeaturePresence        = "presence"
+	FeatureNudges          = "nudges"
+	FeatureOnboard         = "onboard"
+	FeatureTokenValidation = "token_validation"
+	FeatureBlocking        = "blocking"
+	FeatureSessions        = "sessions"
+	FeatureLocationStream  = "location_stream"
+	FeatureStorageQuota    = "storage_quota"
+	FeatureRequireDevice   = "require_device"
+	FeatureRefreshTokens   = "refresh_tokens"
+	FeatureAppleSignIn     = "apple_sign_in"
+)
+
+// GetCapabilities lists the optional features this server supports
+func (s *Server) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	features := []string{FeatureEvents, FeaturePresence, FeatureNudges, FeatureOnboard, FeatureTokenValidation, FeatureBlocking, FeatureSessions, FeatureLocationStream}
+	if s.storageQuota > 0 {
+		features = append(features, FeatureStorageQuota)
+	}
+	if s.requireDevice {
+		features = append(features, FeatureRequireDevice)
+	}
+	if s.refreshTokenDuration > 0 {
+		features = append(features, FeatureRefreshTokens)
+	}
+	if s.appleVerifier != nil {
+		features = append(features, FeatureAppleSignIn)
+	}
+	writeJSON(w, http.StatusOK, Capabilities{Features: features})
+}
+
 // LoginWithGoogle implements Google OAuth login
 func (s *Server) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
 	var req GoogleLoginRequest
@@ -97,10 +432,19 @@ func (s *Server) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Verify Google token
-	claims, err := s.googleVerifier.Verify(r.Context(), req.IdToken)
+	// Verify Google token, bounded so a hung key fetch can't hold the request
+	verifyCtx, cancel := context.WithTimeout(r.Context(), s.verifyTimeout)
+	defer cancel()
+
+	claims, err := s.googleVerifier.Verify(verifyCtx, req.IdToken)
+	if errors.Is(verifyCtx.Err(), context.DeadlineExceeded) {
+		loggerFrom(r.Context()).Warn("Google token verification timed out", "timeout", s.verifyTimeout)
+		writeError(w, http.StatusGatewayTimeout, "verification_timeout", "Timed out verifying Google token")
+		return
+	}
 	if err != nil {
-		log.Printf("Google token verification failed: %v", err)
+		loggerFrom(r.Context()).Info("Google token verification failed", "error", err)
+		s.recordLoginAttempt(r, "", false)
 		writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid Google token")
 		return
 	}
@@ -120,584 +464,2181 @@ func (s *Server) LoginWithGoogle(w http.ResponseWriter, r *http.Request) {
 				Name:     claims.Name,
 			}
 			if err := s.store.Users().Create(r.Context(), user); err != nil {
-				log.Printf("Error creating user: %v", err)
+				loggerFrom(r.Context()).Error("Error creating user", "error", err)
 				writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create user")
 				return
 			}
 			isNewUser = true
 		} else if err != nil {
-			log.Printf("Error getting user by email: %v", err)
+			loggerFrom(r.Context()).Error("Error getting user by email", "error", err)
 			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 			return
 		} else {
 			// Link Google ID to existing account
 			user.GoogleID = claims.Sub
 			if err := s.store.Users().Update(r.Context(), user); err != nil {
-				log.Printf("Error updating user: %v", err)
+				loggerFrom(r.Context()).Error("Error updating user", "error", err)
 			}
 		}
 	} else if err != nil {
-		log.Printf("Error getting user by Google ID: %v", err)
+		loggerFrom(r.Context()).Error("Error getting user by Google ID", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
 	// Create session
-	session := &store.Session{
-		UserID:    user.ID,
-		ExpiresAt: time.Now().Add(s.sessionDuration),
-	}
-	if err := s.store.Sessions().Create(r.Context(), session); err != nil {
-		log.Printf("Error creating session: %v", err)
+	session, refreshToken, err := s.startSession(r.Context(), s.store, user.ID, "")
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error creating session", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create session")
 		return
 	}
 
-	// Check for identity backup and user data
-	_, identityErr := s.store.Users().GetIdentityBackup(r.Context(), user.ID)
-	_, dataErr := s.store.Users().GetUserData(r.Context(), user.ID)
+	s.touchLastLogin(r.Context(), user)
+	s.recordLoginAttempt(r, user.Email, true)
 
 	resp := LoginResponse{
-		Token: session.Token,
-		User: User{
-			Id:                user.ID,
-			Email:             Email(user.Email),
-			Name:              user.Name,
-			CreatedAt:         user.CreatedAt,
-			PublicKey:         ptr(user.PublicKey),
-			HasIdentityBackup: ptr(!errors.Is(identityErr, store.ErrNotFound)),
-			HasUserData:       ptr(!errors.Is(dataErr, store.ErrNotFound)),
-		},
-		IsNewUser: &isNewUser,
+		Token:        session.Token,
+		RefreshToken: optionalString(refreshToken),
+		User:         s.toAPIUser(r.Context(), user),
+		IsNewUser:    &isNewUser,
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
-// Logout implements session termination
-func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
-	session := r.Context().Value(sessionKey).(*store.Session)
-	if err := s.store.Sessions().Delete(r.Context(), session.Token); err != nil {
-		log.Printf("Error deleting session: %v", err)
+// LoginWithApple implements Sign in with Apple login
+func (s *Server) LoginWithApple(w http.ResponseWriter, r *http.Request) {
+	if s.appleVerifier == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Sign in with Apple is not configured")
+		return
 	}
-	w.WriteHeader(http.StatusNoContent)
-}
 
-// DeleteAccount implements account deletion
-func (s *Server) DeleteAccount(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey).(string)
+	var req AppleLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
 
-	// Delete user (cascades to all related data)
-	if err := s.store.Users().Delete(r.Context(), userID); err != nil {
-		log.Printf("Error deleting user: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete account")
+	// Verify Apple token, bounded so a hung key fetch can't hold the request
+	verifyCtx, cancel := context.WithTimeout(r.Context(), s.verifyTimeout)
+	defer cancel()
+
+	claims, err := s.appleVerifier.VerifyNonce(verifyCtx, req.IdToken, req.Nonce)
+	if errors.Is(verifyCtx.Err(), context.DeadlineExceeded) {
+		loggerFrom(r.Context()).Warn("Apple token verification timed out", "timeout", s.verifyTimeout)
+		writeError(w, http.StatusGatewayTimeout, "verification_timeout", "Timed out verifying Apple token")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Info("Apple token verification failed", "error", err)
+		s.recordLoginAttempt(r, "", false)
+		writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid Apple token")
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
-}
+	// Find or create user. Apple only includes the email on the first
+	// sign in, so returning users are found by their Apple ID alone.
+	user, err := s.store.Users().GetByAppleID(r.Context(), claims.Sub)
+	isNewUser := false
 
-// GetCurrentUser implements /me endpoint
-func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey).(string)
+	if errors.Is(err, store.ErrNotFound) {
+		if claims.Email == "" {
+			s.recordLoginAttempt(r, "", false)
+			writeError(w, http.StatusBadRequest, "email_required",
+				"Apple didn't share an email address; remove Whereish from your Apple ID's apps and sign in again")
+			return
+		}
 
-	user, err := s.store.Users().GetByID(r.Context(), userID)
-	if err != nil {
-		log.Printf("Error getting user: %v", err)
+		// Check if user exists by email (link accounts)
+		user, err = s.store.Users().GetByEmail(r.Context(), claims.Email)
+		if errors.Is(err, store.ErrNotFound) {
+			// Apple sends the name to the app, not in the token, and only
+			// the first time
+			name := ""
+			if req.Name != nil {
+				name = strings.TrimSpace(*req.Name)
+			}
+			if name == "" {
+				name = strings.Split(claims.Email, "@")[0]
+			}
+			user = &store.User{
+				Email:   claims.Email,
+				AppleID: claims.Sub,
+				Name:    name,
+			}
+			if err := s.store.Users().Create(r.Context(), user); err != nil {
+				loggerFrom(r.Context()).Error("Error creating user", "error", err)
+				writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create user")
+				return
+			}
+			isNewUser = true
+		} else if err != nil {
+			loggerFrom(r.Context()).Error("Error getting user by email", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		} else {
+			// Link Apple ID to existing account
+			user.AppleID = claims.Sub
+			if err := s.store.Users().Update(r.Context(), user); err != nil {
+				loggerFrom(r.Context()).Error("Error updating user", "error", err)
+			}
+		}
+	} else if err != nil {
+		loggerFrom(r.Context()).Error("Error getting user by Apple ID", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	// Check for identity backup and user data
-	_, identityErr := s.store.Users().GetIdentityBackup(r.Context(), user.ID)
-	_, dataErr := s.store.Users().GetUserData(r.Context(), user.ID)
+	// Create session
+	session, refreshToken, err := s.startSession(r.Context(), s.store, user.ID, "")
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error creating session", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create session")
+		return
+	}
+
+	s.touchLastLogin(r.Context(), user)
+	s.recordLoginAttempt(r, user.Email, true)
 
-	resp := User{
-		Id:                user.ID,
-		Email:             Email(user.Email),
-		Name:              user.Name,
-		CreatedAt:         user.CreatedAt,
-		PublicKey:         ptr(user.PublicKey),
-		HasIdentityBackup: ptr(!errors.Is(identityErr, store.ErrNotFound)),
-		HasUserData:       ptr(!errors.Is(dataErr, store.ErrNotFound)),
+	resp := LoginResponse{
+		Token:        session.Token,
+		RefreshToken: optionalString(refreshToken),
+		User:         s.toAPIUser(r.Context(), user),
+		IsNewUser:    &isNewUser,
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
-// GetIdentityBackup retrieves the encrypted identity backup
-func (s *Server) GetIdentityBackup(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey).(string)
+// Recovery code limits
+const (
+	recoveryCodeCount     = 10
+	maxRecoveryFailures   = 10               // failed recovery logins per IP...
+	recoveryFailureWindow = 15 * time.Minute // ...within this window
+)
 
-	backup, err := s.store.Users().GetIdentityBackup(r.Context(), userID)
-	if errors.Is(err, store.ErrNotFound) {
-		writeError(w, http.StatusNotFound, "not_found", "No identity backup exists")
+// LoginWithRecoveryCode exchanges a one-time recovery code for a session
+func (s *Server) LoginWithRecoveryCode(w http.ResponseWriter, r *http.Request) {
+	var req RecoveryLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
 		return
 	}
+
+	// Codes are guessable only by brute force, so cap failures per IP
+	failures, err := s.store.Users().CountRecentFailures(r.Context(), "", clientIP(r), time.Now().Add(-recoveryFailureWindow))
 	if err != nil {
-		log.Printf("Error getting identity backup: %v", err)
+		loggerFrom(r.Context()).Error("Error counting login failures", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
-
-	resp := IdentityBackup{
-		Algorithm:  IdentityBackupAlgorithm(backup.Algorithm),
-		Kdf:        IdentityBackupKdf(backup.KDF),
-		Iterations: backup.Iterations,
-		Salt:       backup.Salt,
-		Iv:         backup.IV,
-		Payload:    backup.Payload,
+	if failures >= maxRecoveryFailures {
+		writeError(w, http.StatusTooManyRequests, "rate_limited", "Too many failed attempts, try again later")
+		return
 	}
-	writeJSON(w, http.StatusOK, resp)
-}
 
-// SetIdentityBackup stores the encrypted identity backup
-func (s *Server) SetIdentityBackup(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey).(string)
-
-	var req IdentityBackup
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+	userID, err := s.store.Users().UseRecoveryCode(r.Context(), hashRecoveryCode(req.Code))
+	if errors.Is(err, store.ErrNotFound) {
+		s.recordLoginAttempt(r, "", false)
+		writeError(w, http.StatusUnauthorized, "invalid_code", "Invalid or used recovery code")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error using recovery code", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	backup := &store.IdentityBackup{
-		Algorithm:  string(req.Algorithm),
-		KDF:        string(req.Kdf),
-		Iterations: req.Iterations,
-		Salt:       req.Salt,
-		IV:         req.Iv,
-		Payload:    req.Payload,
+	user, err := s.store.Users().GetByID(r.Context(), userID)
+	if errors.Is(err, store.ErrNotFound) {
+		// The account was deleted after the code was redeemed
+		s.recordLoginAttempt(r, "", false)
+		writeError(w, http.StatusUnauthorized, "invalid_code", "Invalid or used recovery code")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
 	}
 
-	if err := s.store.Users().SetIdentityBackup(r.Context(), userID, backup); err != nil {
-		log.Printf("Error setting identity backup: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to store identity backup")
+	session, refreshToken, err := s.startSession(r.Context(), s.store, user.ID, "")
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error creating session", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create session")
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	s.touchLastLogin(r.Context(), user)
+	s.recordLoginAttempt(r, user.Email, true)
+
+	writeJSON(w, http.StatusOK, LoginResponse{
+		Token:        session.Token,
+		RefreshToken: optionalString(refreshToken),
+		User:         s.toAPIUser(r.Context(), user),
+		IsNewUser:    ptr(false),
+	})
 }
 
-// SetPublicKey registers the user's public key
-func (s *Server) SetPublicKey(w http.ResponseWriter, r *http.Request) {
+// GenerateRecoveryCodes replaces the user's recovery codes with a new set
+func (s *Server) GenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	var req PublicKeyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
-		return
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error generating recovery code", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		}
+		codes[i] = code
+		hashes[i] = hashRecoveryCode(code)
 	}
 
-	if err := s.store.Users().SetPublicKey(r.Context(), userID, req.PublicKey); err != nil {
-		log.Printf("Error setting public key: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set public key")
+	if err := s.store.Users().SetRecoveryCodes(r.Context(), userID, hashes); err != nil {
+		loggerFrom(r.Context()).Error("Error saving recovery codes", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to save recovery codes")
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	writeJSON(w, http.StatusOK, RecoveryCodes{Codes: codes})
 }
 
-// GetUserData retrieves the encrypted user data blob
-func (s *Server) GetUserData(w http.ResponseWriter, r *http.Request) {
+// GetRecoveryCodeStatus reports how many recovery codes are unused
+func (s *Server) GetRecoveryCodeStatus(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	data, err := s.store.Users().GetUserData(r.Context(), userID)
-	if errors.Is(err, store.ErrNotFound) {
-		writeError(w, http.StatusNotFound, "not_found", "No user data exists")
-		return
-	}
+	remaining, err := s.store.Users().CountRecoveryCodes(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error getting user data: %v", err)
+		loggerFrom(r.Context()).Error("Error counting recovery codes", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	resp := UserData{
-		Version:   data.Version,
-		UpdatedAt: data.UpdatedAt,
-		Blob:      &data.Blob,
+	writeJSON(w, http.StatusOK, RecoveryCodeStatus{Remaining: remaining})
+}
+
+// newRecoveryCode returns 80 random bits as four groups of base32, e.g.
+// "ABCD-EFGH-JKLM-NPQR"
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	writeJSON(w, http.StatusOK, resp)
+	code := base32.StdEncoding.EncodeToString(b)
+	return code[0:4] + "-" + code[4:8] + "-" + code[8:12] + "-" + code[12:16], nil
 }
 
-// SetUserData updates the encrypted user data blob
-func (s *Server) SetUserData(w http.ResponseWriter, r *http.Request) {
-	userID := r.Context().Value(userIDKey).(string)
+// hashRecoveryCode hashes a code for storage, ignoring case, spaces and
+// dashes. Codes are random, so a plain hash is enough.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
 
-	var req UserDataUpdate
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
-		return
+// ValidateToken reports the current session's user and expiry. The auth
+// middleware has already looked the session up, so this reads nothing else.
+func (s *Server) ValidateToken(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value(sessionKey).(*store.Session)
+	writeJSON(w, http.StatusOK, TokenValidation{
+		UserId:    session.UserID,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
+
+// startSession creates a session for a user, bound to deviceID if set, and
+// issues a refresh token with it if they're enabled. The refresh token is
+// "" when they aren't.
+func (s *Server) startSession(ctx context.Context, st store.Store, userID, deviceID string) (*store.Session, string, error) {
+	session := &store.Session{
+		UserID:    userID,
+		DeviceID:  deviceID,
+		ExpiresAt: time.Now().Add(s.sessionDuration),
+	}
+	if err := st.Sessions().Create(ctx, session); err != nil {
+		return nil, "", err
+	}
+	if s.refreshTokenDuration <= 0 {
+		return session, "", nil
 	}
 
-	data := &store.UserData{
-		Blob: req.Blob,
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+	err = st.Sessions().CreateRefreshToken(ctx, &store.RefreshToken{
+		TokenHash:    hashRefreshToken(refreshToken),
+		UserID:       userID,
+		SessionToken: session.Token,
+		DeviceID:     deviceID,
+		ExpiresAt:    time.Now().Add(s.refreshTokenDuration),
+	})
+	if err != nil {
+		return nil, "", err
 	}
+	return session, refreshToken, nil
+}
 
-	if err := s.store.Users().SetUserData(r.Context(), userID, data, req.Version); err != nil {
-		if errors.Is(err, store.ErrVersionConflict) {
-			// Get current version for conflict response
-			current, _ := s.store.Users().GetUserData(r.Context(), userID)
-			version := 0
-			if current != nil {
-				version = current.Version
-			}
-			resp := ConflictError{
-				CurrentVersion: version,
-				Error: struct {
-					Code    string `json:"code"`
-					Message string `json:"message"`
-				}{
-					Code:    "version_conflict",
-					Message: "Data has been modified by another device",
-				},
-			}
-			writeJSON(w, http.StatusConflict, resp)
-			return
+// RefreshSession exchanges a refresh token for a new session on the same
+// device. The old session ends and the refresh token is rotated, so a
+// stolen token stops working once either party uses it.
+func (s *Server) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	var session *store.Session
+	var refreshToken string
+	err := s.store.WithTx(r.Context(), func(tx store.Store) error {
+		old, err := tx.Sessions().UseRefreshToken(r.Context(), hashRefreshToken(req.RefreshToken))
+		if err != nil {
+			return err
 		}
-		log.Printf("Error setting user data: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update user data")
+		if err := tx.Sessions().Delete(r.Context(), old.SessionToken); err != nil {
+			return err
+		}
+		session, refreshToken, err = s.startSession(r.Context(), tx, old.UserID, old.DeviceID)
+		return err
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusUnauthorized, "invalid_refresh_token", "Invalid, expired or used refresh token")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error refreshing session", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to refresh session")
 		return
 	}
 
-	resp := UserData{
-		Version:   data.Version,
-		UpdatedAt: data.UpdatedAt,
-		Blob:      &data.Blob,
+	writeJSON(w, http.StatusOK, RefreshResponse{
+		Token:        session.Token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    session.ExpiresAt,
+	})
+}
+
+// newRefreshToken returns 256 random bits, base64url encoded
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	writeJSON(w, http.StatusOK, resp)
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
-// ListContacts returns all contacts for the user
-func (s *Server) ListContacts(w http.ResponseWriter, r *http.Request) {
+// hashRefreshToken hashes a refresh token for storage. Tokens are random,
+// so a plain hash is enough.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Logout implements session termination
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value(sessionKey).(*store.Session)
+	if err := s.store.Sessions().Delete(r.Context(), session.Token); err != nil {
+		loggerFrom(r.Context()).Error("Error deleting session", "error", err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionPrefixLen is how much of a session token ListSessions shows. It
+// identifies the session among the user's own without being usable as a
+// credential.
+const sessionPrefixLen = 8
+
+// ListSessions returns the user's active sessions with masked tokens
+func (s *Server) ListSessions(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
+	current := r.Context().Value(sessionKey).(*store.Session)
 
-	contacts, err := s.store.Contacts().ListContacts(r.Context(), userID)
+	sessions, err := s.store.Sessions().ListForUser(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing sessions", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	devices, err := s.store.Devices().List(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error listing contacts: %v", err)
+		loggerFrom(r.Context()).Error("Error listing devices", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
+	deviceNames := make(map[string]string)
+	for _, d := range devices {
+		deviceNames[d.ID] = d.Name
+	}
 
-	apiContacts := make([]Contact, 0, len(contacts))
-	for _, c := range contacts {
-		apiContacts = append(apiContacts, Contact{
-			Id:        c.ContactID,
-			Email:     Email(c.Email),
-			Name:      c.Name,
-			PublicKey: c.PublicKey,
-			CreatedAt: c.CreatedAt,
-		})
+	apiSessions := make([]Session, 0, len(sessions))
+	for _, sess := range sessions {
+		session := Session{
+			Token:     maskToken(sess.Token),
+			CreatedAt: sess.CreatedAt,
+			ExpiresAt: sess.ExpiresAt,
+			IsCurrent: ptr(sess.Token == current.Token),
+		}
+		if sess.DeviceID != "" {
+			session.DeviceId = &sess.DeviceID
+			session.DeviceName = optionalString(deviceNames[sess.DeviceID])
+		}
+		apiSessions = append(apiSessions, session)
 	}
 
-	resp := ContactList{Contacts: apiContacts}
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, SessionList{Sessions: apiSessions})
 }
 
-// RemoveContact removes a contact
-func (s *Server) RemoveContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+// RevokeSession ends one of the user's sessions, identified by its token
+// or the prefix ListSessions shows
+func (s *Server) RevokeSession(w http.ResponseWriter, r *http.Request, token SessionToken) {
 	userID := r.Context().Value(userIDKey).(string)
+	prefix := strings.TrimSuffix(token, "…")
+	// A full token in the path would end up in access logs, so only the
+	// masked prefix the list shows is accepted
+	if len(prefix) > sessionPrefixLen {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Revoke sessions by the masked token from GET /sessions")
+		return
+	}
 
-	// Verify they are contacts
-	areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, string(contactId))
+	sessions, err := s.store.Sessions().ListForUser(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error checking contacts: %v", err)
+		loggerFrom(r.Context()).Error("Error listing sessions", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
-	if !areContacts {
-		writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+	var matches []*store.Session
+	for _, sess := range sessions {
+		if len(prefix) == sessionPrefixLen && strings.HasPrefix(sess.Token, prefix) {
+			matches = append(matches, sess)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		writeError(w, http.StatusNotFound, "not_found", "Session not found")
+		return
+	case 1:
+	default:
+		writeError(w, http.StatusBadRequest, "ambiguous_session", "More than one session matches")
 		return
 	}
 
-	// Remove contact and locations
-	if err := s.store.Contacts().RemoveContact(r.Context(), userID, string(contactId)); err != nil {
-		log.Printf("Error removing contact: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to remove contact")
+	if err := s.store.Sessions().Delete(r.Context(), matches[0].Token); err != nil {
+		loggerFrom(r.Context()).Error("Error deleting session", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to revoke session")
 		return
 	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maskToken shows the start of a session token and hides the rest
+func maskToken(token string) string {
+	if len(token) <= sessionPrefixLen {
+		return token
+	}
+	return token[:sessionPrefixLen] + "…"
+}
+
+// DeleteAccount implements account deletion
+func (s *Server) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
 
-	// Clean up locations between users
-	s.store.Locations().DeleteLocationsBetween(r.Context(), userID, string(contactId))
+	// Delete related data explicitly rather than relying on cascades, so
+	// nothing is orphaned on backends that don't cascade. Each surviving
+	// contact gets a contact.removed event, as if the user had removed them.
+	var deleted *store.AccountDeletion
+	err := s.store.WithTx(r.Context(), func(tx store.Store) error {
+		contacts, err := tx.Contacts().ListContacts(r.Context(), userID)
+		if err != nil {
+			return fmt.Errorf("list contacts: %w", err)
+		}
+		if deleted, err = tx.Users().DeleteAccount(r.Context(), userID); err != nil {
+			return err
+		}
+		for _, contact := range contacts {
+			if err := tx.Audit().Record(r.Context(), &store.AuditEvent{
+				UserID:   contact.ContactID,
+				Action:   store.AuditContactRemoved,
+				TargetID: userID,
+			}); err != nil {
+				return fmt.Errorf("record contact removal: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error deleting user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to delete account")
+		return
+	}
+	loggerFrom(r.Context()).Info("Deleted account",
+		"locations", deleted.Locations, "sessions", deleted.Sessions, "refresh_tokens", deleted.RefreshTokens,
+		"devices", deleted.Devices, "contact_notes", deleted.ContactNotes, "nudges", deleted.Nudges,
+		"presence", deleted.Presence, "blocks", deleted.Blocks, "contacts", deleted.Contacts,
+		"requests", deleted.Requests, "identity_backups", deleted.IdentityBackup, "user_data", deleted.UserData,
+		"settings", deleted.Settings, "audit_events", deleted.AuditEvents, "recovery_codes", deleted.RecoveryCodes)
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// SendContactRequest sends a contact request
-func (s *Server) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+// GetCurrentUser implements /me endpoint
+func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	var req ContactRequestCreate
+	user, err := s.store.Users().GetByID(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := s.toAPIUser(r.Context(), user)
+
+	hasIncoming, latest, err := s.store.Locations().HasIncoming(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking incoming locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	resp.HasIncomingLocations = &hasIncoming
+	if hasIncoming {
+		resp.LatestLocationAt = &latest
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// maxDisplayNameLength bounds display names, in characters
+const maxDisplayNameLength = 100
+
+// UpdateProfile changes the user's display name
+func (s *Server) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req ProfileUpdate
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
 		return
 	}
 
-	// Find recipient by email
-	recipient, err := s.store.Users().GetByEmail(r.Context(), string(req.Email))
-	if errors.Is(err, store.ErrNotFound) {
-		writeError(w, http.StatusNotFound, "user_not_found", "User not found")
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Name is required")
+		return
+	}
+	if utf8.RuneCountInString(name) > maxDisplayNameLength {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Name must be at most %d characters", maxDisplayNameLength))
 		return
 	}
+
+	user, err := s.store.Users().GetByID(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error finding user: %v", err)
+		loggerFrom(r.Context()).Error("Error getting user", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
-
-	// Check not self
-	if recipient.ID == userID {
-		writeError(w, http.StatusBadRequest, "invalid_request", "Cannot send request to yourself")
+	user.Name = name
+	if err := s.store.Users().Update(r.Context(), user); err != nil {
+		loggerFrom(r.Context()).Error("Error updating user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update profile")
 		return
 	}
 
-	// Check not already contacts
-	areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, recipient.ID)
+	writeJSON(w, http.StatusOK, s.toAPIUser(r.Context(), user))
+}
+
+// GetSettings returns the user's settings
+func (s *Server) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	values, err := s.store.Users().GetSettings(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error checking contacts: %v", err)
+		loggerFrom(r.Context()).Error("Error getting settings", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
-	if areContacts {
-		writeError(w, http.StatusConflict, "already_contacts", "Already contacts with this user")
+
+	writeJSON(w, http.StatusOK, toAPISettings(store.SettingsFromMap(values)))
+}
+
+// UpdateSettings updates the provided settings and returns the full set
+func (s *Server) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req UserSettingsUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body or unknown setting")
 		return
 	}
 
-	// Check for existing pending request
-	exists, err := s.store.Contacts().CheckExistingRequest(r.Context(), userID, recipient.ID)
+	values := make(map[string]string)
+	setBool := func(key string, v *bool) {
+		if v != nil {
+			values[key] = strconv.FormatBool(*v)
+		}
+	}
+	setBool(store.SettingDiscoverable, req.Discoverable)
+	setBool(store.SettingSharingEnabled, req.SharingEnabled)
+	setBool(store.SettingAutoAcceptRequests, req.AutoAcceptRequests)
+	setBool(store.SettingLastKnownMode, req.LastKnownMode)
+	setBool(store.SettingAcceptRequests, req.AcceptRequests)
+
+	// Without last-known mode, stopping sharing also withdraws what
+	// contacts can already see. Both happen or neither does.
+	var settings *store.UserSettings
+	err := s.store.WithTx(r.Context(), func(tx store.Store) error {
+		if err := tx.Users().SetSettings(r.Context(), userID, values); err != nil {
+			return fmt.Errorf("set settings: %w", err)
+		}
+		current, err := tx.Users().GetSettings(r.Context(), userID)
+		if err != nil {
+			return fmt.Errorf("get settings: %w", err)
+		}
+		settings = store.SettingsFromMap(current)
+		if !settings.SharingEnabled && !settings.LastKnownMode {
+			if err := tx.Locations().DeleteLocationsFromUser(r.Context(), userID); err != nil {
+				return fmt.Errorf("delete shared locations: %w", err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("Error checking existing request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		loggerFrom(r.Context()).Error("Error updating settings", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update settings")
 		return
 	}
-	if exists {
-		writeError(w, http.StatusConflict, "request_exists", "A pending request already exists")
+
+	writeJSON(w, http.StatusOK, toAPISettings(settings))
+}
+
+// GetIdentityBackup retrieves the encrypted identity backup
+func (s *Server) GetIdentityBackup(w http.ResponseWriter, r *http.Request, params GetIdentityBackupParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	backup, err := s.store.Users().GetIdentityBackup(r.Context(), userID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "No identity backup exists")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting identity backup", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	// Create request
-	request, err := s.store.Contacts().CreateRequest(r.Context(), userID, recipient.ID)
+	resp := toAPIBackup(backup)
+	etag, err := backupETag(&resp)
 	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create request")
+		loggerFrom(r.Context()).Error("Error hashing identity backup", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
+	writeCacheable(w, params.IfNoneMatch, etag, resp)
+}
 
-	resp := ContactRequest{
-		Id:        request.ID,
-		Email:     req.Email,
-		Name:      &recipient.Name,
-		Status:    Pending,
-		Direction: ptr(Outgoing),
-		CreatedAt: request.CreatedAt,
+// backupETag identifies a backup by a hash of the response body
+func backupETag(backup *IdentityBackup) (string, error) {
+	encoded, err := json.Marshal(backup)
+	if err != nil {
+		return "", err
 	}
-	writeJSON(w, http.StatusCreated, resp)
+	sum := sha256.Sum256(encoded)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
 }
 
-// ListContactRequests returns pending contact requests
-func (s *Server) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+// GetStorageUsage returns the user's storage usage by category
+func (s *Server) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	incoming, err := s.store.Contacts().ListIncomingRequests(r.Context(), userID)
+	usage, err := s.store.Users().UserStorageBytes(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error listing incoming requests: %v", err)
+		loggerFrom(r.Context()).Error("Error getting storage usage", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	outgoing, err := s.store.Contacts().ListOutgoingRequests(r.Context(), userID)
+	resp := StorageUsage{
+		IdentityBackup: usage.IdentityBackup,
+		UserData:       usage.UserData,
+		Locations:      usage.Locations,
+		Total:          usage.Total(),
+		Quota:          s.storageQuota,
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetIdentityBackupMeta returns the identity backup parameters without the ciphertext
+func (s *Server) GetIdentityBackupMeta(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	meta, err := s.store.Users().GetIdentityBackupMeta(r.Context(), userID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "No identity backup exists")
+		return
+	}
 	if err != nil {
-		log.Printf("Error listing outgoing requests: %v", err)
+		loggerFrom(r.Context()).Error("Error getting identity backup meta", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
-	// Convert to API types - need to fetch user info
-	apiIncoming := make([]ContactRequest, 0, len(incoming))
-	for _, req := range incoming {
-		user, _ := s.store.Users().GetByID(r.Context(), req.RequesterID)
-		cr := ContactRequest{
-			Id:        req.ID,
-			Status:    Pending,
-			Direction: ptr(Incoming),
-			CreatedAt: req.CreatedAt,
+	resp := IdentityBackupMeta{
+		Algorithm:   meta.Algorithm,
+		Kdf:         meta.KDF,
+		Iterations:  meta.Iterations,
+		Memory:      optionalInt(meta.Memory),
+		Parallelism: optionalInt(meta.Parallelism),
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Replacing an identity backup locks out any device still using the old
+// PIN or keys, so replacements are limited per user
+const (
+	maxBackupReplacements   = 5
+	backupReplacementWindow = 24 * time.Hour
+)
+
+// SetIdentityBackup stores the encrypted identity backup
+func (s *Server) SetIdentityBackup(w http.ResponseWriter, r *http.Request, params SetIdentityBackupParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req IdentityBackup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	if msg := s.validateIdentityBackup(&req); msg != "" {
+		writeError(w, http.StatusBadRequest, "invalid_backup", msg)
+		return
+	}
+
+	backup := toStoreBackup(&req)
+
+	expectedGeneration := 0
+	if req.Generation != nil {
+		expectedGeneration = *req.Generation
+	}
+
+	// overwrite=true stands in for the generation of whatever is stored now
+	if expectedGeneration == 0 && params.Overwrite != nil && *params.Overwrite {
+		current, err := s.store.Users().GetIdentityBackup(r.Context(), userID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			loggerFrom(r.Context()).Error("Error getting identity backup", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Failed to store identity backup")
+			return
 		}
-		if user != nil {
-			cr.Email = Email(user.Email)
-			cr.Name = &user.Name
+		if current != nil {
+			expectedGeneration = current.Generation
 		}
-		apiIncoming = append(apiIncoming, cr)
 	}
 
-	apiOutgoing := make([]ContactRequest, 0, len(outgoing))
-	for _, req := range outgoing {
-		user, _ := s.store.Users().GetByID(r.Context(), req.RecipientID)
-		cr := ContactRequest{
-			Id:        req.ID,
-			Status:    Pending,
-			Direction: ptr(Outgoing),
-			CreatedAt: req.CreatedAt,
+	if expectedGeneration > 0 {
+		since := time.Now().Add(-backupReplacementWindow)
+		count, err := s.store.Audit().CountSince(r.Context(), userID, store.AuditIdentityBackupReplaced, since)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error counting identity backup replacements", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Failed to store identity backup")
+			return
 		}
-		if user != nil {
-			cr.Email = Email(user.Email)
-			cr.Name = &user.Name
+		if count >= maxBackupReplacements {
+			writeError(w, http.StatusTooManyRequests, "rate_limited", "Identity backup replaced too many times recently; try again later")
+			return
+		}
+	}
+
+	err := s.store.WithTx(r.Context(), func(tx store.Store) error {
+		if err := tx.Users().SetIdentityBackup(r.Context(), userID, backup, expectedGeneration); err != nil {
+			return err
+		}
+		if expectedGeneration == 0 {
+			return nil
+		}
+		return tx.Audit().Record(r.Context(), &store.AuditEvent{
+			UserID:   userID,
+			Action:   store.AuditIdentityBackupReplaced,
+			TargetID: userID,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			// Get current generation for conflict response
+			current, _ := s.store.Users().GetIdentityBackup(r.Context(), userID)
+			generation := 0
+			if current != nil {
+				generation = current.Generation
+			}
+			code, message := "version_conflict", "Identity backup has been modified by another device"
+			if expectedGeneration == 0 {
+				code, message = "backup_exists", "An identity backup already exists; pass its generation or overwrite=true to replace it"
+			}
+			resp := ConflictError{
+				CurrentVersion: generation,
+				Error: struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				}{
+					Code:    code,
+					Message: message,
+				},
+			}
+			writeJSON(w, http.StatusConflict, resp)
+			return
+		}
+		loggerFrom(r.Context()).Error("Error setting identity backup", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to store identity backup")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateIdentityBackup checks the backup's algorithm and KDF parameters,
+// returning a message describing the first problem or "" if it's valid
+func (s *Server) validateIdentityBackup(backup *IdentityBackup) string {
+	if backup.Algorithm != AES256GCM {
+		return fmt.Sprintf("Unsupported algorithm %q", backup.Algorithm)
+	}
+	switch backup.Kdf {
+	case PBKDF2SHA256:
+		if backup.Memory != nil || backup.Parallelism != nil {
+			return "Memory and parallelism only apply to argon2id"
+		}
+		if backup.Iterations < s.minBackupIterations || backup.Iterations > s.maxBackupIterations {
+			return fmt.Sprintf("Iterations must be between %d and %d", s.minBackupIterations, s.maxBackupIterations)
+		}
+	case Argon2id:
+		if backup.Memory == nil || backup.Parallelism == nil {
+			return "Argon2id backups need memory and parallelism"
+		}
+		// The same limits clients apply before deriving a key
+		if err := crypto.ValidateArgon2Params(backup.Iterations, *backup.Memory, *backup.Parallelism); err != nil {
+			return fmt.Sprintf("Invalid Argon2id parameters: %v", err)
+		}
+	default:
+		return fmt.Sprintf("Unsupported KDF %q", backup.Kdf)
+	}
+	return ""
+}
+
+// toAPIBackup converts a stored identity backup for responses
+func toAPIBackup(backup *store.IdentityBackup) IdentityBackup {
+	return IdentityBackup{
+		Algorithm:   IdentityBackupAlgorithm(backup.Algorithm),
+		Kdf:         IdentityBackupKdf(backup.KDF),
+		Iterations:  backup.Iterations,
+		Memory:      optionalInt(backup.Memory),
+		Parallelism: optionalInt(backup.Parallelism),
+		Salt:        backup.Salt,
+		Iv:          backup.IV,
+		Payload:     backup.Payload,
+		Generation:  ptr(backup.Generation),
+	}
+}
+
+// toStoreBackup converts a validated identity backup request for storage
+func toStoreBackup(backup *IdentityBackup) *store.IdentityBackup {
+	stored := &store.IdentityBackup{
+		Algorithm:  string(backup.Algorithm),
+		KDF:        string(backup.Kdf),
+		Iterations: backup.Iterations,
+		Salt:       backup.Salt,
+		IV:         backup.Iv,
+		Payload:    backup.Payload,
+	}
+	if backup.Memory != nil {
+		stored.Memory = *backup.Memory
+	}
+	if backup.Parallelism != nil {
+		stored.Parallelism = *backup.Parallelism
+	}
+	return stored
+}
+
+// SetPublicKey registers the user's public key
+func (s *Server) SetPublicKey(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req PublicKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	user, err := s.store.Users().GetByID(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set public key")
+		return
+	}
+
+	if err := s.store.Users().SetPublicKey(r.Context(), userID, req.PublicKey); err != nil {
+		loggerFrom(r.Context()).Error("Error setting public key", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set public key")
+		return
+	}
+
+	// Locations contacts shared with the old key can't be decrypted with
+	// the new one, so tell them to share again
+	if s.keyChangeNotify && user.PublicKey != "" && user.PublicKey != req.PublicKey {
+		contacts, err := s.store.Contacts().ListContacts(r.Context(), userID)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error listing contacts", "error", err)
+		}
+		for _, c := range contacts {
+			s.events.publish(c.ContactID, userID, EventContactKeyChanged, map[string]string{"userId": userID})
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Onboard stores a new account's identity backup and public key together
+func (s *Server) Onboard(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req OnboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+	if req.PublicKey == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Public key is required")
+		return
+	}
+	if msg := s.validateIdentityBackup(&req.IdentityBackup); msg != "" {
+		writeError(w, http.StatusBadRequest, "invalid_backup", msg)
+		return
+	}
+
+	backup := toStoreBackup(&req.IdentityBackup)
+
+	if err := s.store.Users().Onboard(r.Context(), userID, req.PublicKey, backup); err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			writeError(w, http.StatusConflict, "already_onboarded", "Account already has an identity backup or public key")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error onboarding user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to store identity")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserData retrieves the encrypted user data blob
+func (s *Server) GetUserData(w http.ResponseWriter, r *http.Request, params GetUserDataParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	data, err := s.store.Users().GetUserData(r.Context(), userID)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "No user data exists")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting user data", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := UserData{
+		Version:   data.Version,
+		UpdatedAt: data.UpdatedAt,
+		Blob:      &data.Blob,
+	}
+	// Every change bumps the version, so it identifies the blob
+	writeCacheable(w, params.IfNoneMatch, fmt.Sprintf(`"%d"`, data.Version), resp)
+}
+
+// SetUserData updates the encrypted user data blob
+func (s *Server) SetUserData(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req UserDataUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	data := &store.UserData{
+		Blob: req.Blob,
+	}
+
+	if err := s.store.Users().SetUserData(r.Context(), userID, data, req.Version); err != nil {
+		if errors.Is(err, store.ErrVersionConflict) {
+			// The store reports the current version on conflict
+			resp := ConflictError{
+				CurrentVersion: data.Version,
+				Error: struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				}{
+					Code:    "version_conflict",
+					Message: "Data has been modified by another device",
+				},
+			}
+			writeJSON(w, http.StatusConflict, resp)
+			return
+		}
+		loggerFrom(r.Context()).Error("Error setting user data", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to update user data")
+		return
+	}
+
+	resp := UserData{
+		Version:   data.Version,
+		UpdatedAt: data.UpdatedAt,
+		Blob:      &data.Blob,
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListContacts returns all contacts for the user
+func (s *Server) ListContacts(w http.ResponseWriter, r *http.Request, params ListContactsParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if params.Limit != nil || params.Offset != nil {
+		s.listContactsPage(w, r, userID, params)
+		return
+	}
+
+	contacts, err := s.store.Contacts().ListContacts(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing contacts", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	apiContacts := make([]Contact, 0, len(contacts))
+	for _, c := range contacts {
+		if params.Since != nil && !c.UpdatedAt.After(*params.Since) {
+			continue
+		}
+		apiContacts = append(apiContacts, toAPIContact(c))
+	}
+
+	resp := ContactList{Contacts: apiContacts}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Contact list page sizes
+const (
+	defaultContactLimit = 100
+	maxContactLimit     = 500
+)
+
+// listContactsPage returns one page of the user's contacts with the total
+func (s *Server) listContactsPage(w http.ResponseWriter, r *http.Request, userID string, params ListContactsParams) {
+	if params.Since != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Paging can't be combined with since")
+		return
+	}
+
+	limit := defaultContactLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxContactLimit {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Limit must be between 1 and %d", maxContactLimit))
+		return
+	}
+	offset := 0
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if offset < 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Offset can't be negative")
+		return
+	}
+
+	total, err := s.store.Contacts().CountContacts(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error counting contacts", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	contacts, err := s.store.Contacts().ListContactsPage(r.Context(), userID, offset, limit)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing contacts", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := ContactList{Contacts: make([]Contact, 0, len(contacts)), Total: ptr(total)}
+	for _, c := range contacts {
+		resp.Contacts = append(resp.Contacts, toAPIContact(c))
+	}
+	if next := offset + len(contacts); len(contacts) == limit && next < total {
+		resp.NextOffset = ptr(next)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// toAPIContact converts a stored contact to its API form
+func toAPIContact(c *store.Contact) Contact {
+	return Contact{
+		Id:        c.ContactID,
+		Email:     Email(c.Email),
+		Name:      c.Name,
+		PublicKey: c.PublicKey,
+		CreatedAt: c.CreatedAt,
+		SortOrder: c.SortOrder,
+		Note:      optionalString(c.Note),
+		UpdatedAt: ptr(c.UpdatedAt),
+	}
+}
+
+// SetContactOrder pins or unpins a contact in the user's list
+func (s *Server) SetContactOrder(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req ContactOrderUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	if err := s.store.Contacts().SetSortOrder(r.Context(), userID, string(contactId), req.SortOrder); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error setting contact order", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set contact order")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// maxContactNoteLength caps contact notes, in characters
+const maxContactNoteLength = 1000
+
+// SetContactNote sets the user's private note on a contact
+func (s *Server) SetContactNote(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req ContactNoteUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxContactNoteLength {
+		writeError(w, http.StatusBadRequest, "note_too_long", fmt.Sprintf("Note must be at most %d characters", maxContactNoteLength))
+		return
+	}
+
+	if err := s.store.Contacts().SetNote(r.Context(), userID, string(contactId), note); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error setting contact note", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set contact note")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// nudgeInterval is how often a user can nudge the same contact
+const nudgeInterval = 15 * time.Minute
+
+// NudgeContact asks a contact to share their location
+func (s *Server) NudgeContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, string(contactId))
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking contacts", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if !areContacts {
+		writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+		return
+	}
+
+	nudged, err := s.store.Contacts().Nudge(r.Context(), userID, string(contactId), time.Now().Add(-nudgeInterval))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error nudging contact", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to nudge contact")
+		return
+	}
+	if !nudged {
+		writeError(w, http.StatusTooManyRequests, "rate_limited", "Contact was nudged recently; try again later")
+		return
+	}
+	s.events.publish(string(contactId), userID, EventLocationRequested, map[string]string{"fromUserId": userID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListNudges returns contacts waiting for the user to share their location
+func (s *Server) ListNudges(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	nudges, err := s.store.Contacts().ListNudges(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing nudges", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list nudges")
+		return
+	}
+
+	result := NudgeList{Nudges: make([]Nudge, 0, len(nudges))}
+	for _, n := range nudges {
+		result.Nudges = append(result.Nudges, Nudge{FromUserId: n.FromUserID, CreatedAt: n.CreatedAt})
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// BlockUser blocks another user from sending or accepting requests
+func (s *Server) BlockUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req BlockCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+	if req.UserId == "" || req.UserId == userID {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid user to block")
+		return
+	}
+
+	if err := s.store.Blocks().Block(r.Context(), userID, req.UserId); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "user_not_found", "User not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error blocking user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to block user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnblockUser removes a block
+func (s *Server) UnblockUser(w http.ResponseWriter, r *http.Request, userId UserId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if err := s.store.Blocks().Unblock(r.Context(), userID, string(userId)); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "User is not blocked")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error unblocking user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to unblock user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBlocked returns the users the caller has blocked
+func (s *Server) ListBlocked(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	blocked, err := s.store.Blocks().ListBlocked(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing blocked users", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to list blocked users")
+		return
+	}
+
+	result := BlockedList{Users: make([]BlockedUser, 0, len(blocked))}
+	for _, b := range blocked {
+		result.Users = append(result.Users, BlockedUser{
+			UserId:    b.UserID,
+			Name:      b.Name,
+			Email:     Email(b.Email),
+			BlockedAt: b.CreatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// blockedPair reports whether userID has blocked otherID and whether
+// otherID has blocked userID
+func (s *Server) blockedPair(ctx context.Context, userID, otherID string) (byUser, byOther bool, err error) {
+	if byUser, err = s.store.Blocks().IsBlocked(ctx, userID, otherID); err != nil {
+		return false, false, err
+	}
+	if byOther, err = s.store.Blocks().IsBlocked(ctx, otherID, userID); err != nil {
+		return false, false, err
+	}
+	return byUser, byOther, nil
+}
+
+// RemoveContact removes a contact
+func (s *Server) RemoveContact(w http.ResponseWriter, r *http.Request, contactId ContactId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	// Verify they are contacts
+	areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, string(contactId))
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking contacts", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if !areContacts {
+		writeError(w, http.StatusNotFound, "not_found", "Contact not found")
+		return
+	}
+
+	// Remove the contact and the locations between the users together, so
+	// a failure can't leave locations behind for a removed contact
+	err = s.store.WithTx(r.Context(), func(tx store.Store) error {
+		if err := tx.Contacts().RemoveContact(r.Context(), userID, string(contactId)); err != nil {
+			return fmt.Errorf("remove contact: %w", err)
+		}
+		if err := tx.Locations().DeleteLocationsBetween(r.Context(), userID, string(contactId)); err != nil {
+			return fmt.Errorf("delete locations: %w", err)
+		}
+		return tx.Audit().Record(r.Context(), &store.AuditEvent{
+			UserID:   userID,
+			Action:   store.AuditContactRemoved,
+			TargetID: string(contactId),
+		})
+	})
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error removing contact", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to remove contact")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckContact reports whether a contact request to an email would succeed.
+// Unknown and undiscoverable emails report can_request to avoid leaking
+// which emails are registered.
+func (s *Server) CheckContact(w http.ResponseWriter, r *http.Request, params CheckContactParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if params.Email == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Email is required")
+		return
+	}
+
+	status, err := s.contactCheckStatus(r.Context(), userID, params.Email)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking contact", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ContactCheck{Status: status})
+}
+
+// GetContactSummary returns contact, sharing and pending request counts
+func (s *Server) GetContactSummary(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	summary, err := s.store.Contacts().Summary(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting contact summary", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ContactSummary{
+		Contacts:        summary.Contacts,
+		WithPublicKey:   summary.WithPublicKey,
+		SharingTo:       summary.SharingTo,
+		SharingFrom:     summary.SharingFrom,
+		PendingIncoming: summary.PendingIncoming,
+		PendingOutgoing: summary.PendingOutgoing,
+	})
+}
+
+func (s *Server) contactCheckStatus(ctx context.Context, userID, email string) (ContactCheckStatus, error) {
+	other, err := s.store.Users().FindByEmail(ctx, email)
+	if errors.Is(err, store.ErrNotFound) {
+		return CanRequest, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	pair, err := s.store.Contacts().PairStatus(ctx, userID, other.ID)
+	if err != nil {
+		return "", err
+	}
+	if pair == store.PairContacts {
+		return AlreadyContact, nil
+	}
+
+	values, err := s.store.Users().GetSettings(ctx, other.ID)
+	if err != nil {
+		return "", err
+	}
+	settings := store.SettingsFromMap(values)
+	if !settings.Discoverable {
+		return CanRequest, nil
+	}
+
+	if pair == store.PairPending {
+		return RequestPending, nil
+	}
+
+	blocked, blockedBy, err := s.blockedPair(ctx, userID, other.ID)
+	if err != nil {
+		return "", err
+	}
+	if blocked {
+		return Blocked, nil
+	}
+
+	if !settings.AcceptRequests || blockedBy {
+		return NotAccepting, nil
+	}
+
+	return CanRequest, nil
+}
+
+// SendContactRequest sends a contact request
+func (s *Server) SendContactRequest(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req ContactRequestCreate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	// Every attempt counts, including unknown emails, so the limit also
+	// slows probing for who has an account
+	if s.contactRequestLimiter != nil {
+		ok, retryAfter, err := s.contactRequestLimiter.Allow(r.Context(), userID)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error checking contact request rate", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		}
+		if !ok {
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			writeError(w, http.StatusTooManyRequests, "rate_limited", "Too many contact requests; try again later")
+			return
+		}
+	}
+
+	// Find recipient by email
+	recipient, err := s.store.Users().FindByEmail(r.Context(), string(req.Email))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "user_not_found", "User not found")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error finding user", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	// Check not self
+	if recipient.ID == userID {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Cannot send request to yourself")
+		return
+	}
+
+	// Undiscoverable users look the same as users that don't exist
+	recipientSettings, err := s.store.Users().GetSettings(r.Context(), recipient.ID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting recipient settings", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if !store.SettingsFromMap(recipientSettings).Discoverable {
+		writeError(w, http.StatusNotFound, "user_not_found", "User not found")
+		return
+	}
+	if !store.SettingsFromMap(recipientSettings).AcceptRequests {
+		writeError(w, http.StatusForbidden, "not_accepting_requests", "User is not accepting contact requests")
+		return
+	}
+
+	// Being blocked looks the same as the recipient not accepting requests
+	blocked, blockedBy, err := s.blockedPair(r.Context(), userID, recipient.ID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking blocks", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if blocked {
+		writeError(w, http.StatusForbidden, "blocked", "You have blocked this user")
+		return
+	}
+	if blockedBy {
+		writeError(w, http.StatusForbidden, "not_accepting_requests", "User is not accepting contact requests")
+		return
+	}
+
+	// One check covers both directions: already contacts, or a request
+	// pending either way
+	pair, err := s.store.Contacts().PairStatus(r.Context(), userID, recipient.ID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking contact status", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	switch pair {
+	case store.PairContacts:
+		writeError(w, http.StatusConflict, "already_contacts", "Already contacts with this user")
+		return
+	case store.PairPending:
+		writeError(w, http.StatusConflict, "request_exists", "A pending request already exists")
+		return
+	}
+
+	// Create the request, and accept it too if the recipient auto-accepts,
+	// so it's never left pending when accepting fails
+	autoAccept := store.SettingsFromMap(recipientSettings).AutoAcceptRequests
+	var request *store.ContactRequest
+	err = s.store.WithTx(r.Context(), func(tx store.Store) error {
+		var err error
+		if request, err = tx.Contacts().CreateRequest(r.Context(), userID, recipient.ID); err != nil {
+			return err
+		}
+		if autoAccept {
+			return tx.Contacts().AcceptRequest(r.Context(), request.ID, recipient.ID)
+		}
+		return nil
+	})
+	if errors.Is(err, store.ErrDuplicateKey) {
+		// A finished request in this direction still holds the pair's row
+		writeError(w, http.StatusConflict, "request_exists", "A request to this user already exists")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error creating request", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create request")
+		return
+	}
+
+	status := Pending
+	if autoAccept {
+		status = Accepted
+	}
+	s.events.publish(recipient.ID, userID, EventContactRequestReceived, map[string]string{
+		"requestId":  request.ID,
+		"fromUserId": userID,
+		"status":     string(status),
+	})
+
+	resp := ContactRequest{
+		Id:        request.ID,
+		UserId:    ptr(recipient.ID),
+		Email:     req.Email,
+		Name:      &recipient.Name,
+		Status:    status,
+		Direction: ptr(Outgoing),
+		CreatedAt: request.CreatedAt,
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// ListContactRequests returns pending contact requests
+func (s *Server) ListContactRequests(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	incoming, err := s.store.Contacts().ListIncomingRequests(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing incoming requests", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	outgoing, err := s.store.Contacts().ListOutgoingRequests(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing outgoing requests", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	apiIncoming := make([]ContactRequest, 0, len(incoming))
+	for _, req := range incoming {
+		apiIncoming = append(apiIncoming, pendingRequest(req, Incoming))
+	}
+
+	apiOutgoing := make([]ContactRequest, 0, len(outgoing))
+	for _, req := range outgoing {
+		apiOutgoing = append(apiOutgoing, pendingRequest(req, Outgoing))
+	}
+
+	resp := ContactRequestList{
+		Incoming: apiIncoming,
+		Outgoing: apiOutgoing,
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// pendingRequest converts a request from the pending lists, which carry the
+// other user's name and email
+func pendingRequest(req *store.ContactRequest, direction ContactRequestDirection) ContactRequest {
+	otherID := req.RequesterID
+	if direction == Outgoing {
+		otherID = req.RecipientID
+	}
+	return ContactRequest{
+		Id:        req.ID,
+		UserId:    ptr(otherID),
+		Email:     Email(req.Email),
+		Name:      ptr(req.Name),
+		Status:    Pending,
+		Direction: ptr(direction),
+		CreatedAt: req.CreatedAt,
+	}
+}
+
+// Contact request history page sizes
+const (
+	defaultRequestHistoryLimit = 50
+	maxRequestHistoryLimit     = 100
+)
+
+// GetContactRequestHistory pages through all of the user's contact requests
+func (s *Server) GetContactRequestHistory(w http.ResponseWriter, r *http.Request, params GetContactRequestHistoryParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	limit := defaultRequestHistoryLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxRequestHistoryLimit {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Limit must be between 1 and %d", maxRequestHistoryLimit))
+		return
+	}
+
+	var beforeCreatedAt time.Time
+	beforeID := ""
+	if params.Cursor != nil {
+		var err error
+		if beforeCreatedAt, beforeID, err = s.cursors.decode(userID, *params.Cursor); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_cursor", "Invalid cursor")
+			return
+		}
+	}
+
+	// Fetch one extra to know whether there's another page
+	requests, err := s.store.Contacts().ListRequestHistory(r.Context(), userID, beforeCreatedAt, beforeID, limit+1)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error listing request history", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := ContactRequestHistory{}
+	if len(requests) > limit {
+		requests = requests[:limit]
+		last := requests[limit-1]
+		resp.NextCursor = ptr(s.cursors.encode(userID, last.CreatedAt, last.ID))
+	}
+
+	resp.Requests = make([]ContactRequest, 0, len(requests))
+	for _, req := range requests {
+		direction, otherID := Outgoing, req.RecipientID
+		if req.RecipientID == userID {
+			direction, otherID = Incoming, req.RequesterID
+		}
+		cr := ContactRequest{
+			Id:         req.ID,
+			UserId:     ptr(otherID),
+			Status:     ContactRequestStatus(req.Status),
+			Direction:  ptr(direction),
+			CreatedAt:  req.CreatedAt,
+			AcceptedAt: req.AcceptedAt,
+			DeclinedAt: req.DeclinedAt,
+			ExpiredAt:  req.ExpiredAt,
+		}
+		if user, _ := s.store.Users().GetByID(r.Context(), otherID); user != nil {
+			cr.Email = Email(user.Email)
+			cr.Name = &user.Name
+		}
+		resp.Requests = append(resp.Requests, cr)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// AcceptContactRequest accepts a contact request
+func (s *Server) AcceptContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	// Get the request first to return contact info
+	request, err := s.store.Contacts().GetRequest(r.Context(), string(requestId))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", "Request not found")
+		return
+	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting request", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	// Verify ownership
+	if request.RecipientID != userID {
+		writeError(w, http.StatusNotFound, "not_found", "Request not found")
+		return
+	}
+
+	blocked, blockedBy, err := s.blockedPair(r.Context(), userID, request.RequesterID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error checking blocks", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if blocked || blockedBy {
+		writeError(w, http.StatusForbidden, "blocked", "This request can't be accepted")
+		return
+	}
+
+	// Accept request
+	if err := s.store.Contacts().AcceptRequest(r.Context(), string(requestId), userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Request not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error accepting request", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to accept request")
+		return
+	}
+
+	// Return the new contact
+	requester, err := s.store.Users().GetByID(r.Context(), request.RequesterID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting requester", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := Contact{
+		Id:        requester.ID,
+		Email:     Email(requester.Email),
+		Name:      requester.Name,
+		PublicKey: requester.PublicKey,
+		CreatedAt: time.Now(),
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// DeclineContactRequest declines a contact request
+func (s *Server) DeclineContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if err := s.store.Contacts().DeclineRequest(r.Context(), string(requestId), userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Request not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error declining request", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to decline request")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CancelContactRequest cancels an outgoing request
+func (s *Server) CancelContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if err := s.store.Contacts().CancelRequest(r.Context(), string(requestId), userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Request not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error canceling request", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to cancel request")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLocations returns encrypted locations from contacts, all at once or a
+// page at a time
+func (s *Server) GetLocations(w http.ResponseWriter, r *http.Request, params GetLocationsParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if params.Limit != nil || params.Cursor != nil {
+		s.getLocationsPage(w, r, userID, params)
+		return
+	}
+
+	locations, err := s.store.Locations().GetLocationsForUser(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	apiLocations := make([]EncryptedLocation, 0, len(locations))
+	for _, loc := range locations {
+		apiLocations = append(apiLocations, toAPILocation(loc))
+	}
+
+	resp := LocationList{Locations: apiLocations}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Location snapshot page sizes
+const (
+	defaultSnapshotLimit = 100
+	maxSnapshotLimit     = 500
+)
+
+// getLocationsPage returns one page of locations shared with the user, by
+// sender, with the total
+func (s *Server) getLocationsPage(w http.ResponseWriter, r *http.Request, userID string, params GetLocationsParams) {
+	limit := defaultSnapshotLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxSnapshotLimit {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Limit must be between 1 and %d", maxSnapshotLimit))
+		return
+	}
+
+	// The cursor carries when paging started, as for snapshots
+	startedAt := time.Now()
+	after := ""
+	if params.Cursor != nil {
+		var err error
+		if startedAt, after, err = s.cursors.decode(userID, *params.Cursor); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_cursor", "Invalid cursor")
+			return
+		}
+	}
+
+	total, err := s.store.Locations().CountLocationsForUser(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error counting locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	// Fetch one extra to know whether there's another page
+	locations, err := s.store.Locations().GetLocationsForUserPage(r.Context(), userID, after, limit+1)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := LocationList{Total: ptr(total)}
+	if len(locations) > limit {
+		locations = locations[:limit]
+		resp.NextCursor = ptr(s.cursors.encode(userID, startedAt, locations[limit-1].FromUserID))
+	}
+
+	resp.Locations = make([]EncryptedLocation, 0, len(locations))
+	for _, loc := range locations {
+		resp.Locations = append(resp.Locations, toAPILocation(loc))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetLocationSnapshot pages through all locations shared with the user
+func (s *Server) GetLocationSnapshot(w http.ResponseWriter, r *http.Request, params GetLocationSnapshotParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	limit := defaultSnapshotLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if limit < 1 || limit > maxSnapshotLimit {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Limit must be between 1 and %d", maxSnapshotLimit))
+		return
+	}
+
+	snapshotAt := time.Now()
+	after := ""
+	if params.Cursor != nil {
+		var err error
+		if snapshotAt, after, err = s.cursors.decode(userID, *params.Cursor); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_cursor", "Invalid cursor")
+			return
+		}
+	}
+
+	// Fetch one extra to know whether there's another page
+	locations, err := s.store.Locations().GetLocationsForUserPage(r.Context(), userID, after, limit+1)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting location snapshot", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+
+	resp := LocationSnapshot{SnapshotAt: snapshotAt}
+	if len(locations) > limit {
+		locations = locations[:limit]
+		// Location snapshots page by sender, carrying the snapshot start time
+		resp.NextCursor = ptr(s.cursors.encode(userID, snapshotAt, locations[limit-1].FromUserID))
+	}
+
+	resp.Locations = make([]EncryptedLocation, 0, len(locations))
+	for _, loc := range locations {
+		resp.Locations = append(resp.Locations, toAPILocation(loc))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ShareLocations publishes encrypted locations to contacts
+func (s *Server) ShareLocations(w http.ResponseWriter, r *http.Request, params ShareLocationsParams) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	values, err := s.store.Users().GetSettings(r.Context(), userID)
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error getting settings", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		return
+	}
+	if !store.SettingsFromMap(values).SharingEnabled {
+		writeError(w, http.StatusForbidden, "sharing_disabled", "Location sharing is turned off")
+		return
+	}
+
+	if s.maxShareBatch > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxShareBatch)
+	}
+
+	var req LocationShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request_too_large",
+				fmt.Sprintf("Share batch exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	for _, loc := range req.Locations {
+		if loc.Precision != nil && !validPrecision(*loc.Precision) {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Unknown precision %q", *loc.Precision))
+			return
+		}
+		if loc.ExpiresAt != nil && !loc.ExpiresAt.After(time.Now()) {
+			writeError(w, http.StatusBadRequest, "invalid_request", "Expiry must be in the future")
+			return
+		}
+	}
+
+	if params.Partial != nil && *params.Partial {
+		s.shareLocationsPartial(w, r, userID, req.Locations)
+		return
+	}
+
+	// Verify all recipients are contacts
+	for _, loc := range req.Locations {
+		areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, loc.ToUserId)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error checking contacts", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		}
+		if !areContacts {
+			writeError(w, http.StatusConflict, "not_a_contact", "Can only share with contacts")
+			return
 		}
-		apiOutgoing = append(apiOutgoing, cr)
 	}
 
-	resp := ContactRequestList{
-		Incoming: apiIncoming,
-		Outgoing: apiOutgoing,
+	// Convert to store type
+	storeLocations := make([]*store.EncryptedLocation, 0, len(req.Locations))
+	for _, loc := range req.Locations {
+		storeLocations = append(storeLocations, &store.EncryptedLocation{
+			ToUserID:  loc.ToUserId,
+			Blob:      loc.Blob,
+			Precision: sharePrecision(loc),
+			ExpiresAt: loc.ExpiresAt,
+		})
 	}
-	writeJSON(w, http.StatusOK, resp)
-}
-
-// AcceptContactRequest accepts a contact request
-func (s *Server) AcceptContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
-	userID := r.Context().Value(userIDKey).(string)
 
-	// Get the request first to return contact info
-	request, err := s.store.Contacts().GetRequest(r.Context(), string(requestId))
-	if errors.Is(err, store.ErrNotFound) {
-		writeError(w, http.StatusNotFound, "not_found", "Request not found")
+	if err := s.store.Locations().SetLocations(r.Context(), userID, storeLocations); err != nil {
+		loggerFrom(r.Context()).Error("Error setting locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to share locations")
 		return
 	}
-	if err != nil {
-		log.Printf("Error getting request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
-		return
+	for _, loc := range storeLocations {
+		s.publishLocation(loc)
 	}
 
-	// Verify ownership
-	if request.RecipientID != userID {
-		writeError(w, http.StatusNotFound, "not_found", "Request not found")
-		return
-	}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	// Accept request
-	if err := s.store.Contacts().AcceptRequest(r.Context(), string(requestId), userID); err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			writeError(w, http.StatusNotFound, "not_found", "Request not found")
-			return
+// shareLocationsPartial writes each recipient independently and reports
+// per-recipient results. Non-contacts are reported as failures rather than
+// rejecting the batch.
+func (s *Server) shareLocationsPartial(w http.ResponseWriter, r *http.Request, userID string, locations []LocationShare) {
+	results := make([]LocationShareResult, len(locations))
+	var storeLocations []*store.EncryptedLocation
+	var indexes []int
+
+	for i, loc := range locations {
+		results[i] = LocationShareResult{ToUserId: loc.ToUserId}
+
+		areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, loc.ToUserId)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error checking contacts", "error", err)
+			results[i].Error = ptr("internal_error")
+			continue
 		}
-		log.Printf("Error accepting request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to accept request")
-		return
+		if !areContacts {
+			results[i].Error = ptr("not_a_contact")
+			continue
+		}
+
+		storeLocations = append(storeLocations, &store.EncryptedLocation{
+			ToUserID:  loc.ToUserId,
+			Blob:      loc.Blob,
+			Precision: sharePrecision(loc),
+			ExpiresAt: loc.ExpiresAt,
+		})
+		indexes = append(indexes, i)
 	}
 
-	// Return the new contact
-	requester, err := s.store.Users().GetByID(r.Context(), request.RequesterID)
+	written, err := s.store.Locations().SetLocationsBestEffort(r.Context(), userID, storeLocations)
 	if err != nil {
-		log.Printf("Error getting requester: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		loggerFrom(r.Context()).Error("Error setting locations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to share locations")
 		return
 	}
 
-	resp := Contact{
-		Id:        requester.ID,
-		Email:     Email(requester.Email),
-		Name:      requester.Name,
-		PublicKey: requester.PublicKey,
-		CreatedAt: time.Now(),
+	for j, result := range written {
+		i := indexes[j]
+		if result.Err != nil {
+			loggerFrom(r.Context()).Error("Error setting location", "to_user_id", result.ToUserID, "error", result.Err)
+			results[i].Error = ptr("write_failed")
+			continue
+		}
+		results[i].Ok = true
+		s.publishLocation(storeLocations[j])
 	}
-	writeJSON(w, http.StatusOK, resp)
+
+	writeJSON(w, http.StatusOK, LocationShareResults{Results: results})
 }
 
-// DeclineContactRequest declines a contact request
-func (s *Server) DeclineContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
-	userID := r.Context().Value(userIDKey).(string)
+// publishLocation tells the recipient a location was shared with them: a
+// location.shared event on /events, and the location itself on
+// /locations/stream
+func (s *Server) publishLocation(loc *store.EncryptedLocation) {
+	s.events.publish(loc.ToUserID, loc.FromUserID, EventLocationShared, map[string]string{"fromUserId": loc.FromUserID})
+	s.locationEvents.publish(loc.ToUserID, loc.FromUserID, EventLocation, toAPILocation(loc))
+}
 
-	if err := s.store.Contacts().DeclineRequest(r.Context(), string(requestId), userID); err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			writeError(w, http.StatusNotFound, "not_found", "Request not found")
-			return
-		}
-		log.Printf("Error declining request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to decline request")
-		return
+// toAPILocation converts a stored location to its API form
+func toAPILocation(loc *store.EncryptedLocation) EncryptedLocation {
+	return EncryptedLocation{
+		FromUserId: loc.FromUserID,
+		Blob:       loc.Blob,
+		UpdatedAt:  loc.UpdatedAt,
+		Precision:  LocationPrecision(loc.Precision),
+		ExpiresAt:  loc.ExpiresAt,
 	}
-
-	w.WriteHeader(http.StatusNoContent)
 }
 
-// CancelContactRequest cancels an outgoing request
-func (s *Server) CancelContactRequest(w http.ResponseWriter, r *http.Request, requestId RequestId) {
-	userID := r.Context().Value(userIDKey).(string)
-
-	if err := s.store.Contacts().CancelRequest(r.Context(), string(requestId), userID); err != nil {
-		if errors.Is(err, store.ErrNotFound) {
-			writeError(w, http.StatusNotFound, "not_found", "Request not found")
-			return
-		}
-		log.Printf("Error canceling request: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to cancel request")
-		return
+// validPrecision reports whether p is a known precision label
+func validPrecision(p LocationPrecision) bool {
+	switch p {
+	case Country, City, Exact:
+		return true
 	}
+	return false
+}
 
-	w.WriteHeader(http.StatusNoContent)
+// sharePrecision returns the share's precision label, defaulting to exact
+func sharePrecision(loc LocationShare) string {
+	if loc.Precision == nil {
+		return store.PrecisionExact
+	}
+	return string(*loc.Precision)
 }
 
-// GetLocations returns encrypted locations from contacts
-func (s *Server) GetLocations(w http.ResponseWriter, r *http.Request) {
+// maxPresenceBlobLength caps each presence blob; a check-in is a short
+// status, not a location
+const maxPresenceBlobLength = 1024
+
+// GetPresence returns check-ins shared with the user by contacts
+func (s *Server) GetPresence(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	locations, err := s.store.Locations().GetLocationsForUser(r.Context(), userID)
+	statuses, err := s.store.Locations().GetPresenceForUser(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error getting locations: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+		loggerFrom(r.Context()).Error("Error getting presence", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to get presence")
 		return
 	}
 
-	apiLocations := make([]EncryptedLocation, 0, len(locations))
-	for _, loc := range locations {
-		apiLocations = append(apiLocations, EncryptedLocation{
-			FromUserId: loc.FromUserID,
-			Blob:       loc.Blob,
-			UpdatedAt:  loc.UpdatedAt,
+	result := PresenceList{Presence: make([]Presence, 0, len(statuses))}
+	for _, p := range statuses {
+		result.Presence = append(result.Presence, Presence{
+			FromUserId: p.FromUserID,
+			Blob:       p.Blob,
+			UpdatedAt:  p.UpdatedAt,
 		})
 	}
-
-	resp := LocationList{Locations: apiLocations}
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, result)
 }
 
-// ShareLocations publishes encrypted locations to contacts
-func (s *Server) ShareLocations(w http.ResponseWriter, r *http.Request) {
+// SetPresence replaces the user's check-in status
+func (s *Server) SetPresence(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	var req LocationShareRequest
+	var req PresenceUpdate
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
 		return
 	}
 
-	// Verify all recipients are contacts
-	for _, loc := range req.Locations {
-		areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, loc.ToUserId)
+	statuses := make([]*store.Presence, 0, len(req.Statuses))
+	for _, p := range req.Statuses {
+		if p.Blob == "" || len(p.Blob) > maxPresenceBlobLength {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Presence blob must be 1 to %d characters", maxPresenceBlobLength))
+			return
+		}
+
+		areContacts, err := s.store.Contacts().AreContacts(r.Context(), userID, p.ToUserId)
 		if err != nil {
-			log.Printf("Error checking contacts: %v", err)
+			loggerFrom(r.Context()).Error("Error checking contacts", "error", err)
 			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 			return
 		}
 		if !areContacts {
-			writeError(w, http.StatusBadRequest, "invalid_recipient", "Can only share with contacts")
+			writeError(w, http.StatusConflict, "not_a_contact", "Can only share presence with contacts")
 			return
 		}
-	}
 
-	// Convert to store type
-	storeLocations := make([]*store.EncryptedLocation, 0, len(req.Locations))
-	for _, loc := range req.Locations {
-		storeLocations = append(storeLocations, &store.EncryptedLocation{
-			ToUserID: loc.ToUserId,
-			Blob:     loc.Blob,
-		})
+		statuses = append(statuses, &store.Presence{ToUserID: p.ToUserId, Blob: p.Blob})
 	}
 
-	if err := s.store.Locations().SetLocations(r.Context(), userID, storeLocations); err != nil {
-		log.Printf("Error setting locations: %v", err)
-		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to share locations")
+	err := s.store.Locations().SetPresence(r.Context(), userID, statuses)
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusConflict, "not_a_contact", "Can only share presence with contacts")
 		return
 	}
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error setting presence", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to set presence")
+		return
+	}
+	for _, p := range statuses {
+		s.events.publish(p.ToUserID, userID, EventPresenceUpdated, map[string]string{"fromUserId": userID})
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -709,7 +2650,7 @@ func (s *Server) ListDevices(w http.ResponseWriter, r *http.Request) {
 
 	devices, err := s.store.Devices().List(r.Context(), userID)
 	if err != nil {
-		log.Printf("Error listing devices: %v", err)
+		loggerFrom(r.Context()).Error("Error listing devices", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
@@ -720,11 +2661,26 @@ func (s *Server) ListDevices(w http.ResponseWriter, r *http.Request) {
 			Id:        d.ID,
 			Name:      d.Name,
 			Platform:  DevicePlatform(d.Platform),
+			UserAgent: optionalString(d.UserAgent),
 			CreatedAt: d.CreatedAt,
 			LastSeen:  d.LastSeen,
 			IsCurrent: ptr(d.ID == session.DeviceID),
 			IsRevoked: ptr(d.RevokedAt != nil),
 		}
+		if d.IsPaused(time.Now()) {
+			device.PausedUntil = d.PausedUntil
+		}
+
+		sessions, err := s.store.Sessions().ListForDevice(r.Context(), d.ID)
+		if err != nil {
+			loggerFrom(r.Context()).Error("Error listing device sessions", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+			return
+		}
+		device.ActiveSessions = ptr(len(sessions))
+		if len(sessions) > 0 {
+			device.SessionExpiresAt = &sessions[0].ExpiresAt
+		}
 		apiDevices = append(apiDevices, device)
 	}
 
@@ -732,6 +2688,9 @@ func (s *Server) ListDevices(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// maxUserAgentLength caps the stored device user agent in characters
+const maxUserAgentLength = 200
+
 // RegisterDevice registers a new device
 func (s *Server) RegisterDevice(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value(userIDKey).(string)
@@ -742,22 +2701,38 @@ func (s *Server) RegisterDevice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userAgent := r.UserAgent()
+	if req.UserAgent != nil {
+		userAgent = *req.UserAgent
+	}
+	userAgent = truncateRunes(userAgent, maxUserAgentLength)
+
 	device := &store.Device{
-		UserID:   userID,
-		Name:     req.Name,
-		Platform: string(req.Platform),
+		UserID:    userID,
+		Name:      req.Name,
+		Platform:  string(req.Platform),
+		UserAgent: userAgent,
 	}
 
 	if err := s.store.Devices().Create(r.Context(), device); err != nil {
-		log.Printf("Error creating device: %v", err)
+		loggerFrom(r.Context()).Error("Error creating device", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to register device")
 		return
 	}
 
+	// Bind the current session to the new device if it has none yet
+	session := r.Context().Value(sessionKey).(*store.Session)
+	if session.DeviceID == "" {
+		if err := s.store.Sessions().SetDevice(r.Context(), session.Token, device.ID); err != nil {
+			loggerFrom(r.Context()).Error("Error binding session to device", "error", err)
+		}
+	}
+
 	resp := DeviceWithToken{
 		Id:        device.ID,
 		Name:      device.Name,
 		Platform:  DeviceWithTokenPlatform(device.Platform),
+		UserAgent: optionalString(device.UserAgent),
 		CreatedAt: device.CreatedAt,
 		LastSeen:  device.LastSeen,
 		Token:     device.Token,
@@ -765,16 +2740,73 @@ func (s *Server) RegisterDevice(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, resp)
 }
 
+// maxDeviceNameLength bounds device names, in characters; names must be
+// shorter than this
+const maxDeviceNameLength = 100
+
+// RenameDevice changes a device's name
+func (s *Server) RenameDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req DeviceUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Name is required")
+		return
+	}
+	if utf8.RuneCountInString(name) >= maxDeviceNameLength {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Name must be under %d characters", maxDeviceNameLength))
+		return
+	}
+
+	if err := s.store.Devices().Rename(r.Context(), string(deviceId), userID, name); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Device not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error renaming device", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to rename device")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // RevokeDevice revokes a device
 func (s *Server) RevokeDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
 	userID := r.Context().Value(userIDKey).(string)
 
-	if err := s.store.Devices().Revoke(r.Context(), string(deviceId), userID); err != nil {
+	// With no device left to keep them current, the user's shared
+	// locations would go stale, so they're removed with the last device
+	err := s.store.WithTx(r.Context(), func(tx store.Store) error {
+		if err := tx.Devices().Revoke(r.Context(), string(deviceId), userID); err != nil {
+			return err
+		}
+		if err := tx.Sessions().DeleteRefreshTokensForDevice(r.Context(), string(deviceId)); err != nil {
+			return err
+		}
+		devices, err := tx.Devices().List(r.Context(), userID)
+		if err != nil {
+			return err
+		}
+		for _, d := range devices {
+			if d.RevokedAt == nil {
+				return nil
+			}
+		}
+		return tx.Locations().DeleteLocationsFromUser(r.Context(), userID)
+	})
+	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "not_found", "Device not found")
 			return
 		}
-		log.Printf("Error revoking device: %v", err)
+		loggerFrom(r.Context()).Error("Error revoking device", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to revoke device")
 		return
 	}
@@ -782,8 +2814,80 @@ func (s *Server) RevokeDevice(w http.ResponseWriter, r *http.Request, deviceId D
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// PauseDevice blocks a device until the requested time
+func (s *Server) PauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	var req DevicePause
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
+		return
+	}
+	if !req.Until.After(time.Now()) {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Pause must end in the future")
+		return
+	}
+
+	if err := s.store.Devices().Pause(r.Context(), string(deviceId), userID, req.Until); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Device not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error pausing device", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to pause device")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnpauseDevice ends a device's pause
+func (s *Server) UnpauseDevice(w http.ResponseWriter, r *http.Request, deviceId DeviceId) {
+	userID := r.Context().Value(userIDKey).(string)
+
+	if err := s.store.Devices().Unpause(r.Context(), string(deviceId), userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "not_found", "Device not found")
+			return
+		}
+		loggerFrom(r.Context()).Error("Error unpausing device", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to unpause device")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Helper functions
 
+// isHealthPath reports whether the path is an unauthenticated health probe
+func isHealthPath(path string) bool {
+	switch path {
+	case "/api/health", "/health", "/api/ready", "/ready":
+		return true
+	}
+	return false
+}
+
+// isStreamPath reports whether path is a server-sent event stream, which
+// stays open for as long as the client listens
+func isStreamPath(path string) bool {
+	switch path {
+	case "/api/events", "/events", "/api/locations/stream", "/locations/stream":
+		return true
+	}
+	return false
+}
+
+// clientIP returns the request's remote IP without the port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func extractToken(r *http.Request) string {
 	auth := r.Header.Get("Authorization")
 	if auth == "" {
@@ -795,24 +2899,20 @@ func extractToken(r *http.Request) string {
 		return ""
 	}
 
-	// Token may include device token: "sessionToken:deviceToken"
-	// For now, just use the session token part
-	token := parts[1]
-	if idx := strings.Index(token, ":"); idx > 0 {
-		token = token[:idx]
-	}
-
-	return token
-}
-
-// DevLoginRequest is the request body for dev login
-type DevLoginRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name,omitempty"`
+	// The whole credential is the session token. Devices are identified by
+	// the session they're bound to, not by a token packed in here, so
+	// tokens may contain any character, including ":".
+	return parts[1]
 }
 
 // DevLogin creates a test user and session (dev mode only)
 func (s *Server) DevLogin(w http.ResponseWriter, r *http.Request) {
+	// Anyone could sign in as anyone; never serve it outside dev mode
+	if !s.devMode {
+		writeError(w, http.StatusNotFound, "not_found", "Dev login is not enabled")
+		return
+	}
+
 	var req DevLoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid request body")
@@ -825,9 +2925,9 @@ func (s *Server) DevLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Use email prefix as name if not provided
-	name := req.Name
-	if name == "" {
-		name = strings.Split(req.Email, "@")[0]
+	name := strings.Split(req.Email, "@")[0]
+	if req.Name != nil && *req.Name != "" {
+		name = *req.Name
 	}
 
 	// Find or create user
@@ -841,44 +2941,33 @@ func (s *Server) DevLogin(w http.ResponseWriter, r *http.Request) {
 			Name:  name,
 		}
 		if err := s.store.Users().Create(r.Context(), user); err != nil {
-			log.Printf("Error creating user: %v", err)
+			loggerFrom(r.Context()).Error("Error creating user", "error", err)
 			writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create user")
 			return
 		}
 		isNewUser = true
 	} else if err != nil {
-		log.Printf("Error getting user by email: %v", err)
+		loggerFrom(r.Context()).Error("Error getting user by email", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
 		return
 	}
 
 	// Create session
-	session := &store.Session{
-		UserID:    user.ID,
-		ExpiresAt: time.Now().Add(s.sessionDuration),
-	}
-	if err := s.store.Sessions().Create(r.Context(), session); err != nil {
-		log.Printf("Error creating session: %v", err)
+	session, refreshToken, err := s.startSession(r.Context(), s.store, user.ID, "")
+	if err != nil {
+		loggerFrom(r.Context()).Error("Error creating session", "error", err)
 		writeError(w, http.StatusInternalServerError, "internal_error", "Failed to create session")
 		return
 	}
 
-	// Check for identity backup and user data
-	_, identityErr := s.store.Users().GetIdentityBackup(r.Context(), user.ID)
-	_, dataErr := s.store.Users().GetUserData(r.Context(), user.ID)
+	s.touchLastLogin(r.Context(), user)
+	s.recordLoginAttempt(r, user.Email, true)
 
 	resp := LoginResponse{
-		Token: session.Token,
-		User: User{
-			Id:                user.ID,
-			Email:             Email(user.Email),
-			Name:              user.Name,
-			CreatedAt:         user.CreatedAt,
-			PublicKey:         ptr(user.PublicKey),
-			HasIdentityBackup: ptr(!errors.Is(identityErr, store.ErrNotFound)),
-			HasUserData:       ptr(!errors.Is(dataErr, store.ErrNotFound)),
-		},
-		IsNewUser: &isNewUser,
+		Token:        session.Token,
+		RefreshToken: optionalString(refreshToken),
+		User:         s.toAPIUser(r.Context(), user),
+		IsNewUser:    &isNewUser,
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
@@ -889,6 +2978,31 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// writeCacheable writes data with its ETag, or just a 304 if ifNoneMatch
+// already names that ETag
+func writeCacheable(w http.ResponseWriter, ifNoneMatch *string, etag string, data interface{}) {
+	w.Header().Set("ETag", etag)
+	if ifNoneMatch != nil && etagMatches(*ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, or is
+// "*". Weak tags match their strong form, as If-None-Match requires.
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func writeError(w http.ResponseWriter, status int, code, message string) {
 	resp := Error{
 		Error: struct {
@@ -902,6 +3016,83 @@ func writeError(w http.ResponseWriter, status int, code, message string) {
 	writeJSON(w, status, resp)
 }
 
+// toAPIUser converts a store user to the API type, including whether
+// the user has an identity backup and user data
+func (s *Server) toAPIUser(ctx context.Context, user *store.User) User {
+	_, identityErr := s.store.Users().GetIdentityBackup(ctx, user.ID)
+	_, dataErr := s.store.Users().GetUserData(ctx, user.ID)
+
+	return User{
+		Id:                user.ID,
+		Email:             Email(user.Email),
+		Name:              user.Name,
+		CreatedAt:         user.CreatedAt,
+		LastLoginAt:       user.LastLoginAt,
+		PublicKey:         ptr(user.PublicKey),
+		HasIdentityBackup: ptr(!errors.Is(identityErr, store.ErrNotFound)),
+		HasUserData:       ptr(!errors.Is(dataErr, store.ErrNotFound)),
+	}
+}
+
+// touchLastLogin records a successful login. Failures are logged but don't
+// fail the login.
+func (s *Server) touchLastLogin(ctx context.Context, user *store.User) {
+	if err := s.store.Users().TouchLastLogin(ctx, user.ID); err != nil {
+		loggerFrom(ctx).Error("Error recording last login", "error", err)
+		return
+	}
+	now := time.Now()
+	user.LastLoginAt = &now
+}
+
+// recordLoginAttempt records a login attempt for rate limiting and
+// auditing. Failures are logged but don't affect the response.
+func (s *Server) recordLoginAttempt(r *http.Request, email string, success bool) {
+	attempt := &store.LoginAttempt{
+		Email:   email,
+		IP:      clientIP(r),
+		Success: success,
+	}
+	if err := s.store.Users().RecordLoginAttempt(r.Context(), attempt); err != nil {
+		loggerFrom(r.Context()).Error("Error recording login attempt", "error", err)
+	}
+}
+
+// toAPISettings converts store settings to the API type
+func toAPISettings(settings *store.UserSettings) UserSettings {
+	return UserSettings{
+		Discoverable:       settings.Discoverable,
+		SharingEnabled:     settings.SharingEnabled,
+		AutoAcceptRequests: settings.AutoAcceptRequests,
+		LastKnownMode:      settings.LastKnownMode,
+		AcceptRequests:     settings.AcceptRequests,
+	}
+}
+
+// optionalString returns nil for empty strings so they're omitted from responses
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalInt returns nil for zero so it's omitted from responses
+func optionalInt(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
+// truncateRunes shortens s to at most n characters
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -4,18 +4,23 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/whereish/server/internal/auth"
 	"github.com/whereish/server/internal/store"
 	"github.com/whereish/server/internal/store/sqlite"
 )
 
 // testServer creates a test server with an in-memory SQLite store
-func testServer(t *testing.T) (*Server, *sqlite.Store) {
+func testServer(t *testing.T, opts ...Option) (*Server, *sqlite.Store) {
 	t.Helper()
 
 	st, err := sqlite.New(":memory:")
@@ -24,7 +29,7 @@ func testServer(t *testing.T) (*Server, *sqlite.Store) {
 	}
 	t.Cleanup(func() { st.Close() })
 
-	server := NewServer(st, "test-google-client-id", 24*time.Hour)
+	server := NewServer(st, "test-google-client-id", 24*time.Hour, opts...)
 	return server, st
 }
 
@@ -34,8 +39,8 @@ func testRouter(t *testing.T, server *Server) *chi.Mux {
 
 	r := chi.NewRouter()
 	r.Use(server.AuthMiddleware)
+	r.Use(server.DeviceMiddleware)
 	HandlerFromMuxWithBaseURL(server, r, "/api")
-	r.Post("/api/dev/login", server.DevLogin)
 
 	return r
 }
@@ -112,12 +117,115 @@ func TestGetHealth(t *testing.T) {
 	}
 }
 
+func TestGetReadiness(t *testing.T) {
+	server, _ := testServer(t)
+	r := testRouter(t, server)
+
+	rec := doRequest(t, r, "GET", "/api/ready", nil, "")
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp ReadinessResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+
+	if resp.Status != Ready {
+		t.Errorf("status = %q, want %q", resp.Status, Ready)
+	}
+	if resp.Components["store"] != Ok || resp.Components["crypto"] != Ok {
+		t.Errorf("components = %v, want store=ok crypto=ok", resp.Components)
+	}
+}
+
+func TestGetReadiness_StoreDown(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	// Closing the database makes Ping fail
+	st.Close()
+
+	rec := doRequest(t, r, "GET", "/api/ready", nil, "")
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	var resp ReadinessResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+
+	if resp.Status != Degraded {
+		t.Errorf("status = %q, want %q", resp.Status, Degraded)
+	}
+	if resp.Components["store"] != Fail {
+		t.Errorf("store = %q, want %q", resp.Components["store"], Fail)
+	}
+	if resp.Components["crypto"] != Ok {
+		t.Errorf("crypto = %q, want %q", resp.Components["crypto"], Ok)
+	}
+}
+
+func TestGetOpenAPISpec(t *testing.T) {
+	server, _ := testServer(t)
+	r := testRouter(t, server)
+
+	// No token: the spec is public
+	rec := doRequest(t, r, "GET", "/api/openapi.json", nil, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want 3.x", spec.OpenAPI)
+	}
+	for _, path := range []string{"/contacts", "/locations", "/openapi.json"} {
+		if _, ok := spec.Paths[path]; !ok {
+			t.Errorf("spec missing path %s", path)
+		}
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	server, _ := testServer(t, WithStorageQuota(1000))
+	r := testRouter(t, server)
+
+	// No token: capabilities are public
+	rec := doRequest(t, r, "GET", "/api/capabilities", nil, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var caps Capabilities
+	json.NewDecoder(rec.Body).Decode(&caps)
+
+	has := make(map[string]bool)
+	for _, f := range caps.Features {
+		has[f] = true
+	}
+	if !has[FeatureEvents] || !has[FeaturePresence] || !has[FeatureStorageQuota] {
+		t.Errorf("features = %v, want events, presence and storage_quota", caps.Features)
+	}
+	// Off in config
+	if has[FeatureRequireDevice] {
+		t.Errorf("features = %v, require_device is off", caps.Features)
+	}
+}
+
 // =============================================================================
 // Auth Tests
 // =============================================================================
 
 func TestDevLogin(t *testing.T) {
-	server, _ := testServer(t)
+	server, _ := testServer(t, WithDevMode(true))
 	r := testRouter(t, server)
 
 	body := map[string]string{
@@ -140,10 +248,13 @@ func TestDevLogin(t *testing.T) {
 	if resp.User.Email != "test@example.com" {
 		t.Errorf("email = %q, want %q", resp.User.Email, "test@example.com")
 	}
+	if resp.User.LastLoginAt == nil {
+		t.Error("expected lastLoginAt to be set")
+	}
 }
 
 func TestDevLogin_ExistingUser(t *testing.T) {
-	server, st := testServer(t)
+	server, st := testServer(t, WithDevMode(true))
 	r := testRouter(t, server)
 
 	// Create existing user
@@ -166,213 +277,1666 @@ func TestDevLogin_ExistingUser(t *testing.T) {
 	}
 }
 
-func TestLogout(t *testing.T) {
+func TestDevLogin_DevModeOff(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	token, _ := createTestUser(t, st, "test@example.com", "Test")
+	rec := doRequest(t, r, "POST", "/api/dev/login", DevLoginRequest{Email: "test@example.com"}, "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if _, err := st.Users().GetByEmail(context.Background(), "test@example.com"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByEmail = %v, want no user created", err)
+	}
+}
 
-	rec := doRequest(t, r, "POST", "/api/auth/logout", nil, token)
+// blockingVerifier never returns until its context is done
+type blockingVerifier struct{}
 
-	if rec.Code != http.StatusNoContent {
-		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+func (blockingVerifier) Verify(ctx context.Context, token string) (*auth.GoogleClaims, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestLoginWithGoogle_VerifyTimeout(t *testing.T) {
+	st, err := sqlite.New(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create test store: %v", err)
 	}
+	t.Cleanup(func() { st.Close() })
 
-	// Token should no longer work
-	rec = doRequest(t, r, "GET", "/api/me", nil, token)
-	if rec.Code != http.StatusUnauthorized {
-		t.Errorf("expected 401 after logout, got %d", rec.Code)
+	server := NewServer(st, "test-google-client-id", 24*time.Hour,
+		WithGoogleVerifier(blockingVerifier{}),
+		WithVerifyTimeout(20*time.Millisecond),
+	)
+	r := testRouter(t, server)
+
+	start := time.Now()
+	rec := doRequest(t, r, "POST", "/api/auth/google", GoogleLoginRequest{IdToken: "token"}, "")
+
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d; body = %s", rec.Code, http.StatusGatewayTimeout, rec.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %s, expected to time out quickly", elapsed)
 	}
 }
 
-func TestGetCurrentUser(t *testing.T) {
-	server, st := testServer(t)
-	r := testRouter(t, server)
+// rejectingVerifier fails every token
+type rejectingVerifier struct{}
 
-	token, user := createTestUser(t, st, "test@example.com", "Test User")
+func (rejectingVerifier) Verify(ctx context.Context, token string) (*auth.GoogleClaims, error) {
+	return nil, errors.New("invalid token")
+}
 
-	rec := doRequest(t, r, "GET", "/api/me", nil, token)
+func TestLoginWithGoogle_RecordsFailures(t *testing.T) {
+	server, st := testServer(t, WithGoogleVerifier(rejectingVerifier{}))
+	r := testRouter(t, server)
 
-	if rec.Code != http.StatusOK {
-		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	for i := 0; i < 2; i++ {
+		rec := doRequest(t, r, "POST", "/api/auth/google", GoogleLoginRequest{IdToken: "bad"}, "")
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
 	}
 
-	var resp User
-	json.NewDecoder(rec.Body).Decode(&resp)
+	// httptest requests come from 192.0.2.1
+	count, err := st.Users().CountRecentFailures(context.Background(), "", "192.0.2.1", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountRecentFailures failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("failures = %d, want 2", count)
+	}
+}
 
-	if string(resp.Email) != user.Email {
-		t.Errorf("email = %q, want %q", resp.Email, user.Email)
+// emailVerifier accepts any Google token as sub "google-<token>" with the
+// token as its email
+type emailVerifier struct{}
+
+func (emailVerifier) Verify(ctx context.Context, token string) (*auth.GoogleClaims, error) {
+	return &auth.GoogleClaims{Sub: "google-" + token, Email: token}, nil
+}
+
+func TestLogin_NormalizedEmailDoesNotLink(t *testing.T) {
+	st, err := sqlite.New(":memory:", sqlite.WithEmailNormalization(true, true))
+	if err != nil {
+		t.Fatalf("failed to create test store: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	server := NewServer(st, "test-google-client-id", 24*time.Hour,
+		WithGoogleVerifier(emailVerifier{}),
+		WithAppleVerifier(fakeAppleVerifier{}),
+	)
+	r := testRouter(t, server)
+	_, victim := createTestUser(t, st, "ab@gmail.com", "Victim")
+
+	// Variants reach the same mailbox for contact requests, but whoever
+	// controls one mustn't be signed in to the existing account
+	for _, tc := range []struct {
+		path string
+		body any
+	}{
+		{"/api/auth/google", GoogleLoginRequest{IdToken: "a.b+x@gmail.com"}},
+		{"/api/auth/apple", AppleLoginRequest{IdToken: "apple-1:ab+y@gmail.com", Nonce: "nonce"}},
+	} {
+		rec := doRequest(t, r, "POST", tc.path, tc.body, "")
+		var resp LoginResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		if rec.Code != http.StatusOK || resp.User.Id == victim.ID || !*resp.IsNewUser {
+			t.Errorf("%s: status = %d, user = %s; want a new account", tc.path, rec.Code, resp.User.Id)
+		}
+	}
+
+	got, err := st.Users().GetByID(context.Background(), victim.ID)
+	if err != nil || got.GoogleID != "" || got.AppleID != "" {
+		t.Errorf("victim = %+v, %v; want no linked logins", got, err)
 	}
 }
 
-func TestGetCurrentUser_Unauthorized(t *testing.T) {
-	server, _ := testServer(t)
+func TestLoginWithGoogle_AfterSoftDelete(t *testing.T) {
+	server, st := testServer(t, WithGoogleVerifier(emailVerifier{}))
 	r := testRouter(t, server)
+	ctx := context.Background()
 
-	rec := doRequest(t, r, "GET", "/api/me", nil, "")
+	user := &store.User{Email: "alice@example.com", GoogleID: "google-alice@example.com", Name: "Alice"}
+	if err := st.Users().Create(ctx, user); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := st.Users().SoftDelete(ctx, user.ID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
 
-	if rec.Code != http.StatusUnauthorized {
-		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	// Someone whose account was swept for inactivity starts over
+	rec := doRequest(t, r, "POST", "/api/auth/google", GoogleLoginRequest{IdToken: "alice@example.com"}, "")
+	var resp LoginResponse
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusOK || !*resp.IsNewUser || resp.User.Id == user.ID {
+		t.Errorf("status = %d, %+v; want a new account", rec.Code, resp)
 	}
 }
 
-// =============================================================================
-// Identity Tests
-// =============================================================================
+// fakeAppleVerifier accepts tokens of the form "sub" or "sub:email" issued
+// for the nonce "nonce"
+type fakeAppleVerifier struct{}
 
-func TestIdentityBackup(t *testing.T) {
-	server, st := testServer(t)
-	r := testRouter(t, server)
+func (fakeAppleVerifier) VerifyNonce(ctx context.Context, token, nonce string) (*auth.GoogleClaims, error) {
+	if nonce != "nonce" {
+		return nil, errors.New("nonce mismatch")
+	}
+	sub, email, _ := strings.Cut(token, ":")
+	return &auth.GoogleClaims{Sub: sub, Email: email}, nil
+}
 
-	token, _ := createTestUser(t, st, "test@example.com", "Test")
+func TestLoginWithApple(t *testing.T) {
+	server, st := testServer(t, WithAppleVerifier(fakeAppleVerifier{}))
+	r := testRouter(t, server)
 
-	// Initially no backup
-	rec := doRequest(t, r, "GET", "/api/identity/backup", nil, token)
-	if rec.Code != http.StatusNotFound {
-		t.Errorf("expected 404 for no backup, got %d", rec.Code)
+	login := func(req AppleLoginRequest) (*httptest.ResponseRecorder, LoginResponse) {
+		t.Helper()
+		rec := doRequest(t, r, "POST", "/api/auth/apple", req, "")
+		var resp LoginResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec, resp
 	}
 
-	// Set backup
-	backup := IdentityBackup{
-		Algorithm:  "AES-256-GCM",
-		Kdf:        "PBKDF2-SHA256",
-		Iterations: 100000,
-		Salt:       "dGVzdHNhbHQ=",
-		Iv:         "dGVzdGl2",
-		Payload:    "ZW5jcnlwdGVk",
+	// The first sign in has the email, and the app sends the name
+	rec, first := login(AppleLoginRequest{IdToken: "apple-1:alice@example.com", Nonce: "nonce", Name: ptr("Alice A")})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first login status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !*first.IsNewUser || first.User.Name != "Alice A" || first.User.Email != "alice@example.com" {
+		t.Errorf("first login = %+v", first)
 	}
 
-	rec = doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
-	if rec.Code != http.StatusNoContent {
-		t.Errorf("PUT status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	// Later sign ins have neither and find the account by Apple ID
+	rec, again := login(AppleLoginRequest{IdToken: "apple-1", Nonce: "nonce"})
+	if rec.Code != http.StatusOK || *again.IsNewUser || again.User.Id != first.User.Id {
+		t.Errorf("second login: status = %d, %+v", rec.Code, again)
 	}
 
-	// Get backup
-	rec = doRequest(t, r, "GET", "/api/identity/backup", nil, token)
-	if rec.Code != http.StatusOK {
-		t.Errorf("GET status = %d, want %d", rec.Code, http.StatusOK)
+	// An existing account is linked by email
+	_, bob := createTestUser(t, st, "bob@example.com", "Bob")
+	if _, linked := login(AppleLoginRequest{IdToken: "apple-2:bob@example.com", Nonce: "nonce"}); linked.User.Id != bob.ID {
+		t.Errorf("linked login user = %s, want %s", linked.User.Id, bob.ID)
+	}
+	if user, err := st.Users().GetByAppleID(context.Background(), "apple-2"); err != nil || user.ID != bob.ID {
+		t.Errorf("GetByAppleID = %v, %v; want Bob", user, err)
 	}
 
-	var got IdentityBackup
-	json.NewDecoder(rec.Body).Decode(&got)
+	// Without a linked account or an email there's no one to sign in as
+	if rec, _ := login(AppleLoginRequest{IdToken: "apple-3", Nonce: "nonce"}); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown Apple ID without email: status = %d, want 400", rec.Code)
+	}
+	if rec, _ := login(AppleLoginRequest{IdToken: "apple-1", Nonce: "replayed"}); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong nonce: status = %d, want 401", rec.Code)
+	}
 
-	if got.Algorithm != backup.Algorithm {
-		t.Errorf("algorithm = %q, want %q", got.Algorithm, backup.Algorithm)
+	// Without an Apple client ID the endpoint is off
+	server, _ = testServer(t)
+	rec = doRequest(t, testRouter(t, server), "POST", "/api/auth/apple", AppleLoginRequest{IdToken: "apple-1", Nonce: "nonce"}, "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unconfigured status = %d, want 404", rec.Code)
 	}
 }
 
-func TestSetPublicKey(t *testing.T) {
+func TestValidateToken(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
 	token, user := createTestUser(t, st, "test@example.com", "Test")
 
-	body := PublicKeyRequest{PublicKey: "base64publickey"}
-	rec := doRequest(t, r, "POST", "/api/identity/public-key", body, token)
-
-	if rec.Code != http.StatusNoContent {
-		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	rec := doRequest(t, r, "GET", "/api/auth/validate", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
 	}
-
-	// Verify via store
-	ctx := context.Background()
-	got, _ := st.Users().GetByID(ctx, user.ID)
-	if got.PublicKey != "base64publickey" {
-		t.Errorf("publicKey = %q, want %q", got.PublicKey, "base64publickey")
+	var resp TokenValidation
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.UserId != user.ID {
+		t.Errorf("userId = %q, want %q", resp.UserId, user.ID)
+	}
+	session, _ := st.Sessions().GetByToken(context.Background(), token)
+	if !resp.ExpiresAt.Equal(session.ExpiresAt) {
+		t.Errorf("expiresAt = %v, want %v", resp.ExpiresAt, session.ExpiresAt)
 	}
 }
 
-// =============================================================================
-// Contact Tests
-// =============================================================================
-
-func TestListContacts_Empty(t *testing.T) {
+func TestValidateToken_Expired(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	token, _ := createTestUser(t, st, "test@example.com", "Test")
-
-	rec := doRequest(t, r, "GET", "/api/contacts", nil, token)
-
-	if rec.Code != http.StatusOK {
-		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	_, user := createTestUser(t, st, "test@example.com", "Test")
+	expired := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := st.Sessions().Create(context.Background(), expired); err != nil {
+		t.Fatalf("failed to create session: %v", err)
 	}
 
-	var resp ContactList
-	json.NewDecoder(rec.Body).Decode(&resp)
-
-	if len(resp.Contacts) != 0 {
-		t.Errorf("contacts = %d, want 0", len(resp.Contacts))
+	rec := doRequest(t, r, "GET", "/api/auth/validate", nil, expired.Token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
 	}
 }
 
-func TestContactRequestFlow(t *testing.T) {
+func TestLogout(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	// Create two users
-	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
-	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
 
-	// Alice sends request to Bob
-	body := ContactRequestCreate{Email: "bob@example.com"}
-	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	rec := doRequest(t, r, "POST", "/api/auth/logout", nil, token)
 
-	if rec.Code != http.StatusCreated {
-		t.Errorf("send request status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
 	}
 
-	var req ContactRequest
-	json.NewDecoder(rec.Body).Decode(&req)
-	requestID := req.Id
-
-	// Bob checks incoming requests
-	rec = doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB)
-	if rec.Code != http.StatusOK {
-		t.Errorf("list requests status = %d, want %d", rec.Code, http.StatusOK)
+	// Token should no longer work
+	rec = doRequest(t, r, "GET", "/api/me", nil, token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 after logout, got %d", rec.Code)
 	}
+}
 
-	var requests ContactRequestList
-	json.NewDecoder(rec.Body).Decode(&requests)
+func TestRefreshSession(t *testing.T) {
+	server, _ := testServer(t, WithRefreshTokenDuration(time.Hour), WithDevMode(true))
+	r := testRouter(t, server)
 
-	if len(requests.Incoming) != 1 {
-		t.Errorf("incoming = %d, want 1", len(requests.Incoming))
+	login := func() LoginResponse {
+		t.Helper()
+		rec := doRequest(t, r, "POST", "/api/dev/login", DevLoginRequest{Email: "test@example.com"}, "")
+		var resp LoginResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		if resp.RefreshToken == nil {
+			t.Fatal("login returned no refresh token")
+		}
+		return resp
+	}
+	refresh := func(refreshToken string) (*httptest.ResponseRecorder, RefreshResponse) {
+		t.Helper()
+		rec := doRequest(t, r, "POST", "/api/auth/refresh", RefreshRequest{RefreshToken: refreshToken}, "")
+		var resp RefreshResponse
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return rec, resp
 	}
 
-	// Bob accepts
-	rec = doRequest(t, r, "POST", "/api/contacts/requests/"+requestID+"/accept", nil, tokenB)
+	// Refreshing replaces the session and rotates the refresh token
+	first := login()
+	rec, refreshed := refresh(*first.RefreshToken)
 	if rec.Code != http.StatusOK {
-		t.Errorf("accept status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		t.Fatalf("refresh status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if refreshed.Token == first.Token || refreshed.RefreshToken == *first.RefreshToken {
+		t.Error("refresh reused the old tokens")
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, first.Token); rec.Code != http.StatusUnauthorized {
+		t.Errorf("old session after refresh: status = %d, want 401", rec.Code)
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, refreshed.Token); rec.Code != http.StatusOK {
+		t.Errorf("new session: status = %d, want 200", rec.Code)
+	}
+	if rec, _ := refresh(*first.RefreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("reused refresh token: status = %d, want 401", rec.Code)
 	}
 
-	// Both should see each other as contacts
-	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
-	var contactsA ContactList
-	json.NewDecoder(rec.Body).Decode(&contactsA)
+	// Logging out invalidates the session's refresh token
+	second := login()
+	doRequest(t, r, "POST", "/api/auth/logout", nil, second.Token)
+	if rec, _ := refresh(*second.RefreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: status = %d, want 401", rec.Code)
+	}
 
-	if len(contactsA.Contacts) != 1 {
-		t.Errorf("Alice contacts = %d, want 1", len(contactsA.Contacts))
+	// So does revoking the session's device, and the refreshed session
+	// stays on the device
+	third := login()
+	rec = doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "Phone", Platform: DeviceCreatePlatformIos}, third.Token)
+	var device DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&device)
+	rec, refreshed = refresh(*third.RefreshToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("refresh status = %d, want %d", rec.Code, http.StatusOK)
 	}
-	if contactsA.Contacts[0].Id != userB.ID {
-		t.Errorf("Alice's contact ID = %q, want %q", contactsA.Contacts[0].Id, userB.ID)
+	session, err := server.store.Sessions().GetByToken(context.Background(), refreshed.Token)
+	if err != nil || session.DeviceID != device.Id {
+		t.Errorf("refreshed session device = %v, %v; want %s", session, err, device.Id)
+	}
+	manager := login()
+	doRequest(t, r, "DELETE", "/api/devices/"+device.Id, nil, manager.Token)
+	if rec, _ := refresh(refreshed.RefreshToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after device revoked: status = %d, want 401", rec.Code)
 	}
+}
 
-	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenB)
-	var contactsB ContactList
-	json.NewDecoder(rec.Body).Decode(&contactsB)
+func TestRefreshSession_Disabled(t *testing.T) {
+	server, _ := testServer(t, WithDevMode(true))
+	r := testRouter(t, server)
 
-	if len(contactsB.Contacts) != 1 {
-		t.Errorf("Bob contacts = %d, want 1", len(contactsB.Contacts))
+	rec := doRequest(t, r, "POST", "/api/dev/login", DevLoginRequest{Email: "test@example.com"}, "")
+	var login LoginResponse
+	json.NewDecoder(rec.Body).Decode(&login)
+	if login.RefreshToken != nil {
+		t.Error("login returned a refresh token with refresh tokens disabled")
 	}
 }
 
-func TestContactRequestDecline(t *testing.T) {
+func TestSessions(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
+	ctx := context.Background()
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+	other := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.Sessions().Create(ctx, other); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	rec := doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "Laptop", Platform: "cli"}, other.Token)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register device: status = %d", rec.Code)
+	}
+	strangerToken, _ := createTestUser(t, st, "stranger@example.com", "Stranger")
+
+	rec = doRequest(t, r, "GET", "/api/sessions", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var list SessionList
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list.Sessions) != 2 {
+		t.Fatalf("session count = %d, want 2", len(list.Sessions))
+	}
+	if body := rec.Body.String(); strings.Contains(body, token) || strings.Contains(body, other.Token) {
+		t.Fatal("response contains a full session token")
+	}
+	var otherMasked string
+	for _, sess := range list.Sessions {
+		if *sess.IsCurrent != strings.HasPrefix(token, strings.TrimSuffix(sess.Token, "…")) {
+			t.Errorf("session %s isCurrent = %v", sess.Token, *sess.IsCurrent)
+		}
+		if !*sess.IsCurrent {
+			otherMasked = sess.Token
+			if sess.DeviceName == nil || *sess.DeviceName != "Laptop" {
+				t.Errorf("device name = %v, want Laptop", sess.DeviceName)
+			}
+		}
+	}
+
+	// Another user's session, or too short a prefix, isn't found
+	for _, path := range []string{"/api/sessions/" + strangerToken[:sessionPrefixLen], "/api/sessions/" + other.Token[:4]} {
+		if rec := doRequest(t, r, "DELETE", path, nil, token); rec.Code != http.StatusNotFound {
+			t.Errorf("DELETE %s status = %d, want %d", path, rec.Code, http.StatusNotFound)
+		}
+	}
+
+	// Full tokens don't belong in a URL
+	if rec := doRequest(t, r, "DELETE", "/api/sessions/"+other.Token, nil, token); rec.Code != http.StatusBadRequest {
+		t.Errorf("DELETE by full token status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, r, "DELETE", "/api/sessions/"+url.PathEscape(otherMasked), nil, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, other.Token); rec.Code != http.StatusUnauthorized {
+		t.Errorf("revoked session status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, strangerToken); rec.Code != http.StatusOK {
+		t.Errorf("stranger's session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "PATCH", "/api/me", ProfileUpdate{Name: "   "}, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("empty name status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	// The limit counts characters, not bytes
+	rec = doRequest(t, r, "PATCH", "/api/me", ProfileUpdate{Name: strings.Repeat("é", maxDisplayNameLength)}, token)
+	if rec.Code != http.StatusOK {
+		t.Errorf("name at limit status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	rec = doRequest(t, r, "PATCH", "/api/me", ProfileUpdate{Name: strings.Repeat("é", maxDisplayNameLength+1)}, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("long name status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = doRequest(t, r, "PATCH", "/api/me", ProfileUpdate{Name: " Alice Smith "}, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp User
+	json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Name != "Alice Smith" || string(resp.Email) != "test@example.com" {
+		t.Errorf("user = %s <%s>, want Alice Smith <test@example.com>", resp.Name, resp.Email)
+	}
+
+	got, _ := st.Users().GetByID(context.Background(), user.ID)
+	if got.Name != "Alice Smith" {
+		t.Errorf("stored name = %q, want %q", got.Name, "Alice Smith")
+	}
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test User")
+
+	rec := doRequest(t, r, "GET", "/api/me", nil, token)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp User
+	json.NewDecoder(rec.Body).Decode(&resp)
+
+	if string(resp.Email) != user.Email {
+		t.Errorf("email = %q, want %q", resp.Email, user.Email)
+	}
+}
+
+func TestGetCurrentUser_IncomingLocations(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	rec = doRequest(t, r, "GET", "/api/me", nil, tokenB)
+	var user User
+	json.NewDecoder(rec.Body).Decode(&user)
+	if user.HasIncomingLocations == nil || *user.HasIncomingLocations {
+		t.Errorf("hasIncomingLocations = %v, want false", user.HasIncomingLocations)
+	}
+	if user.LatestLocationAt != nil {
+		t.Errorf("latestLocationAt = %v, want none", user.LatestLocationAt)
+	}
+
+	before := time.Now()
+	doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "to-bob"}},
+	}, tokenA)
+
+	rec = doRequest(t, r, "GET", "/api/me", nil, tokenB)
+	user = User{}
+	json.NewDecoder(rec.Body).Decode(&user)
+	if user.HasIncomingLocations == nil || !*user.HasIncomingLocations {
+		t.Errorf("hasIncomingLocations = %v, want true", user.HasIncomingLocations)
+	}
+	if user.LatestLocationAt == nil || user.LatestLocationAt.Before(before) {
+		t.Errorf("latestLocationAt = %v, want after %v", user.LatestLocationAt, before)
+	}
+}
+
+func TestGetCurrentUser_Unauthorized(t *testing.T) {
+	server, _ := testServer(t)
+	r := testRouter(t, server)
+
+	rec := doRequest(t, r, "GET", "/api/me", nil, "")
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestAuthMiddleware_TokenWithSeparator(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ctx := context.Background()
+
+	_, user := createTestUser(t, st, "test@example.com", "Test")
+	for _, token := range []string{"base64url:with:colons", "prefix:suffix"} {
+		session := &store.Session{Token: token, UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := st.Sessions().Create(ctx, session); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+
+	// The whole token is used, not just the part before the first ":"
+	if rec := doRequest(t, r, "GET", "/api/me", nil, "base64url:with:colons"); rec.Code != http.StatusOK {
+		t.Errorf("token with colons status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// A token's prefix isn't a session of its own
+	if rec := doRequest(t, r, "GET", "/api/me", nil, "prefix"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("token prefix status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, "prefix:other"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("different suffix status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// =============================================================================
+// Settings Tests
+// =============================================================================
+
+func TestSettings(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "GET", "/api/me/settings", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var settings UserSettings
+	json.NewDecoder(rec.Body).Decode(&settings)
+	if !settings.Discoverable || !settings.SharingEnabled {
+		t.Errorf("defaults = %+v, want discoverable and sharing enabled", settings)
+	}
+
+	// Update one setting
+	rec = doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{SharingEnabled: ptr(false)}, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	// Persisted and returned on subsequent reads
+	rec = doRequest(t, r, "GET", "/api/me/settings", nil, token)
+	json.NewDecoder(rec.Body).Decode(&settings)
+	if settings.SharingEnabled {
+		t.Error("expected sharingEnabled to be false after update")
+	}
+	if !settings.Discoverable {
+		t.Error("expected discoverable to be unchanged")
+	}
+}
+
+func TestSettings_UnknownKeyRejected(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	body := map[string]interface{}{"notASetting": true}
+	rec := doRequest(t, r, "PUT", "/api/me/settings", body, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestSettings_SharingDisabled(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	share := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := LocationShareRequest{Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob"}}}
+		return doRequest(t, r, "POST", path, body, tokenA)
+	}
+	visible := func() int {
+		t.Helper()
+		var locations LocationList
+		json.NewDecoder(doRequest(t, r, "GET", "/api/locations", nil, tokenB).Body).Decode(&locations)
+		return len(locations.Locations)
+	}
+
+	if rec := share("/api/locations"); rec.Code != http.StatusNoContent {
+		t.Fatalf("share status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// In last-known mode Bob keeps the last location, but no new shares
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{LastKnownMode: ptr(true), SharingEnabled: ptr(false)}, tokenA)
+	rec = share("/api/locations")
+	var errResp Error
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if rec.Code != http.StatusForbidden || errResp.Error.Code != "sharing_disabled" {
+		t.Errorf("share with sharing off = %d %q, want 403 sharing_disabled", rec.Code, errResp.Error.Code)
+	}
+	if rec := share("/api/locations?partial=true"); rec.Code != http.StatusForbidden {
+		t.Errorf("partial share with sharing off = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if n := visible(); n != 1 {
+		t.Errorf("Bob sees %d locations in last-known mode, want 1", n)
+	}
+
+	// Leaving last-known mode withdraws it
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{LastKnownMode: ptr(false)}, tokenA)
+	if n := visible(); n != 0 {
+		t.Errorf("Bob sees %d locations after sharing stopped, want 0", n)
+	}
+
+	// Turning sharing back on allows shares again
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{SharingEnabled: ptr(true)}, tokenA)
+	if rec := share("/api/locations"); rec.Code != http.StatusNoContent {
+		t.Errorf("share after re-enabling = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestSendContactRequest_AutoAccept(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{AutoAcceptRequests: ptr(true)}, tokenB)
+	_, events, cancel := server.events.subscribe(userB.ID, 0, nil)
+	defer cancel()
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	if req.Status != Accepted {
+		t.Errorf("request status = %q, want %q", req.Status, Accepted)
+	}
+
+	// Bob has Alice as a contact and nothing left to act on
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenB)
+	var contacts ContactList
+	json.NewDecoder(rec.Body).Decode(&contacts)
+	if len(contacts.Contacts) != 1 || contacts.Contacts[0].Id != userA.ID {
+		t.Errorf("Bob's contacts = %+v, want Alice", contacts.Contacts)
+	}
+	rec = doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB)
+	var requests ContactRequestList
+	json.NewDecoder(rec.Body).Decode(&requests)
+	if len(requests.Incoming) != 0 {
+		t.Errorf("Bob has %d incoming requests, want 0", len(requests.Incoming))
+	}
+
+	// Bob is told once, with the final status
+	if ev := <-events; ev.Type != EventContactRequestReceived || !strings.Contains(string(ev.Data), `"status":"accepted"`) {
+		t.Errorf("event = %s %s, want contact_request.received with status accepted", ev.Type, ev.Data)
+	}
+	select {
+	case ev := <-events:
+		t.Errorf("unexpected second event %s %s", ev.Type, ev.Data)
+	default:
+	}
+}
+
+func TestSendContactRequest_ExistingPair(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+	createTestUser(t, st, "carol@example.com", "Carol")
+
+	send := func(token, email string) (int, string) {
+		t.Helper()
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: Email(email)}, token)
+		var errResp Error
+		json.NewDecoder(rec.Body).Decode(&errResp)
+		return rec.Code, errResp.Error.Code
+	}
+
+	// Clean pair
+	if code, _ := send(tokenA, "bob@example.com"); code != http.StatusCreated {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusCreated)
+	}
+
+	// Pending in either direction
+	if code, errCode := send(tokenA, "bob@example.com"); code != http.StatusConflict || errCode != "request_exists" {
+		t.Errorf("repeat request = %d %q, want 409 request_exists", code, errCode)
+	}
+	if code, errCode := send(tokenB, "alice@example.com"); code != http.StatusConflict || errCode != "request_exists" {
+		t.Errorf("reverse request = %d %q, want 409 request_exists", code, errCode)
+	}
+
+	// Already contacts, from either side
+	rec := doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB)
+	var requests ContactRequestList
+	json.NewDecoder(rec.Body).Decode(&requests)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+requests.Incoming[0].Id+"/accept", nil, tokenB)
+	for _, tc := range []struct{ token, email string }{{tokenA, "bob@example.com"}, {tokenB, "alice@example.com"}} {
+		if code, errCode := send(tc.token, tc.email); code != http.StatusConflict || errCode != "already_contacts" {
+			t.Errorf("request to %s = %d %q, want 409 already_contacts", tc.email, code, errCode)
+		}
+	}
+
+	// Unrelated pairs are unaffected
+	if code, _ := send(tokenB, "carol@example.com"); code != http.StatusCreated {
+		t.Errorf("clean request status = %d, want %d", code, http.StatusCreated)
+	}
+}
+
+func TestSendContactRequest_Undiscoverable(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{Discoverable: ptr(false)}, tokenB)
+
+	body := ContactRequestCreate{Email: "bob@example.com"}
+	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestSendContactRequest_NotAcceptingRequests(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{AcceptRequests: ptr(false)}, tokenB)
+
+	body := ContactRequestCreate{Email: "bob@example.com"}
+	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	// No pending request was created
+	rec = doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB)
+	var requests ContactRequestList
+	json.NewDecoder(rec.Body).Decode(&requests)
+	if len(requests.Incoming) != 0 {
+		t.Errorf("incoming requests = %d, want 0", len(requests.Incoming))
+	}
+
+	// Turning it back on lets requests through
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{AcceptRequests: ptr(true)}, tokenB)
+	rec = doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+}
+
+func TestCheckContact(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+	createTestUser(t, st, "carol@example.com", "Carol")
+
+	check := func(email string) ContactCheckStatus {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/contacts/check?email="+email, nil, tokenA)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var resp ContactCheck
+		json.NewDecoder(rec.Body).Decode(&resp)
+		return resp.Status
+	}
+
+	// Unknown emails look the same as requestable users
+	if got := check("nobody@example.com"); got != CanRequest {
+		t.Errorf("unknown email status = %q, want %q", got, CanRequest)
+	}
+	if got := check("carol@example.com"); got != CanRequest {
+		t.Errorf("carol status = %q, want %q", got, CanRequest)
+	}
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	if got := check("bob@example.com"); got != RequestPending {
+		t.Errorf("pending status = %q, want %q", got, RequestPending)
+	}
+
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+	if got := check("bob@example.com"); got != AlreadyContact {
+		t.Errorf("contact status = %q, want %q", got, AlreadyContact)
+	}
+}
+
+func TestRequireDevice(t *testing.T) {
+	server, st := testServer(t, WithRequireDevice(true))
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	// Reads are allowed without a device
+	rec := doRequest(t, r, "GET", "/api/me", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /me status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// Changes are blocked
+	body := PublicKeyRequest{PublicKey: "dGVzdHB1YmxpY2tleQ=="}
+	rec = doRequest(t, r, "POST", "/api/identity/public-key", body, token)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("POST status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var errResp Error
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if errResp.Error.Code != "device_required" {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, "device_required")
+	}
+
+	// Registering a device binds the session and unblocks it
+	rec = doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "Laptop", Platform: "cli"}, token)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var device DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&device)
+
+	rec = doRequest(t, r, "POST", "/api/identity/public-key", body, token)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("POST after register status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// Revoking the device ends the session's access
+	doRequest(t, r, "DELETE", "/api/devices/"+device.Id, nil, token)
+	rec = doRequest(t, r, "POST", "/api/identity/public-key", body, token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("POST after revoke status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+// deviceRouter serves a handler behind the auth and device middleware that
+// reports the context device's ID
+func deviceRouter(server *Server) http.Handler {
+	r := chi.NewRouter()
+	r.Use(server.AuthMiddleware)
+	r.Use(server.DeviceMiddleware)
+	r.Get("/api/whoami-device", func(w http.ResponseWriter, r *http.Request) {
+		if device := DeviceFromContext(r.Context()); device != nil {
+			w.Write([]byte(device.ID))
+		}
+	})
+	return r
+}
+
+func TestDeviceFromContext(t *testing.T) {
+	server, st := testServer(t)
+	r := deviceRouter(server)
+	ctx := context.Background()
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	device := &store.Device{UserID: user.ID, Name: "Laptop", Platform: "cli"}
+	if err := st.Devices().Create(ctx, device); err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+	if err := st.Sessions().SetDevice(ctx, token, device.ID); err != nil {
+		t.Fatalf("failed to bind device: %v", err)
+	}
+
+	rec := doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != device.ID {
+		t.Errorf("context device = %q, want %q", got, device.ID)
+	}
+
+	// Revoking the device ends the session's access, though it hasn't
+	// expired
+	if err := st.Devices().Revoke(ctx, device.ID, user.ID); err != nil {
+		t.Fatalf("failed to revoke device: %v", err)
+	}
+	rec = doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status after revoke = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// Other sessions are unaffected
+	other := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.Sessions().Create(ctx, other); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	if rec := doRequest(t, r, "GET", "/api/whoami-device", nil, other.Token); rec.Code != http.StatusOK {
+		t.Errorf("other session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestDeviceMiddleware_LastSeen(t *testing.T) {
+	server, st := testServer(t)
+	server.lastSeenInterval = 10 * time.Millisecond
+	r := deviceRouter(server)
+	ctx := context.Background()
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+	device := &store.Device{UserID: user.ID, Name: "Laptop", Platform: "cli"}
+	if err := st.Devices().Create(ctx, device); err != nil {
+		t.Fatalf("failed to create device: %v", err)
+	}
+	if err := st.Sessions().SetDevice(ctx, token, device.ID); err != nil {
+		t.Fatalf("failed to bind device: %v", err)
+	}
+
+	// The update is asynchronous, so wait for it to land
+	waitLastSeen := func(after time.Time) time.Time {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			got, err := st.Devices().GetByID(ctx, device.ID)
+			if err != nil {
+				t.Fatalf("GetByID failed: %v", err)
+			}
+			if got.LastSeen.After(after) {
+				return got.LastSeen
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("last seen didn't advance past %v", after)
+		return time.Time{}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	first := waitLastSeen(device.LastSeen)
+
+	time.Sleep(20 * time.Millisecond)
+	doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	waitLastSeen(first)
+
+	// Within the interval a request doesn't write
+	server.lastSeenInterval = time.Hour
+	latest, _ := st.Devices().GetByID(ctx, device.ID)
+	doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	time.Sleep(20 * time.Millisecond)
+	if got, _ := st.Devices().GetByID(ctx, device.ID); !got.LastSeen.Equal(latest.LastSeen) {
+		t.Errorf("last seen moved from %v to %v within the interval", latest.LastSeen, got.LastSeen)
+	}
+}
+
+func TestDeviceFromContext_DevicelessSession(t *testing.T) {
+	server, st := testServer(t)
+	r := deviceRouter(server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "GET", "/api/whoami-device", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "" {
+		t.Errorf("context device = %q, want none", got)
+	}
+}
+
+// =============================================================================
+// Usage Tests
+// =============================================================================
+
+func TestGetStorageUsage(t *testing.T) {
+	server, st := testServer(t, WithStorageQuota(1000))
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Blob: strings.Repeat("d", 300), Version: 0}, token)
+
+	rec := doRequest(t, r, "GET", "/api/me/usage", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var usage StorageUsage
+	json.NewDecoder(rec.Body).Decode(&usage)
+	if usage.UserData != 300 || usage.Total != 300 {
+		t.Errorf("usage = %+v, want 300 bytes of user data", usage)
+	}
+	if usage.Quota != 1000 {
+		t.Errorf("quota = %d, want 1000", usage.Quota)
+	}
+}
+
+// =============================================================================
+// Identity Tests
+// =============================================================================
+
+func TestIdentityBackup(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	// Initially no backup
+	rec := doRequest(t, r, "GET", "/api/identity/backup", nil, token)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for no backup, got %d", rec.Code)
+	}
+
+	// Set backup
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZW5jcnlwdGVk",
+	}
+
+	rec = doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("PUT status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	// Get backup
+	rec = doRequest(t, r, "GET", "/api/identity/backup", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got IdentityBackup
+	json.NewDecoder(rec.Body).Decode(&got)
+
+	if got.Algorithm != backup.Algorithm {
+		t.Errorf("algorithm = %q, want %q", got.Algorithm, backup.Algorithm)
+	}
+}
+
+func TestIdentityBackup_Conflict(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZGV2aWNlMQ==",
+	}
+	rec := doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("first PUT status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// A second device creating its own identity gets a conflict
+	backup.Payload = "ZGV2aWNlMg=="
+	rec = doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("second PUT status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	var conflict ConflictError
+	json.NewDecoder(rec.Body).Decode(&conflict)
+	if conflict.CurrentVersion != 1 {
+		t.Errorf("currentVersion = %d, want 1", conflict.CurrentVersion)
+	}
+
+	// Resolving by passing the current generation replaces the backup
+	backup.Generation = ptr(conflict.CurrentVersion)
+	rec = doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("resolved PUT status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	rec = doRequest(t, r, "GET", "/api/identity/backup", nil, token)
+	var got IdentityBackup
+	json.NewDecoder(rec.Body).Decode(&got)
+	if got.Payload != "ZGV2aWNlMg==" || got.Generation == nil || *got.Generation != 2 {
+		t.Errorf("backup = %+v, want device 2 payload at generation 2", got)
+	}
+}
+
+func TestIdentityBackup_Overwrite(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZGV2aWNlMQ==",
+	}
+	rec := doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("first PUT status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// Without confirmation the existing backup is kept
+	backup.Payload = "ZGV2aWNlMg=="
+	rec = doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("unconfirmed PUT status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	var conflict ConflictError
+	json.NewDecoder(rec.Body).Decode(&conflict)
+	if conflict.Error.Code != "backup_exists" {
+		t.Errorf("error code = %q, want %q", conflict.Error.Code, "backup_exists")
+	}
+
+	events, _ := st.Audit().ListForUser(context.Background(), user.ID)
+	if len(events) != 0 {
+		t.Errorf("audit events after rejected PUT = %d, want 0", len(events))
+	}
+
+	rec = doRequest(t, r, "PUT", "/api/identity/backup?overwrite=true", backup, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("overwrite PUT status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	rec = doRequest(t, r, "GET", "/api/identity/backup", nil, token)
+	var got IdentityBackup
+	json.NewDecoder(rec.Body).Decode(&got)
+	if got.Payload != "ZGV2aWNlMg==" {
+		t.Errorf("payload = %q, want device 2's", got.Payload)
+	}
+
+	events, _ = st.Audit().ListForUser(context.Background(), user.ID)
+	if len(events) != 1 || events[0].Action != store.AuditIdentityBackupReplaced {
+		t.Errorf("audit events = %+v, want one %s", events, store.AuditIdentityBackupReplaced)
+	}
+}
+
+func TestIdentityBackup_ReplacementLimit(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZGV2aWNlMQ==",
+	}
+	doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+
+	for i := 0; i < maxBackupReplacements; i++ {
+		rec := doRequest(t, r, "PUT", "/api/identity/backup?overwrite=true", backup, token)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("replacement %d status = %d, want %d", i+1, rec.Code, http.StatusNoContent)
+		}
+	}
+
+	rec := doRequest(t, r, "PUT", "/api/identity/backup?overwrite=true", backup, token)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status over limit = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestIdentityBackup_Iterations(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	tests := []struct {
+		name       string
+		iterations int
+		wantStatus int
+	}{
+		{"zero", 0, http.StatusBadRequest},
+		{"extreme", 1 << 30, http.StatusBadRequest},
+		{"normal", 100000, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := IdentityBackup{
+				Algorithm:  "AES-256-GCM",
+				Kdf:        "PBKDF2-SHA256",
+				Iterations: tt.iterations,
+				Salt:       "dGVzdHNhbHQ=",
+				Iv:         "dGVzdGl2",
+				Payload:    "ZW5jcnlwdGVk",
+			}
+			rec := doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusBadRequest {
+				var errResp Error
+				json.NewDecoder(rec.Body).Decode(&errResp)
+				if errResp.Error.Code != "invalid_backup" {
+					t.Errorf("error code = %q, want %q", errResp.Error.Code, "invalid_backup")
+				}
+			}
+		})
+	}
+}
+
+func TestIdentityBackup_Argon2id(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	tests := []struct {
+		name        string
+		kdf         IdentityBackupKdf
+		time        int
+		memory      *int
+		parallelism *int
+		wantStatus  int
+	}{
+		{"defaults", Argon2id, 3, ptr(64 * 1024), ptr(4), http.StatusNoContent},
+		{"missing memory", Argon2id, 3, nil, ptr(4), http.StatusBadRequest},
+		{"zero time", Argon2id, 0, ptr(64 * 1024), ptr(4), http.StatusBadRequest},
+		{"too much memory", Argon2id, 3, ptr(4 * 1024 * 1024), ptr(4), http.StatusBadRequest},
+		{"memory below threads", Argon2id, 3, ptr(16), ptr(4), http.StatusBadRequest},
+		{"too many threads", Argon2id, 3, ptr(64 * 1024), ptr(256), http.StatusBadRequest},
+		{"memory on PBKDF2", PBKDF2SHA256, 100000, ptr(64 * 1024), ptr(4), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := IdentityBackup{
+				Algorithm:   "AES-256-GCM",
+				Kdf:         tt.kdf,
+				Iterations:  tt.time,
+				Memory:      tt.memory,
+				Parallelism: tt.parallelism,
+				Salt:        "dGVzdHNhbHQ=",
+				Iv:          "dGVzdGl2",
+				Payload:     "ZW5jcnlwdGVk",
+			}
+			rec := doRequest(t, r, "PUT", "/api/identity/backup?overwrite=true", backup, token)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+
+	// The parameters come back with the backup
+	rec := doRequest(t, r, "GET", "/api/identity/backup", nil, token)
+	var got IdentityBackup
+	json.NewDecoder(rec.Body).Decode(&got)
+	if got.Kdf != Argon2id || got.Memory == nil || *got.Memory != 64*1024 || got.Parallelism == nil || *got.Parallelism != 4 {
+		t.Errorf("stored backup = %s, memory %v, parallelism %v; want argon2id 65536/4", got.Kdf, got.Memory, got.Parallelism)
+	}
+}
+
+func TestIdentityBackupMeta(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "GET", "/api/identity/backup/meta", nil, token)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for no backup, got %d", rec.Code)
+	}
+
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZW5jcnlwdGVk",
+	}
+	doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+
+	rec = doRequest(t, r, "GET", "/api/identity/backup/meta", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var raw map[string]interface{}
+	json.NewDecoder(rec.Body).Decode(&raw)
+
+	if raw["kdf"] != "PBKDF2-SHA256" {
+		t.Errorf("kdf = %v, want %q", raw["kdf"], "PBKDF2-SHA256")
+	}
+	if raw["iterations"] != float64(100000) {
+		t.Errorf("iterations = %v, want %d", raw["iterations"], 100000)
+	}
+	for _, field := range []string{"payload", "salt", "iv"} {
+		if _, ok := raw[field]; ok {
+			t.Errorf("expected %q to be omitted from meta response", field)
+		}
+	}
+}
+
+func TestSetPublicKey(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	body := PublicKeyRequest{PublicKey: "base64publickey"}
+	rec := doRequest(t, r, "POST", "/api/identity/public-key", body, token)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// Verify via store
+	ctx := context.Background()
+	got, _ := st.Users().GetByID(ctx, user.ID)
+	if got.PublicKey != "base64publickey" {
+		t.Errorf("publicKey = %q, want %q", got.PublicKey, "base64publickey")
+	}
+}
+
+func TestSetPublicKey_KeyChangedEvent(t *testing.T) {
+	for _, notify := range []bool{true, false} {
+		t.Run(fmt.Sprintf("notify=%v", notify), func(t *testing.T) {
+			server, st := testServer(t, WithKeyChangeNotify(notify))
+			r := testRouter(t, server)
+
+			ctx := context.Background()
+			tokenA, alice := createTestUser(t, st, "alice@example.com", "Alice")
+			_, bob := createTestUser(t, st, "bob@example.com", "Bob")
+			req, _ := st.Contacts().CreateRequest(ctx, alice.ID, bob.ID)
+			st.Contacts().AcceptRequest(ctx, req.ID, bob.ID)
+
+			doRequest(t, r, "POST", "/api/identity/public-key", PublicKeyRequest{PublicKey: "oldkey"}, tokenA)
+			_, events, cancel := server.events.subscribe(bob.ID, 0, nil)
+			defer cancel()
+
+			rec := doRequest(t, r, "POST", "/api/identity/public-key", PublicKeyRequest{PublicKey: "newkey"}, tokenA)
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+			}
+
+			select {
+			case ev := <-events:
+				if !notify {
+					t.Errorf("unexpected event %s %s", ev.Type, ev.Data)
+				} else if ev.Type != EventContactKeyChanged {
+					t.Errorf("event = %s, want %s", ev.Type, EventContactKeyChanged)
+				}
+			default:
+				if notify {
+					t.Errorf("no %s event", EventContactKeyChanged)
+				}
+			}
+		})
+	}
+}
+
+func TestOnboard(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	body := OnboardRequest{
+		PublicKey: "base64publickey",
+		IdentityBackup: IdentityBackup{
+			Algorithm:  "AES-256-GCM",
+			Kdf:        "PBKDF2-SHA256",
+			Iterations: 100000,
+			Salt:       "dGVzdHNhbHQ=",
+			Iv:         "dGVzdGl2",
+			Payload:    "ZW5jcnlwdGVk",
+		},
+	}
+	rec := doRequest(t, r, "POST", "/api/onboard", body, token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	ctx := context.Background()
+	got, _ := st.Users().GetByID(ctx, user.ID)
+	if got.PublicKey != "base64publickey" {
+		t.Errorf("publicKey = %q, want %q", got.PublicKey, "base64publickey")
+	}
+	backup, err := st.Users().GetIdentityBackup(ctx, user.ID)
+	if err != nil || backup.Payload != "ZW5jcnlwdGVk" {
+		t.Errorf("backup = %+v, %v", backup, err)
+	}
+
+	// Only once
+	rec = doRequest(t, r, "POST", "/api/onboard", body, token)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("repeat status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+func TestOnboard_RollsBack(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	// Another device already uploaded a backup
+	backup := IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZXhpc3Rpbmc=",
+	}
+	doRequest(t, r, "PUT", "/api/identity/backup", backup, token)
+
+	backup.Payload = "bmV3"
+	rec := doRequest(t, r, "POST", "/api/onboard", OnboardRequest{PublicKey: "base64publickey", IdentityBackup: backup}, token)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	ctx := context.Background()
+	got, _ := st.Users().GetByID(ctx, user.ID)
+	if got.PublicKey != "" {
+		t.Errorf("publicKey = %q, want it rolled back", got.PublicKey)
+	}
+	stored, _ := st.Users().GetIdentityBackup(ctx, user.ID)
+	if stored.Payload != "ZXhpc3Rpbmc=" {
+		t.Errorf("backup payload = %q, want the original", stored.Payload)
+	}
+}
+
+func TestOnboard_InvalidBackup(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	body := OnboardRequest{
+		PublicKey:      "base64publickey",
+		IdentityBackup: IdentityBackup{Algorithm: "AES-256-GCM", Kdf: "PBKDF2-SHA256", Iterations: 1},
+	}
+	rec := doRequest(t, r, "POST", "/api/onboard", body, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// =============================================================================
+// Contact Tests
+// =============================================================================
+
+func TestListContacts_Empty(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "GET", "/api/contacts", nil, token)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp ContactList
+	json.NewDecoder(rec.Body).Decode(&resp)
+
+	if len(resp.Contacts) != 0 {
+		t.Errorf("contacts = %d, want 0", len(resp.Contacts))
+	}
+}
+
+func TestListContacts_Since(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	tokenC, _ := createTestUser(t, st, "carol@example.com", "Carol")
+
+	for _, token := range []string{tokenB, tokenC} {
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, token)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenA)
+	}
+
+	since := time.Now()
+
+	// Bob changes his name
+	userB.Name = "Robert"
+	if err := st.Users().Update(context.Background(), userB); err != nil {
+		t.Fatalf("failed to update user: %v", err)
+	}
+
+	rec := doRequest(t, r, "GET", "/api/contacts?since="+url.QueryEscape(since.Format(time.RFC3339Nano)), nil, tokenA)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var contacts ContactList
+	json.NewDecoder(rec.Body).Decode(&contacts)
+
+	if len(contacts.Contacts) != 1 {
+		t.Fatalf("contacts = %d, want 1", len(contacts.Contacts))
+	}
+	if contacts.Contacts[0].Name != "Robert" {
+		t.Errorf("name = %q, want %q", contacts.Contacts[0].Name, "Robert")
+	}
+
+	// Without since, everyone is returned
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
+	json.NewDecoder(rec.Body).Decode(&contacts)
+	if len(contacts.Contacts) != 2 {
+		t.Errorf("contacts = %d, want 2", len(contacts.Contacts))
+	}
+}
+
+func TestGetContactSummary(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	tokenC, _ := createTestUser(t, st, "carol@example.com", "Carol")
+	createTestUser(t, st, "dave@example.com", "Dave")
+	tokenE, _ := createTestUser(t, st, "erin@example.com", "Erin")
+
+	// Bob and Carol are Alice's contacts
+	for _, token := range []string{tokenB, tokenC} {
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, token)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenA)
+	}
+
+	// One pending request each way
+	doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "dave@example.com"}, tokenA)
+	doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, tokenE)
+
+	// Only Bob has a key; Alice shares with Bob, Carol shares with Alice
+	st.Users().SetPublicKey(context.Background(), userB.ID, "bob-key")
+	doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "to-bob"}},
+	}, tokenA)
+	doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userA.ID, Blob: "to-alice"}},
+	}, tokenC)
+
+	rec := doRequest(t, r, "GET", "/api/contacts/summary", nil, tokenA)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var summary ContactSummary
+	json.NewDecoder(rec.Body).Decode(&summary)
+
+	want := ContactSummary{
+		Contacts:        2,
+		WithPublicKey:   1,
+		SharingTo:       1,
+		SharingFrom:     1,
+		PendingIncoming: 1,
+		PendingOutgoing: 1,
+	}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+}
+
+func TestContactRequestFlow(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	// Create two users
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	// Alice sends request to Bob
+	body := ContactRequestCreate{Email: "bob@example.com"}
+	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("send request status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	requestID := req.Id
+
+	// Bob checks incoming requests
+	rec = doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB)
+	if rec.Code != http.StatusOK {
+		t.Errorf("list requests status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var requests ContactRequestList
+	json.NewDecoder(rec.Body).Decode(&requests)
+
+	if len(requests.Incoming) != 1 {
+		t.Errorf("incoming = %d, want 1", len(requests.Incoming))
+	}
+
+	// Bob accepts
+	rec = doRequest(t, r, "POST", "/api/contacts/requests/"+requestID+"/accept", nil, tokenB)
+	if rec.Code != http.StatusOK {
+		t.Errorf("accept status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	// Both should see each other as contacts
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
+	var contactsA ContactList
+	json.NewDecoder(rec.Body).Decode(&contactsA)
+
+	if len(contactsA.Contacts) != 1 {
+		t.Errorf("Alice contacts = %d, want 1", len(contactsA.Contacts))
+	}
+	if contactsA.Contacts[0].Id != userB.ID {
+		t.Errorf("Alice's contact ID = %q, want %q", contactsA.Contacts[0].Id, userB.ID)
+	}
+
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenB)
+	var contactsB ContactList
+	json.NewDecoder(rec.Body).Decode(&contactsB)
+
+	if len(contactsB.Contacts) != 1 {
+		t.Errorf("Bob contacts = %d, want 1", len(contactsB.Contacts))
+	}
+}
+
+func TestContactRequestDecline(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
 
-	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
-	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
-
 	// Alice sends request
 	body := ContactRequestCreate{Email: "bob@example.com"}
 	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
@@ -380,100 +1944,1005 @@ func TestContactRequestDecline(t *testing.T) {
 	var req ContactRequest
 	json.NewDecoder(rec.Body).Decode(&req)
 
-	// Bob declines
-	rec = doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/decline", nil, tokenB)
+	// Bob declines
+	rec = doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/decline", nil, tokenB)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("decline status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// No contacts created
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
+	var contacts ContactList
+	json.NewDecoder(rec.Body).Decode(&contacts)
+
+	if len(contacts.Contacts) != 0 {
+		t.Errorf("contacts after decline = %d, want 0", len(contacts.Contacts))
+	}
+}
+
+func TestContactRequestHistory(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+	tokenC, _ := createTestUser(t, st, "carol@example.com", "Carol")
+	createTestUser(t, st, "dave@example.com", "Dave")
+
+	// Alice -> Bob, accepted
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var toBob ContactRequest
+	json.NewDecoder(rec.Body).Decode(&toBob)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+toBob.Id+"/accept", nil, tokenB)
+
+	// Carol -> Alice, declined
+	rec = doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, tokenC)
+	var fromCarol ContactRequest
+	json.NewDecoder(rec.Body).Decode(&fromCarol)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+fromCarol.Id+"/decline", nil, tokenA)
+
+	// Alice -> Dave, expired
+	rec = doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "dave@example.com"}, tokenA)
+	var toDave ContactRequest
+	json.NewDecoder(rec.Body).Decode(&toDave)
+	if _, err := st.Contacts().ExpireRequests(context.Background(), time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("ExpireRequests failed: %v", err)
+	}
+
+	var got []ContactRequest
+	path := "/api/contacts/requests/history?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("too many pages")
+		}
+		rec = doRequest(t, r, "GET", path, nil, tokenA)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var page ContactRequestHistory
+		json.NewDecoder(rec.Body).Decode(&page)
+		got = append(got, page.Requests...)
+		if page.NextCursor == nil {
+			break
+		}
+		path = "/api/contacts/requests/history?limit=2&cursor=" + *page.NextCursor
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("history = %d requests, want 3", len(got))
+	}
+
+	byID := make(map[string]ContactRequest)
+	for _, req := range got {
+		byID[req.Id] = req
+	}
+	if req := byID[toBob.Id]; req.Status != Accepted || req.AcceptedAt == nil || *req.Direction != Outgoing || req.Email != "bob@example.com" {
+		t.Errorf("request to Bob = %+v, want accepted outgoing with acceptedAt", req)
+	}
+	if req := byID[fromCarol.Id]; req.Status != Declined || req.DeclinedAt == nil || *req.Direction != Incoming || req.Email != "carol@example.com" {
+		t.Errorf("request from Carol = %+v, want declined incoming with declinedAt", req)
+	}
+	if req := byID[toDave.Id]; req.Status != Expired || req.ExpiredAt == nil {
+		t.Errorf("request to Dave = %+v, want expired with expiredAt", req)
+	}
+	if got[0].Id != toDave.Id {
+		t.Errorf("first request = %s, want newest (Dave)", got[0].Id)
+	}
+
+	rec = doRequest(t, r, "GET", "/api/contacts/requests/history?cursor=!!!", nil, tokenA)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid cursor status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRemoveContact(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	// Create contact
+	body := ContactRequestCreate{Email: "bob@example.com"}
+	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	// Alice removes Bob
+	rec = doRequest(t, r, "DELETE", "/api/contacts/"+userB.ID, nil, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("remove status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// Both should have no contacts
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
+	var contactsA ContactList
+	json.NewDecoder(rec.Body).Decode(&contactsA)
+	if len(contactsA.Contacts) != 0 {
+		t.Errorf("Alice contacts after remove = %d, want 0", len(contactsA.Contacts))
+	}
+}
+
+func TestRemoveContact_RequestAgain(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+	doRequest(t, r, "DELETE", "/api/contacts/"+userA.ID, nil, tokenB)
+
+	// Either side can ask again once they're no longer contacts
+	rec = doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("re-request status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	json.NewDecoder(rec.Body).Decode(&req)
+	if rec := doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB); rec.Code != http.StatusOK {
+		t.Errorf("accept status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if ok, _ := st.Contacts().AreContacts(context.Background(), userA.ID, userB.ID); !ok {
+		t.Error("not contacts after the second request was accepted")
+	}
+}
+
+func TestRemoveContact_DeletesLocationsAndAudits(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+	doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userA.ID, Blob: "to-alice"}},
+	}, tokenB)
+
+	rec = doRequest(t, r, "DELETE", "/api/contacts/"+userB.ID, nil, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("remove status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	locations, _ := st.Locations().GetLocationsForUser(context.Background(), userA.ID)
+	if len(locations) != 0 {
+		t.Errorf("locations after remove = %d, want 0", len(locations))
+	}
+
+	events, _ := st.Audit().ListForUser(context.Background(), userA.ID)
+	if len(events) != 1 || events[0].Action != store.AuditContactRemoved || events[0].TargetID != userB.ID {
+		t.Errorf("audit events = %+v, want one contact.removed for Bob", events)
+	}
+}
+
+// failingLocationsStore is a store whose location deletes always fail
+type failingLocationsStore struct {
+	store.Store
+}
+
+func (s failingLocationsStore) Locations() store.LocationRepository {
+	return failingLocations{s.Store.Locations()}
+}
+
+func (s failingLocationsStore) WithTx(ctx context.Context, fn func(tx store.Store) error) error {
+	return s.Store.WithTx(ctx, func(tx store.Store) error {
+		return fn(failingLocationsStore{tx})
+	})
+}
+
+type failingLocations struct {
+	store.LocationRepository
+}
+
+func (failingLocations) DeleteLocationsBetween(ctx context.Context, userID, contactID string) error {
+	return errors.New("disk I/O error")
+}
+
+func (failingLocations) DeleteLocationsFromUser(ctx context.Context, userID string) error {
+	return errors.New("disk I/O error")
+}
+
+// failingAcceptStore is a store whose contact request accepts always fail
+type failingAcceptStore struct {
+	store.Store
+}
+
+func (s failingAcceptStore) Contacts() store.ContactRepository {
+	return failingAccept{s.Store.Contacts()}
+}
+
+func (s failingAcceptStore) WithTx(ctx context.Context, fn func(tx store.Store) error) error {
+	return s.Store.WithTx(ctx, func(tx store.Store) error {
+		return fn(failingAcceptStore{tx})
+	})
+}
+
+type failingAccept struct {
+	store.ContactRepository
+}
+
+func (failingAccept) AcceptRequest(ctx context.Context, requestID, userID string) error {
+	return errors.New("disk I/O error")
+}
+
+func TestRemoveContact_LocationFailureRollsBack(t *testing.T) {
+	_, st := testServer(t)
+	server := NewServer(failingLocationsStore{st}, "test-google-client-id", 24*time.Hour)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	rec = doRequest(t, r, "DELETE", "/api/contacts/"+userB.ID, nil, tokenA)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("remove status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	areContacts, _ := st.Contacts().AreContacts(context.Background(), userA.ID, userB.ID)
+	if !areContacts {
+		t.Error("contact was removed despite the location cleanup failing")
+	}
+	events, _ := st.Audit().ListForUser(context.Background(), userA.ID)
+	if len(events) != 0 {
+		t.Errorf("audit events = %d, want 0", len(events))
+	}
+}
+
+func TestUpdateSettings_LocationFailureRollsBack(t *testing.T) {
+	_, st := testServer(t)
+	server := NewServer(failingLocationsStore{st}, "test-google-client-id", 24*time.Hour)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "alice@example.com", "Alice")
+
+	rec := doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{SharingEnabled: ptr(false)}, token)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	var settings UserSettings
+	json.NewDecoder(doRequest(t, r, "GET", "/api/me/settings", nil, token).Body).Decode(&settings)
+	if !settings.SharingEnabled {
+		t.Error("sharing was turned off despite the location cleanup failing")
+	}
+}
+
+func TestSendContactRequest_AutoAcceptFailureRollsBack(t *testing.T) {
+	_, st := testServer(t)
+	server := NewServer(failingAcceptStore{st}, "test-google-client-id", 24*time.Hour)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, _ := createTestUser(t, st, "bob@example.com", "Bob")
+	doRequest(t, r, "PUT", "/api/me/settings", UserSettingsUpdate{AutoAcceptRequests: ptr(true)}, tokenB)
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	// No pending request was left behind
+	var requests ContactRequestList
+	json.NewDecoder(doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenB).Body).Decode(&requests)
+	if len(requests.Incoming) != 0 {
+		t.Errorf("Bob has %d incoming requests, want 0", len(requests.Incoming))
+	}
+}
+
+func TestSetContactNote(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	rec = doRequest(t, r, "PUT", "/api/contacts/"+userB.ID+"/note", ContactNoteUpdate{Note: "Climbing partner"}, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	// Alice sees her note
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
+	var contacts ContactList
+	json.NewDecoder(rec.Body).Decode(&contacts)
+	if contacts.Contacts[0].Note == nil || *contacts.Contacts[0].Note != "Climbing partner" {
+		t.Errorf("note = %v, want %q", contacts.Contacts[0].Note, "Climbing partner")
+	}
+
+	// Bob never does
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenB)
+	if strings.Contains(rec.Body.String(), "Climbing partner") {
+		t.Error("note leaked to the contact")
+	}
+
+	// Length is capped
+	long := ContactNoteUpdate{Note: strings.Repeat("x", maxContactNoteLength+1)}
+	rec = doRequest(t, r, "PUT", "/api/contacts/"+userB.ID+"/note", long, tokenA)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("long note status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBlockUser(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, alice := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, bob := createTestUser(t, st, "bob@example.com", "Bob")
+
+	errorCode := func(rec *httptest.ResponseRecorder) string {
+		var errResp Error
+		json.NewDecoder(rec.Body).Decode(&errResp)
+		return errResp.Error.Code
+	}
+
+	// Bob's request carries his ID, which Alice blocks him by
+	doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, tokenB)
+	rec := doRequest(t, r, "GET", "/api/contacts/requests", nil, tokenA)
+	var requests ContactRequestList
+	json.NewDecoder(rec.Body).Decode(&requests)
+	if len(requests.Incoming) != 1 || requests.Incoming[0].UserId == nil || *requests.Incoming[0].UserId != bob.ID {
+		t.Fatalf("incoming = %+v, want Bob's request with his ID", requests.Incoming)
+	}
+	pendingID := requests.Incoming[0].Id
+
+	if rec := doRequest(t, r, "POST", "/api/contacts/block", BlockCreate{UserId: bob.ID}, tokenA); rec.Code != http.StatusNoContent {
+		t.Fatalf("block status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	// The pending request is gone and can't be accepted
+	if rec := doRequest(t, r, "POST", "/api/contacts/requests/"+pendingID+"/accept", nil, tokenA); rec.Code != http.StatusNotFound {
+		t.Errorf("accept after block status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	// Bob sees the same answer as for someone not accepting requests
+	rec = doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "alice@example.com"}, tokenB)
+	if code := errorCode(rec); rec.Code != http.StatusForbidden || code != "not_accepting_requests" {
+		t.Errorf("request from blocked user = %d %q, want 403 not_accepting_requests", rec.Code, code)
+	}
+	rec = doRequest(t, r, "GET", "/api/contacts/check?email=alice@example.com", nil, tokenB)
+	var check ContactCheck
+	json.NewDecoder(rec.Body).Decode(&check)
+	if check.Status != NotAccepting {
+		t.Errorf("check from blocked user = %q, want %q", check.Status, NotAccepting)
+	}
+
+	// Alice can't request Bob either while he's blocked
+	rec = doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	if code := errorCode(rec); rec.Code != http.StatusForbidden || code != "blocked" {
+		t.Errorf("request to blocked user = %d %q, want 403 blocked", rec.Code, code)
+	}
+
+	rec = doRequest(t, r, "GET", "/api/contacts/blocked", nil, tokenA)
+	var blocked BlockedList
+	json.NewDecoder(rec.Body).Decode(&blocked)
+	if len(blocked.Users) != 1 || blocked.Users[0].UserId != bob.ID || blocked.Users[0].Email != "bob@example.com" {
+		t.Errorf("blocked = %+v, want Bob", blocked.Users)
+	}
+
+	// After unblocking, requests work again
+	if rec := doRequest(t, r, "DELETE", "/api/contacts/block/"+bob.ID, nil, tokenA); rec.Code != http.StatusNoContent {
+		t.Fatalf("unblock status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA); rec.Code != http.StatusCreated {
+		t.Errorf("request after unblock status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if rec := doRequest(t, r, "DELETE", "/api/contacts/block/"+bob.ID, nil, tokenA); rec.Code != http.StatusNotFound {
+		t.Errorf("unblock twice status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if rec := doRequest(t, r, "POST", "/api/contacts/block", BlockCreate{UserId: alice.ID}, tokenA); rec.Code != http.StatusBadRequest {
+		t.Errorf("block self status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNudgeContact(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	_, userC := createTestUser(t, st, "carol@example.com", "Carol")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	rec = doRequest(t, r, "POST", "/api/contacts/"+userB.ID+"/nudge", nil, tokenA)
 	if rec.Code != http.StatusNoContent {
-		t.Errorf("decline status = %d, want %d", rec.Code, http.StatusNoContent)
+		t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	// Bob sees the nudge
+	rec = doRequest(t, r, "GET", "/api/contacts/nudges", nil, tokenB)
+	var nudges NudgeList
+	json.NewDecoder(rec.Body).Decode(&nudges)
+	if len(nudges.Nudges) != 1 || nudges.Nudges[0].FromUserId != userA.ID {
+		t.Errorf("nudges = %+v, want one from Alice", nudges.Nudges)
+	}
+
+	// A second nudge right away is rate limited
+	rec = doRequest(t, r, "POST", "/api/contacts/"+userB.ID+"/nudge", nil, tokenA)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("second nudge status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	var errResp Error
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if errResp.Error.Code != "rate_limited" {
+		t.Errorf("error code = %q, want rate_limited", errResp.Error.Code)
+	}
+
+	// Only contacts can be nudged
+	rec = doRequest(t, r, "POST", "/api/contacts/"+userC.ID+"/nudge", nil, tokenA)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("non-contact status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestSetContactOrder(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	tokenC, userC := createTestUser(t, st, "carol@example.com", "Carol")
+
+	// Alice becomes contacts with Bob and Carol
+	for _, tc := range []struct{ email, token string }{
+		{"bob@example.com", tokenB},
+		{"carol@example.com", tokenC},
+	} {
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: Email(tc.email)}, tokenA)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tc.token)
+	}
+
+	// Pin Carol
+	rec := doRequest(t, r, "PUT", "/api/contacts/"+userC.ID+"/order", ContactOrderUpdate{SortOrder: ptr(0)}, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("pin status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
 	}
 
-	// No contacts created
 	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
 	var contacts ContactList
 	json.NewDecoder(rec.Body).Decode(&contacts)
 
-	if len(contacts.Contacts) != 0 {
-		t.Errorf("contacts after decline = %d, want 0", len(contacts.Contacts))
+	if len(contacts.Contacts) != 2 {
+		t.Fatalf("contacts = %d, want 2", len(contacts.Contacts))
+	}
+	if contacts.Contacts[0].Id != userC.ID {
+		t.Errorf("first contact = %q, want pinned %q", contacts.Contacts[0].Id, userC.ID)
+	}
+	if contacts.Contacts[1].Id != userB.ID {
+		t.Errorf("second contact = %q, want %q", contacts.Contacts[1].Id, userB.ID)
+	}
+
+	// Unknown contact
+	rec = doRequest(t, r, "PUT", "/api/contacts/nobody/order", ContactOrderUpdate{SortOrder: ptr(0)}, tokenA)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown contact status = %d, want %d", rec.Code, http.StatusNotFound)
 	}
 }
 
-func TestRemoveContact(t *testing.T) {
+// =============================================================================
+// Location Tests
+// =============================================================================
+
+func TestShareAndGetLocations(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	// Create two users and make them contacts
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
 	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
 
-	// Create contact
+	// Set public keys
+	ctx := context.Background()
+	st.Users().SetPublicKey(ctx, userA.ID, "alicePubKey")
+	st.Users().SetPublicKey(ctx, userB.ID, "bobPubKey")
+
+	// Make contacts
 	body := ContactRequestCreate{Email: "bob@example.com"}
 	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
 	var req ContactRequest
 	json.NewDecoder(rec.Body).Decode(&req)
 	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
 
-	// Alice removes Bob
-	rec = doRequest(t, r, "DELETE", "/api/contacts/"+userB.ID, nil, tokenA)
+	// Alice shares location
+	shareBody := LocationShareRequest{
+		Locations: []LocationShare{
+			{ToUserId: userB.ID, Blob: "encrypted_location_for_bob"},
+		},
+	}
+	rec = doRequest(t, r, "POST", "/api/locations", shareBody, tokenA)
 	if rec.Code != http.StatusNoContent {
-		t.Errorf("remove status = %d, want %d", rec.Code, http.StatusNoContent)
+		t.Errorf("share status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
 	}
 
-	// Both should have no contacts
-	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenA)
-	var contactsA ContactList
-	json.NewDecoder(rec.Body).Decode(&contactsA)
-	if len(contactsA.Contacts) != 0 {
-		t.Errorf("Alice contacts after remove = %d, want 0", len(contactsA.Contacts))
+	// Bob gets locations
+	rec = doRequest(t, r, "GET", "/api/locations", nil, tokenB)
+	if rec.Code != http.StatusOK {
+		t.Errorf("get locations status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var locations LocationList
+	json.NewDecoder(rec.Body).Decode(&locations)
+
+	if len(locations.Locations) != 1 {
+		t.Errorf("locations = %d, want 1", len(locations.Locations))
+	}
+	if locations.Locations[0].Blob != "encrypted_location_for_bob" {
+		t.Errorf("blob = %q, want %q", locations.Locations[0].Blob, "encrypted_location_for_bob")
 	}
 }
 
-// =============================================================================
-// Location Tests
-// =============================================================================
+func TestPresence(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	_, userC := createTestUser(t, st, "carol@example.com", "Carol")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	getPresence := func() PresenceList {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/presence", nil, tokenB)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("get presence status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var list PresenceList
+		json.NewDecoder(rec.Body).Decode(&list)
+		return list
+	}
+
+	// Alice checks in and Bob sees it
+	body := PresenceUpdate{Statuses: []PresenceShare{{ToUserId: userB.ID, Blob: "arrived"}}}
+	if rec := doRequest(t, r, "POST", "/api/presence", body, tokenA); rec.Code != http.StatusNoContent {
+		t.Fatalf("set presence status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+	list := getPresence()
+	if len(list.Presence) != 1 || list.Presence[0].FromUserId != userA.ID || list.Presence[0].Blob != "arrived" {
+		t.Fatalf("presence = %+v, want arrived from Alice", list.Presence)
+	}
+
+	// An update replaces it
+	body.Statuses[0].Blob = "left"
+	doRequest(t, r, "POST", "/api/presence", body, tokenA)
+	if list := getPresence(); len(list.Presence) != 1 || list.Presence[0].Blob != "left" {
+		t.Errorf("presence after update = %+v, want left", list.Presence)
+	}
+
+	// Only contacts, and only small statuses
+	rec = doRequest(t, r, "POST", "/api/presence", PresenceUpdate{Statuses: []PresenceShare{{ToUserId: userC.ID, Blob: "x"}}}, tokenA)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("non-contact status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	rec = doRequest(t, r, "POST", "/api/presence", PresenceUpdate{Statuses: []PresenceShare{{ToUserId: userB.ID, Blob: strings.Repeat("x", maxPresenceBlobLength+1)}}}, tokenA)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("oversized status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if list := getPresence(); len(list.Presence) != 1 || list.Presence[0].Blob != "left" {
+		t.Errorf("rejected updates changed presence: %+v", list.Presence)
+	}
+}
+
+func TestGetLocationSnapshot_Paginates(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "me@example.com", "Me")
+
+	// Five contacts share with the user
+	want := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		email := fmt.Sprintf("sender%d@example.com", i)
+		senderToken, sender := createTestUser(t, st, email, "Sender")
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "me@example.com"}, senderToken)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, token)
+		doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+			Locations: []LocationShare{{ToUserId: user.ID, Blob: "blob"}},
+		}, senderToken)
+		want[sender.ID] = true
+	}
+
+	seen := make(map[string]int)
+	var snapshotAt time.Time
+	path := "/api/locations/all?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("too many pages")
+		}
+		rec := doRequest(t, r, "GET", path, nil, token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var page LocationSnapshot
+		json.NewDecoder(rec.Body).Decode(&page)
+
+		if pages == 0 {
+			snapshotAt = page.SnapshotAt
+		} else if !page.SnapshotAt.Equal(snapshotAt) {
+			t.Errorf("snapshotAt changed between pages: %v != %v", page.SnapshotAt, snapshotAt)
+		}
+		for _, loc := range page.Locations {
+			seen[loc.FromUserId]++
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		path = "/api/locations/all?limit=2&cursor=" + url.QueryEscape(*page.NextCursor)
+	}
+
+	if len(seen) != len(want) {
+		t.Errorf("senders seen = %d, want %d", len(seen), len(want))
+	}
+	for id, n := range seen {
+		if !want[id] || n != 1 {
+			t.Errorf("sender %s seen %d times", id, n)
+		}
+	}
+}
+
+func TestGetLocationSnapshot_InvalidCursor(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "me@example.com", "Me")
+
+	rec := doRequest(t, r, "GET", "/api/locations/all?cursor=not-a-cursor", nil, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGetLocations_Paginates(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "me@example.com", "Me")
+
+	// Three contacts share with the user
+	for i := 0; i < 3; i++ {
+		senderToken, _ := createTestUser(t, st, fmt.Sprintf("sender%d@example.com", i), "Sender")
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "me@example.com"}, senderToken)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, token)
+		doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+			Locations: []LocationShare{{ToUserId: user.ID, Blob: "blob"}},
+		}, senderToken)
+	}
+
+	// Unpaged requests still get everything
+	rec := doRequest(t, r, "GET", "/api/locations", nil, token)
+	var all LocationList
+	json.NewDecoder(rec.Body).Decode(&all)
+	if len(all.Locations) != 3 || all.Total != nil || all.NextCursor != nil {
+		t.Errorf("unpaged list = %d locations, total %v, cursor %v; want 3 and no paging fields", len(all.Locations), all.Total, all.NextCursor)
+	}
+
+	seen := make(map[string]bool)
+	path := "/api/locations?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("too many pages")
+		}
+		rec := doRequest(t, r, "GET", path, nil, token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var page LocationList
+		json.NewDecoder(rec.Body).Decode(&page)
+		if page.Total == nil || *page.Total != 3 {
+			t.Errorf("total = %v, want 3", page.Total)
+		}
+		for _, loc := range page.Locations {
+			seen[loc.FromUserId] = true
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		path = "/api/locations?limit=2&cursor=" + url.QueryEscape(*page.NextCursor)
+	}
+	if len(seen) != 3 {
+		t.Errorf("senders seen = %d, want 3", len(seen))
+	}
+
+	for _, query := range []string{"limit=0", "limit=501", "cursor=not-a-cursor"} {
+		if rec := doRequest(t, r, "GET", "/api/locations?"+query, nil, token); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestListContacts_Paginates(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "me@example.com", "Me")
+	for i := 0; i < 5; i++ {
+		email := fmt.Sprintf("friend%d@example.com", i)
+		friendToken, _ := createTestUser(t, st, email, fmt.Sprintf("Friend %d", i))
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: Email(email)}, token)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, friendToken)
+	}
+
+	var names []string
+	path := "/api/contacts?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("too many pages")
+		}
+		rec := doRequest(t, r, "GET", path, nil, token)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var page ContactList
+		json.NewDecoder(rec.Body).Decode(&page)
+		if page.Total == nil || *page.Total != 5 {
+			t.Errorf("total = %v, want 5", page.Total)
+		}
+		for _, c := range page.Contacts {
+			names = append(names, c.Name)
+		}
+		if page.NextOffset == nil {
+			break
+		}
+		path = fmt.Sprintf("/api/contacts?limit=2&offset=%d", *page.NextOffset)
+	}
+	if got := strings.Join(names, ","); got != "Friend 0,Friend 1,Friend 2,Friend 3,Friend 4" {
+		t.Errorf("paged contacts = %s", got)
+	}
+
+	for _, query := range []string{"limit=0", "limit=501", "offset=-1", "limit=2&since=2026-01-01T00:00:00Z"} {
+		if rec := doRequest(t, r, "GET", "/api/contacts?"+query, nil, token); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestShareLocations_Partial(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+	_, userC := createTestUser(t, st, "carol@example.com", "Carol")
+
+	// Only Alice and Bob are contacts
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	shareBody := LocationShareRequest{
+		Locations: []LocationShare{
+			{ToUserId: userB.ID, Blob: "for_bob"},
+			{ToUserId: userC.ID, Blob: "for_carol"},
+		},
+	}
+
+	// Atomic by default: the whole batch is rejected
+	rec = doRequest(t, r, "POST", "/api/locations", shareBody, tokenA)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("atomic status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	rec = doRequest(t, r, "POST", "/api/locations?partial=true", shareBody, tokenA)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("partial status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var results LocationShareResults
+	json.NewDecoder(rec.Body).Decode(&results)
+	if len(results.Results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results.Results))
+	}
+	if !results.Results[0].Ok {
+		t.Errorf("expected Bob to succeed, got %+v", results.Results[0])
+	}
+	if results.Results[1].Ok || results.Results[1].Error == nil || *results.Results[1].Error != "not_a_contact" {
+		t.Errorf("expected Carol to fail with not_a_contact, got %+v", results.Results[1])
+	}
+
+	// Bob received the location
+	rec = doRequest(t, r, "GET", "/api/locations", nil, tokenB)
+	var locations LocationList
+	json.NewDecoder(rec.Body).Decode(&locations)
+	if len(locations.Locations) != 1 {
+		t.Errorf("locations = %d, want 1", len(locations.Locations))
+	}
+}
+
+func TestShareLocations_RemovedByContact(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	// Bob removes Alice, but Alice's client still has him as a contact
+	rec = doRequest(t, r, "DELETE", "/api/contacts/"+userA.ID, nil, tokenB)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("remove status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	shareBody := LocationShareRequest{Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob"}}}
+	rec = doRequest(t, r, "POST", "/api/locations", shareBody, tokenA)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	var errResp Error
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if errResp.Error.Code != "not_a_contact" {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, "not_a_contact")
+	}
+}
+
+func TestShareLocations_Precision(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	getPrecision := func(token, fromID string) LocationPrecision {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/locations", nil, token)
+		var locations LocationList
+		json.NewDecoder(rec.Body).Decode(&locations)
+		for _, loc := range locations.Locations {
+			if loc.FromUserId == fromID {
+				return loc.Precision
+			}
+		}
+		t.Fatalf("no location from %s", fromID)
+		return ""
+	}
+
+	// Labelled share round-trips
+	city := City
+	rec = doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob", Precision: &city}},
+	}, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("share status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+	if got := getPrecision(tokenB, userA.ID); got != City {
+		t.Errorf("precision = %q, want %q", got, City)
+	}
+
+	// Unlabelled shares default to exact, including partial ones
+	rec = doRequest(t, r, "POST", "/api/locations?partial=true", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userA.ID, Blob: "for_alice"}},
+	}, tokenB)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("partial share status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := getPrecision(tokenA, userB.ID); got != Exact {
+		t.Errorf("default precision = %q, want %q", got, Exact)
+	}
+
+	unknown := LocationPrecision("street")
+	rec = doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob", Precision: &unknown}},
+	}, tokenA)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown precision status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
 
-func TestShareAndGetLocations(t *testing.T) {
+func TestShareLocations_Expiry(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	// Create two users and make them contacts
-	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
 	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
 
-	// Set public keys
-	ctx := context.Background()
-	st.Users().SetPublicKey(ctx, userA.ID, "alicePubKey")
-	st.Users().SetPublicKey(ctx, userB.ID, "bobPubKey")
-
-	// Make contacts
-	body := ContactRequestCreate{Email: "bob@example.com"}
-	rec := doRequest(t, r, "POST", "/api/contacts/request", body, tokenA)
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
 	var req ContactRequest
 	json.NewDecoder(rec.Body).Decode(&req)
 	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
 
-	// Alice shares location
-	shareBody := LocationShareRequest{
-		Locations: []LocationShare{
-			{ToUserId: userB.ID, Blob: "encrypted_location_for_bob"},
-		},
+	past := time.Now().Add(-time.Minute)
+	rec = doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob", ExpiresAt: &past}},
+	}, tokenA)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("past expiry status = %d, want %d", rec.Code, http.StatusBadRequest)
 	}
-	rec = doRequest(t, r, "POST", "/api/locations", shareBody, tokenA)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	rec = doRequest(t, r, "POST", "/api/locations", LocationShareRequest{
+		Locations: []LocationShare{{ToUserId: userB.ID, Blob: "for_bob", ExpiresAt: &expires}},
+	}, tokenA)
 	if rec.Code != http.StatusNoContent {
-		t.Errorf("share status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+		t.Fatalf("share status = %d, want %d", rec.Code, http.StatusNoContent)
 	}
 
-	// Bob gets locations
 	rec = doRequest(t, r, "GET", "/api/locations", nil, tokenB)
-	if rec.Code != http.StatusOK {
-		t.Errorf("get locations status = %d, want %d", rec.Code, http.StatusOK)
-	}
-
 	var locations LocationList
 	json.NewDecoder(rec.Body).Decode(&locations)
+	if len(locations.Locations) != 1 || locations.Locations[0].ExpiresAt == nil || !locations.Locations[0].ExpiresAt.Equal(expires) {
+		t.Errorf("locations = %+v, want one expiring at %v", locations.Locations, expires)
+	}
+}
 
-	if len(locations.Locations) != 1 {
-		t.Errorf("locations = %d, want 1", len(locations.Locations))
+func TestShareLocations_MaxBatchBytes(t *testing.T) {
+	batch := LocationShareRequest{Locations: []LocationShare{{ToUserId: "", Blob: strings.Repeat("b", 500)}}}
+	share := func(limit int64, batchSize int) int {
+		t.Helper()
+		server, st := testServer(t, WithMaxShareBatchBytes(limit))
+		r := testRouter(t, server)
+		tokenA, _ := createTestUser(t, st, "alice@example.com", "Alice")
+		tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+		rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+		var req ContactRequest
+		json.NewDecoder(rec.Body).Decode(&req)
+		doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+		batch.Locations[0].ToUserId = userB.ID
+		data, _ := json.Marshal(batch)
+		if len(data) != batchSize {
+			t.Fatalf("batch is %d bytes, want %d", len(data), batchSize)
+		}
+
+		// The user data endpoint isn't affected by the share limit
+		rec = doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Blob: strings.Repeat("d", 2000), Version: 0}, tokenA)
+		if rec.Code != http.StatusOK {
+			t.Errorf("user data status = %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		return doRequest(t, r, "POST", "/api/locations", batch, tokenA).Code
 	}
-	if locations.Locations[0].Blob != "encrypted_location_for_bob" {
-		t.Errorf("blob = %q, want %q", locations.Locations[0].Blob, "encrypted_location_for_bob")
+
+	batch.Locations[0].ToUserId = strings.Repeat("x", 36)
+	data, _ := json.Marshal(batch)
+	size := len(data)
+
+	if code := share(int64(size), size); code != http.StatusNoContent {
+		t.Errorf("batch at the limit status = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := share(int64(size-1), size); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("batch over the limit status = %d, want %d", code, http.StatusRequestEntityTooLarge)
 	}
 }
 
@@ -485,7 +2954,7 @@ func TestDeviceOperations(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
 
-	token, _ := createTestUser(t, st, "test@example.com", "Test")
+	token, user := createTestUser(t, st, "test@example.com", "Test")
 
 	// Initially no devices
 	rec := doRequest(t, r, "GET", "/api/devices", nil, token)
@@ -528,14 +2997,269 @@ func TestDeviceOperations(t *testing.T) {
 		t.Errorf("revoke status = %d, want %d", rec.Code, http.StatusNoContent)
 	}
 
-	// Device should show as revoked
+	// The revoked device's session is over
 	rec = doRequest(t, r, "GET", "/api/devices", nil, token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("list from revoked device status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// Another session sees it as revoked
+	other := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.Sessions().Create(context.Background(), other); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	rec = doRequest(t, r, "GET", "/api/devices", nil, other.Token)
 	json.NewDecoder(rec.Body).Decode(&devices)
 	if devices.Devices[0].IsRevoked == nil || !*devices.Devices[0].IsRevoked {
 		t.Error("expected device to be revoked")
 	}
 }
 
+func TestRenameDevice(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+	otherToken, _ := createTestUser(t, st, "other@example.com", "Other")
+
+	rec := doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "phone", Platform: DeviceCreatePlatformIos}, token)
+	var device DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&device)
+	path := "/api/devices/" + device.Id
+
+	tests := []struct {
+		name  string
+		token string
+		body  DeviceUpdate
+		want  int
+	}{
+		{"empty", token, DeviceUpdate{Name: "  "}, http.StatusBadRequest},
+		{"too long", token, DeviceUpdate{Name: strings.Repeat("x", maxDeviceNameLength)}, http.StatusBadRequest},
+		{"not owner", otherToken, DeviceUpdate{Name: "Mine now"}, http.StatusNotFound},
+		{"ok", token, DeviceUpdate{Name: " Work iPhone "}, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		if rec := doRequest(t, r, "PATCH", path, tt.body, tt.token); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+
+	got, _ := st.Devices().GetByID(context.Background(), device.Id)
+	if got.Name != "Work iPhone" {
+		t.Errorf("name = %q, want %q", got.Name, "Work iPhone")
+	}
+}
+
+func TestRevokeDevice_LastDeviceClearsLocations(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ctx := context.Background()
+
+	_, alice := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, bob := createTestUser(t, st, "bob@example.com", "Bob")
+	req, _ := st.Contacts().CreateRequest(ctx, alice.ID, bob.ID)
+	st.Contacts().AcceptRequest(ctx, req.ID, bob.ID)
+
+	// Registering binds the calling session, so each device gets its own,
+	// and a third session manages them
+	session := func() string {
+		t.Helper()
+		sess := &store.Session{UserID: alice.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		if err := st.Sessions().Create(ctx, sess); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		return sess.Token
+	}
+	var devices []DeviceWithToken
+	for _, name := range []string{"Phone", "Laptop"} {
+		rec := doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: name, Platform: DeviceCreatePlatformIos}, session())
+		var d DeviceWithToken
+		json.NewDecoder(rec.Body).Decode(&d)
+		devices = append(devices, d)
+	}
+	manager := session()
+
+	if err := st.Locations().SetLocations(ctx, alice.ID, []*store.EncryptedLocation{{ToUserID: bob.ID, Blob: "blob"}}); err != nil {
+		t.Fatalf("SetLocations failed: %v", err)
+	}
+	bobLocations := func() int {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/locations", nil, tokenB)
+		var list LocationList
+		json.NewDecoder(rec.Body).Decode(&list)
+		return len(list.Locations)
+	}
+
+	// Another device is still active
+	if rec := doRequest(t, r, "DELETE", "/api/devices/"+devices[0].Id, nil, manager); rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if n := bobLocations(); n != 1 {
+		t.Errorf("Bob sees %d locations with a device left, want 1", n)
+	}
+
+	// The last one goes
+	if rec := doRequest(t, r, "DELETE", "/api/devices/"+devices[1].Id, nil, manager); rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if n := bobLocations(); n != 0 {
+		t.Errorf("Bob sees %d locations after all devices revoked, want 0", n)
+	}
+}
+
+func TestRegisterDevice_UserAgent(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	// Declared in the body
+	regBody := DeviceCreate{Name: "Phone", Platform: DeviceCreatePlatformIos, UserAgent: ptr("Whereish iOS/2.3.1")}
+	rec := doRequest(t, r, "POST", "/api/devices", regBody, token)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var device DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&device)
+	if device.UserAgent == nil || *device.UserAgent != "Whereish iOS/2.3.1" {
+		t.Errorf("register userAgent = %v, want %q", device.UserAgent, "Whereish iOS/2.3.1")
+	}
+
+	// Falls back to the User-Agent header
+	data, _ := json.Marshal(DeviceCreate{Name: "Laptop", Platform: DeviceCreatePlatformCli})
+	req := httptest.NewRequest("POST", "/api/devices", bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", "whereish-cli")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register status = %d, want %d; body = %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	rec = doRequest(t, r, "GET", "/api/devices", nil, token)
+	var devices DeviceList
+	json.NewDecoder(rec.Body).Decode(&devices)
+	got := make(map[string]string)
+	for _, d := range devices.Devices {
+		if d.UserAgent != nil {
+			got[d.Name] = *d.UserAgent
+		}
+	}
+	if got["Phone"] != "Whereish iOS/2.3.1" {
+		t.Errorf("Phone userAgent = %q, want %q", got["Phone"], "Whereish iOS/2.3.1")
+	}
+	if got["Laptop"] != "whereish-cli" {
+		t.Errorf("Laptop userAgent = %q, want %q", got["Laptop"], "whereish-cli")
+	}
+}
+
+func TestListDevices_Sessions(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ctx := context.Background()
+
+	phoneToken, user := createTestUser(t, st, "test@example.com", "Test")
+	laptop := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.Sessions().Create(ctx, laptop); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	// Registering binds the phone's session to the device
+	rec := doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "Phone", Platform: DeviceCreatePlatformIos}, phoneToken)
+	var phone DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&phone)
+
+	listPhone := func() Device {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/devices", nil, laptop.Token)
+		var devices DeviceList
+		json.NewDecoder(rec.Body).Decode(&devices)
+		if len(devices.Devices) != 1 {
+			t.Fatalf("devices = %+v, want the phone", devices.Devices)
+		}
+		return devices.Devices[0]
+	}
+
+	got := listPhone()
+	if got.ActiveSessions == nil || *got.ActiveSessions != 1 || got.SessionExpiresAt == nil {
+		t.Errorf("phone sessions = %v, expiry %v; want 1 with an expiry", got.ActiveSessions, got.SessionExpiresAt)
+	}
+
+	doRequest(t, r, "POST", "/api/auth/logout", nil, phoneToken)
+
+	got = listPhone()
+	if got.ActiveSessions == nil || *got.ActiveSessions != 0 || got.SessionExpiresAt != nil {
+		t.Errorf("after logout phone sessions = %v, expiry %v; want 0 and no expiry", got.ActiveSessions, got.SessionExpiresAt)
+	}
+}
+
+func TestPauseDevice(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	ctx := context.Background()
+
+	// The phone's session is bound to it; the laptop session manages it
+	phoneToken, user := createTestUser(t, st, "test@example.com", "Test")
+	laptop := &store.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := st.Sessions().Create(ctx, laptop); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	rec := doRequest(t, r, "POST", "/api/devices", DeviceCreate{Name: "Phone", Platform: DeviceCreatePlatformIos}, phoneToken)
+	var phone DeviceWithToken
+	json.NewDecoder(rec.Body).Decode(&phone)
+
+	rec = doRequest(t, r, "POST", "/api/devices/"+phone.Id+"/pause", DevicePause{Until: time.Now().Add(-time.Minute)}, laptop.Token)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("pause in the past status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	until := time.Now().Add(time.Hour)
+	rec = doRequest(t, r, "POST", "/api/devices/"+phone.Id+"/pause", DevicePause{Until: until}, laptop.Token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("pause status = %d, want %d; body = %s", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+
+	// The paused device is blocked, other sessions aren't
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, phoneToken)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "device_paused") {
+		t.Errorf("paused device status = %d, body = %s; want 403 device_paused", rec.Code, rec.Body.String())
+	}
+	rec = doRequest(t, r, "GET", "/api/devices", nil, laptop.Token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var devices DeviceList
+	json.NewDecoder(rec.Body).Decode(&devices)
+	if len(devices.Devices) != 1 || devices.Devices[0].PausedUntil == nil || !devices.Devices[0].PausedUntil.Equal(until) {
+		t.Errorf("devices = %+v, want phone paused until %v", devices.Devices, until)
+	}
+
+	rec = doRequest(t, r, "POST", "/api/devices/"+phone.Id+"/unpause", nil, laptop.Token)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unpause status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, phoneToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("unpaused device status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// A pause that has run out no longer blocks
+	if err := st.Devices().Pause(ctx, phone.Id, user.ID, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("failed to pause device: %v", err)
+	}
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, phoneToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("device after pause ended status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	rec = doRequest(t, r, "POST", "/api/devices/unknown/pause", DevicePause{Until: until}, laptop.Token)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown device status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
 // =============================================================================
 // User Data Tests
 // =============================================================================
@@ -590,10 +3314,210 @@ func TestUserData(t *testing.T) {
 	}
 }
 
+func TestConditionalGet(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+	doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Version: 0, Blob: "first"}, token)
+	doRequest(t, r, "PUT", "/api/identity/backup", IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		Kdf:        "PBKDF2-SHA256",
+		Iterations: 100000,
+		Salt:       "dGVzdHNhbHQ=",
+		Iv:         "dGVzdGl2",
+		Payload:    "ZW5jcnlwdGVk",
+	}, token)
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/api/user-data", "/api/identity/backup"} {
+		rec := get(path, "")
+		etag := rec.Header().Get("ETag")
+		if rec.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s: status = %d, ETag = %q; want 200 with an ETag", path, rec.Code, etag)
+		}
+
+		for _, header := range []string{etag, `"other", W/` + etag, "*"} {
+			rec = get(path, header)
+			if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+				t.Errorf("%s with If-None-Match %s: status = %d, body %q; want a bare 304", path, header, rec.Code, rec.Body.String())
+			}
+		}
+		if rec := get(path, `"stale"`); rec.Code != http.StatusOK {
+			t.Errorf("%s with a stale ETag: status = %d, want 200", path, rec.Code)
+		}
+	}
+
+	// A new version gets a new ETag
+	rec := get("/api/user-data", "")
+	etag := rec.Header().Get("ETag")
+	doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Version: 1, Blob: "second"}, token)
+	if rec := get("/api/user-data", etag); rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("after update: status = %d, ETag = %q; want 200 with a new ETag", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestUserData_CreateConflictReportsVersion(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "test@example.com", "Test")
+
+	// Another device created the data first
+	doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Version: 0, Blob: "first"}, token)
+
+	rec := doRequest(t, r, "PUT", "/api/user-data", UserDataUpdate{Version: 0, Blob: "second"}, token)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	var conflict ConflictError
+	json.NewDecoder(rec.Body).Decode(&conflict)
+	if conflict.CurrentVersion != 1 {
+		t.Errorf("currentVersion = %d, want 1", conflict.CurrentVersion)
+	}
+}
+
 // =============================================================================
 // Delete Account Tests
 // =============================================================================
 
+func TestRecoveryCodes(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	remaining := func() int {
+		t.Helper()
+		rec := doRequest(t, r, "GET", "/api/auth/recovery-codes", nil, token)
+		var status RecoveryCodeStatus
+		json.NewDecoder(rec.Body).Decode(&status)
+		return status.Remaining
+	}
+	if n := remaining(); n != 0 {
+		t.Errorf("remaining before generating = %d, want 0", n)
+	}
+
+	rec := doRequest(t, r, "POST", "/api/auth/recovery-codes", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("generate status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var codes RecoveryCodes
+	json.NewDecoder(rec.Body).Decode(&codes)
+	if len(codes.Codes) != recoveryCodeCount {
+		t.Fatalf("codes = %d, want %d", len(codes.Codes), recoveryCodeCount)
+	}
+
+	// Case and dashes don't matter
+	code := strings.ToLower(strings.ReplaceAll(codes.Codes[0], "-", ""))
+	rec = doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: code}, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("recovery login status = %d, want %d; body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var login LoginResponse
+	json.NewDecoder(rec.Body).Decode(&login)
+	if login.User.Id != user.ID {
+		t.Errorf("logged in as %s, want %s", login.User.Id, user.ID)
+	}
+	if rec := doRequest(t, r, "GET", "/api/me", nil, login.Token); rec.Code != http.StatusOK {
+		t.Errorf("recovered session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if n := remaining(); n != recoveryCodeCount-1 {
+		t.Errorf("remaining after use = %d, want %d", n, recoveryCodeCount-1)
+	}
+
+	// A code works once
+	rec = doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: codes.Codes[0]}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("reused code status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// Regenerating replaces the old codes
+	doRequest(t, r, "POST", "/api/auth/recovery-codes", nil, token)
+	rec = doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: codes.Codes[1]}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("replaced code status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if n := remaining(); n != recoveryCodeCount {
+		t.Errorf("remaining after regenerating = %d, want %d", n, recoveryCodeCount)
+	}
+}
+
+func TestRecoveryCodes_SoftDeletedUser(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	token, user := createTestUser(t, st, "test@example.com", "Test")
+
+	rec := doRequest(t, r, "POST", "/api/auth/recovery-codes", nil, token)
+	var codes RecoveryCodes
+	json.NewDecoder(rec.Body).Decode(&codes)
+	if len(codes.Codes) == 0 {
+		t.Fatalf("no codes generated; status = %d", rec.Code)
+	}
+
+	if err := st.Users().SoftDelete(context.Background(), user.ID); err != nil {
+		t.Fatalf("SoftDelete failed: %v", err)
+	}
+
+	rec = doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: codes.Codes[0]}, "")
+	var errResp Error
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if rec.Code != http.StatusUnauthorized || errResp.Error.Code != "invalid_code" {
+		t.Errorf("code of deleted user = %d %q, want 401 invalid_code", rec.Code, errResp.Error.Code)
+	}
+}
+
+func TestRecoveryCodes_RateLimit(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+	createTestUser(t, st, "test@example.com", "Test")
+
+	for i := 0; i < maxRecoveryFailures; i++ {
+		doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: "AAAA-BBBB-CCCC-DDDD"}, "")
+	}
+	rec := doRequest(t, r, "POST", "/api/auth/recovery", RecoveryLoginRequest{Code: "AAAA-BBBB-CCCC-DDDD"}, "")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status after %d failures = %d, want %d", maxRecoveryFailures, rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestRecoveryCodes_RateLimitSpoofedIP(t *testing.T) {
+	server, st := testServer(t)
+	r := RealIP(nil)(testRouter(t, server))
+	createTestUser(t, st, "test@example.com", "Test")
+
+	// A new X-Forwarded-For per attempt doesn't reset the count when the
+	// client isn't a trusted proxy
+	attempt := func(i int) int {
+		t.Helper()
+		body, _ := json.Marshal(RecoveryLoginRequest{Code: "AAAA-BBBB-CCCC-DDDD"})
+		req := httptest.NewRequest("POST", "/api/auth/recovery", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	for i := 0; i < maxRecoveryFailures; i++ {
+		attempt(i)
+	}
+	if code := attempt(maxRecoveryFailures); code != http.StatusTooManyRequests {
+		t.Errorf("status after %d spoofed failures = %d, want %d", maxRecoveryFailures, code, http.StatusTooManyRequests)
+	}
+}
+
 func TestDeleteAccount(t *testing.T) {
 	server, st := testServer(t)
 	r := testRouter(t, server)
@@ -618,3 +3542,33 @@ func TestDeleteAccount(t *testing.T) {
 		t.Errorf("expected 401 after account delete, got %d", rec.Code)
 	}
 }
+
+func TestDeleteAccount_RemovesFromContacts(t *testing.T) {
+	server, st := testServer(t)
+	r := testRouter(t, server)
+
+	tokenA, userA := createTestUser(t, st, "alice@example.com", "Alice")
+	tokenB, userB := createTestUser(t, st, "bob@example.com", "Bob")
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: "bob@example.com"}, tokenA)
+	var req ContactRequest
+	json.NewDecoder(rec.Body).Decode(&req)
+	doRequest(t, r, "POST", "/api/contacts/requests/"+req.Id+"/accept", nil, tokenB)
+
+	rec = doRequest(t, r, "DELETE", "/api/auth/account", nil, tokenA)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	rec = doRequest(t, r, "GET", "/api/contacts", nil, tokenB)
+	var contactsB ContactList
+	json.NewDecoder(rec.Body).Decode(&contactsB)
+	if len(contactsB.Contacts) != 0 {
+		t.Errorf("Bob contacts after Alice deleted = %d, want 0", len(contactsB.Contacts))
+	}
+
+	events, _ := st.Audit().ListForUser(context.Background(), userB.ID)
+	if len(events) != 1 || events[0].Action != store.AuditContactRemoved || events[0].TargetID != userA.ID {
+		t.Errorf("Bob audit events = %+v, want one contact.removed for Alice", events)
+	}
+}
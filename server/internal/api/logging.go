@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// requestLog is the per-request logging state RequestLogger puts in the
+// context. AuthMiddleware fills in the user once the session is known, so
+// the request line carries it even though the middleware runs outside
+// authentication.
+type requestLog struct {
+	logger *slog.Logger
+	userID string
+}
+
+const requestLogKey contextKey = "requestLog"
+
+// RequestLogger logs one line per request with its ID, method, path,
+// status, size, latency and, when authenticated, user. Handlers log
+// through loggerFrom so their lines carry the same request ID, which is
+// also returned to the client in X-Request-Id. It must run after
+// middleware.RequestID.
+func RequestLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			reqID := middleware.GetReqID(r.Context())
+			if reqID != "" {
+				w.Header().Set(middleware.RequestIDHeader, reqID)
+			}
+
+			entry := &requestLog{logger: logger.With(
+				"request_id", reqID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), requestLogKey, entry)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			}
+			attrs := []any{
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration", time.Since(start),
+			}
+			if entry.userID != "" {
+				attrs = append(attrs, "user_id", entry.userID)
+			}
+			entry.logger.Log(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// setLogUser records the authenticated user for the request line
+func setLogUser(ctx context.Context, userID string) {
+	if entry, ok := ctx.Value(requestLogKey).(*requestLog); ok {
+		entry.userID = userID
+	}
+}
+
+// loggerFrom returns a logger tagged with the request's ID, method, path
+// and user. Outside RequestLogger it falls back to the default logger.
+func loggerFrom(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if entry, ok := ctx.Value(requestLogKey).(*requestLog); ok {
+		logger = entry.logger
+	}
+	if userID, ok := ctx.Value(userIDKey).(string); ok {
+		logger = logger.With("user_id", userID)
+	}
+	return logger
+}
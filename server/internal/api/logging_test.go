@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// logLines decodes the JSON lines a test logger wrote
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q isn't JSON: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestLogger(t *testing.T) {
+	server, st := testServer(t)
+	token, user := createTestUser(t, st, "alice@example.com", "Alice")
+
+	var buf bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(RequestLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	r.Use(server.AuthMiddleware)
+	r.Get("/api/fail", func(w http.ResponseWriter, r *http.Request) {
+		loggerFrom(r.Context()).Error("Error doing something", "error", "boom")
+		writeError(w, http.StatusInternalServerError, "internal_error", "Internal server error")
+	})
+
+	rec := doRequest(t, r, "GET", "/api/fail", nil, token)
+	reqID := rec.Header().Get("X-Request-Id")
+	if reqID == "" {
+		t.Fatal("response has no X-Request-Id")
+	}
+
+	// The handler's line and the request line share the request ID
+	lines := logLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if line["request_id"] != reqID || line["user_id"] != user.ID || line["path"] != "/api/fail" {
+			t.Errorf("log line = %v, want request %s by %s", line, reqID, user.ID)
+		}
+	}
+	if lines[0]["msg"] != "Error doing something" || lines[0]["error"] != "boom" {
+		t.Errorf("handler line = %v", lines[0])
+	}
+	request := lines[1]
+	if request["msg"] != "request" || request["level"] != "ERROR" || request["status"] != float64(500) || request["method"] != "GET" {
+		t.Errorf("request line = %v", request)
+	}
+	if _, ok := request["duration"]; !ok {
+		t.Errorf("request line has no duration: %v", request)
+	}
+
+	// Unauthenticated requests are logged without a user
+	buf.Reset()
+	rec = doRequest(t, r, "GET", "/api/fail", nil, "")
+	lines = logLines(t, &buf)
+	if len(lines) != 1 || lines[0]["status"] != float64(401) || lines[0]["level"] != "INFO" {
+		t.Errorf("unauthenticated request logged as %v", lines)
+	}
+	if _, ok := lines[0]["user_id"]; ok {
+		t.Errorf("unauthenticated request logged with a user: %v", lines[0])
+	}
+}
@@ -0,0 +1,387 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ConcurrencyLimit caps the number of requests being served at once.
+// When all slots are taken the request is rejected with 503 instead of
+// queueing. Health checks and event streams are never limited. A max of 0
+// disables the limit.
+func ConcurrencyLimit(max int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if max <= 0 {
+			return next
+		}
+
+		sem := make(chan struct{}, max)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Event streams stay open, so they'd hold a slot indefinitely
+			if isHealthPath(r.URL.Path) || isStreamPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				next.ServeHTTP(w, r)
+			default:
+				w.Header().Set("Retry-After", "1")
+				writeError(w, http.StatusServiceUnavailable, "server_busy", "Server is busy, try again later")
+			}
+		})
+	}
+}
+
+// ClientVersionHeader carries the client's version, e.g. "1.4.0"
+const ClientVersionHeader = "X-Client-Version"
+
+// MinClientVersion rejects clients older than min with 426 so broken old
+// releases can be retired. Requests without a version header pass unless
+// strict is set; unparseable versions are treated as too old. Health checks
+// are never checked. An empty min disables the check.
+func MinClientVersion(min string, strict bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if min == "" {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHealthPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			version := r.Header.Get(ClientVersionHeader)
+			if version == "" && !strict {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cmp, ok := compareVersions(version, min); !ok || cmp < 0 {
+				writeError(w, http.StatusUpgradeRequired, "upgrade_required",
+					fmt.Sprintf("Client version %s or later is required", min))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// compareVersions compares dotted numeric versions like "1.10.2", returning
+// -1, 0 or 1. Missing components count as zero and anything after a "-" or
+// "+" is ignored. ok is false if either version isn't in that form.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	pa, ok := parseVersion(a)
+	if !ok {
+		return 0, false
+	}
+	pb, ok := parseVersion(b)
+	if !ok {
+		return 0, false
+	}
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// RequireHTTPS rejects requests that didn't arrive over HTTPS. A request
+// counts as HTTPS if it came over TLS directly, or from one of
+// trustedProxies with X-Forwarded-Proto: https; the header is ignored from
+// anyone else so clients can't spoof it. API calls get 403, other GETs are
+// redirected to HTTPS. Health checks are never checked, so load balancers
+// can probe over plain HTTP. required=false disables the check.
+func RequireHTTPS(required bool, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !required {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isHealthPath(r.URL.Path) || r.TLS != nil || forwardedHTTPS(r, trustedProxies) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !strings.HasPrefix(r.URL.Path, "/api/") && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				http.Redirect(w, r, "https://"+r.Host+r.URL.RequestURI(), http.StatusMovedPermanently)
+				return
+			}
+			writeError(w, http.StatusForbidden, "https_required", "HTTPS is required")
+		})
+	}
+}
+
+// forwardedHTTPS reports whether a trusted proxy forwarded r from an HTTPS
+// connection. It must run before anything that rewrites RemoteAddr from
+// forwarding headers.
+func forwardedHTTPS(r *http.Request, trustedProxies []*net.IPNet) bool {
+	if !trustedIP(net.ParseIP(clientIP(r)), trustedProxies) {
+		return false
+	}
+	// The nearest proxy's value is last if several were appended
+	values := strings.Split(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(values[len(values)-1]), "https")
+}
+
+// RealIP sets RemoteAddr to the client's IP as reported by
+// X-Forwarded-For or X-Real-IP, but only when the request came from one of
+// trustedProxies. Anyone else's headers are ignored and the peer address
+// is kept, so clients can't pick the IP that login limits are keyed on.
+func RealIP(trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := forwardedIP(r, trustedProxies); ip != "" {
+				r.RemoteAddr = ip
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP returns the client IP that trusted proxies forwarded r for,
+// or "" if r didn't come from a trusted proxy. X-Forwarded-For is read
+// from the right, skipping trusted hops, since earlier entries are
+// whatever the client sent.
+func forwardedIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	if !trustedIP(net.ParseIP(clientIP(r)), trustedProxies) {
+		return ""
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(hops[i]))
+			if ip == nil {
+				return ""
+			}
+			if i == 0 || !trustedIP(ip, trustedProxies) {
+				return ip.String()
+			}
+		}
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// trustedIP reports whether ip is in one of trustedProxies
+func trustedIP(ip net.IP, trustedProxies []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrustedProxies parses a comma-separated list of proxy IPs and CIDR
+// ranges, e.g. "10.0.0.0/8, 127.0.0.1"
+func ParseTrustedProxies(list string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Methods and headers browsers may send cross-origin, and the response
+// headers beyond the safelisted ones scripts may read
+var (
+	corsMethods        = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	corsHeaders        = []string{"Authorization", "Content-Type", "If-None-Match", ClientVersionHeader}
+	corsExposedHeaders = []string{"ETag", "Retry-After", middleware.RequestIDHeader}
+)
+
+// CORSOrigins is the set of origins browsers may call the API from
+type CORSOrigins struct {
+	any     bool
+	origins map[string]bool
+}
+
+// ParseCORSOrigins parses a comma-separated list of origins, such as
+// "https://app.example.com, http://localhost:3000". "*" allows any origin.
+func ParseCORSOrigins(list string) (CORSOrigins, error) {
+	o := CORSOrigins{origins: make(map[string]bool)}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			o.any = true
+			continue
+		}
+		u, err := url.Parse(entry)
+		if err != nil || u.Scheme == "" || u.Host == "" || strings.TrimSuffix(u.Path, "/") != "" {
+			return CORSOrigins{}, fmt.Errorf("invalid origin %q (use scheme://host[:port])", entry)
+		}
+		o.origins[normalizeOrigin(entry)] = true
+	}
+	return o, nil
+}
+
+// Any reports whether every origin is allowed
+func (o CORSOrigins) Any() bool {
+	return o.any
+}
+
+func (o CORSOrigins) allows(origin string) bool {
+	return o.any || o.origins[normalizeOrigin(origin)]
+}
+
+// normalizeOrigin lowercases an origin and drops a trailing slash, so
+// allowlist entries match however they were typed
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
+
+// CORS lets browsers call the API from the allowed origins and answers
+// preflight requests. The request's Origin is echoed back only if it's
+// allowed; other origins get no CORS headers, and their preflights get
+// 403, so the browser refuses the call. With credentials set, allowed
+// origins may send cookies and other credentials, except when any origin
+// is allowed, where that would let any site act as the user. With debug
+// set, each preflight is logged with its origin and requested method and
+// headers, along with the reason the browser will refuse it.
+func CORS(origins CORSOrigins, credentials, debug bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			allowed := origin != "" && origins.allows(origin)
+
+			if origins.any {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				// Responses differ by origin, so caches must key on it
+				w.Header().Add("Vary", "Origin")
+				if allowed {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+					if credentials {
+						w.Header().Set("Access-Control-Allow-Credentials", "true")
+					}
+				}
+			}
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(corsMethods, ", "))
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(corsHeaders, ", "))
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+			}
+
+			if r.Method == "OPTIONS" {
+				if debug {
+					logPreflight(r, allowed)
+				}
+				if origin != "" && !allowed {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// logPreflight logs a CORS preflight and whether it will be refused
+func logPreflight(r *http.Request, originAllowed bool) {
+	origin := r.Header.Get("Origin")
+	method := r.Header.Get("Access-Control-Request-Method")
+	headers := r.Header.Get("Access-Control-Request-Headers")
+	logger := loggerFrom(r.Context()).With("origin", origin)
+	logger.Info("CORS preflight", "request_method", method, "request_headers", headers)
+
+	var reasons []string
+	if origin != "" && !originAllowed {
+		reasons = append(reasons, "origin not in ALLOWED_ORIGINS")
+	}
+	if method != "" && !containsFold(corsMethods, method) {
+		reasons = append(reasons, fmt.Sprintf("method %s not allowed", method))
+	}
+	for _, header := range strings.Split(headers, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !containsFold(corsHeaders, header) {
+			reasons = append(reasons, fmt.Sprintf("header %s not allowed", header))
+		}
+	}
+	if len(reasons) > 0 {
+		logger.Warn("CORS preflight rejected", "reason", strings.Join(reasons, "; "))
+	}
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
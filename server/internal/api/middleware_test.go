@@ -0,0 +1,469 @@
+package api
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// Concurrency Limit Tests
+// =============================================================================
+
+func TestConcurrencyLimit(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blocking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	h := ConcurrencyLimit(1)(blocking)
+
+	// First request holds the only slot
+	done := make(chan int)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/contacts", nil))
+		done <- rec.Code
+	}()
+	<-started
+
+	// Second request is rejected while the first is in flight
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/contacts", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header")
+	}
+
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("first request status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestConcurrencyLimit_HealthExempt(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{})
+	h := ConcurrencyLimit(1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		close(started)
+		<-release
+	}))
+
+	go h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/contacts", nil))
+	<-started
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("health status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestConcurrencyLimit_StreamsExempt(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	started := make(chan struct{}, 2)
+	h := ConcurrencyLimit(1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStreamPath(r.URL.Path) {
+			started <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Open streams don't take up the only slot
+	for _, path := range []string{"/api/events", "/api/locations/stream"} {
+		go h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+		<-started
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/contacts", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status with streams open = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// =============================================================================
+// Client Version Tests
+// =============================================================================
+
+func TestMinClientVersion(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		strict  bool
+		path    string
+		version string
+		want    int
+	}{
+		{"old version", false, "/api/contacts", "1.1.9", http.StatusUpgradeRequired},
+		{"current version", false, "/api/contacts", "1.2.0", http.StatusOK},
+		{"newer version", false, "/api/contacts", "1.10.0", http.StatusOK},
+		{"pre-release suffix", false, "/api/contacts", "1.2.0-beta.1", http.StatusOK},
+		{"unparseable version", false, "/api/contacts", "latest", http.StatusUpgradeRequired},
+		{"missing header", false, "/api/contacts", "", http.StatusOK},
+		{"missing header strict", true, "/api/contacts", "", http.StatusUpgradeRequired},
+		{"health exempt", true, "/api/health", "1.0.0", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := MinClientVersion("1.2", tt.strict)(ok)
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.version != "" {
+				req.Header.Set(ClientVersionHeader, tt.version)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinClientVersion_Disabled(t *testing.T) {
+	h := MinClientVersion("", true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/contacts", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// =============================================================================
+// Require HTTPS Tests
+// =============================================================================
+
+func TestRequireHTTPS(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.5")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		proto  string
+		path   string
+		want   int
+	}{
+		{"forwarded https from proxy", "10.1.2.3:5000", "https", "/api/contacts", http.StatusOK},
+		{"forwarded http from proxy", "10.1.2.3:5000", "http", "/api/contacts", http.StatusForbidden},
+		{"single proxy IP", "192.168.1.5:5000", "https", "/api/contacts", http.StatusOK},
+		{"no header from proxy", "10.1.2.3:5000", "", "/api/contacts", http.StatusForbidden},
+		{"spoofed header from client", "203.0.113.9:5000", "https", "/api/contacts", http.StatusForbidden},
+		{"nearest proxy wins", "10.1.2.3:5000", "https, http", "/api/contacts", http.StatusForbidden},
+		{"non-API redirected", "203.0.113.9:5000", "", "/index.html", http.StatusMovedPermanently},
+		{"health exempt", "203.0.113.9:5000", "", "/api/health", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := RequireHTTPS(true, proxies)(ok)
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.RemoteAddr = tt.remote
+			if tt.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireHTTPS_Disabled(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := RequireHTTPS(false, nil)(ok)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/api/contacts", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    string
+		realIP string
+		want   string
+	}{
+		{"forwarded by proxy", "10.1.2.3:5000", "198.51.100.7", "", "198.51.100.7"},
+		{"spoofed by client", "203.0.113.9:5000", "198.51.100.7", "", "203.0.113.9:5000"},
+		{"X-Real-IP spoofed by client", "203.0.113.9:5000", "", "198.51.100.7", "203.0.113.9:5000"},
+		{"X-Real-IP from proxy", "10.1.2.3:5000", "", "198.51.100.7", "198.51.100.7"},
+		{"client prepends a hop", "10.1.2.3:5000", "1.2.3.4, 198.51.100.7", "", "198.51.100.7"},
+		{"trusted hops skipped", "10.1.2.3:5000", "198.51.100.7, 10.9.9.9", "", "198.51.100.7"},
+		{"malformed hop", "10.1.2.3:5000", "not-an-ip", "", "10.1.2.3:5000"},
+		{"no header from proxy", "10.1.2.3:5000", "", "", "10.1.2.3:5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := RealIP(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			req := httptest.NewRequest("GET", "/api/contacts", nil)
+			req.RemoteAddr = tt.remote
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if got != tt.want {
+				t.Errorf("RemoteAddr = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	if _, err := ParseTrustedProxies("10.0.0.0/8, proxy.internal"); err == nil {
+		t.Error("expected error for a hostname")
+	}
+}
+
+// =============================================================================
+// CORS Tests
+// =============================================================================
+
+// captureLog redirects the default logger for the rest of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func preflight(method, headers string) *http.Request {
+	req := httptest.NewRequest("OPTIONS", "/api/contacts", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", method)
+	req.Header.Set("Access-Control-Request-Headers", headers)
+	return req
+}
+
+// appOrigins allows the origin preflight sends from
+func appOrigins(t *testing.T) CORSOrigins {
+	t.Helper()
+	origins, err := ParseCORSOrigins("https://app.example.com, http://localhost:3000")
+	if err != nil {
+		t.Fatalf("ParseCORSOrigins failed: %v", err)
+	}
+	return origins
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("preflight reached the handler")
+	})
+	h := CORS(appOrigins(t), false, false)(next)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight("PUT", "authorization, content-type"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Allow-Origin = %q, want the request origin", got)
+	}
+	if got := rec.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("Vary = %q, want Origin", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Allow-Credentials = %q without credentials enabled", got)
+	}
+}
+
+func TestCORS_Patch(t *testing.T) {
+	logs := captureLog(t)
+	h := CORS(appOrigins(t), false, true)(http.NotFoundHandler())
+
+	// PATCH /devices/{id} and PATCH /me are called from web clients
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight("PATCH", "authorization, content-type"))
+	if !containsFold(strings.Split(rec.Header().Get("Access-Control-Allow-Methods"), ", "), "PATCH") {
+		t.Errorf("Allow-Methods = %q, want PATCH", rec.Header().Get("Access-Control-Allow-Methods"))
+	}
+	if strings.Contains(logs.String(), "rejected") {
+		t.Errorf("PATCH preflight logged as rejected:\n%s", logs.String())
+	}
+}
+
+func TestCORS_ConditionalRequests(t *testing.T) {
+	h := CORS(appOrigins(t), false, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+	}))
+
+	// Browsers may send If-None-Match...
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight("GET", "authorization, if-none-match"))
+	if !containsFold(strings.Split(rec.Header().Get("Access-Control-Allow-Headers"), ", "), "If-None-Match") {
+		t.Errorf("Allow-Headers = %q, want If-None-Match", rec.Header().Get("Access-Control-Allow-Headers"))
+	}
+
+	// ...and scripts may read the ETag and the other headers clients act on
+	req := httptest.NewRequest("GET", "/api/contacts", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	exposed := strings.Split(rec.Header().Get("Access-Control-Expose-Headers"), ", ")
+	for _, header := range []string{"ETag", "Retry-After", "X-Request-Id"} {
+		if !containsFold(exposed, header) {
+			t.Errorf("Expose-Headers = %q, missing %s", exposed, header)
+		}
+	}
+}
+
+func TestCORS_UnlistedOrigin(t *testing.T) {
+	h := CORS(appOrigins(t), true, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := preflight("GET", "")
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("preflight status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	req = httptest.NewRequest("GET", "/api/contacts", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials"} {
+		if got := rec.Header().Get(header); got != "" {
+			t.Errorf("%s = %q for an unlisted origin", header, got)
+		}
+	}
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	h := CORS(appOrigins(t), true, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Origins match regardless of case
+	req := httptest.NewRequest("GET", "/api/contacts", nil)
+	req.Header.Set("Origin", "http://LOCALHOST:3000")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "http://LOCALHOST:3000" {
+		t.Errorf("Allow-Origin = %q, want the request origin", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Allow-Credentials = %q, want true", got)
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	origins, err := ParseCORSOrigins("*")
+	if err != nil || !origins.Any() {
+		t.Fatalf("ParseCORSOrigins(*) = %+v, %v", origins, err)
+	}
+	h := CORS(origins, true, false)(http.NotFoundHandler())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, preflight("GET", ""))
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Allow-Origin = %q, want *", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Allow-Credentials = %q with any origin allowed", got)
+	}
+}
+
+func TestParseCORSOrigins_Invalid(t *testing.T) {
+	for _, list := range []string{"app.example.com", "https://app.example.com/path", "https://"} {
+		if _, err := ParseCORSOrigins(list); err == nil {
+			t.Errorf("ParseCORSOrigins(%q) succeeded, want error", list)
+		}
+	}
+}
+
+func TestCORS_DebugLogsRejection(t *testing.T) {
+	logs := captureLog(t)
+	h := CORS(appOrigins(t), false, true)(http.NotFoundHandler())
+
+	h.ServeHTTP(httptest.NewRecorder(), preflight("PROPFIND", "Authorization, X-Custom"))
+
+	out := logs.String()
+	for _, want := range []string{
+		"origin=https://app.example.com",
+		"CORS preflight rejected",
+		"method PROPFIND not allowed",
+		"header X-Custom not allowed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "header Authorization") {
+		t.Errorf("allowed header reported as rejected:\n%s", out)
+	}
+}
+
+func TestCORS_DebugAllowedPreflight(t *testing.T) {
+	logs := captureLog(t)
+	h := CORS(appOrigins(t), false, true)(http.NotFoundHandler())
+
+	h.ServeHTTP(httptest.NewRecorder(), preflight("POST", "authorization, content-type, x-client-version"))
+
+	if !strings.Contains(logs.String(), `msg="CORS preflight"`) {
+		t.Errorf("preflight not logged:\n%s", logs.String())
+	}
+	if strings.Contains(logs.String(), "rejected") {
+		t.Errorf("allowed preflight logged as rejected:\n%s", logs.String())
+	}
+}
+
+func TestCORS_NoLogsWithoutDebug(t *testing.T) {
+	logs := captureLog(t)
+	h := CORS(appOrigins(t), false, false)(http.NotFoundHandler())
+
+	h.ServeHTTP(httptest.NewRecorder(), preflight("PROPFIND", "X-Custom"))
+
+	if logs.Len() != 0 {
+		t.Errorf("expected no logs, got:\n%s", logs.String())
+	}
+}
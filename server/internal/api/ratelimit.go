@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter decides whether the holder of a key, such as a user ID, may
+// act now. Implementations may keep state in memory for a single server or
+// in a shared store.
+type RateLimiter interface {
+	// Allow takes one action from key's allowance. If none is left it
+	// returns false and how long until there will be.
+	Allow(ctx context.Context, key string) (ok bool, retryAfter time.Duration, err error)
+}
+
+// Rate is a number of actions allowed per period
+type Rate struct {
+	Count  int
+	Period time.Duration
+}
+
+// ParseRate parses a rate like "20/1h" or "5/m". An empty string or "0"
+// means no limit and returns a zero Rate.
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return Rate{}, nil
+	}
+
+	count, period, ok := strings.Cut(s, "/")
+	n, err := strconv.Atoi(count)
+	if !ok || err != nil || n < 0 {
+		return Rate{}, fmt.Errorf("invalid rate %q (use count/period, e.g. 20/1h)", s)
+	}
+	// Allow a bare unit, as in "5/m"
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("invalid rate period in %q", s)
+	}
+	if n == 0 {
+		return Rate{}, nil
+	}
+	return Rate{Count: n, Period: d}, nil
+}
+
+// tokenBucketLimiter is an in-memory RateLimiter. Each key has a bucket of
+// Count tokens, refilled evenly over Period, so bursts up to Count are
+// allowed. State is lost on restart.
+type tokenBucketLimiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewTokenBucketLimiter returns an in-memory limiter allowing rate per key
+func NewTokenBucketLimiter(rate Rate) RateLimiter {
+	return &tokenBucketLimiter{
+		rate:    rate,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (l *tokenBucketLimiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	capacity := float64(l.rate.Count)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(capacity, b.tokens+l.refill(now.Sub(b.updated)))
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	perToken := l.rate.Period / time.Duration(l.rate.Count)
+	return false, time.Duration((1 - b.tokens) * float64(perToken)), nil
+}
+
+// refill is how many tokens accrue over elapsed
+func (l *tokenBucketLimiter) refill(elapsed time.Duration) float64 {
+	return float64(elapsed) / float64(l.rate.Period) * float64(l.rate.Count)
+}
+
+// sweep drops buckets that have refilled completely, at most once a
+// period, so keys that stop acting don't accumulate. Callers hold mu.
+func (l *tokenBucketLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.rate.Period {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.rate.Period {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// retryAfterSeconds renders a wait for the Retry-After header, rounding up
+// so clients don't retry early
+func retryAfterSeconds(d time.Duration) string {
+	secs := int((d + time.Second - 1) / time.Second)
+	return strconv.Itoa(max(secs, 1))
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	for in, want := range map[string]Rate{
+		"":       {},
+		"0":      {},
+		"0/1h":   {},
+		"20/1h":  {Count: 20, Period: time.Hour},
+		"5/m":    {Count: 5, Period: time.Minute},
+		" 3/30s": {Count: 3, Period: 30 * time.Second},
+	} {
+		if got, err := ParseRate(in); err != nil || got != want {
+			t.Errorf("ParseRate(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"20", "x/1h", "-1/1h", "5/", "5/0s", "5/fortnight"} {
+		if _, err := ParseRate(in); err == nil {
+			t.Errorf("ParseRate(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestTokenBucketLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	l := NewTokenBucketLimiter(Rate{Count: 2, Period: time.Minute}).(*tokenBucketLimiter)
+	l.now = func() time.Time { return now }
+
+	// A burst up to the count is allowed, then the next waits for a token
+	for i := 0; i < 2; i++ {
+		if ok, _, _ := l.Allow(ctx, "alice"); !ok {
+			t.Fatalf("request %d refused", i+1)
+		}
+	}
+	ok, retryAfter, _ := l.Allow(ctx, "alice")
+	if ok || retryAfter != 30*time.Second {
+		t.Errorf("third request = %v, retry after %v; want refused for 30s", ok, retryAfter)
+	}
+
+	// Keys are independent
+	if ok, _, _ := l.Allow(ctx, "bob"); !ok {
+		t.Error("bob refused because of alice")
+	}
+
+	// Tokens refill over the period
+	now = now.Add(30 * time.Second)
+	if ok, _, _ := l.Allow(ctx, "alice"); !ok {
+		t.Error("refused after a token refilled")
+	}
+	if ok, _, _ := l.Allow(ctx, "alice"); ok {
+		t.Error("allowed more than refilled")
+	}
+
+	// Idle keys are swept once full again
+	now = now.Add(2 * time.Minute)
+	l.Allow(ctx, "carol")
+	if _, ok := l.buckets["bob"]; ok {
+		t.Error("idle bucket wasn't swept")
+	}
+}
+
+func TestSendContactRequest_RateLimited(t *testing.T) {
+	server, st := testServer(t, WithContactRequestLimiter(NewTokenBucketLimiter(Rate{Count: 3, Period: time.Hour})))
+	r := testRouter(t, server)
+
+	token, _ := createTestUser(t, st, "spammer@example.com", "Spammer")
+	for i := 0; i < 4; i++ {
+		createTestUser(t, st, fmt.Sprintf("target%d@example.com", i), "Target")
+	}
+
+	// Attempts count whether or not the recipient exists
+	emails := []Email{"target0@example.com", "nobody@example.com", "target1@example.com", "target2@example.com"}
+	for i, email := range emails[:3] {
+		if rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: email}, token); rec.Code == http.StatusTooManyRequests {
+			t.Fatalf("request %d rate limited", i+1)
+		}
+	}
+
+	rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: emails[3]}, token)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("fourth request status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1200" {
+		t.Errorf("Retry-After = %q, want 1200", got)
+	}
+
+	// Other users have their own allowance
+	otherToken, _ := createTestUser(t, st, "other@example.com", "Other")
+	if rec := doRequest(t, r, "POST", "/api/contacts/request", ContactRequestCreate{Email: emails[3]}, otherToken); rec.Code != http.StatusCreated {
+		t.Errorf("other user's request status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
@@ -0,0 +1,288 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	appleIssuer  = "https://appleid.apple.com"
+	appleKeysURL = "https://appleid.apple.com/auth/keys"
+
+	// appleKeysTTL is how long Apple's signing keys are cached. Apple
+	// rotates them rarely; a token signed with a key we haven't seen
+	// triggers an early refetch.
+	appleKeysTTL = 24 * time.Hour
+	// appleKeysMinRefetch stops tokens with unknown key IDs from making
+	// us hit Apple on every request
+	appleKeysMinRefetch = time.Minute
+)
+
+// NonceVerifier validates an identity provider token that must carry a
+// nonce chosen by the client, so a token can't be replayed from elsewhere
+type NonceVerifier interface {
+	VerifyNonce(ctx context.Context, token, nonce string) (*GoogleClaims, error)
+}
+
+// AppleVerifier verifies Sign in with Apple identity tokens. Apple only
+// puts the user's name in the authorization response the app receives,
+// never in the token, so Name is always empty.
+type AppleVerifier struct {
+	clientID   string
+	keysURL    string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// NewAppleVerifier creates a verifier for tokens issued to clientID, the
+// app's bundle ID or Services ID
+func NewAppleVerifier(clientID string) *AppleVerifier {
+	return &AppleVerifier{
+		clientID:   clientID,
+		keysURL:    appleKeysURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}
+}
+
+// Verify validates an Apple identity token and returns the claims, without
+// checking a nonce
+func (v *AppleVerifier) Verify(ctx context.Context, token string) (*GoogleClaims, error) {
+	claims, _, err := v.verify(ctx, token)
+	return claims, err
+}
+
+// VerifyNonce validates an Apple identity token issued for nonce. The app
+// passes Apple the SHA-256 of the nonce in hex and keeps the nonce itself,
+// so only the app that asked for the token can present it; the token's
+// own nonce claim doesn't match. Tokens from platforms Apple says don't
+// support nonces are accepted without one.
+func (v *AppleVerifier) VerifyNonce(ctx context.Context, token, nonce string) (*GoogleClaims, error) {
+	claims, payload, err := v.verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Nonce == "" {
+		if supported, ok := claimBool(payload.NonceSupported); ok && !supported {
+			return claims, nil
+		}
+		return nil, errors.New("token missing nonce")
+	}
+	if !nonceMatches(payload.Nonce, nonce) {
+		return nil, errors.New("nonce mismatch")
+	}
+	return claims, nil
+}
+
+// applePayload is the part of an Apple identity token we check
+type applePayload struct {
+	Issuer         string   `json:"iss"`
+	Subject        string   `json:"sub"`
+	Audience       audience `json:"aud"`
+	ExpiresAt      int64    `json:"exp"`
+	Nonce          string   `json:"nonce"`
+	NonceSupported any      `json:"nonce_supported"`
+	Email          string   `json:"email"`
+	EmailVerified  any      `json:"email_verified"` // Apple sends "true" or true
+}
+
+// audience is a JWT aud claim, which may be a string or a list
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = audience{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+func (v *AppleVerifier) verify(ctx context.Context, token string) (*GoogleClaims, *applePayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, errors.New("invalid token: malformed JWT")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid token header: %w", err)
+	}
+	if header.Alg != "RS256" {
+		return nil, nil, fmt.Errorf("invalid token: unsupported algorithm %q", header.Alg)
+	}
+	key, err := v.key(ctx, header.Kid)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	var payload applePayload
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+	now := v.now()
+	switch {
+	case payload.Issuer != appleIssuer:
+		return nil, nil, fmt.Errorf("invalid token: issuer %q", payload.Issuer)
+	case !slices.Contains(payload.Audience, v.clientID):
+		return nil, nil, errors.New("invalid token: audience mismatch")
+	case now.After(time.Unix(payload.ExpiresAt, 0)):
+		return nil, nil, errors.New("invalid token: expired")
+	case payload.Subject == "":
+		return nil, nil, errors.New("invalid token: missing subject")
+	}
+
+	claims := &GoogleClaims{Sub: payload.Subject}
+	// Apple verifies the emails it hands out, but don't trust one it
+	// says it hasn't
+	if verified, ok := claimBool(payload.EmailVerified); payload.Email != "" && (!ok || verified) {
+		claims.Email = payload.Email
+	}
+	return claims, &payload, nil
+}
+
+// key returns Apple's public key with the given ID, fetching the key set
+// if it isn't cached or has gone stale
+func (v *AppleVerifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	key, ok := v.keys[kid]
+	stale := now.Sub(v.fetchedAt) > appleKeysTTL
+	if ok && !stale {
+		return key, nil
+	}
+	if !stale && now.Sub(v.fetchedAt) < appleKeysMinRefetch {
+		return nil, fmt.Errorf("invalid token: unknown key %q", kid)
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		if ok {
+			// Apple is unreachable; the cached key is better than nothing
+			return key, nil
+		}
+		return nil, fmt.Errorf("fetch Apple keys: %w", err)
+	}
+	v.keys = keys
+	v.fetchedAt = now
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("invalid token: unknown key %q", kid)
+}
+
+// fetchKeys downloads Apple's JSON Web Key Set
+func (v *AppleVerifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", v.keysURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable keys")
+	}
+	return keys, nil
+}
+
+// nonceMatches reports whether a token's nonce is the SHA-256 of nonce in
+// hex. The claim itself is readable by anyone holding the token, so it
+// must not match.
+func nonceMatches(tokenNonce, nonce string) bool {
+	if nonce == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(nonce))
+	hashed := hex.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(hashed)) == 1
+}
+
+// claimBool reads a boolean claim Apple may send as a bool or a string
+func claimBool(v any) (value, ok bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		return b == "true", b == "true" || b == "false"
+	}
+	return false, false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
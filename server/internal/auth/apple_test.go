@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// appleServer serves a JWKS with one key and signs tokens with it
+type appleServer struct {
+	key     *rsa.PrivateKey
+	fetches int
+}
+
+func newAppleServer(t *testing.T) (*appleServer, *AppleVerifier) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	a := &appleServer{key: key}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.fetches++
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(ts.Close)
+
+	v := NewAppleVerifier("com.example.whereish")
+	v.keysURL = ts.URL
+	return a, v
+}
+
+// sign makes a token with claims layered over valid defaults
+func (a *appleServer) sign(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	payload := map[string]any{
+		"iss":            appleIssuer,
+		"aud":            "com.example.whereish",
+		"sub":            "apple-user",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "alice@privaterelay.appleid.com",
+		"email_verified": "true",
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(payload, k)
+		} else {
+			payload[k] = v
+		}
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid})
+	body, _ := json.Marshal(payload)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("SignPKCS1v15 failed: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestAppleVerifier(t *testing.T) {
+	a, v := newAppleServer(t)
+	ctx := context.Background()
+	sum := sha256.Sum256([]byte("raw-nonce"))
+	hashedNonce := hex.EncodeToString(sum[:])
+
+	claims, err := v.VerifyNonce(ctx, a.sign(t, "key-1", map[string]any{"nonce": hashedNonce}), "raw-nonce")
+	if err != nil {
+		t.Fatalf("VerifyNonce failed: %v", err)
+	}
+	if claims.Sub != "apple-user" || claims.Email != "alice@privaterelay.appleid.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	// Apple leaves the email out after the first sign in
+	claims, err = v.VerifyNonce(ctx, a.sign(t, "key-1", map[string]any{"nonce": hashedNonce, "email": nil}), "raw-nonce")
+	if err != nil || claims.Email != "" {
+		t.Errorf("token without email = %+v, %v", claims, err)
+	}
+
+	// A leaked token can't be replayed by sending its own nonce claim
+	if _, err := v.VerifyNonce(ctx, a.sign(t, "key-1", map[string]any{"nonce": hashedNonce}), hashedNonce); err == nil {
+		t.Error("token verified with its nonce claim as the nonce")
+	}
+	if _, err := v.VerifyNonce(ctx, a.sign(t, "key-1", map[string]any{"nonce": "raw-nonce"}), "raw-nonce"); err == nil {
+		t.Error("token with an unhashed nonce claim verified")
+	}
+
+	for name, tc := range map[string]struct {
+		kid    string
+		claims map[string]any
+		nonce  string
+	}{
+		"wrong nonce":    {"key-1", map[string]any{"nonce": hashedNonce}, "other-nonce"},
+		"missing nonce":  {"key-1", nil, "raw-nonce"},
+		"wrong audience": {"key-1", map[string]any{"nonce": hashedNonce, "aud": "com.example.other"}, "raw-nonce"},
+		"wrong issuer":   {"key-1", map[string]any{"nonce": hashedNonce, "iss": "https://example.com"}, "raw-nonce"},
+		"expired":        {"key-1", map[string]any{"nonce": hashedNonce, "exp": time.Now().Add(-time.Minute).Unix()}, "raw-nonce"},
+		"unknown key":    {"key-2", map[string]any{"nonce": hashedNonce}, "raw-nonce"},
+	} {
+		if _, err := v.VerifyNonce(ctx, a.sign(t, tc.kid, tc.claims), tc.nonce); err == nil {
+			t.Errorf("%s: VerifyNonce succeeded, want error", name)
+		}
+	}
+
+	// An unverified email is dropped rather than trusted
+	claims, err = v.Verify(ctx, a.sign(t, "key-1", map[string]any{"email_verified": false}))
+	if err != nil || claims.Email != "" {
+		t.Errorf("unverified email: claims = %+v, %v", claims, err)
+	}
+
+	// Platforms without nonce support are let through without one
+	if _, err := v.VerifyNonce(ctx, a.sign(t, "key-1", map[string]any{"nonce_supported": false}), "raw-nonce"); err != nil {
+		t.Errorf("nonce unsupported: %v", err)
+	}
+
+	// A forged signature fails
+	token := a.sign(t, "key-1", map[string]any{"nonce": hashedNonce})
+	forged := token[:len(token)-4] + "AAAA"
+	if _, err := v.VerifyNonce(ctx, forged, "raw-nonce"); err == nil {
+		t.Error("forged token verified")
+	}
+
+	// Keys are cached, and an unknown key ID only refetches once a minute
+	if a.fetches != 1 {
+		t.Errorf("fetched keys %d times, want 1", a.fetches)
+	}
+}
@@ -15,6 +15,11 @@ type GoogleClaims struct {
 	Name  string
 }
 
+// TokenVerifier validates an identity provider token and returns its claims
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*GoogleClaims, error)
+}
+
 // GoogleVerifier verifies Google ID tokens
 type GoogleVerifier struct {
 	clientID string
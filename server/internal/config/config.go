@@ -13,33 +13,169 @@ type Config struct {
 	Port string
 	Host string
 
+	// How long shutdown waits for in-flight requests before cutting them off
+	ShutdownTimeout time.Duration
+
+	// Log output format: "text" or "json"
+	LogFormat string
+
 	// Database configuration
 	DatabaseURL  string
 	DatabaseType string // "sqlite", "postgres", "firestore"
 
+	// Passphrase for SQLCipher at-rest encryption (empty = unencrypted)
+	DatabaseEncryptionKey string
+
+	// Key that signs page cursors (empty = random per process)
+	CursorKey string
+
 	// Google OAuth
-	GoogleClientID string
+	GoogleClientID     string
+	AppleClientID      string // bundle ID or Services ID; empty disables Sign in with Apple
+	OAuthVerifyTimeout time.Duration
 
 	// Session configuration
 	SessionDuration time.Duration
 
+	// How long refresh tokens issued at login last
+	RefreshTokenDuration time.Duration // 0 disables refresh tokens
+
+	// How often expired sessions are deleted
+	SessionCleanupInterval time.Duration
+
+	// Request limits
+	MaxConcurrentRequests int // 0 disables the limit
+
+	// Largest location share request body in bytes
+	MaxShareBatchBytes int // 0 means unlimited
+
+	// Per-user storage quota in bytes
+	StorageQuotaBytes int // 0 means unlimited
+
+	// Contact requests each user may send, as count/period, e.g. "20/1h"
+	ContactRequestRate string // "0" means unlimited
+
+	// Reject requests that didn't arrive over HTTPS. Forwarding headers
+	// (X-Forwarded-Proto, X-Forwarded-For, X-Real-IP) are trusted only
+	// from these comma-separated IPs and CIDRs.
+	RequireHTTPS   bool
+	TrustedProxies string
+
+	// Require sessions to be bound to a device before making changes
+	RequireDevice bool
+
+	// Comma-separated names of background jobs not to run
+	DisabledJobs string
+
+	// Oldest client version served (empty disables the check), and whether
+	// clients that don't send a version are rejected
+	MinClientVersion    string
+	StrictClientVersion bool
+
+	// Leave a shared location's updated_at alone when the same ciphertext
+	// is uploaded again
+	DedupeIdenticalShares bool
+
+	// Delete the locations a user has shared when they change their key
+	ClearSharesOnKeyChange bool
+
+	// Match contact request recipients ignoring "+tag" suffixes and Gmail
+	// dots. Sign in always matches emails exactly.
+	EmailNormalizePlus bool
+	EmailNormalizeDots bool
+
+	// Accepted PBKDF2 iteration range for identity backups
+	BackupMinIterations int
+	BackupMaxIterations int
+
+	// Opt-in sweep of accounts with no login for InactiveAccountTTL: they
+	// are flagged, then soft-deleted once flagged for InactiveAccountGrace.
+	// A dry run only logs what the sweep would do.
+	InactiveAccountSweep  bool
+	InactiveAccountDryRun bool
+	InactiveAccountTTL    time.Duration
+	InactiveAccountGrace  time.Duration
+
+	// Log CORS preflights and why the browser would refuse them
+	CORSDebug bool
+
+	// Comma-separated origins browsers may call the API from; "*" (dev
+	// mode only) allows any
+	AllowedOrigins string
+	// Let allowed origins send credentials such as cookies
+	CORSAllowCredentials bool
+
 	// Development mode
 	DevMode bool
 }
 
-// Load loads configuration from environment variables
-func Load() *Config {
+// Load loads configuration from environment variables. Secrets can also be
+// read from files; see DefaultSecretSources.
+func Load() (*Config, error) {
+	return LoadWithSecrets(DefaultSecretSources()...)
+}
+
+// LoadWithSecrets loads configuration from environment variables, taking
+// sensitive settings from the first of sources that has them
+func LoadWithSecrets(sources ...SecretSource) (*Config, error) {
 	cfg := &Config{
-		Port:            getEnv("PORT", "8080"),
-		Host:            getEnv("HOST", ""),
-		DatabaseURL:     getEnv("DATABASE_URL", "whereish.db"),
-		DatabaseType:    getEnv("DATABASE_TYPE", "sqlite"),
-		GoogleClientID:  getEnv("GOOGLE_CLIENT_ID", ""),
-		SessionDuration: getDuration("SESSION_DURATION", 7*24*time.Hour),
-		DevMode:         getBool("DEV_MODE", false),
+		Port:               getEnv("PORT", "8080"),
+		Host:               getEnv("HOST", ""),
+		ShutdownTimeout:    getDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		LogFormat:          getEnv("LOG_FORMAT", "text"),
+		DatabaseURL:        getEnv("DATABASE_URL", "whereish.db"),
+		DatabaseType:       getEnv("DATABASE_TYPE", "sqlite"),
+		OAuthVerifyTimeout: getDuration("OAUTH_VERIFY_TIMEOUT", 10*time.Second),
+		AppleClientID:      getEnv("APPLE_CLIENT_ID", ""),
+		SessionDuration:    getDuration("SESSION_DURATION", 7*24*time.Hour),
+		DevMode:            getBool("DEV_MODE", false),
+		CORSDebug:          getBool("CORS_DEBUG", false),
+		AllowedOrigins:     getEnv("ALLOWED_ORIGINS", ""),
+		RequireDevice:      getBool("REQUIRE_DEVICE", false),
+		RequireHTTPS:       getBool("REQUIRE_HTTPS", false),
+		TrustedProxies:     getEnv("TRUSTED_PROXIES", ""),
+		DisabledJobs:       getEnv("DISABLED_JOBS", ""),
+
+		ContactRequestRate: getEnv("CONTACT_REQUEST_RATE", "20/1h"),
+
+		CORSAllowCredentials: getBool("CORS_ALLOW_CREDENTIALS", false),
+
+		SessionCleanupInterval: getDuration("SESSION_CLEANUP_INTERVAL", time.Hour),
+		RefreshTokenDuration:   getDuration("REFRESH_TOKEN_DURATION", 90*24*time.Hour),
+
+		DedupeIdenticalShares:  getBool("DEDUPE_IDENTICAL_SHARES", false),
+		ClearSharesOnKeyChange: getBool("CLEAR_SHARES_ON_KEY_CHANGE", true),
+
+		InactiveAccountSweep:  getBool("INACTIVE_ACCOUNT_SWEEP", false),
+		InactiveAccountDryRun: getBool("INACTIVE_ACCOUNT_DRY_RUN", true),
+		InactiveAccountTTL:    getDuration("INACTIVE_ACCOUNT_TTL", 2*365*24*time.Hour),
+		InactiveAccountGrace:  getDuration("INACTIVE_ACCOUNT_GRACE", 30*24*time.Hour),
+
+		EmailNormalizePlus: getBool("EMAIL_NORMALIZE_PLUS", false),
+		EmailNormalizeDots: getBool("EMAIL_NORMALIZE_DOTS", false),
+
+		MinClientVersion:    getEnv("MIN_CLIENT_VERSION", ""),
+		StrictClientVersion: getBool("STRICT_CLIENT_VERSION", false),
+
+		MaxConcurrentRequests: getInt("MAX_CONCURRENT_REQUESTS", 0),
+		StorageQuotaBytes:     getInt("STORAGE_QUOTA_BYTES", 0),
+		MaxShareBatchBytes:    getInt("MAX_SHARE_BATCH_BYTES", 1<<20),
+		BackupMinIterations:   getInt("BACKUP_MIN_ITERATIONS", 100000),
+		BackupMaxIterations:   getInt("BACKUP_MAX_ITERATIONS", 1000000),
 	}
 
-	return cfg
+	var err error
+	if cfg.GoogleClientID, err = getSecret(sources, "GOOGLE_CLIENT_ID", ""); err != nil {
+		return nil, err
+	}
+	if cfg.DatabaseEncryptionKey, err = getSecret(sources, "DB_ENCRYPTION_KEY", ""); err != nil {
+		return nil, err
+	}
+	if cfg.CursorKey, err = getSecret(sources, "CURSOR_KEY", ""); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultVal string) string {
@@ -59,6 +195,16 @@ func getBool(key string, defaultVal bool) bool {
 	return defaultVal
 }
 
+func getInt(key string, defaultVal int) int {
+	if val := os.Getenv(key); val != "" {
+		i, err := strconv.Atoi(val)
+		if err == nil {
+			return i
+		}
+	}
+	return defaultVal
+}
+
 func getDuration(key string, defaultVal time.Duration) time.Duration {
 	if val := os.Getenv(key); val != "" {
 		d, err := time.ParseDuration(val)
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSecret(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return path
+}
+
+func TestLoad_SecretFromFile(t *testing.T) {
+	t.Setenv("GOOGLE_CLIENT_ID", "from-env")
+	t.Setenv("GOOGLE_CLIENT_ID_FILE", writeSecret(t, "from-file\n"))
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.GoogleClientID != "from-file" {
+		t.Errorf("GoogleClientID = %q, want %q", cfg.GoogleClientID, "from-file")
+	}
+}
+
+func TestLoad_SecretFromEnv(t *testing.T) {
+	t.Setenv("GOOGLE_CLIENT_ID", "from-env")
+	t.Setenv("GOOGLE_CLIENT_ID_FILE", "")
+	t.Setenv("DB_ENCRYPTION_KEY", "")
+	t.Setenv("DB_ENCRYPTION_KEY_FILE", "")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.GoogleClientID != "from-env" {
+		t.Errorf("GoogleClientID = %q, want %q", cfg.GoogleClientID, "from-env")
+	}
+	if cfg.DatabaseEncryptionKey != "" {
+		t.Errorf("DatabaseEncryptionKey = %q, want empty", cfg.DatabaseEncryptionKey)
+	}
+}
+
+func TestLoad_MissingSecretFile(t *testing.T) {
+	t.Setenv("DB_ENCRYPTION_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a missing secret file")
+	}
+}
+
+// mapSecrets is a SecretSource backed by a map
+type mapSecrets map[string]string
+
+func (m mapSecrets) Secret(key string) (string, bool, error) {
+	val, ok := m[key]
+	return val, ok, nil
+}
+
+func TestLoadWithSecrets_CustomSource(t *testing.T) {
+	t.Setenv("DB_ENCRYPTION_KEY", "from-env")
+
+	cfg, err := LoadWithSecrets(mapSecrets{"GOOGLE_CLIENT_ID": "from-vault"}, EnvSecrets{})
+	if err != nil {
+		t.Fatalf("LoadWithSecrets failed: %v", err)
+	}
+	if cfg.GoogleClientID != "from-vault" {
+		t.Errorf("GoogleClientID = %q, want %q", cfg.GoogleClientID, "from-vault")
+	}
+	if cfg.DatabaseEncryptionKey != "from-env" {
+		t.Errorf("DatabaseEncryptionKey = %q, want fallback %q", cfg.DatabaseEncryptionKey, "from-env")
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SecretSource supplies sensitive settings by environment variable name.
+// ok is false when the source doesn't have the secret, so the next source
+// is tried.
+type SecretSource interface {
+	Secret(key string) (value string, ok bool, err error)
+}
+
+// FileSecrets reads a secret from the file named by KEY_FILE, the Docker
+// secrets convention. A trailing newline is dropped.
+type FileSecrets struct{}
+
+func (FileSecrets) Secret(key string) (string, bool, error) {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// EnvSecrets reads a secret from the KEY environment variable
+type EnvSecrets struct{}
+
+func (EnvSecrets) Secret(key string) (string, bool, error) {
+	val := os.Getenv(key)
+	return val, val != "", nil
+}
+
+// DefaultSecretSources checks KEY_FILE, then KEY
+func DefaultSecretSources() []SecretSource {
+	return []SecretSource{FileSecrets{}, EnvSecrets{}}
+}
+
+// getSecret returns the first value any source has for key, or defaultVal
+func getSecret(sources []SecretSource, key, defaultVal string) (string, error) {
+	for _, source := range sources {
+		val, ok, err := source.Secret(key)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return val, nil
+		}
+	}
+	return defaultVal, nil
+}
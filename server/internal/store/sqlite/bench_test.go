@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Sizes of the benchmark data set: the measured user has benchContacts
+// contacts who all share a location with them, and benchRequests pending
+// incoming requests. Everyone else has contacts and locations of their own
+// so queries have rows to skip.
+const (
+	benchUsers    = 1000
+	benchContacts = 300
+	benchRequests = 100
+	benchFanout   = 20 // contacts per other user
+)
+
+// benchStore returns a store populated for benchmarks and the measured
+// user's ID
+func benchStore(b *testing.B) (*Store, string) {
+	b.Helper()
+	s, err := New(":memory:")
+	if err != nil {
+		b.Fatalf("failed to create store: %v", err)
+	}
+	b.Cleanup(func() { s.Close() })
+
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	exec := func(query string, args ...any) {
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			b.Fatalf("%s: %v", query, err)
+		}
+	}
+	id := func(i int) string { return fmt.Sprintf("user-%04d", i) }
+	now := time.Now()
+
+	for i := 0; i < benchUsers; i++ {
+		exec(`INSERT INTO users (id, email, name, public_key) VALUES (?, ?, ?, ?)`,
+			id(i), fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("User %d", i), "key")
+	}
+
+	share := func(from, to int) {
+		exec(`INSERT INTO contacts (user_id, contact_id, created_at) VALUES (?, ?, ?), (?, ?, ?)`,
+			id(from), id(to), now, id(to), id(from), now)
+		exec(`INSERT INTO encrypted_locations (from_user_id, to_user_id, blob, updated_at) VALUES (?, ?, ?, ?)`,
+			id(to), id(from), "blob", now)
+	}
+
+	// User 0 is measured
+	for i := 1; i <= benchContacts; i++ {
+		share(0, i)
+		if i%10 == 0 {
+			exec(`INSERT INTO contact_notes (user_id, contact_id, note) VALUES (?, ?, ?)`, id(0), id(i), "note")
+		}
+	}
+	for i := benchContacts + 1; i <= benchContacts+benchRequests; i++ {
+		exec(`INSERT INTO contact_requests (id, requester_id, recipient_id, status, created_at) VALUES (?, ?, ?, 'pending', ?)`,
+			fmt.Sprintf("req-%d", i), id(i), id(0), now.Add(-time.Duration(i)*time.Minute))
+	}
+
+	// Everyone else
+	for i := benchContacts + benchRequests + 1; i < benchUsers-benchFanout; i++ {
+		for j := 1; j <= benchFanout; j++ {
+			if (i+j)%benchFanout == 0 {
+				exec(`INSERT INTO contact_requests (id, requester_id, recipient_id, status, created_at) VALUES (?, ?, ?, 'pending', ?)`,
+					fmt.Sprintf("req-%d-%d", i, j), id(i), id(i+j), now)
+				continue
+			}
+			if (i+j)%benchFanout < benchFanout/2 && i%2 == 0 {
+				share(i, i+j)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		b.Fatal(err)
+	}
+	return s, id(0)
+}
+
+func BenchmarkListContacts(b *testing.B) {
+	s, userID := benchStore(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		contacts, err := s.Contacts().ListContacts(ctx, userID)
+		if err != nil || len(contacts) != benchContacts {
+			b.Fatalf("ListContacts = %d, %v", len(contacts), err)
+		}
+	}
+}
+
+func BenchmarkCountContactsWithKeys(b *testing.B) {
+	s, userID := benchStore(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count, err := s.Contacts().CountContactsWithKeys(ctx, userID)
+		if err != nil || count != benchContacts {
+			b.Fatalf("CountContactsWithKeys = %d, %v", count, err)
+		}
+	}
+}
+
+func BenchmarkGetLocationsForUser(b *testing.B) {
+	s, userID := benchStore(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		locations, err := s.Locations().GetLocationsForUser(ctx, userID)
+		if err != nil || len(locations) != benchContacts {
+			b.Fatalf("GetLocationsForUser = %d, %v", len(locations), err)
+		}
+	}
+}
+
+func BenchmarkListIncomingRequests(b *testing.B) {
+	s, userID := benchStore(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		requests, err := s.Contacts().ListIncomingRequests(ctx, userID)
+		if err != nil || len(requests) != benchRequests {
+			b.Fatalf("ListIncomingRequests = %d, %v", len(requests), err)
+		}
+	}
+}
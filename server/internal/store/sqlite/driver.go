@@ -0,0 +1,20 @@
+//go:build !sqlcipher
+
+package sqlite
+
+import (
+	_ "modernc.org/sqlite"
+)
+
+// driverName is the database/sql driver used to open databases
+const driverName = "sqlite"
+
+// foreignKeysParam is the DSN parameter that turns on foreign key
+// enforcement for every connection the driver opens
+const foreignKeysParam = "_pragma=foreign_keys(1)"
+
+// keyedDSN adds an encryption key to a DSN. The default driver has no
+// encryption support; build with -tags sqlcipher to enable it.
+func keyedDSN(dsn, key string) (string, error) {
+	return "", ErrEncryptionUnsupported
+}
@@ -0,0 +1,22 @@
+//go:build sqlcipher
+
+package sqlite
+
+import (
+	"net/url"
+
+	_ "github.com/mutecomm/go-sqlcipher/v4"
+)
+
+// driverName is the database/sql driver used to open databases
+const driverName = "sqlite3"
+
+// foreignKeysParam is the DSN parameter that turns on foreign key
+// enforcement for every connection the driver opens
+const foreignKeysParam = "_foreign_keys=1"
+
+// keyedDSN adds an encryption key to a DSN. The key is passed as a DSN
+// parameter so SQLCipher applies it to every pooled connection.
+func keyedDSN(dsn, key string) (string, error) {
+	return withParam(dsn, "_pragma_key="+url.QueryEscape(key)), nil
+}
@@ -0,0 +1,33 @@
+//go:build sqlcipher
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNew_EncryptedDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "encrypted.db")
+
+	s, err := New(path, WithEncryptionKey("correct horse"))
+	if err != nil {
+		t.Fatalf("create encrypted database: %v", err)
+	}
+	s.Close()
+
+	if s, err := New(path); err == nil {
+		s.Close()
+		t.Error("expected opening without a key to fail")
+	}
+	if s, err := New(path, WithEncryptionKey("wrong key")); err == nil {
+		s.Close()
+		t.Error("expected opening with the wrong key to fail")
+	}
+
+	s, err = New(path, WithEncryptionKey("correct horse"))
+	if err != nil {
+		t.Fatalf("reopen with key: %v", err)
+	}
+	s.Close()
+}
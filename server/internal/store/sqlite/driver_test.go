@@ -0,0 +1,15 @@
+//go:build !sqlcipher
+
+package sqlite
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew_EncryptionKeyUnsupported(t *testing.T) {
+	_, err := New(":memory:", WithEncryptionKey("secret"))
+	if !errors.Is(err, ErrEncryptionUnsupported) {
+		t.Errorf("expected ErrEncryptionUnsupported, got %v", err)
+	}
+}
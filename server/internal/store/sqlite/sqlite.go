@@ -4,44 +4,161 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "modernc.org/sqlite"
 	"github.com/whereish/server/internal/store"
 )
 
+// ErrEncryptionUnsupported is returned when an encryption key is given but
+// the binary was built without SQLCipher support
+var ErrEncryptionUnsupported = errors.New("database encryption requires building with -tags sqlcipher")
+
 // Store implements store.Store using SQLite
 type Store struct {
-	db *sql.DB
+	db         *sql.DB
+	tx         *sql.Tx     // set inside WithTx
+	dedupe     bool        // skip location writes that don't change the blob
+	emails     emailPolicy // how emails are matched in GetByEmail
+	keyCleanup bool        // drop a user's outbound shares when their key changes
+}
+
+// Option configures how the database is opened
+type Option func(*options)
+
+type options struct {
+	encryptionKey string
+	dedupeShares  bool
+	emails        emailPolicy
+	keyCleanup    bool
+}
+
+// WithEncryptionKey opens the database encrypted with the given passphrase.
+// An empty key leaves the database unencrypted.
+func WithEncryptionKey(key string) Option {
+	return func(o *options) { o.encryptionKey = key }
+}
+
+// WithDedupeIdenticalShares leaves a shared location untouched, including
+// its updated_at, when it's re-uploaded with a byte-identical blob and the
+// same precision. Encryption uses a fresh nonce each time, so this only
+// catches clients that resend ciphertext they already uploaded.
+func WithDedupeIdenticalShares(enabled bool) Option {
+	return func(o *options) { o.dedupeShares = enabled }
+}
+
+// WithEmailNormalization makes FindByEmail treat address variants as the
+// same mailbox: plus drops a "+tag" suffix from the local part on
+// providers known to deliver those to the base address, and dots ignores
+// dots in Gmail local parts. GetByEmail, which sign in links accounts by,
+// always matches exactly. Stored emails are left as given.
+func WithEmailNormalization(plus, dots bool) Option {
+	return func(o *options) { o.emails = emailPolicy{plus: plus, dots: dots} }
+}
+
+// WithKeyChangeCleanup deletes the locations and presence a user has
+// shared when they replace or clear their public key. Those blobs were
+// encrypted with the old key pair, so recipients can no longer trust or
+// decrypt them. On by default, matching CLEAR_SHARES_ON_KEY_CHANGE.
+func WithKeyChangeCleanup(enabled bool) Option {
+	return func(o *options) { o.keyCleanup = enabled }
+}
+
+// plusAddressingDomains deliver user+tag@domain to user@domain. Elsewhere
+// the two may be different mailboxes, so the tag is kept.
+var plusAddressingDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"outlook.com":    true,
+	"hotmail.com":    true,
+	"live.com":       true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"mac.com":        true,
+	"fastmail.com":   true,
+	"proton.me":      true,
+	"protonmail.com": true,
+}
+
+// emailPolicy derives the key emails are matched on
+type emailPolicy struct {
+	plus bool
+	dots bool
+}
+
+// key returns the lookup key for an email: always lowercased and trimmed,
+// then normalized as configured
+func (p emailPolicy) key(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if p.plus && plusAddressingDomains[domain] {
+		if i := strings.Index(local, "+"); i > 0 {
+			local = local[:i]
+		}
+	}
+	if p.dots && (domain == "gmail.com" || domain == "googlemail.com") {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + domain
+}
+
+// withParam appends a query parameter to a DSN
+func withParam(dsn, param string) string {
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + param
+	}
+	return dsn + "?" + param
 }
 
 // New creates a new SQLite store
-func New(dsn string) (*Store, error) {
-	db, err := sql.Open("sqlite", dsn)
+func New(dsn string, opts ...Option) (*Store, error) {
+	o := options{keyCleanup: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.encryptionKey != "" {
+		var err error
+		if dsn, err = keyedDSN(dsn, o.encryptionKey); err != nil {
+			return nil, err
+		}
+	}
+
+	// A PRAGMA only reaches the connection that runs it, so foreign keys
+	// are enabled through the DSN to cover every pooled connection
+	db, err := sql.Open(driverName, withParam(dsn, foreignKeysParam))
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	// Enable WAL mode for better concurrent access
-	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+	// Reading the schema fails if the key is wrong or missing
+	if _, err := db.Exec("SELECT count(*) FROM sqlite_master"); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("enable WAL: %w", err)
+		return nil, fmt.Errorf("read database (wrong encryption key?): %w", err)
 	}
 
-	// Enable foreign keys
-	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
+	// Enable WAL mode for better concurrent access
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
 		db.Close()
-		return nil, fmt.Errorf("enable foreign keys: %w", err)
+		return nil, fmt.Errorf("enable WAL: %w", err)
 	}
 
-	s := &Store{db: db}
+	s := &Store{db: db, dedupe: o.dedupeShares, emails: o.emails, keyCleanup: o.keyCleanup}
 	if err := s.migrate(); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("migrate: %w", err)
 	}
+	if err := s.rekeyEmails(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("rekey emails: %w", err)
+	}
 
 	return s, nil
 }
@@ -52,10 +169,16 @@ func (s *Store) migrate() error {
 	CREATE TABLE IF NOT EXISTS users (
 		id TEXT PRIMARY KEY,
 		email TEXT UNIQUE NOT NULL,
+		email_key TEXT NOT NULL DEFAULT '',
 		google_id TEXT UNIQUE,
+		apple_id TEXT,
 		name TEXT NOT NULL,
 		public_key TEXT,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		last_login_at TIMESTAMP,
+		updated_at TIMESTAMP,
+		deleted_at TIMESTAMP,
+		inactive_flagged_at TIMESTAMP
 	);
 
 	CREATE TABLE IF NOT EXISTS identity_backups (
@@ -63,9 +186,12 @@ func (s *Store) migrate() error {
 		algorithm TEXT NOT NULL,
 		kdf TEXT NOT NULL,
 		iterations INTEGER NOT NULL,
+		memory INTEGER NOT NULL DEFAULT 0,
+		parallelism INTEGER NOT NULL DEFAULT 0,
 		salt TEXT NOT NULL,
 		iv TEXT NOT NULL,
-		payload TEXT NOT NULL
+		payload TEXT NOT NULL,
+		generation INTEGER NOT NULL DEFAULT 1
 	);
 
 	CREATE TABLE IF NOT EXISTS user_data (
@@ -75,6 +201,37 @@ func (s *Store) migrate() error {
 		blob TEXT NOT NULL
 	);
 
+	CREATE TABLE IF NOT EXISTS user_settings (
+		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, key)
+	);
+
+	CREATE TABLE IF NOT EXISTS login_attempts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		email TEXT NOT NULL DEFAULT '',
+		ip TEXT NOT NULL DEFAULT '',
+		success BOOLEAN NOT NULL,
+		created_at TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		action TEXT NOT NULL,
+		target_id TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS recovery_codes (
+		code_hash TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMP NOT NULL,
+		used_at TIMESTAMP
+	);
+
 	CREATE TABLE IF NOT EXISTS contact_requests (
 		id TEXT PRIMARY KEY,
 		requester_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -82,6 +239,8 @@ func (s *Store) migrate() error {
 		status TEXT NOT NULL DEFAULT 'pending',
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		accepted_at TIMESTAMP,
+		declined_at TIMESTAMP,
+		expired_at TIMESTAMP,
 		UNIQUE(requester_id, recipient_id)
 	);
 
@@ -89,18 +248,57 @@ func (s *Store) migrate() error {
 		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 		contact_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		sort_order INTEGER,
+		updated_at TIMESTAMP,
 		PRIMARY KEY (user_id, contact_id)
 	);
 
+	CREATE TABLE IF NOT EXISTS contact_notes (
+		user_id TEXT NOT NULL,
+		contact_id TEXT NOT NULL,
+		note TEXT NOT NULL,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (user_id, contact_id),
+		FOREIGN KEY (user_id, contact_id) REFERENCES contacts(user_id, contact_id) ON DELETE CASCADE
+	);
+
+	-- Last time from_user_id asked to_user_id to share their location
+	CREATE TABLE IF NOT EXISTS nudges (
+		from_user_id TEXT NOT NULL,
+		to_user_id TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (from_user_id, to_user_id),
+		FOREIGN KEY (from_user_id, to_user_id) REFERENCES contacts(user_id, contact_id) ON DELETE CASCADE
+	);
+
+	-- Check-in statuses, separate from locations
+	CREATE TABLE IF NOT EXISTS presence (
+		from_user_id TEXT NOT NULL,
+		to_user_id TEXT NOT NULL,
+		blob TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (from_user_id, to_user_id),
+		FOREIGN KEY (from_user_id, to_user_id) REFERENCES contacts(user_id, contact_id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS blocked_users (
+		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		blocked_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (user_id, blocked_id)
+	);
+
 	CREATE TABLE IF NOT EXISTS devices (
 		id TEXT PRIMARY KEY,
 		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 		name TEXT NOT NULL,
 		platform TEXT NOT NULL,
+		user_agent TEXT NOT NULL DEFAULT '',
 		token TEXT UNIQUE NOT NULL,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		last_seen TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		revoked_at TIMESTAMP
+		revoked_at TIMESTAMP,
+		paused_until TIMESTAMP
 	);
 
 	CREATE TABLE IF NOT EXISTS encrypted_locations (
@@ -108,6 +306,8 @@ func (s *Store) migrate() error {
 		to_user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
 		blob TEXT NOT NULL,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		precision TEXT NOT NULL DEFAULT 'exact',
+		expires_at TIMESTAMP,
 		PRIMARY KEY (from_user_id, to_user_id)
 	);
 
@@ -119,34 +319,183 @@ func (s *Store) migrate() error {
 		expires_at TIMESTAMP NOT NULL
 	);
 
+	-- Refresh tokens outlive the session they were issued with, so
+	-- session_token isn't a foreign key
+	CREATE TABLE IF NOT EXISTS refresh_tokens (
+		token_hash TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		session_token TEXT NOT NULL,
+		device_id TEXT REFERENCES devices(id) ON DELETE CASCADE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		expires_at TIMESTAMP NOT NULL
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 	CREATE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id);
-	CREATE INDEX IF NOT EXISTS idx_contact_requests_recipient ON contact_requests(recipient_id);
+	-- Covers "contacts with keys" checks; queries must spell the condition
+	-- as public_key > '' for the planner to use it
+	CREATE INDEX IF NOT EXISTS idx_users_with_key ON users(id, deleted_at) WHERE public_key > '';
+	-- Covers ListIncomingRequests' filter and sort; replaces the
+	-- recipient_id-only index
+	DROP INDEX IF EXISTS idx_contact_requests_recipient;
+	CREATE INDEX IF NOT EXISTS idx_contact_requests_incoming ON contact_requests(recipient_id, status, created_at);
 	CREATE INDEX IF NOT EXISTS idx_contact_requests_requester ON contact_requests(requester_id);
 	CREATE INDEX IF NOT EXISTS idx_contacts_user ON contacts(user_id);
+	CREATE INDEX IF NOT EXISTS idx_nudges_to ON nudges(to_user_id);
+	CREATE INDEX IF NOT EXISTS idx_presence_to ON presence(to_user_id);
+	CREATE INDEX IF NOT EXISTS idx_blocked_users_blocked ON blocked_users(blocked_id);
 	CREATE INDEX IF NOT EXISTS idx_devices_user ON devices(user_id);
 	CREATE INDEX IF NOT EXISTS idx_devices_token ON devices(token);
 	CREATE INDEX IF NOT EXISTS idx_locations_to ON encrypted_locations(to_user_id);
 	CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
+	CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON login_attempts(email, created_at);
+	CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts(ip, created_at);
 	CREATE INDEX IF NOT EXISTS idx_sessions_expires ON sessions(expires_at);
+	CREATE INDEX IF NOT EXISTS idx_audit_events_user ON audit_events(user_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_recovery_codes_user ON recovery_codes(user_id);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens(session_token);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_device ON refresh_tokens(device_id);
+	CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires ON refresh_tokens(expires_at);
 	`
 
-	_, err := s.db.Exec(schema)
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	// Columns added after the initial schema. CREATE TABLE IF NOT EXISTS
+	// won't touch existing tables, so older databases are upgraded here.
+	columns := []struct{ table, column, definition string }{
+		{"contacts", "sort_order", "INTEGER"},
+		{"users", "last_login_at", "TIMESTAMP"},
+		{"identity_backups", "generation", "INTEGER NOT NULL DEFAULT 1"},
+		{"users", "updated_at", "TIMESTAMP"},
+		{"contacts", "updated_at", "TIMESTAMP"},
+		{"devices", "user_agent", "TEXT NOT NULL DEFAULT ''"},
+		{"contact_requests", "declined_at", "TIMESTAMP"},
+		{"contact_requests", "expired_at", "TIMESTAMP"},
+		{"devices", "paused_until", "TIMESTAMP"},
+		{"encrypted_locations", "precision", "TEXT NOT NULL DEFAULT 'exact'"},
+		{"users", "email_key", "TEXT NOT NULL DEFAULT ''"},
+		{"users", "deleted_at", "TIMESTAMP"},
+		{"users", "inactive_flagged_at", "TIMESTAMP"},
+		{"encrypted_locations", "expires_at", "TIMESTAMP"},
+		{"users", "apple_id", "TEXT"},
+		{"identity_backups", "memory", "INTEGER NOT NULL DEFAULT 0"},
+		{"identity_backups", "parallelism", "INTEGER NOT NULL DEFAULT 0"},
+	}
+	for _, c := range columns {
+		if err := s.addColumnIfMissing(c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("add %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
+	// Indexes on added columns can only be created once the column exists
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_users_email_key ON users(email_key)`); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_locations_expires ON encrypted_locations(expires_at) WHERE expires_at IS NOT NULL`); err != nil {
+		return err
+	}
+	// ALTER TABLE can't add a UNIQUE column, so apple_id's uniqueness is
+	// an index
+	if _, err := s.db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_apple_id ON users(apple_id) WHERE apple_id IS NOT NULL`); err != nil {
+		return err
+	}
+	// Contacts removed before RemoveContact cleared the accepted request
+	// left it behind, blocking the pair from asking again
+	if _, err := s.db.Exec(`
+		DELETE FROM contact_requests WHERE status = 'accepted' AND NOT EXISTS (
+			SELECT 1 FROM contacts WHERE user_id = requester_id AND contact_id = recipient_id)
+	`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// rekeyEmails brings every user's email_key in line with the current email
+// policy, so turning normalization on or off applies to existing accounts
+func (s *Store) rekeyEmails() error {
+	rows, err := s.db.Query(`SELECT id, email, email_key FROM users`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	stale := make(map[string]string)
+	for rows.Next() {
+		var id, email, key string
+		if err := rows.Scan(&id, &email, &key); err != nil {
+			return err
+		}
+		if want := s.emails.key(email); key != want {
+			stale[id] = want
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for id, key := range stale {
+		if _, err := s.db.Exec(`UPDATE users SET email_key = ? WHERE id = ?`, key, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to an existing table unless it is already present
+func (s *Store) addColumnIfMissing(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultVal, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
-func (s *Store) Users() store.UserRepository       { return &userRepo{db: s.db} }
-func (s *Store) Contacts() store.ContactRepository { return &contactRepo{db: s.db} }
-func (s *Store) Devices() store.DeviceRepository   { return &deviceRepo{db: s.db} }
+func (s *Store) Users() store.UserRepository {
+	return &userRepo{db: s.conn(), emails: s.emails, keyCleanup: s.keyCleanup}
+}
+func (s *Store) Contacts() store.ContactRepository { return &contactRepo{db: s.conn()} }
+func (s *Store) Devices() store.DeviceRepository   { return &deviceRepo{db: s.conn()} }
 func (s *Store) Locations() store.LocationRepository {
-	return &locationRepo{db: s.db}
+	return &locationRepo{db: s.conn(), dedupe: s.dedupe}
 }
-func (s *Store) Sessions() store.SessionRepository { return &sessionRepo{db: s.db} }
+func (s *Store) Sessions() store.SessionRepository { return &sessionRepo{db: s.conn()} }
+func (s *Store) Audit() store.AuditRepository      { return &auditRepo{db: s.conn()} }
+func (s *Store) Blocks() store.BlockRepository     { return &blockRepo{db: s.conn()} }
+func (s *Store) Ping(ctx context.Context) error    { return s.db.PingContext(ctx) }
 func (s *Store) Close() error                      { return s.db.Close() }
 
 // userRepo implements store.UserRepository
 type userRepo struct {
-	db *sql.DB
+	db         dbtx
+	emails     emailPolicy
+	keyCleanup bool
 }
 
 func (r *userRepo) Create(ctx context.Context, user *store.User) error {
@@ -158,9 +507,9 @@ func (r *userRepo) Create(ctx context.Context, user *store.User) error {
 	}
 
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO users (id, email, google_id, name, public_key, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, user.ID, strings.ToLower(user.Email), nullString(user.GoogleID), user.Name, nullString(user.PublicKey), user.CreatedAt)
+		INSERT INTO users (id, email, email_key, google_id, apple_id, name, public_key, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, user.ID, strings.ToLower(user.Email), r.emails.key(user.Email), nullString(user.GoogleID), nullString(user.AppleID), user.Name, nullString(user.PublicKey), user.CreatedAt)
 
 	if err != nil && strings.Contains(err.Error(), "UNIQUE") {
 		return store.ErrDuplicateKey
@@ -170,11 +519,12 @@ func (r *userRepo) Create(ctx context.Context, user *store.User) error {
 
 func (r *userRepo) GetByID(ctx context.Context, id string) (*store.User, error) {
 	user := &store.User{}
-	var googleID, publicKey sql.NullString
+	var googleID, appleID, publicKey sql.NullString
+	var lastLogin sql.NullTime
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, email, google_id, name, public_key, created_at
-		FROM users WHERE id = ?
-	`, id).Scan(&user.ID, &user.Email, &googleID, &user.Name, &publicKey, &user.CreatedAt)
+		SELECT id, email, google_id, apple_id, name, public_key, created_at, last_login_at
+		FROM users WHERE id = ? AND deleted_at IS NULL
+	`, id).Scan(&user.ID, &user.Email, &googleID, &appleID, &user.Name, &publicKey, &user.CreatedAt, &lastLogin)
 
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
@@ -184,17 +534,32 @@ func (r *userRepo) GetByID(ctx context.Context, id string) (*store.User, error)
 	}
 
 	user.GoogleID = googleID.String
+	user.AppleID = appleID.String
 	user.PublicKey = publicKey.String
+	if lastLogin.Valid {
+		user.LastLoginAt = &lastLogin.Time
+	}
 	return user, nil
 }
 
 func (r *userRepo) GetByEmail(ctx context.Context, email string) (*store.User, error) {
+	return r.getUser(ctx, `email = ?`, strings.ToLower(strings.TrimSpace(email)))
+}
+
+func (r *userRepo) FindByEmail(ctx context.Context, email string) (*store.User, error) {
+	return r.getUser(ctx, `email_key = ?`, r.emails.key(email))
+}
+
+// getUser returns the oldest live user matching where
+func (r *userRepo) getUser(ctx context.Context, where string, args ...any) (*store.User, error) {
 	user := &store.User{}
-	var googleID, publicKey sql.NullString
+	var googleID, appleID, publicKey sql.NullString
+	var lastLogin sql.NullTime
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, email, google_id, name, public_key, created_at
-		FROM users WHERE email = ?
-	`, strings.ToLower(email)).Scan(&user.ID, &user.Email, &googleID, &user.Name, &publicKey, &user.CreatedAt)
+		SELECT id, email, google_id, apple_id, name, public_key, created_at, last_login_at
+		FROM users WHERE `+where+` AND deleted_at IS NULL
+		ORDER BY created_at, id LIMIT 1
+	`, args...).Scan(&user.ID, &user.Email, &googleID, &appleID, &user.Name, &publicKey, &user.CreatedAt, &lastLogin)
 
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
@@ -204,17 +569,22 @@ func (r *userRepo) GetByEmail(ctx context.Context, email string) (*store.User, e
 	}
 
 	user.GoogleID = googleID.String
+	user.AppleID = appleID.String
 	user.PublicKey = publicKey.String
+	if lastLogin.Valid {
+		user.LastLoginAt = &lastLogin.Time
+	}
 	return user, nil
 }
 
 func (r *userRepo) GetByGoogleID(ctx context.Context, googleID string) (*store.User, error) {
 	user := &store.User{}
-	var gid, publicKey sql.NullString
+	var gid, appleID, publicKey sql.NullString
+	var lastLogin sql.NullTime
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, email, google_id, name, public_key, created_at
-		FROM users WHERE google_id = ?
-	`, googleID).Scan(&user.ID, &user.Email, &gid, &user.Name, &publicKey, &user.CreatedAt)
+		SELECT id, email, google_id, apple_id, name, public_key, created_at, last_login_at
+		FROM users WHERE google_id = ? AND deleted_at IS NULL
+	`, googleID).Scan(&user.ID, &user.Email, &gid, &appleID, &user.Name, &publicKey, &user.CreatedAt, &lastLogin)
 
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
@@ -224,15 +594,44 @@ func (r *userRepo) GetByGoogleID(ctx context.Context, googleID string) (*store.U
 	}
 
 	user.GoogleID = gid.String
+	user.AppleID = appleID.String
+	user.PublicKey = publicKey.String
+	if lastLogin.Valid {
+		user.LastLoginAt = &lastLogin.Time
+	}
+	return user, nil
+}
+
+func (r *userRepo) GetByAppleID(ctx context.Context, appleID string) (*store.User, error) {
+	user := &store.User{}
+	var googleID, aid, publicKey sql.NullString
+	var lastLogin sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, email, google_id, apple_id, name, public_key, created_at, last_login_at
+		FROM users WHERE apple_id = ? AND deleted_at IS NULL
+	`, appleID).Scan(&user.ID, &user.Email, &googleID, &aid, &user.Name, &publicKey, &user.CreatedAt, &lastLogin)
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user.GoogleID = googleID.String
+	user.AppleID = aid.String
 	user.PublicKey = publicKey.String
+	if lastLogin.Valid {
+		user.LastLoginAt = &lastLogin.Time
+	}
 	return user, nil
 }
 
 func (r *userRepo) Update(ctx context.Context, user *store.User) error {
 	result, err := r.db.ExecContext(ctx, `
-		UPDATE users SET email = ?, name = ?, google_id = ?
+		UPDATE users SET email = ?, email_key = ?, name = ?, google_id = ?, apple_id = ?, updated_at = ?
 		WHERE id = ?
-	`, strings.ToLower(user.Email), user.Name, nullString(user.GoogleID), user.ID)
+	`, strings.ToLower(user.Email), r.emails.key(user.Email), user.Name, nullString(user.GoogleID), nullString(user.AppleID), time.Now(), user.ID)
 
 	if err != nil {
 		return err
@@ -256,11 +655,20 @@ func (r *userRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *userRepo) SetPublicKey(ctx context.Context, userID, publicKey string) error {
-	result, err := r.db.ExecContext(ctx, `
-		UPDATE users SET public_key = ? WHERE id = ?
-	`, publicKey, userID)
+func (r *userRepo) SoftDelete(ctx context.Context, id string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
+	// Release the email and login IDs, so the person can sign up again
+	// instead of colliding with the row left behind
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users SET deleted_at = ?, email = 'deleted:' || id, email_key = 'deleted:' || id,
+			google_id = NULL, apple_id = NULL
+		WHERE id = ? AND deleted_at IS NULL
+	`, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -268,136 +676,750 @@ func (r *userRepo) SetPublicKey(ctx context.Context, userID, publicKey string) e
 	if rows == 0 {
 		return store.ErrNotFound
 	}
-	return nil
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *userRepo) GetIdentityBackup(ctx context.Context, userID string) (*store.IdentityBackup, error) {
-	backup := &store.IdentityBackup{}
-	err := r.db.QueryRowContext(ctx, `
-		SELECT algorithm, kdf, iterations, salt, iv, payload
-		FROM identity_backups WHERE user_id = ?
-	`, userID).Scan(&backup.Algorithm, &backup.KDF, &backup.Iterations, &backup.Salt, &backup.IV, &backup.Payload)
+func (r *userRepo) DeleteAccount(ctx context.Context, id string) (*store.AccountDeletion, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	if err == sql.ErrNoRows {
-		return nil, store.ErrNotFound
+	var deleted store.AccountDeletion
+	steps := []struct {
+		count *int64
+		query string
+		args  []any
+	}{
+		{&deleted.Locations, `DELETE FROM encrypted_locations WHERE from_user_id = ? OR to_user_id = ?`, []any{id, id}},
+		{&deleted.Sessions, `DELETE FROM sessions WHERE user_id = ?`, []any{id}},
+		{&deleted.RefreshTokens, `DELETE FROM refresh_tokens WHERE user_id = ?`, []any{id}},
+		{&deleted.Devices, `DELETE FROM devices WHERE user_id = ?`, []any{id}},
+		{&deleted.ContactNotes, `DELETE FROM contact_notes WHERE user_id = ? OR contact_id = ?`, []any{id, id}},
+		{&deleted.Nudges, `DELETE FROM nudges WHERE from_user_id = ? OR to_user_id = ?`, []any{id, id}},
+		{&deleted.Presence, `DELETE FROM presence WHERE from_user_id = ? OR to_user_id = ?`, []any{id, id}},
+		{&deleted.Blocks, `DELETE FROM blocked_users WHERE user_id = ? OR blocked_id = ?`, []any{id, id}},
+		{&deleted.Contacts, `DELETE FROM contacts WHERE user_id = ? OR contact_id = ?`, []any{id, id}},
+		{&deleted.Requests, `DELETE FROM contact_requests WHERE requester_id = ? OR recipient_id = ?`, []any{id, id}},
+		{&deleted.IdentityBackup, `DELETE FROM identity_backups WHERE user_id = ?`, []any{id}},
+		{&deleted.UserData, `DELETE FROM user_data WHERE user_id = ?`, []any{id}},
+		{&deleted.Settings, `DELETE FROM user_settings WHERE user_id = ?`, []any{id}},
+		{&deleted.AuditEvents, `DELETE FROM audit_events WHERE user_id = ?`, []any{id}},
+		{&deleted.RecoveryCodes, `DELETE FROM recovery_codes WHERE user_id = ?`, []any{id}},
+	}
+	for _, step := range steps {
+		result, err := tx.ExecContext(ctx, step.query, step.args...)
+		if err != nil {
+			return nil, err
+		}
+		*step.count, _ = result.RowsAffected()
 	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
 	if err != nil {
 		return nil, err
 	}
-	return backup, nil
-}
-
-func (r *userRepo) SetIdentityBackup(ctx context.Context, userID string, backup *store.IdentityBackup) error {
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO identity_backups (user_id, algorithm, kdf, iterations, salt, iv, payload)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(user_id) DO UPDATE SET
-			algorithm = excluded.algorithm,
-			kdf = excluded.kdf,
-			iterations = excluded.iterations,
-			salt = excluded.salt,
-			iv = excluded.iv,
-			payload = excluded.payload
-	`, userID, backup.Algorithm, backup.KDF, backup.Iterations, backup.Salt, backup.IV, backup.Payload)
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, store.ErrNotFound
+	}
 
-	return err
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &deleted, nil
 }
 
-func (r *userRepo) GetUserData(ctx context.Context, userID string) (*store.UserData, error) {
-	data := &store.UserData{}
-	err := r.db.QueryRowContext(ctx, `
-		SELECT version, updated_at, blob FROM user_data WHERE user_id = ?
-	`, userID).Scan(&data.Version, &data.UpdatedAt, &data.Blob)
+func (r *userRepo) SetPublicKey(ctx context.Context, userID, publicKey string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
+	var existing sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT public_key FROM users WHERE id = ?`, userID).Scan(&existing)
 	if err == sql.ErrNoRows {
-		return nil, store.ErrNotFound
+		return store.ErrNotFound
 	}
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return data, nil
-}
 
-func (r *userRepo) SetUserData(ctx context.Context, userID string, data *store.UserData, expectedVersion int) error {
-	data.UpdatedAt = time.Now()
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET public_key = ?, updated_at = ? WHERE id = ?
+	`, publicKey, time.Now(), userID); err != nil {
+		return err
+	}
 
-	// For new data (version 0), just insert
-	if expectedVersion == 0 {
-		data.Version = 1
-		_, err := r.db.ExecContext(ctx, `
-			INSERT INTO user_data (user_id, version, updated_at, blob)
-			VALUES (?, ?, ?, ?)
-		`, userID, data.Version, data.UpdatedAt, data.Blob)
-		if err != nil && strings.Contains(err.Error(), "UNIQUE") {
-			return store.ErrVersionConflict
+	// Blobs shared under the old key are stale once it changes
+	if r.keyCleanup && existing.String != "" && existing.String != publicKey {
+		for _, query := range []string{
+			`DELETE FROM encrypted_locations WHERE from_user_id = ?`,
+			`DELETE FROM presence WHERE from_user_id = ?`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+				return err
+			}
 		}
-		return err
 	}
+	return tx.Commit()
+}
 
-	// For updates, check version
+func (r *userRepo) TouchLastLogin(ctx context.Context, userID string) error {
 	result, err := r.db.ExecContext(ctx, `
-		UPDATE user_data SET version = version + 1, updated_at = ?, blob = ?
-		WHERE user_id = ? AND version = ?
-	`, data.UpdatedAt, data.Blob, userID, expectedVersion)
+		UPDATE users SET last_login_at = ?, inactive_flagged_at = NULL WHERE id = ?
+	`, time.Now(), userID)
 
 	if err != nil {
 		return err
 	}
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return store.ErrVersionConflict
+		return store.ErrNotFound
 	}
-	data.Version = expectedVersion + 1
 	return nil
 }
 
-// contactRepo implements store.ContactRepository
-type contactRepo struct {
-	db *sql.DB
-}
-
-func (r *contactRepo) ListContacts(ctx context.Context, userID string) ([]*store.Contact, error) {
+func (r *userRepo) ListInactive(ctx context.Context, before time.Time) ([]*store.InactiveAccount, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT c.contact_id, u.name, u.email, u.public_key, c.created_at
-		FROM contacts c
-		JOIN users u ON u.id = c.contact_id
-		WHERE c.user_id = ?
-		ORDER BY u.name
-	`, userID)
+		SELECT id, created_at, last_login_at, inactive_flagged_at FROM users
+		WHERE deleted_at IS NULL AND COALESCE(last_login_at, created_at) < ?
+		ORDER BY id
+	`, before)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var contacts []*store.Contact
+	var accounts []*store.InactiveAccount
 	for rows.Next() {
-		c := &store.Contact{UserID: userID}
-		var publicKey sql.NullString
-		if err := rows.Scan(&c.ContactID, &c.Name, &c.Email, &publicKey, &c.CreatedAt); err != nil {
+		var a store.InactiveAccount
+		var lastLogin, flagged sql.NullTime
+		if err := rows.Scan(&a.UserID, &a.LastActive, &lastLogin, &flagged); err != nil {
 			return nil, err
 		}
-		c.PublicKey = publicKey.String
-		contacts = append(contacts, c)
+		if lastLogin.Valid {
+			a.LastActive = lastLogin.Time
+		}
+		if flagged.Valid {
+			a.FlaggedAt = &flagged.Time
+		}
+		accounts = append(accounts, &a)
 	}
-	return contacts, rows.Err()
+	return accounts, rows.Err()
 }
 
-func (r *contactRepo) RemoveContact(ctx context.Context, userID, contactID string) error {
-	// Remove both directions
+func (r *userRepo) FlagInactive(ctx context.Context, userID string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET inactive_flagged_at = COALESCE(inactive_flagged_at, ?)
+		WHERE id = ? AND deleted_at IS NULL
+	`, at, userID)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *userRepo) RecordLoginAttempt(ctx context.Context, attempt *store.LoginAttempt) error {
+	attempt.Email = strings.ToLower(strings.TrimSpace(attempt.Email))
+	attempt.CreatedAt = time.Now()
 	_, err := r.db.ExecContext(ctx, `
-		DELETE FROM contacts
-		WHERE (user_id = ? AND contact_id = ?) OR (user_id = ? AND contact_id = ?)
-	`, userID, contactID, contactID, userID)
+		INSERT INTO login_attempts (email, ip, success, created_at)
+		VALUES (?, ?, ?, ?)
+	`, attempt.Email, attempt.IP, attempt.Success, attempt.CreatedAt)
 	return err
 }
 
-func (r *contactRepo) CreateRequest(ctx context.Context, requesterID, recipientID string) (*store.ContactRequest, error) {
-	req := &store.ContactRequest{
-		ID:          uuid.New().String(),
-		RequesterID: requesterID,
-		RecipientID: recipientID,
-		Status:      "pending",
-		CreatedAt:   time.Now(),
+func (r *userRepo) CountRecentFailures(ctx context.Context, email, ip string, since time.Time) (int, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	// A successful login resets only the count it shares a key with, so
+	// someone logging in to their own account from an IP doesn't clear
+	// the failures against other emails tried from there
+	emailSince, err := r.lastLoginSuccess(ctx, "email", email, since)
+	if err != nil {
+		return 0, err
+	}
+	ipSince, err := r.lastLoginSuccess(ctx, "ip", ip, since)
+	if err != nil {
+		return 0, err
+	}
+
+	// Empty keys never match, so an unknown email doesn't match every
+	// attempt that failed before an email was known
+	var count int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM login_attempts WHERE NOT success AND (
+			(email != '' AND email = ? AND created_at > ?) OR
+			(ip != '' AND ip = ? AND created_at > ?))
+	`, email, emailSince, ip, ipSince).Scan(&count)
+	return count, err
+}
+
+// lastLoginSuccess returns when the last successful login attempt with
+// column equal to value happened, or since if that's later
+func (r *userRepo) lastLoginSuccess(ctx context.Context, column, value string, since time.Time) (time.Time, error) {
+	if value == "" {
+		return since, nil
+	}
+	var last time.Time
+	err := r.db.QueryRowContext(ctx, `
+		SELECT created_at FROM login_attempts WHERE success AND `+column+` = ?
+		ORDER BY created_at DESC LIMIT 1
+	`, value).Scan(&last)
+	if err != nil && err != sql.ErrNoRows {
+		return since, err
+	}
+	if last.After(since) {
+		return last, nil
+	}
+	return since, nil
+}
+
+func (r *userRepo) PruneLoginAttempts(ctx context.Context, before time.Time) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE created_at < ?`, before)
+	return err
+}
+
+func (r *userRepo) SetRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO recovery_codes (code_hash, user_id, created_at) VALUES (?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, hash := range hashes {
+		if _, err := stmt.ExecContext(ctx, hash, userID, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepo) UseRecoveryCode(ctx context.Context, hash string) (string, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE recovery_codes SET used_at = ?
+		WHERE code_hash = ? AND used_at IS NULL
+			AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+		RETURNING user_id
+	`, time.Now(), hash).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", store.ErrNotFound
+	}
+	return userID, err
+}
+
+func (r *userRepo) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM recovery_codes WHERE user_id = ? AND used_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *userRepo) GetIdentityBackup(ctx context.Context, userID string) (*store.IdentityBackup, error) {
+	backup := &store.IdentityBackup{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT algorithm, kdf, iterations, memory, parallelism, salt, iv, payload, generation
+		FROM identity_backups WHERE user_id = ?
+	`, userID).Scan(&backup.Algorithm, &backup.KDF, &backup.Iterations, &backup.Memory, &backup.Parallelism,
+		&backup.Salt, &backup.IV, &backup.Payload, &backup.Generation)
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+func (r *userRepo) SetIdentityBackup(ctx context.Context, userID string, backup *store.IdentityBackup, expectedGeneration int) error {
+	// For a new backup (generation 0), just insert
+	if expectedGeneration == 0 {
+		backup.Generation = 1
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO identity_backups (user_id, algorithm, kdf, iterations, memory, parallelism, salt, iv, payload, generation)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, userID, backup.Algorithm, backup.KDF, backup.Iterations, backup.Memory, backup.Parallelism,
+			backup.Salt, backup.IV, backup.Payload, backup.Generation)
+		if err != nil && strings.Contains(err.Error(), "UNIQUE") {
+			return store.ErrVersionConflict
+		}
+		return err
+	}
+
+	// For replacements, check generation
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE identity_backups SET
+			algorithm = ?, kdf = ?, iterations = ?, memory = ?, parallelism = ?, salt = ?, iv = ?, payload = ?,
+			generation = generation + 1
+		WHERE user_id = ? AND generation = ?
+	`, backup.Algorithm, backup.KDF, backup.Iterations, backup.Memory, backup.Parallelism,
+		backup.Salt, backup.IV, backup.Payload, userID, expectedGeneration)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrVersionConflict
+	}
+	backup.Generation = expectedGeneration + 1
+	return nil
+}
+
+func (r *userRepo) Onboard(ctx context.Context, userID, publicKey string, backup *store.IdentityBackup) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var existing sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT public_key FROM users WHERE id = ?`, userID).Scan(&existing)
+	if err == sql.ErrNoRows {
+		return store.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if existing.String != "" {
+		return store.ErrVersionConflict
+	}
+
+	// An existing backup makes the insert fail and undoes the key
+	inTx := &userRepo{db: tx, emails: r.emails, keyCleanup: r.keyCleanup}
+	if err := inTx.SetPublicKey(ctx, userID, publicKey); err != nil {
+		return err
+	}
+	if err := inTx.SetIdentityBackup(ctx, userID, backup, 0); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *userRepo) GetIdentityBackupMeta(ctx context.Context, userID string) (*store.IdentityBackupMeta, error) {
+	meta := &store.IdentityBackupMeta{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT algorithm, kdf, iterations, memory, parallelism
+		FROM identity_backups WHERE user_id = ?
+	`, userID).Scan(&meta.Algorithm, &meta.KDF, &meta.Iterations, &meta.Memory, &meta.Parallelism)
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (r *userRepo) GetUserData(ctx context.Context, userID string) (*store.UserData, error) {
+	data := &store.UserData{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT version, updated_at, blob FROM user_data WHERE user_id = ?
+	`, userID).Scan(&data.Version, &data.UpdatedAt, &data.Blob)
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *userRepo) SetUserData(ctx context.Context, userID string, data *store.UserData, expectedVersion int) error {
+	data.UpdatedAt = time.Now()
+
+	// For new data (version 0), insert unless another device got there first
+	if expectedVersion == 0 {
+		result, err := r.db.ExecContext(ctx, `
+			INSERT INTO user_data (user_id, version, updated_at, blob)
+			VALUES (?, 1, ?, ?)
+			ON CONFLICT(user_id) DO NOTHING
+		`, userID, data.UpdatedAt, data.Blob)
+		if err != nil {
+			return err
+		}
+		rows, _ := result.RowsAffected()
+		if rows == 0 {
+			return r.userDataConflict(ctx, userID, data)
+		}
+		data.Version = 1
+		return nil
+	}
+
+	// For updates, check version
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE user_data SET version = version + 1, updated_at = ?, blob = ?
+		WHERE user_id = ? AND version = ?
+	`, data.UpdatedAt, data.Blob, userID, expectedVersion)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return r.userDataConflict(ctx, userID, data)
+	}
+	data.Version = expectedVersion + 1
+	return nil
+}
+
+// userDataConflict reports the stored version in data and returns
+// ErrVersionConflict, so callers can retry without another round trip
+func (r *userRepo) userDataConflict(ctx context.Context, userID string, data *store.UserData) error {
+	err := r.db.QueryRowContext(ctx, `
+		SELECT version FROM user_data WHERE user_id = ?
+	`, userID).Scan(&data.Version)
+	if err == sql.ErrNoRows {
+		data.Version = 0
+	} else if err != nil {
+		return err
+	}
+	return store.ErrVersionConflict
+}
+
+func (r *userRepo) UserStorageBytes(ctx context.Context, userID string) (*store.StorageUsage, error) {
+	usage := &store.StorageUsage{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COALESCE(SUM(LENGTH(salt) + LENGTH(iv) + LENGTH(payload)), 0)
+				FROM identity_backups WHERE user_id = ?),
+			(SELECT COALESCE(SUM(LENGTH(blob)), 0) FROM user_data WHERE user_id = ?),
+			(SELECT COALESCE(SUM(LENGTH(blob)), 0) FROM encrypted_locations WHERE from_user_id = ?)
+	`, userID, userID, userID).Scan(&usage.IdentityBackup, &usage.UserData, &usage.Locations)
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func (r *userRepo) GetSettings(ctx context.Context, userID string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT key, value FROM user_settings WHERE user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+func (r *userRepo) SetSettings(ctx context.Context, userID string, values map[string]string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO user_settings (user_id, key, value, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id, key) DO UPDATE SET
+			value = excluded.value,
+			updated_at = excluded.updated_at
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for key, value := range values {
+		if _, err := stmt.ExecContext(ctx, userID, key, value, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// contactRepo implements store.ContactRepository
+type contactRepo struct {
+	db dbtx
+}
+
+func (r *contactRepo) ListContacts(ctx context.Context, userID string) ([]*store.Contact, error) {
+	return r.listContacts(ctx, userID, "", nil)
+}
+
+func (r *contactRepo) ListContactsPage(ctx context.Context, userID string, offset, limit int) ([]*store.Contact, error) {
+	return r.listContacts(ctx, userID, "LIMIT ? OFFSET ?", []any{limit, offset})
+}
+
+// listContacts lists the user's contacts, pinned first, then by name, with
+// page appended to the query
+func (r *contactRepo) listContacts(ctx context.Context, userID, page string, pageArgs []any) ([]*store.Contact, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.contact_id, u.name, u.email, u.public_key, c.created_at, c.sort_order, COALESCE(n.note, ''),
+			c.updated_at, u.updated_at
+		FROM contacts c
+		JOIN users u ON u.id = c.contact_id
+		LEFT JOIN contact_notes n ON n.user_id = c.user_id AND n.contact_id = c.contact_id
+		WHERE c.user_id = ? AND u.deleted_at IS NULL
+		ORDER BY c.sort_order IS NULL, c.sort_order, u.name, c.contact_id
+		`+page, append([]any{userID}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []*store.Contact
+	for rows.Next() {
+		c := &store.Contact{UserID: userID}
+		var publicKey sql.NullString
+		var sortOrder sql.NullInt64
+		var contactUpdated, userUpdated sql.NullTime
+		if err := rows.Scan(&c.ContactID, &c.Name, &c.Email, &publicKey, &c.CreatedAt, &sortOrder, &c.Note,
+			&contactUpdated, &userUpdated); err != nil {
+			return nil, err
+		}
+		c.PublicKey = publicKey.String
+		if sortOrder.Valid {
+			order := int(sortOrder.Int64)
+			c.SortOrder = &order
+		}
+		c.UpdatedAt = c.CreatedAt
+		for _, t := range []sql.NullTime{contactUpdated, userUpdated} {
+			if t.Valid && t.Time.After(c.UpdatedAt) {
+				c.UpdatedAt = t.Time
+			}
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, rows.Err()
+}
+
+func (r *contactRepo) CountContacts(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+		WHERE c.user_id = ? AND u.deleted_at IS NULL
+	`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *contactRepo) Summary(ctx context.Context, userID string) (*store.ContactSummary, error) {
+	summary := &store.ContactSummary{}
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+				WHERE c.user_id = ? AND u.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+				WHERE c.user_id = ? AND u.deleted_at IS NULL AND u.public_key > ''),
+			(SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id JOIN encrypted_locations l
+				ON l.from_user_id = c.user_id AND l.to_user_id = c.contact_id
+				WHERE c.user_id = ? AND u.deleted_at IS NULL AND (l.expires_at IS NULL OR l.expires_at > ?)),
+			(SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id JOIN encrypted_locations l
+				ON l.from_user_id = c.contact_id AND l.to_user_id = c.user_id
+				WHERE c.user_id = ? AND u.deleted_at IS NULL AND (l.expires_at IS NULL OR l.expires_at > ?)),
+			(SELECT COUNT(*) FROM contact_requests cr JOIN users u ON u.id = cr.requester_id
+				WHERE cr.recipient_id = ? AND cr.status = 'pending' AND u.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM contact_requests cr JOIN users u ON u.id = cr.recipient_id
+				WHERE cr.requester_id = ? AND cr.status = 'pending' AND u.deleted_at IS NULL)
+	`, userID, userID, userID, now, userID, now, userID, userID).Scan(
+		&summary.Contacts, &summary.WithPublicKey, &summary.SharingTo,
+		&summary.SharingFrom, &summary.PendingIncoming, &summary.PendingOutgoing)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func (r *contactRepo) CountContactsWithKeys(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+		WHERE c.user_id = ? AND u.deleted_at IS NULL AND u.public_key > ''
+	`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *contactRepo) SetSortOrder(ctx context.Context, userID, contactID string, order *int) error {
+	var value sql.NullInt64
+	if order != nil {
+		value = sql.NullInt64{Int64: int64(*order), Valid: true}
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE contacts SET sort_order = ?, updated_at = ? WHERE user_id = ? AND contact_id = ?
+	`, value, time.Now(), userID, contactID)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *contactRepo) SetNote(ctx context.Context, userID, contactID, note string) error {
+	if note == "" {
+		// Still report unknown contacts
+		areContacts, err := r.AreContacts(ctx, userID, contactID)
+		if err != nil {
+			return err
+		}
+		if !areContacts {
+			return store.ErrNotFound
+		}
+		_, err = r.db.ExecContext(ctx, `
+			DELETE FROM contact_notes WHERE user_id = ? AND contact_id = ?
+		`, userID, contactID)
+		if err != nil {
+			return err
+		}
+		return r.touchContact(ctx, userID, contactID)
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO contact_notes (user_id, contact_id, note, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id, contact_id) DO UPDATE SET
+			note = excluded.note,
+			updated_at = excluded.updated_at
+	`, userID, contactID, note, time.Now())
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY") {
+		return store.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return r.touchContact(ctx, userID, contactID)
+}
+
+func (r *contactRepo) Nudge(ctx context.Context, userID, contactID string, notSince time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO nudges (from_user_id, to_user_id, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(from_user_id, to_user_id) DO UPDATE SET
+			created_at = excluded.created_at
+		WHERE nudges.created_at < ?
+	`, userID, contactID, time.Now(), notSince)
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY") {
+		return false, store.ErrNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	rows, _ := result.RowsAffected()
+	return rows > 0, nil
+}
+
+func (r *contactRepo) ListNudges(ctx context.Context, userID string) ([]*store.Nudge, error) {
+	// A nudge is answered once the user shares with the nudger
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT n.from_user_id, n.to_user_id, n.created_at
+		FROM nudges n
+		JOIN users u ON u.id = n.from_user_id
+		LEFT JOIN encrypted_locations l ON l.from_user_id = n.to_user_id AND l.to_user_id = n.from_user_id
+		WHERE n.to_user_id = ? AND u.deleted_at IS NULL
+		  AND (l.updated_at IS NULL OR l.updated_at < n.created_at)
+		ORDER BY n.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nudges []*store.Nudge
+	for rows.Next() {
+		var n store.Nudge
+		if err := rows.Scan(&n.FromUserID, &n.ToUserID, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		nudges = append(nudges, &n)
+	}
+	return nudges, rows.Err()
+}
+
+// touchContact bumps a contact's updated_at so incremental syncs pick it up
+func (r *contactRepo) touchContact(ctx context.Context, userID, contactID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE contacts SET updated_at = ? WHERE user_id = ? AND contact_id = ?
+	`, time.Now(), userID, contactID)
+	return err
+}
+
+func (r *contactRepo) RemoveContact(ctx context.Context, userID, contactID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Remove both directions
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM contacts
+		WHERE (user_id = ? AND contact_id = ?) OR (user_id = ? AND contact_id = ?)
+	`, userID, contactID, contactID, userID); err != nil {
+		return err
+	}
+
+	// The accepted request that made them contacts would otherwise hold
+	// the pair's row, and either of them asking again would conflict
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM contact_requests
+		WHERE ((requester_id = ?1 AND recipient_id = ?2) OR (requester_id = ?2 AND recipient_id = ?1))
+		AND status = 'accepted'
+	`, userID, contactID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *contactRepo) CreateRequest(ctx context.Context, requesterID, recipientID string) (*store.ContactRequest, error) {
+	req := &store.ContactRequest{
+		ID:          uuid.New().String(),
+		RequesterID: requesterID,
+		RecipientID: recipientID,
+		Status:      "pending",
+		CreatedAt:   time.Now(),
 	}
 
 	_, err := r.db.ExecContext(ctx, `
@@ -411,13 +1433,33 @@ func (r *contactRepo) CreateRequest(ctx context.Context, requesterID, recipientI
 	return req, err
 }
 
-func (r *contactRepo) GetRequest(ctx context.Context, requestID string) (*store.ContactRequest, error) {
+// contactRequestColumns are the columns read by scanContactRequest
+const contactRequestColumns = `id, requester_id, recipient_id, status, created_at, accepted_at, declined_at, expired_at`
+
+// scanContactRequest reads a row selected with contactRequestColumns
+func scanContactRequest(row interface{ Scan(...any) error }) (*store.ContactRequest, error) {
 	req := &store.ContactRequest{}
-	var acceptedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, requester_id, recipient_id, status, created_at, accepted_at
+	var acceptedAt, declinedAt, expiredAt sql.NullTime
+	if err := row.Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &acceptedAt, &declinedAt, &expiredAt); err != nil {
+		return nil, err
+	}
+	if acceptedAt.Valid {
+		req.AcceptedAt = &acceptedAt.Time
+	}
+	if declinedAt.Valid {
+		req.DeclinedAt = &declinedAt.Time
+	}
+	if expiredAt.Valid {
+		req.ExpiredAt = &expiredAt.Time
+	}
+	return req, nil
+}
+
+func (r *contactRepo) GetRequest(ctx context.Context, requestID string) (*store.ContactRequest, error) {
+	req, err := scanContactRequest(r.db.QueryRowContext(ctx, `
+		SELECT `+contactRequestColumns+`
 		FROM contact_requests WHERE id = ?
-	`, requestID).Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &acceptedAt)
+	`, requestID))
 
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
@@ -425,18 +1467,45 @@ func (r *contactRepo) GetRequest(ctx context.Context, requestID string) (*store.
 	if err != nil {
 		return nil, err
 	}
-	if acceptedAt.Valid {
-		req.AcceptedAt = &acceptedAt.Time
-	}
 	return req, nil
 }
 
+func (r *contactRepo) ListRequestHistory(ctx context.Context, userID string, beforeCreatedAt time.Time, beforeID string, limit int) ([]*store.ContactRequest, error) {
+	query := `
+		SELECT ` + contactRequestColumns + `
+		FROM contact_requests
+		WHERE (requester_id = ? OR recipient_id = ?)`
+	args := []any{userID, userID}
+	if beforeID != "" {
+		query += ` AND (created_at < ? OR (created_at = ? AND id < ?))`
+		args = append(args, beforeCreatedAt, beforeCreatedAt, beforeID)
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*store.ContactRequest
+	for rows.Next() {
+		req, err := scanContactRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, rows.Err()
+}
+
 func (r *contactRepo) ListIncomingRequests(ctx context.Context, userID string) ([]*store.ContactRequest, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT cr.id, cr.requester_id, cr.recipient_id, cr.status, cr.created_at, u.name, u.email
 		FROM contact_requests cr
 		JOIN users u ON u.id = cr.requester_id
-		WHERE cr.recipient_id = ? AND cr.status = 'pending'
+		WHERE cr.recipient_id = ? AND cr.status = 'pending' AND u.deleted_at IS NULL
 		ORDER BY cr.created_at DESC
 	`, userID)
 	if err != nil {
@@ -447,8 +1516,7 @@ func (r *contactRepo) ListIncomingRequests(ctx context.Context, userID string) (
 	var requests []*store.ContactRequest
 	for rows.Next() {
 		req := &store.ContactRequest{}
-		var name, email string
-		if err := rows.Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &name, &email); err != nil {
+		if err := rows.Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &req.Name, &req.Email); err != nil {
 			return nil, err
 		}
 		requests = append(requests, req)
@@ -461,7 +1529,7 @@ func (r *contactRepo) ListOutgoingRequests(ctx context.Context, userID string) (
 		SELECT cr.id, cr.requester_id, cr.recipient_id, cr.status, cr.created_at, u.name, u.email
 		FROM contact_requests cr
 		JOIN users u ON u.id = cr.recipient_id
-		WHERE cr.requester_id = ? AND cr.status = 'pending'
+		WHERE cr.requester_id = ? AND cr.status = 'pending' AND u.deleted_at IS NULL
 		ORDER BY cr.created_at DESC
 	`, userID)
 	if err != nil {
@@ -472,8 +1540,7 @@ func (r *contactRepo) ListOutgoingRequests(ctx context.Context, userID string) (
 	var requests []*store.ContactRequest
 	for rows.Next() {
 		req := &store.ContactRequest{}
-		var name, email string
-		if err := rows.Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &name, &email); err != nil {
+		if err := rows.Scan(&req.ID, &req.RequesterID, &req.RecipientID, &req.Status, &req.CreatedAt, &req.Name, &req.Email); err != nil {
 			return nil, err
 		}
 		requests = append(requests, req)
@@ -482,7 +1549,7 @@ func (r *contactRepo) ListOutgoingRequests(ctx context.Context, userID string) (
 }
 
 func (r *contactRepo) AcceptRequest(ctx context.Context, requestID, userID string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return err
 	}
@@ -534,9 +1601,9 @@ func (r *contactRepo) AcceptRequest(ctx context.Context, requestID, userID strin
 
 func (r *contactRepo) DeclineRequest(ctx context.Context, requestID, userID string) error {
 	result, err := r.db.ExecContext(ctx, `
-		UPDATE contact_requests SET status = 'declined'
+		UPDATE contact_requests SET status = 'declined', declined_at = ?
 		WHERE id = ? AND recipient_id = ? AND status = 'pending'
-	`, requestID, userID)
+	`, time.Now(), requestID, userID)
 
 	if err != nil {
 		return err
@@ -548,10 +1615,22 @@ func (r *contactRepo) DeclineRequest(ctx context.Context, requestID, userID stri
 	return nil
 }
 
+func (r *contactRepo) ExpireRequests(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE contact_requests SET status = 'expired', expired_at = ?
+		WHERE status = 'pending' AND created_at < ?
+	`, time.Now(), before)
+	if err != nil {
+		return 0, err
+	}
+	rows, _ := result.RowsAffected()
+	return int(rows), nil
+}
+
 func (r *contactRepo) CancelRequest(ctx context.Context, requestID, userID string) error {
 	result, err := r.db.ExecContext(ctx, `
 		DELETE FROM contact_requests
-		WHERE id = ? AND requester_id = ? AND status = 'pending'
+		WHERE id = ? AND requester_id = ? AND status IN ('pending', 'expired')
 	`, requestID, userID)
 
 	if err != nil {
@@ -564,21 +1643,26 @@ func (r *contactRepo) CancelRequest(ctx context.Context, requestID, userID strin
 	return nil
 }
 
-func (r *contactRepo) CheckExistingRequest(ctx context.Context, requesterID, recipientID string) (bool, error) {
-	var count int
+func (r *contactRepo) PairStatus(ctx context.Context, userID, otherID string) (string, error) {
+	var status string
 	err := r.db.QueryRowContext(ctx, `
-		SELECT COUNT(*) FROM contact_requests
-		WHERE ((requester_id = ? AND recipient_id = ?) OR (requester_id = ? AND recipient_id = ?))
-		AND status = 'pending'
-	`, requesterID, recipientID, recipientID, requesterID).Scan(&count)
-
-	return count > 0, err
+		SELECT CASE
+			WHEN EXISTS (SELECT 1 FROM contacts
+				WHERE (user_id = ?1 AND contact_id = ?2) OR (user_id = ?2 AND contact_id = ?1)) THEN ?3
+			WHEN EXISTS (SELECT 1 FROM contact_requests
+				WHERE ((requester_id = ?1 AND recipient_id = ?2) OR (requester_id = ?2 AND recipient_id = ?1))
+				AND status = 'pending') THEN ?4
+			ELSE ?5
+		END
+	`, userID, otherID, store.PairContacts, store.PairPending, store.PairNone).Scan(&status)
+	return status, err
 }
 
 func (r *contactRepo) AreContacts(ctx context.Context, userID, otherID string) (bool, error) {
 	var count int
 	err := r.db.QueryRowContext(ctx, `
-		SELECT COUNT(*) FROM contacts WHERE user_id = ? AND contact_id = ?
+		SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+		WHERE c.user_id = ? AND c.contact_id = ? AND u.deleted_at IS NULL
 	`, userID, otherID).Scan(&count)
 
 	return count > 0, err
@@ -586,7 +1670,7 @@ func (r *contactRepo) AreContacts(ctx context.Context, userID, otherID string) (
 
 // deviceRepo implements store.DeviceRepository
 type deviceRepo struct {
-	db *sql.DB
+	db dbtx
 }
 
 func (r *deviceRepo) Create(ctx context.Context, device *store.Device) error {
@@ -602,16 +1686,35 @@ func (r *deviceRepo) Create(ctx context.Context, device *store.Device) error {
 	device.LastSeen = device.CreatedAt
 
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO devices (id, user_id, name, platform, token, created_at, last_seen)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-	`, device.ID, device.UserID, device.Name, device.Platform, device.Token, device.CreatedAt, device.LastSeen)
+		INSERT INTO devices (id, user_id, name, platform, user_agent, token, created_at, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, device.ID, device.UserID, device.Name, device.Platform, device.UserAgent, device.Token, device.CreatedAt, device.LastSeen)
 
 	return err
 }
 
+// deviceColumns are the columns read by scanDevice
+const deviceColumns = `id, user_id, name, platform, user_agent, token, created_at, last_seen, revoked_at, paused_until`
+
+// scanDevice reads a row selected with deviceColumns
+func scanDevice(row interface{ Scan(...any) error }) (*store.Device, error) {
+	d := &store.Device{}
+	var revokedAt, pausedUntil sql.NullTime
+	if err := row.Scan(&d.ID, &d.UserID, &d.Name, &d.Platform, &d.UserAgent, &d.Token, &d.CreatedAt, &d.LastSeen, &revokedAt, &pausedUntil); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		d.RevokedAt = &revokedAt.Time
+	}
+	if pausedUntil.Valid {
+		d.PausedUntil = &pausedUntil.Time
+	}
+	return d, nil
+}
+
 func (r *deviceRepo) List(ctx context.Context, userID string) ([]*store.Device, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, name, platform, token, created_at, last_seen, revoked_at
+		SELECT `+deviceColumns+`
 		FROM devices WHERE user_id = ?
 		ORDER BY last_seen DESC
 	`, userID)
@@ -622,26 +1725,20 @@ func (r *deviceRepo) List(ctx context.Context, userID string) ([]*store.Device,
 
 	var devices []*store.Device
 	for rows.Next() {
-		d := &store.Device{}
-		var revokedAt sql.NullTime
-		if err := rows.Scan(&d.ID, &d.UserID, &d.Name, &d.Platform, &d.Token, &d.CreatedAt, &d.LastSeen, &revokedAt); err != nil {
+		d, err := scanDevice(rows)
+		if err != nil {
 			return nil, err
 		}
-		if revokedAt.Valid {
-			d.RevokedAt = &revokedAt.Time
-		}
 		devices = append(devices, d)
 	}
 	return devices, rows.Err()
 }
 
 func (r *deviceRepo) GetByID(ctx context.Context, deviceID string) (*store.Device, error) {
-	d := &store.Device{}
-	var revokedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, user_id, name, platform, token, created_at, last_seen, revoked_at
+	d, err := scanDevice(r.db.QueryRowContext(ctx, `
+		SELECT `+deviceColumns+`
 		FROM devices WHERE id = ?
-	`, deviceID).Scan(&d.ID, &d.UserID, &d.Name, &d.Platform, &d.Token, &d.CreatedAt, &d.LastSeen, &revokedAt)
+	`, deviceID))
 
 	if err == sql.ErrNoRows {
 		return nil, store.ErrNotFound
@@ -649,109 +1746,312 @@ func (r *deviceRepo) GetByID(ctx context.Context, deviceID string) (*store.Devic
 	if err != nil {
 		return nil, err
 	}
-	if revokedAt.Valid {
-		d.RevokedAt = &revokedAt.Time
+	return d, nil
+}
+
+func (r *deviceRepo) GetByToken(ctx context.Context, token string) (*store.Device, error) {
+	d, err := scanDevice(r.db.QueryRowContext(ctx, `
+		SELECT `+deviceColumns+`
+		FROM devices WHERE token = ?
+	`, token))
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *deviceRepo) UpdateLastSeen(ctx context.Context, deviceID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE devices SET last_seen = ? WHERE id = ?
+	`, time.Now(), deviceID)
+	return err
+}
+
+func (r *deviceRepo) Rename(ctx context.Context, deviceID, userID, name string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE devices SET name = ? WHERE id = ? AND user_id = ?
+	`, name, deviceID, userID)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *deviceRepo) Revoke(ctx context.Context, deviceID, userID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE devices SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, time.Now(), deviceID, userID)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *deviceRepo) Pause(ctx context.Context, deviceID, userID string, until time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE devices SET paused_until = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, until, deviceID, userID)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *deviceRepo) Unpause(ctx context.Context, deviceID, userID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE devices SET paused_until = NULL WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`, deviceID, userID)
+
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// locationRepo implements store.LocationRepository
+type locationRepo struct {
+	db     dbtx
+	dedupe bool
+}
+
+// upsertSQL stores a shared location. With dedupe, an unchanged blob,
+// precision and expiry leave the existing row, and its updated_at, as they
+// were.
+func (r *locationRepo) upsertSQL() string {
+	query := `
+		INSERT INTO encrypted_locations (from_user_id, to_user_id, blob, updated_at, precision, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(from_user_id, to_user_id) DO UPDATE SET
+			blob = excluded.blob,
+			updated_at = excluded.updated_at,
+			precision = excluded.precision,
+			expires_at = excluded.expires_at`
+	if r.dedupe {
+		query += `
+		WHERE blob != excluded.blob OR precision != excluded.precision
+			OR expires_at IS NOT excluded.expires_at`
+	}
+	return query
+}
+
+// scanLocation reads a row selected as from_user_id, to_user_id, blob,
+// updated_at, precision, expires_at
+func scanLocation(rows *sql.Rows) (*store.EncryptedLocation, error) {
+	loc := &store.EncryptedLocation{}
+	var expiresAt sql.NullTime
+	if err := rows.Scan(&loc.FromUserID, &loc.ToUserID, &loc.Blob, &loc.UpdatedAt, &loc.Precision, &expiresAt); err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		loc.ExpiresAt = &expiresAt.Time
+	}
+	return loc, nil
+}
+
+func (r *locationRepo) GetLocationsForUser(ctx context.Context, userID string) ([]*store.EncryptedLocation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.from_user_id, l.to_user_id, l.blob, l.updated_at, l.precision, l.expires_at
+		FROM encrypted_locations l JOIN users u ON u.id = l.from_user_id
+		WHERE l.to_user_id = ? AND u.deleted_at IS NULL
+		  AND (l.expires_at IS NULL OR l.expires_at > ?)
+	`, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []*store.EncryptedLocation
+	for rows.Next() {
+		loc, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+	return locations, rows.Err()
+}
+
+func (r *locationRepo) GetLocationsForUserPage(ctx context.Context, userID, afterFromUserID string, limit int) ([]*store.EncryptedLocation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT l.from_user_id, l.to_user_id, l.blob, l.updated_at, l.precision, l.expires_at
+		FROM encrypted_locations l JOIN users u ON u.id = l.from_user_id
+		WHERE l.to_user_id = ? AND l.from_user_id > ? AND u.deleted_at IS NULL
+		  AND (l.expires_at IS NULL OR l.expires_at > ?)
+		ORDER BY l.from_user_id
+		LIMIT ?
+	`, userID, afterFromUserID, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []*store.EncryptedLocation
+	for rows.Next() {
+		loc, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+	return locations, rows.Err()
+}
+
+func (r *locationRepo) CountLocationsForUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM encrypted_locations l JOIN users u ON u.id = l.from_user_id
+		WHERE l.to_user_id = ? AND u.deleted_at IS NULL
+		  AND (l.expires_at IS NULL OR l.expires_at > ?)
+	`, userID, time.Now()).Scan(&count)
+	return count, err
+}
+
+func (r *locationRepo) HasIncoming(ctx context.Context, userID string) (bool, time.Time, error) {
+	// The latest unexpired row answers both questions
+	var latest time.Time
+	err := r.db.QueryRowContext(ctx, `
+		SELECT l.updated_at FROM encrypted_locations l JOIN users u ON u.id = l.from_user_id
+		WHERE l.to_user_id = ? AND u.deleted_at IS NULL
+		  AND (l.expires_at IS NULL OR l.expires_at > ?)
+		ORDER BY l.updated_at DESC LIMIT 1
+	`, userID, time.Now()).Scan(&latest)
+	if err == sql.ErrNoRows {
+		return false, time.Time{}, nil
+	}
+	if err != nil {
+		return false, time.Time{}, err
 	}
-	return d, nil
+	return true, latest, nil
 }
 
-func (r *deviceRepo) GetByToken(ctx context.Context, token string) (*store.Device, error) {
-	d := &store.Device{}
-	var revokedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, user_id, name, platform, token, created_at, last_seen, revoked_at
-		FROM devices WHERE token = ?
-	`, token).Scan(&d.ID, &d.UserID, &d.Name, &d.Platform, &d.Token, &d.CreatedAt, &d.LastSeen, &revokedAt)
-
-	if err == sql.ErrNoRows {
-		return nil, store.ErrNotFound
+func (r *locationRepo) SetLocations(ctx context.Context, fromUserID string, locations []*store.EncryptedLocation) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, r.upsertSQL())
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if revokedAt.Valid {
-		d.RevokedAt = &revokedAt.Time
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, loc := range locations {
+		loc.FromUserID = fromUserID
+		loc.UpdatedAt = now
+		if loc.Precision == "" {
+			loc.Precision = store.PrecisionExact
+		}
+		if _, err := stmt.ExecContext(ctx, loc.FromUserID, loc.ToUserID, loc.Blob, loc.UpdatedAt, loc.Precision, loc.ExpiresAt); err != nil {
+			return err
+		}
 	}
-	return d, nil
-}
 
-func (r *deviceRepo) UpdateLastSeen(ctx context.Context, deviceID string) error {
-	_, err := r.db.ExecContext(ctx, `
-		UPDATE devices SET last_seen = ? WHERE id = ?
-	`, time.Now(), deviceID)
-	return err
+	return tx.Commit()
 }
 
-func (r *deviceRepo) Revoke(ctx context.Context, deviceID, userID string) error {
-	result, err := r.db.ExecContext(ctx, `
-		UPDATE devices SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL
-	`, time.Now(), deviceID, userID)
-
+func (r *locationRepo) SetPresence(ctx context.Context, fromUserID string, statuses []*store.Presence) error {
+	tx, err := beginTx(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	rows, _ := result.RowsAffected()
-	if rows == 0 {
-		return store.ErrNotFound
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM presence WHERE from_user_id = ?`, fromUserID); err != nil {
+		return err
 	}
-	return nil
-}
 
-// locationRepo implements store.LocationRepository
-type locationRepo struct {
-	db *sql.DB
+	now := time.Now()
+	for _, p := range statuses {
+		p.FromUserID = fromUserID
+		p.UpdatedAt = now
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO presence (from_user_id, to_user_id, blob, updated_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT(from_user_id, to_user_id) DO UPDATE SET
+				blob = excluded.blob,
+				updated_at = excluded.updated_at
+		`, p.FromUserID, p.ToUserID, p.Blob, p.UpdatedAt)
+		if err != nil && strings.Contains(err.Error(), "FOREIGN KEY") {
+			return store.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
-func (r *locationRepo) GetLocationsForUser(ctx context.Context, userID string) ([]*store.EncryptedLocation, error) {
+func (r *locationRepo) GetPresenceForUser(ctx context.Context, userID string) ([]*store.Presence, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT from_user_id, to_user_id, blob, updated_at
-		FROM encrypted_locations WHERE to_user_id = ?
+		SELECT p.from_user_id, p.to_user_id, p.blob, p.updated_at
+		FROM presence p
+		JOIN users u ON u.id = p.from_user_id
+		WHERE p.to_user_id = ? AND u.deleted_at IS NULL
+		ORDER BY p.updated_at DESC, p.from_user_id
 	`, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var locations []*store.EncryptedLocation
+	var statuses []*store.Presence
 	for rows.Next() {
-		loc := &store.EncryptedLocation{}
-		if err := rows.Scan(&loc.FromUserID, &loc.ToUserID, &loc.Blob, &loc.UpdatedAt); err != nil {
+		var p store.Presence
+		if err := rows.Scan(&p.FromUserID, &p.ToUserID, &p.Blob, &p.UpdatedAt); err != nil {
 			return nil, err
 		}
-		locations = append(locations, loc)
+		statuses = append(statuses, &p)
 	}
-	return locations, rows.Err()
+	return statuses, rows.Err()
 }
 
-func (r *locationRepo) SetLocations(ctx context.Context, fromUserID string, locations []*store.EncryptedLocation) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO encrypted_locations (from_user_id, to_user_id, blob, updated_at)
-		VALUES (?, ?, ?, ?)
-		ON CONFLICT(from_user_id, to_user_id) DO UPDATE SET
-			blob = excluded.blob,
-			updated_at = excluded.updated_at
-	`)
+func (r *locationRepo) SetLocationsBestEffort(ctx context.Context, fromUserID string, locations []*store.EncryptedLocation) ([]store.LocationResult, error) {
+	stmt, err := r.db.PrepareContext(ctx, r.upsertSQL())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer stmt.Close()
 
 	now := time.Now()
+	results := make([]store.LocationResult, 0, len(locations))
 	for _, loc := range locations {
 		loc.FromUserID = fromUserID
 		loc.UpdatedAt = now
-		if _, err := stmt.ExecContext(ctx, loc.FromUserID, loc.ToUserID, loc.Blob, loc.UpdatedAt); err != nil {
-			return err
+		if loc.Precision == "" {
+			loc.Precision = store.PrecisionExact
 		}
+		_, err := stmt.ExecContext(ctx, loc.FromUserID, loc.ToUserID, loc.Blob, loc.UpdatedAt, loc.Precision, loc.ExpiresAt)
+		results = append(results, store.LocationResult{ToUserID: loc.ToUserID, Err: err})
 	}
 
-	return tx.Commit()
+	return results, nil
 }
 
 func (r *locationRepo) DeleteLocationsFromUser(ctx context.Context, userID string) error {
@@ -769,9 +2069,17 @@ func (r *locationRepo) DeleteLocationsBetween(ctx context.Context, userID, conta
 	return err
 }
 
+func (r *locationRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM encrypted_locations WHERE expires_at <= ?`, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // sessionRepo implements store.SessionRepository
 type sessionRepo struct {
-	db *sql.DB
+	db dbtx
 }
 
 func (r *sessionRepo) Create(ctx context.Context, session *store.Session) error {
@@ -808,18 +2116,179 @@ func (r *sessionRepo) GetByToken(ctx context.Context, token string) (*store.Sess
 	return s, nil
 }
 
+func (r *sessionRepo) SetDevice(ctx context.Context, token, deviceID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE sessions SET device_id = ? WHERE token = ?
+	`, deviceID, token)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return store.ErrNotFound
+	}
+
+	// Sessions refreshed from this one stay bound to the device
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET device_id = ? WHERE session_token = ?
+	`, deviceID, token); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sessionRepo) ListForDevice(ctx context.Context, deviceID string) ([]*store.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT token, user_id, device_id, created_at, expires_at
+		FROM sessions WHERE device_id = ? AND expires_at > ?
+		ORDER BY expires_at DESC
+	`, deviceID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*store.Session
+	for rows.Next() {
+		s := &store.Session{}
+		if err := rows.Scan(&s.Token, &s.UserID, &s.DeviceID, &s.CreatedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *sessionRepo) ListForUser(ctx context.Context, userID string) ([]*store.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT token, user_id, device_id, created_at, expires_at
+		FROM sessions WHERE user_id = ? AND expires_at > ?
+		ORDER BY created_at DESC
+	`, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*store.Session
+	for rows.Next() {
+		s := &store.Session{}
+		var deviceID sql.NullString
+		if err := rows.Scan(&s.Token, &s.UserID, &deviceID, &s.CreatedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		s.DeviceID = deviceID.String
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
 func (r *sessionRepo) Delete(ctx context.Context, token string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE token = ?`, token)
-	return err
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE token = ?`, token); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE session_token = ?`, token); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *sessionRepo) DeleteForUser(ctx context.Context, userID string) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, userID)
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sessionRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var deleted int64
+	for _, query := range []string{
+		`DELETE FROM sessions WHERE expires_at < ?`,
+		`DELETE FROM refresh_tokens WHERE expires_at < ?`,
+	} {
+		result, err := tx.ExecContext(ctx, query, now)
+		if err != nil {
+			return 0, err
+		}
+		n, _ := result.RowsAffected()
+		deleted += n
+	}
+	return deleted, tx.Commit()
+}
+
+func (r *sessionRepo) CreateRefreshToken(ctx context.Context, token *store.RefreshToken) error {
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (token_hash, user_id, session_token, device_id, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, token.TokenHash, token.UserID, token.SessionToken, nullString(token.DeviceID), token.CreatedAt, token.ExpiresAt)
 	return err
 }
 
-func (r *sessionRepo) DeleteExpired(ctx context.Context) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at < ?`, time.Now())
+func (r *sessionRepo) UseRefreshToken(ctx context.Context, tokenHash string) (*store.RefreshToken, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t := &store.RefreshToken{}
+	var deviceID sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT token_hash, user_id, session_token, device_id, created_at, expires_at
+		FROM refresh_tokens WHERE token_hash = ? AND expires_at > ?
+	`, tokenHash, time.Now()).Scan(&t.TokenHash, &t.UserID, &t.SessionToken, &deviceID, &t.CreatedAt, &t.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.DeviceID = deviceID.String
+
+	// A concurrent use may have got there first; only one wins
+	result, err := tx.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE token_hash = ?`, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, store.ErrNotFound
+	}
+	return t, tx.Commit()
+}
+
+func (r *sessionRepo) DeleteRefreshTokensForDevice(ctx context.Context, deviceID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE device_id = ?`, deviceID)
 	return err
 }
 
@@ -830,3 +2299,134 @@ func nullString(s string) sql.NullString {
 	}
 	return sql.NullString{String: s, Valid: true}
 }
+
+// blockRepo implements store.BlockRepository
+type blockRepo struct {
+	db dbtx
+}
+
+func (r *blockRepo) Block(ctx context.Context, userID, targetID string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO blocked_users (user_id, blocked_id, created_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(user_id, blocked_id) DO NOTHING
+	`, userID, targetID, time.Now())
+	if err != nil && strings.Contains(err.Error(), "FOREIGN KEY") {
+		return store.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM contact_requests
+		WHERE ((requester_id = ?1 AND recipient_id = ?2) OR (requester_id = ?2 AND recipient_id = ?1))
+		AND status = 'pending'
+	`, userID, targetID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *blockRepo) Unblock(ctx context.Context, userID, targetID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM blocked_users WHERE user_id = ? AND blocked_id = ?
+	`, userID, targetID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (r *blockRepo) IsBlocked(ctx context.Context, userID, targetID string) (bool, error) {
+	var blocked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM blocked_users WHERE user_id = ? AND blocked_id = ?)
+	`, userID, targetID).Scan(&blocked)
+	return blocked, err
+}
+
+func (r *blockRepo) ListBlocked(ctx context.Context, userID string) ([]*store.BlockedUser, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT u.id, u.name, u.email, b.created_at
+		FROM blocked_users b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.user_id = ? AND u.deleted_at IS NULL
+		ORDER BY b.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocked []*store.BlockedUser
+	for rows.Next() {
+		b := &store.BlockedUser{}
+		if err := rows.Scan(&b.UserID, &b.Name, &b.Email, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, b)
+	}
+	return blocked, rows.Err()
+}
+
+// auditRepo implements store.AuditRepository
+type auditRepo struct {
+	db dbtx
+}
+
+func (r *auditRepo) Record(ctx context.Context, event *store.AuditEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_events (user_id, action, target_id, created_at)
+		VALUES (?, ?, ?, ?)
+	`, event.UserID, event.Action, event.TargetID, event.CreatedAt)
+	if err != nil {
+		return err
+	}
+	event.ID, _ = result.LastInsertId()
+	return nil
+}
+
+func (r *auditRepo) ListForUser(ctx context.Context, userID string) ([]*store.AuditEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, action, target_id, created_at
+		FROM audit_events WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*store.AuditEvent
+	for rows.Next() {
+		e := &store.AuditEvent{}
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Action, &e.TargetID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (r *auditRepo) CountSince(ctx context.Context, userID, action string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM audit_events
+		WHERE user_id = ? AND action = ? AND created_at >= ?
+	`, userID, action, since).Scan(&count)
+	return count, err
+}
@@ -2,6 +2,11 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +24,32 @@ func newTestStore(t *testing.T) *Store {
 	return s
 }
 
+func TestNew_ForeignKeysOnEveryConnection(t *testing.T) {
+	s, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+
+	// Hold several connections at once so the pool has to open new ones
+	for i := 0; i < 3; i++ {
+		conn, err := s.db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn failed: %v", err)
+		}
+		defer conn.Close()
+
+		var enabled int
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enabled); err != nil {
+			t.Fatalf("PRAGMA foreign_keys failed: %v", err)
+		}
+		if enabled != 1 {
+			t.Errorf("connection %d has foreign_keys = %d, want 1", i, enabled)
+		}
+	}
+}
+
 // =============================================================================
 // UserRepository Tests
 // =============================================================================
@@ -110,6 +141,73 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 	}
 }
 
+func TestUserRepository_EmailNormalization(t *testing.T) {
+	for _, normalize := range []bool{true, false} {
+		s, err := New(":memory:", WithEmailNormalization(normalize, normalize))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer s.Close()
+		ctx := context.Background()
+
+		user := &store.User{Email: "ab@gmail.com", Name: "Test User"}
+		must(t, s.Users().Create(ctx, user))
+
+		got, err := s.Users().FindByEmail(ctx, "A.b+x@gmail.com")
+		if normalize {
+			if err != nil || got.ID != user.ID {
+				t.Errorf("normalized FindByEmail = %v, %v; want %s", got, err, user.ID)
+			} else if got.Email != "ab@gmail.com" {
+				t.Errorf("Email = %q, want the stored address", got.Email)
+			}
+		} else if !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("FindByEmail without normalization = %v, %v; want ErrNotFound", got, err)
+		}
+
+		// Sign in links accounts by email, so GetByEmail never normalizes
+		if _, err := s.Users().GetByEmail(ctx, "ab+x@gmail.com"); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("GetByEmail matched a variant (normalize=%v): %v", normalize, err)
+		}
+		if got, err := s.Users().GetByEmail(ctx, "AB@gmail.com"); err != nil || got.ID != user.ID {
+			t.Errorf("GetByEmail ignoring case = %v, %v; want %s", got, err, user.ID)
+		}
+
+		// Dots only matter for Gmail, and tags only for providers that
+		// deliver them to the base address
+		other := &store.User{Email: "c.d@example.com", Name: "Other"}
+		must(t, s.Users().Create(ctx, other))
+		for _, email := range []string{"cd@example.com", "c.d+x@example.com"} {
+			if _, err := s.Users().FindByEmail(ctx, email); !errors.Is(err, store.ErrNotFound) {
+				t.Errorf("%s matched c.d@example.com (normalize=%v): %v", email, normalize, err)
+			}
+		}
+	}
+}
+
+func TestUserRepository_EmailNormalizationExistingUsers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	s, err := New(path)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	user := &store.User{Email: "a.b@gmail.com", Name: "Test User"}
+	must(t, s.Users().Create(ctx, user))
+	s.Close()
+
+	// Turning normalization on applies to accounts created before
+	s, err = New(path, WithEmailNormalization(true, true))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer s.Close()
+	got, err := s.Users().FindByEmail(ctx, "ab+tag@gmail.com")
+	if err != nil || got.ID != user.ID {
+		t.Errorf("FindByEmail = %v, %v; want %s", got, err, user.ID)
+	}
+}
+
 func TestUserRepository_GetByGoogleID(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -131,6 +229,32 @@ func TestUserRepository_GetByGoogleID(t *testing.T) {
 	}
 }
 
+func TestUserRepository_GetByAppleID(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	must(t, s.Users().Create(ctx, user))
+	if _, err := s.Users().GetByAppleID(ctx, "apple123"); err != store.ErrNotFound {
+		t.Fatalf("GetByAppleID before linking: err = %v, want ErrNotFound", err)
+	}
+
+	user.AppleID = "apple123"
+	must(t, s.Users().Update(ctx, user))
+	got, err := s.Users().GetByAppleID(ctx, "apple123")
+	if err != nil {
+		t.Fatalf("GetByAppleID failed: %v", err)
+	}
+	if got.ID != user.ID || got.AppleID != "apple123" {
+		t.Errorf("GetByAppleID = %+v", got)
+	}
+
+	other := &store.User{Email: "other@example.com", AppleID: "apple123", Name: "Other"}
+	if err := s.Users().Create(ctx, other); err != store.ErrDuplicateKey {
+		t.Errorf("duplicate Apple ID: err = %v, want ErrDuplicateKey", err)
+	}
+}
+
 func TestUserRepository_Update(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -166,6 +290,191 @@ func TestUserRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestUserRepository_DeleteAccount(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b, c := users[0], users[1], users[2]
+
+	// Give a something in every table, in both directions where it applies
+	device := &store.Device{UserID: a.ID, Name: "Phone", Platform: "ios"}
+	must(t, s.Devices().Create(ctx, device))
+	must(t, s.Sessions().Create(ctx, &store.Session{Token: "a-token", UserID: a.ID, DeviceID: device.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+	req, err := s.Contacts().CreateRequest(ctx, a.ID, b.ID)
+	must(t, err)
+	must(t, s.Contacts().AcceptRequest(ctx, req.ID, b.ID))
+	must(t, s.Contacts().SetNote(ctx, a.ID, b.ID, "from a"))
+	must(t, s.Contacts().SetNote(ctx, b.ID, a.ID, "about a"))
+	_, err = s.Contacts().Nudge(ctx, b.ID, a.ID, time.Now())
+	must(t, err)
+	must(t, s.Locations().SetPresence(ctx, a.ID, []*store.Presence{{ToUserID: b.ID, Blob: "here"}}))
+	_, err = s.Contacts().CreateRequest(ctx, c.ID, a.ID)
+	must(t, err)
+	must(t, s.Locations().SetLocations(ctx, a.ID, []*store.EncryptedLocation{{ToUserID: b.ID, Blob: "ab"}}))
+	must(t, s.Locations().SetLocations(ctx, b.ID, []*store.EncryptedLocation{{ToUserID: a.ID, Blob: "ba"}}))
+	must(t, s.Users().SetIdentityBackup(ctx, a.ID, &store.IdentityBackup{Algorithm: "AES-256-GCM", KDF: "PBKDF2-SHA256", Iterations: 1, Salt: "s", IV: "i", Payload: "p"}, 0))
+	must(t, s.Users().SetUserData(ctx, a.ID, &store.UserData{Blob: "blob"}, 0))
+	must(t, s.Users().SetSettings(ctx, a.ID, map[string]string{"theme": "dark"}))
+	must(t, s.Audit().Record(ctx, &store.AuditEvent{UserID: a.ID, Action: store.AuditContactRemoved}))
+	must(t, s.Users().SetRecoveryCodes(ctx, a.ID, []string{"hash-1", "hash-2"}))
+
+	// b and c keep data that doesn't involve a
+	must(t, s.Locations().SetLocations(ctx, b.ID, []*store.EncryptedLocation{{ToUserID: c.ID, Blob: "bc"}}))
+
+	deleted, err := s.Users().DeleteAccount(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("DeleteAccount failed: %v", err)
+	}
+	want := store.AccountDeletion{
+		Locations: 2, Sessions: 1, Devices: 1, ContactNotes: 2, Nudges: 1,
+		Presence: 1, Contacts: 2, Requests: 2, IdentityBackup: 1, UserData: 1, Settings: 1, AuditEvents: 1,
+		RecoveryCodes: 2,
+	}
+	if *deleted != want {
+		t.Errorf("deleted = %+v, want %+v", *deleted, want)
+	}
+
+	for _, q := range []string{
+		`SELECT COUNT(*) FROM users WHERE id = ?1`,
+		`SELECT COUNT(*) FROM encrypted_locations WHERE from_user_id = ?1 OR to_user_id = ?1`,
+		`SELECT COUNT(*) FROM sessions WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM devices WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM contact_notes WHERE user_id = ?1 OR contact_id = ?1`,
+		`SELECT COUNT(*) FROM nudges WHERE from_user_id = ?1 OR to_user_id = ?1`,
+		`SELECT COUNT(*) FROM presence WHERE from_user_id = ?1 OR to_user_id = ?1`,
+		`SELECT COUNT(*) FROM contacts WHERE user_id = ?1 OR contact_id = ?1`,
+		`SELECT COUNT(*) FROM contact_requests WHERE requester_id = ?1 OR recipient_id = ?1`,
+		`SELECT COUNT(*) FROM identity_backups WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM user_data WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM user_settings WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM audit_events WHERE user_id = ?1`,
+		`SELECT COUNT(*) FROM recovery_codes WHERE user_id = ?1`,
+	} {
+		var n int
+		if err := s.db.QueryRowContext(ctx, q, a.ID).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+		if n != 0 {
+			t.Errorf("%s = %d, want 0", q, n)
+		}
+	}
+
+	if locs, _ := s.Locations().GetLocationsForUser(ctx, c.ID); len(locs) != 1 {
+		t.Errorf("c has %d locations after deleting a, want 1", len(locs))
+	}
+
+	if _, err := s.Users().DeleteAccount(ctx, a.ID); err != store.ErrNotFound {
+		t.Errorf("second DeleteAccount err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUserRepository_SoftDelete(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b, c := users[0], users[1], users[2]
+
+	a.GoogleID = "google-a"
+	must(t, s.Users().Update(ctx, a))
+	must(t, s.Sessions().Create(ctx, &store.Session{Token: "a-token", UserID: a.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+	req, err := s.Contacts().CreateRequest(ctx, a.ID, b.ID)
+	must(t, err)
+	must(t, s.Contacts().AcceptRequest(ctx, req.ID, b.ID))
+	must(t, s.Locations().SetLocations(ctx, a.ID, []*store.EncryptedLocation{{ToUserID: b.ID, Blob: "ab"}}))
+	_, err = s.Contacts().CreateRequest(ctx, a.ID, c.ID)
+	must(t, err)
+	_, err = s.Contacts().CreateRequest(ctx, c.ID, b.ID)
+	must(t, err)
+
+	must(t, s.Users().SoftDelete(ctx, a.ID))
+
+	// The row stays
+	var n int
+	must(t, s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE id = ?`, a.ID).Scan(&n))
+	if n != 1 {
+		t.Fatalf("users rows for a = %d, want 1", n)
+	}
+
+	// Lookups don't find a
+	if _, err := s.Users().GetByID(ctx, a.ID); err != store.ErrNotFound {
+		t.Errorf("GetByID err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Users().GetByEmail(ctx, a.Email); err != store.ErrNotFound {
+		t.Errorf("GetByEmail err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Users().GetByGoogleID(ctx, "google-a"); err != store.ErrNotFound {
+		t.Errorf("GetByGoogleID err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Sessions().GetByToken(ctx, "a-token"); err != store.ErrNotFound {
+		t.Errorf("GetByToken err = %v, want ErrNotFound", err)
+	}
+
+	// a is gone from b's contacts and locations
+	if contacts, _ := s.Contacts().ListContacts(ctx, b.ID); len(contacts) != 0 {
+		t.Errorf("b has %d contacts, want 0", len(contacts))
+	}
+	if ok, _ := s.Contacts().AreContacts(ctx, b.ID, a.ID); ok {
+		t.Error("AreContacts(b, a) = true, want false")
+	}
+	if locs, _ := s.Locations().GetLocationsForUser(ctx, b.ID); len(locs) != 0 {
+		t.Errorf("b has %d locations, want 0", len(locs))
+	}
+	if has, _, _ := s.Locations().HasIncoming(ctx, b.ID); has {
+		t.Error("HasIncoming(b) = true, want false")
+	}
+
+	// a's pending request to c is hidden, c's own request isn't
+	if incoming, _ := s.Contacts().ListIncomingRequests(ctx, c.ID); len(incoming) != 0 {
+		t.Errorf("c has %d incoming requests, want 0", len(incoming))
+	}
+	if outgoing, _ := s.Contacts().ListOutgoingRequests(ctx, c.ID); len(outgoing) != 1 {
+		t.Errorf("c has %d outgoing requests, want 1", len(outgoing))
+	}
+
+	summary, err := s.Contacts().Summary(ctx, b.ID)
+	must(t, err)
+	if *summary != (store.ContactSummary{PendingIncoming: 1}) {
+		t.Errorf("b summary = %+v, want only c's pending request", *summary)
+	}
+
+	if err := s.Users().SoftDelete(ctx, a.ID); err != store.ErrNotFound {
+		t.Errorf("second SoftDelete err = %v, want ErrNotFound", err)
+	}
+	// The email and Google ID are free for a new account
+	again := &store.User{Email: a.Email, GoogleID: "google-a", Name: a.Name}
+	if err := s.Users().Create(ctx, again); err != nil {
+		t.Errorf("Create with a's email and Google ID failed: %v", err)
+	}
+}
+
+func TestUserRepository_RecoveryCodes(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 1)
+
+	must(t, s.Users().SetRecoveryCodes(ctx, users[0].ID, []string{"hash-1", "hash-2"}))
+
+	userID, err := s.Users().UseRecoveryCode(ctx, "hash-1")
+	if err != nil || userID != users[0].ID {
+		t.Fatalf("UseRecoveryCode = %q, %v; want %q", userID, err, users[0].ID)
+	}
+	if _, err := s.Users().UseRecoveryCode(ctx, "hash-1"); err != store.ErrNotFound {
+		t.Errorf("reusing code err = %v, want ErrNotFound", err)
+	}
+	if n, _ := s.Users().CountRecoveryCodes(ctx, users[0].ID); n != 1 {
+		t.Errorf("remaining = %d, want 1", n)
+	}
+
+	// Replacing drops the old codes, used or not
+	must(t, s.Users().SetRecoveryCodes(ctx, users[0].ID, []string{"hash-3"}))
+	if _, err := s.Users().UseRecoveryCode(ctx, "hash-2"); err != store.ErrNotFound {
+		t.Errorf("replaced code err = %v, want ErrNotFound", err)
+	}
+	if n, _ := s.Users().CountRecoveryCodes(ctx, users[0].ID); n != 1 {
+		t.Errorf("remaining after replace = %d, want 1", n)
+	}
+}
+
 func TestUserRepository_SetPublicKey(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -184,6 +493,146 @@ func TestUserRepository_SetPublicKey(t *testing.T) {
 	}
 }
 
+func TestUserRepository_SetPublicKey_ClearsShares(t *testing.T) {
+	for _, cleanup := range []bool{true, false} {
+		s, err := New(":memory:", WithKeyChangeCleanup(cleanup))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer s.Close()
+		ctx := context.Background()
+		users := createTestUsers(t, s, 2)
+		a, b := users[0], users[1]
+
+		req, _ := s.Contacts().CreateRequest(ctx, a.ID, b.ID)
+		s.Contacts().AcceptRequest(ctx, req.ID, b.ID)
+		for _, pair := range [][2]*store.User{{a, b}, {b, a}} {
+			must(t, s.Locations().SetLocations(ctx, pair[0].ID, []*store.EncryptedLocation{{ToUserID: pair[1].ID, Blob: "loc"}}))
+			must(t, s.Locations().SetPresence(ctx, pair[0].ID, []*store.Presence{{ToUserID: pair[1].ID, Blob: "here"}}))
+		}
+
+		// Setting the same key again changes nothing
+		must(t, s.Users().SetPublicKey(ctx, a.ID, a.PublicKey))
+		if got, _ := s.Locations().GetLocationsForUser(ctx, b.ID); len(got) != 1 {
+			t.Fatalf("cleanup=%v: b has %d locations after same key, want 1", cleanup, len(got))
+		}
+
+		// Clearing the key drops a's outbound shares, but not what b shared
+		must(t, s.Users().SetPublicKey(ctx, a.ID, ""))
+		want := 1
+		if cleanup {
+			want = 0
+		}
+		if got, _ := s.Locations().GetLocationsForUser(ctx, b.ID); len(got) != want {
+			t.Errorf("cleanup=%v: b has %d locations, want %d", cleanup, len(got), want)
+		}
+		if got, _ := s.Locations().GetPresenceForUser(ctx, b.ID); len(got) != want {
+			t.Errorf("cleanup=%v: b has %d presence, want %d", cleanup, len(got), want)
+		}
+		if got, _ := s.Locations().GetLocationsForUser(ctx, a.ID); len(got) != 1 {
+			t.Errorf("cleanup=%v: a has %d locations, want 1", cleanup, len(got))
+		}
+	}
+}
+
+func TestUserRepository_TouchLastLogin(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	s.Users().Create(ctx, user)
+
+	got, _ := s.Users().GetByID(ctx, user.ID)
+	if got.LastLoginAt != nil {
+		t.Errorf("expected no last login for new user, got %v", got.LastLoginAt)
+	}
+
+	if err := s.Users().TouchLastLogin(ctx, user.ID); err != nil {
+		t.Fatalf("TouchLastLogin failed: %v", err)
+	}
+
+	got, _ = s.Users().GetByEmail(ctx, user.Email)
+	if got.LastLoginAt == nil {
+		t.Error("expected last login to be set")
+	}
+
+	if err := s.Users().TouchLastLogin(ctx, "nonexistent"); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUserRepository_LoginAttempts(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	record := func(email, ip string, success bool) {
+		t.Helper()
+		if err := s.Users().RecordLoginAttempt(ctx, &store.LoginAttempt{Email: email, IP: ip, Success: success}); err != nil {
+			t.Fatalf("RecordLoginAttempt failed: %v", err)
+		}
+	}
+	count := func(email, ip string, since time.Time) int {
+		t.Helper()
+		n, err := s.Users().CountRecentFailures(ctx, email, ip, since)
+		if err != nil {
+			t.Fatalf("CountRecentFailures failed: %v", err)
+		}
+		return n
+	}
+
+	record("Test@Example.com", "10.0.0.1", false)
+	record("test@example.com", "10.0.0.2", false)
+	record("", "10.0.0.1", false)
+	record("other@example.com", "10.0.0.3", false)
+
+	if got := count("test@example.com", "", since); got != 2 {
+		t.Errorf("failures by email = %d, want 2", got)
+	}
+	if got := count("", "10.0.0.1", since); got != 2 {
+		t.Errorf("failures by IP = %d, want 2", got)
+	}
+	if got := count("test@example.com", "10.0.0.1", since); got != 3 {
+		t.Errorf("failures by email or IP = %d, want 3", got)
+	}
+	if got := count("test@example.com", "", time.Now().Add(time.Minute)); got != 0 {
+		t.Errorf("failures outside window = %d, want 0", got)
+	}
+
+	// A successful login resets the count
+	record("test@example.com", "10.0.0.2", true)
+	if got := count("test@example.com", "", since); got != 0 {
+		t.Errorf("failures after success = %d, want 0", got)
+	}
+	record("test@example.com", "10.0.0.2", false)
+	if got := count("test@example.com", "", since); got != 1 {
+		t.Errorf("failures after success and new failure = %d, want 1", got)
+	}
+
+	// A success on one account doesn't reset failures against another
+	// from the same IP, but does reset the IP's own count
+	record("victim@example.com", "10.0.0.9", false)
+	record("victim@example.com", "10.0.0.9", false)
+	record("attacker@example.com", "10.0.0.9", true)
+	if got := count("victim@example.com", "10.0.0.9", since); got != 2 {
+		t.Errorf("failures after another account's success = %d, want 2", got)
+	}
+	if got := count("victim@example.com", "", since); got != 2 {
+		t.Errorf("failures by email after another account's success = %d, want 2", got)
+	}
+	if got := count("", "10.0.0.9", since); got != 0 {
+		t.Errorf("failures by IP after a success from it = %d, want 0", got)
+	}
+
+	// Pruning removes old attempts
+	if err := s.Users().PruneLoginAttempts(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("PruneLoginAttempts failed: %v", err)
+	}
+	if got := count("other@example.com", "", since); got != 0 {
+		t.Errorf("failures after prune = %d, want 0", got)
+	}
+}
+
 func TestUserRepository_IdentityBackup(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -207,7 +656,7 @@ func TestUserRepository_IdentityBackup(t *testing.T) {
 		Payload:    "encryptedpayload",
 	}
 
-	if err := s.Users().SetIdentityBackup(ctx, user.ID, backup); err != nil {
+	if err := s.Users().SetIdentityBackup(ctx, user.ID, backup, 0); err != nil {
 		t.Fatalf("SetIdentityBackup failed: %v", err)
 	}
 
@@ -225,6 +674,179 @@ func TestUserRepository_IdentityBackup(t *testing.T) {
 	}
 }
 
+func TestUserRepository_IdentityBackupGeneration(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	s.Users().Create(ctx, user)
+
+	newBackup := func(payload string) *store.IdentityBackup {
+		return &store.IdentityBackup{
+			Algorithm: "AES-256-GCM", KDF: "PBKDF2-SHA256", Iterations: 100000,
+			Salt: "somesalt", IV: "someiv", Payload: payload,
+		}
+	}
+
+	// Two devices both try to create an identity
+	first := newBackup("from_device_1")
+	if err := s.Users().SetIdentityBackup(ctx, user.ID, first, 0); err != nil {
+		t.Fatalf("first SetIdentityBackup failed: %v", err)
+	}
+	if first.Generation != 1 {
+		t.Errorf("generation = %d, want 1", first.Generation)
+	}
+	if err := s.Users().SetIdentityBackup(ctx, user.ID, newBackup("from_device_2"), 0); err != store.ErrVersionConflict {
+		t.Errorf("expected ErrVersionConflict for second create, got %v", err)
+	}
+
+	got, _ := s.Users().GetIdentityBackup(ctx, user.ID)
+	if got.Payload != "from_device_1" {
+		t.Errorf("payload = %q, want %q", got.Payload, "from_device_1")
+	}
+
+	// Replacing with the current generation succeeds
+	if err := s.Users().SetIdentityBackup(ctx, user.ID, newBackup("replaced"), got.Generation); err != nil {
+		t.Fatalf("replace failed: %v", err)
+	}
+
+	// A stale generation is rejected
+	if err := s.Users().SetIdentityBackup(ctx, user.ID, newBackup("stale"), 1); err != store.ErrVersionConflict {
+		t.Errorf("expected ErrVersionConflict for stale generation, got %v", err)
+	}
+
+	got, _ = s.Users().GetIdentityBackup(ctx, user.ID)
+	if got.Payload != "replaced" || got.Generation != 2 {
+		t.Errorf("backup = %q gen %d, want %q gen 2", got.Payload, got.Generation, "replaced")
+	}
+}
+
+func TestUserRepository_IdentityBackupMeta(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	s.Users().Create(ctx, user)
+
+	if _, err := s.Users().GetIdentityBackupMeta(ctx, user.ID); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for new user, got %v", err)
+	}
+
+	s.Users().SetIdentityBackup(ctx, user.ID, &store.IdentityBackup{
+		Algorithm:  "AES-256-GCM",
+		KDF:        "PBKDF2-SHA256",
+		Iterations: 250000,
+		Salt:       "somesalt",
+		IV:         "someiv",
+		Payload:    "encryptedpayload",
+	}, 0)
+
+	meta, err := s.Users().GetIdentityBackupMeta(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetIdentityBackupMeta failed: %v", err)
+	}
+	if meta.KDF != "PBKDF2-SHA256" {
+		t.Errorf("KDF = %q, want %q", meta.KDF, "PBKDF2-SHA256")
+	}
+	if meta.Iterations != 250000 {
+		t.Errorf("Iterations = %d, want %d", meta.Iterations, 250000)
+	}
+}
+
+func TestUserRepository_IdentityBackupArgon2id(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	must(t, s.Users().Create(ctx, user))
+
+	backup := &store.IdentityBackup{
+		Algorithm: "AES-256-GCM", KDF: "argon2id", Iterations: 3, Memory: 65536, Parallelism: 4,
+		Salt: "somesalt", IV: "someiv", Payload: "encryptedpayload",
+	}
+	must(t, s.Users().SetIdentityBackup(ctx, user.ID, backup, 0))
+
+	got, err := s.Users().GetIdentityBackup(ctx, user.ID)
+	if err != nil || got.Memory != 65536 || got.Parallelism != 4 {
+		t.Errorf("GetIdentityBackup = %+v, %v; want memory 65536, parallelism 4", got, err)
+	}
+	meta, err := s.Users().GetIdentityBackupMeta(ctx, user.ID)
+	if err != nil || meta.Memory != 65536 || meta.Parallelism != 4 {
+		t.Errorf("GetIdentityBackupMeta = %+v, %v; want memory 65536, parallelism 4", meta, err)
+	}
+
+	// Replacing with PBKDF2 clears them
+	backup = &store.IdentityBackup{
+		Algorithm: "AES-256-GCM", KDF: "PBKDF2-SHA256", Iterations: 100000,
+		Salt: "somesalt", IV: "someiv", Payload: "encryptedpayload",
+	}
+	must(t, s.Users().SetIdentityBackup(ctx, user.ID, backup, 1))
+	got, _ = s.Users().GetIdentityBackup(ctx, user.ID)
+	if got.Memory != 0 || got.Parallelism != 0 {
+		t.Errorf("after PBKDF2 replacement memory = %d, parallelism = %d; want 0", got.Memory, got.Parallelism)
+	}
+}
+
+func TestUserRepository_Onboard(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := make([]*store.User, 2)
+	for i := range users {
+		users[i] = &store.User{Email: fmt.Sprintf("new%d@example.com", i), Name: "New User"}
+		must(t, s.Users().Create(ctx, users[i]))
+	}
+
+	newBackup := func(payload string) *store.IdentityBackup {
+		return &store.IdentityBackup{
+			Algorithm: "AES-256-GCM", KDF: "PBKDF2-SHA256", Iterations: 100000,
+			Salt: "somesalt", IV: "someiv", Payload: payload,
+		}
+	}
+
+	// A new user gets both
+	if err := s.Users().Onboard(ctx, users[0].ID, "key0", newBackup("backup0")); err != nil {
+		t.Fatalf("Onboard failed: %v", err)
+	}
+	user, _ := s.Users().GetByID(ctx, users[0].ID)
+	backup, err := s.Users().GetIdentityBackup(ctx, users[0].ID)
+	if user.PublicKey != "key0" || err != nil || backup.Payload != "backup0" || backup.Generation != 1 {
+		t.Errorf("after Onboard: key %q, backup %+v, %v", user.PublicKey, backup, err)
+	}
+
+	// A second attempt changes nothing
+	if err := s.Users().Onboard(ctx, users[0].ID, "other", newBackup("other")); err != store.ErrVersionConflict {
+		t.Errorf("repeat Onboard = %v, want ErrVersionConflict", err)
+	}
+	user, _ = s.Users().GetByID(ctx, users[0].ID)
+	if user.PublicKey != "key0" {
+		t.Errorf("public key = %q, want unchanged", user.PublicKey)
+	}
+
+	// An existing backup fails the backup step and rolls back the key
+	must(t, s.Users().SetIdentityBackup(ctx, users[1].ID, newBackup("existing"), 0))
+	if err := s.Users().Onboard(ctx, users[1].ID, "key1", newBackup("backup1")); err != store.ErrVersionConflict {
+		t.Errorf("Onboard with existing backup = %v, want ErrVersionConflict", err)
+	}
+	user, _ = s.Users().GetByID(ctx, users[1].ID)
+	if user.PublicKey != "" {
+		t.Errorf("public key = %q, want it rolled back", user.PublicKey)
+	}
+	backup, _ = s.Users().GetIdentityBackup(ctx, users[1].ID)
+	if backup.Payload != "existing" {
+		t.Errorf("backup = %q, want unchanged", backup.Payload)
+	}
+
+	// A user who already registered a key can't onboard
+	existing := createTestUsers(t, s, 1)[0]
+	if err := s.Users().Onboard(ctx, existing.ID, "key", newBackup("backup")); err != store.ErrVersionConflict {
+		t.Errorf("Onboard with existing key = %v, want ErrVersionConflict", err)
+	}
+
+	if err := s.Users().Onboard(ctx, "missing", "key", newBackup("backup")); err != store.ErrNotFound {
+		t.Errorf("Onboard for missing user = %v, want ErrNotFound", err)
+	}
+}
+
 func TestUserRepository_UserData(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -279,6 +901,129 @@ func TestUserRepository_UserData(t *testing.T) {
 	}
 }
 
+func TestUserRepository_UserDataCreateRace(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	// Each :memory: connection is its own database
+	s.db.SetMaxOpenConns(1)
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	s.Users().Create(ctx, user)
+
+	// Two devices both believe there's no data yet
+	results := make([]*store.UserData, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = &store.UserData{Blob: "blob"}
+			errs[i] = s.Users().SetUserData(ctx, user.ID, results[i], 0)
+		}(i)
+	}
+	wg.Wait()
+
+	var created, conflicts int
+	for i, err := range errs {
+		switch err {
+		case nil:
+			created++
+		case store.ErrVersionConflict:
+			conflicts++
+			if results[i].Version != 1 {
+				t.Errorf("conflict version = %d, want 1", results[i].Version)
+			}
+		default:
+			t.Fatalf("SetUserData failed: %v", err)
+		}
+	}
+	if created != 1 || conflicts != 1 {
+		t.Errorf("created = %d, conflicts = %d, want 1 each", created, conflicts)
+	}
+}
+
+func TestUserRepository_Settings(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	user := &store.User{Email: "test@example.com", Name: "Test User"}
+	s.Users().Create(ctx, user)
+
+	// Defaults when nothing is stored
+	values, err := s.Users().GetSettings(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetSettings failed: %v", err)
+	}
+	if len(values) != 0 {
+		t.Errorf("initial settings = %v, want empty", values)
+	}
+	if !store.SettingsFromMap(values).Discoverable {
+		t.Error("expected Discoverable to default to true")
+	}
+
+	// Set and overwrite
+	s.Users().SetSettings(ctx, user.ID, map[string]string{store.SettingDiscoverable: "false"})
+	if err := s.Users().SetSettings(ctx, user.ID, map[string]string{
+		store.SettingDiscoverable:  "true",
+		store.SettingLastKnownMode: "true",
+	}); err != nil {
+		t.Fatalf("SetSettings failed: %v", err)
+	}
+
+	values, _ = s.Users().GetSettings(ctx, user.ID)
+	settings := store.SettingsFromMap(values)
+	if !settings.Discoverable || !settings.LastKnownMode {
+		t.Errorf("settings = %+v, want Discoverable and LastKnownMode true", settings)
+	}
+}
+
+func TestUserRepository_UserStorageBytes(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	usage, err := s.Users().UserStorageBytes(ctx, users[0].ID)
+	if err != nil {
+		t.Fatalf("UserStorageBytes failed: %v", err)
+	}
+	if usage.Total() != 0 {
+		t.Errorf("total for new user = %d, want 0", usage.Total())
+	}
+
+	s.Users().SetIdentityBackup(ctx, users[0].ID, &store.IdentityBackup{
+		Algorithm: "AES-256-GCM", KDF: "PBKDF2-SHA256", Iterations: 600000,
+		Salt: "0123456789", IV: "abcde", Payload: strings.Repeat("p", 100),
+	}, 0)
+	s.Users().SetUserData(ctx, users[0].ID, &store.UserData{Blob: strings.Repeat("d", 250)}, 0)
+	s.Locations().SetLocations(ctx, users[0].ID, []*store.EncryptedLocation{
+		{ToUserID: users[1].ID, Blob: strings.Repeat("l", 40)},
+		{ToUserID: users[2].ID, Blob: strings.Repeat("l", 60)},
+	})
+	// Locations shared with the user don't count against them
+	s.Locations().SetLocations(ctx, users[1].ID, []*store.EncryptedLocation{
+		{ToUserID: users[0].ID, Blob: strings.Repeat("x", 500)},
+	})
+
+	usage, err = s.Users().UserStorageBytes(ctx, users[0].ID)
+	if err != nil {
+		t.Fatalf("UserStorageBytes failed: %v", err)
+	}
+	if usage.IdentityBackup != 115 {
+		t.Errorf("identity backup = %d, want 115", usage.IdentityBackup)
+	}
+	if usage.UserData != 250 {
+		t.Errorf("user data = %d, want 250", usage.UserData)
+	}
+	if usage.Locations != 100 {
+		t.Errorf("locations = %d, want 100", usage.Locations)
+	}
+	if usage.Total() != 465 {
+		t.Errorf("total = %d, want 465", usage.Total())
+	}
+}
+
 // =============================================================================
 // ContactRepository Tests
 // =============================================================================
@@ -300,6 +1045,14 @@ func createTestUsers(t *testing.T, s *Store, n int) []*store.User {
 	return users
 }
 
+// must fails the test on a setup error
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+}
+
 func TestContactRepository_CreateRequest(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -335,15 +1088,50 @@ func TestContactRepository_CreateRequest_Duplicate(t *testing.T) {
 	}
 
 	// Check that duplicate is detected
-	exists, err := s.Contacts().CheckExistingRequest(ctx, users[0].ID, users[1].ID)
+	status, err := s.Contacts().PairStatus(ctx, users[0].ID, users[1].ID)
 	if err != nil {
-		t.Fatalf("CheckExistingRequest failed: %v", err)
+		t.Fatalf("PairStatus failed: %v", err)
 	}
-	if !exists {
-		t.Error("expected existing request to be found")
+	if status != store.PairPending {
+		t.Errorf("status = %q, want %q", status, store.PairPending)
 	}
 }
 
+func TestContactRepository_PairStatus(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b, c := users[0], users[1], users[2]
+
+	check := func(x, y *store.User, want string) {
+		t.Helper()
+		status, err := s.Contacts().PairStatus(ctx, x.ID, y.ID)
+		must(t, err)
+		if status != want {
+			t.Errorf("PairStatus = %q, want %q", status, want)
+		}
+	}
+
+	check(a, b, store.PairNone)
+
+	// Pending, seen from either side
+	req, err := s.Contacts().CreateRequest(ctx, a.ID, b.ID)
+	must(t, err)
+	check(a, b, store.PairPending)
+	check(b, a, store.PairPending)
+
+	// Contacts once accepted, either side
+	must(t, s.Contacts().AcceptRequest(ctx, req.ID, b.ID))
+	check(a, b, store.PairContacts)
+	check(b, a, store.PairContacts)
+
+	// Declined requests don't block
+	req, err = s.Contacts().CreateRequest(ctx, c.ID, a.ID)
+	must(t, err)
+	must(t, s.Contacts().DeclineRequest(ctx, req.ID, a.ID))
+	check(a, c, store.PairNone)
+}
+
 func TestContactRepository_ListRequests(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -360,7 +1148,10 @@ func TestContactRepository_ListRequests(t *testing.T) {
 		t.Fatalf("ListIncomingRequests failed: %v", err)
 	}
 	if len(incoming) != 1 {
-		t.Errorf("incoming count = %d, want 1", len(incoming))
+		t.Fatalf("incoming count = %d, want 1", len(incoming))
+	}
+	if incoming[0].Name != users[2].Name || incoming[0].Email != users[2].Email {
+		t.Errorf("incoming from %q <%s>, want User C", incoming[0].Name, incoming[0].Email)
 	}
 
 	// Check outgoing for User A
@@ -369,7 +1160,10 @@ func TestContactRepository_ListRequests(t *testing.T) {
 		t.Fatalf("ListOutgoingRequests failed: %v", err)
 	}
 	if len(outgoing) != 1 {
-		t.Errorf("outgoing count = %d, want 1", len(outgoing))
+		t.Fatalf("outgoing count = %d, want 1", len(outgoing))
+	}
+	if outgoing[0].Name != users[1].Name || outgoing[0].Email != users[1].Email {
+		t.Errorf("outgoing to %q <%s>, want User B", outgoing[0].Name, outgoing[0].Email)
 	}
 }
 
@@ -429,6 +1223,73 @@ func TestContactRepository_DeclineRequest(t *testing.T) {
 	}
 }
 
+func TestContactRepository_ExpireRequests(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	stale, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	cutoff := time.Now()
+	fresh, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[2].ID)
+
+	expired, err := s.Contacts().ExpireRequests(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("ExpireRequests failed: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired = %d, want 1", expired)
+	}
+
+	got, _ := s.Contacts().GetRequest(ctx, stale.ID)
+	if got.Status != "expired" || got.ExpiredAt == nil {
+		t.Errorf("stale request = %s (expiredAt %v), want expired", got.Status, got.ExpiredAt)
+	}
+	got, _ = s.Contacts().GetRequest(ctx, fresh.ID)
+	if got.Status != "pending" {
+		t.Errorf("fresh request status = %s, want pending", got.Status)
+	}
+}
+
+func TestContactRepository_ListRequestHistory(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 4)
+
+	accepted, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	s.Contacts().AcceptRequest(ctx, accepted.ID, users[1].ID)
+	declined, _ := s.Contacts().CreateRequest(ctx, users[2].ID, users[0].ID)
+	s.Contacts().DeclineRequest(ctx, declined.ID, users[0].ID)
+	pending, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[3].ID)
+
+	page, err := s.Contacts().ListRequestHistory(ctx, users[0].ID, time.Time{}, "", 2)
+	if err != nil {
+		t.Fatalf("ListRequestHistory failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != pending.ID || page[1].ID != declined.ID {
+		t.Fatalf("first page = %v, want pending then declined", page)
+	}
+	if page[1].Status != "declined" || page[1].DeclinedAt == nil {
+		t.Errorf("declined request = %s (declinedAt %v)", page[1].Status, page[1].DeclinedAt)
+	}
+
+	page, err = s.Contacts().ListRequestHistory(ctx, users[0].ID, page[1].CreatedAt, page[1].ID, 2)
+	if err != nil {
+		t.Fatalf("ListRequestHistory failed: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != accepted.ID {
+		t.Fatalf("second page = %v, want the accepted request", page)
+	}
+	if page[0].Status != "accepted" || page[0].AcceptedAt == nil {
+		t.Errorf("accepted request = %s (acceptedAt %v)", page[0].Status, page[0].AcceptedAt)
+	}
+
+	// Other users only see their own requests
+	page, _ = s.Contacts().ListRequestHistory(ctx, users[3].ID, time.Time{}, "", 10)
+	if len(page) != 1 {
+		t.Errorf("history for recipient = %d, want 1", len(page))
+	}
+}
+
 func TestContactRepository_CancelRequest(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -445,6 +1306,24 @@ func TestContactRepository_CancelRequest(t *testing.T) {
 	if len(outgoing) != 0 {
 		t.Errorf("outgoing after cancel = %d, want 0", len(outgoing))
 	}
+
+	// An expired request can be cancelled and sent again, a declined one
+	// can't
+	req, _ = s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	if _, err := s.Contacts().ExpireRequests(ctx, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("ExpireRequests failed: %v", err)
+	}
+	if err := s.Contacts().CancelRequest(ctx, req.ID, users[0].ID); err != nil {
+		t.Fatalf("CancelRequest on expired request failed: %v", err)
+	}
+	req, err := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	if err != nil {
+		t.Fatalf("CreateRequest after cancelling expired: %v", err)
+	}
+	must(t, s.Contacts().DeclineRequest(ctx, req.ID, users[1].ID))
+	if err := s.Contacts().CancelRequest(ctx, req.ID, users[0].ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("CancelRequest on declined request = %v, want ErrNotFound", err)
+	}
 }
 
 func TestContactRepository_RemoveContact(t *testing.T) {
@@ -501,6 +1380,216 @@ func TestContactRepository_AreContacts(t *testing.T) {
 	}
 }
 
+func TestContactRepository_SetSortOrder(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 4)
+
+	// User A is contacts with B, C, D
+	for _, u := range users[1:] {
+		req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, u.ID)
+		s.Contacts().AcceptRequest(ctx, req.ID, u.ID)
+	}
+
+	// Pin D first, then C
+	first, second := 0, 1
+	if err := s.Contacts().SetSortOrder(ctx, users[0].ID, users[3].ID, &first); err != nil {
+		t.Fatalf("SetSortOrder failed: %v", err)
+	}
+	s.Contacts().SetSortOrder(ctx, users[0].ID, users[2].ID, &second)
+
+	contacts, _ := s.Contacts().ListContacts(ctx, users[0].ID)
+	want := []string{users[3].ID, users[2].ID, users[1].ID}
+	for i, c := range contacts {
+		if c.ContactID != want[i] {
+			t.Errorf("contacts[%d] = %q, want %q", i, c.ContactID, want[i])
+		}
+	}
+
+	// Unpin everyone: back to name order
+	s.Contacts().SetSortOrder(ctx, users[0].ID, users[3].ID, nil)
+	s.Contacts().SetSortOrder(ctx, users[0].ID, users[2].ID, nil)
+
+	contacts, _ = s.Contacts().ListContacts(ctx, users[0].ID)
+	want = []string{users[1].ID, users[2].ID, users[3].ID}
+	for i, c := range contacts {
+		if c.ContactID != want[i] {
+			t.Errorf("after unpin contacts[%d] = %q, want %q", i, c.ContactID, want[i])
+		}
+		if c.SortOrder != nil {
+			t.Errorf("after unpin contacts[%d].SortOrder = %d, want nil", i, *c.SortOrder)
+		}
+	}
+
+	// Not a contact
+	if err := s.Contacts().SetSortOrder(ctx, users[1].ID, users[2].ID, &first); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for non-contact, got %v", err)
+	}
+}
+
+func TestContactRepository_SetNote(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	s.Contacts().AcceptRequest(ctx, req.ID, users[1].ID)
+
+	if err := s.Contacts().SetNote(ctx, users[0].ID, users[1].ID, "Met at the conference"); err != nil {
+		t.Fatalf("SetNote failed: %v", err)
+	}
+
+	contacts, _ := s.Contacts().ListContacts(ctx, users[0].ID)
+	if contacts[0].Note != "Met at the conference" {
+		t.Errorf("note = %q, want %q", contacts[0].Note, "Met at the conference")
+	}
+
+	// The note is private to its owner
+	contacts, _ = s.Contacts().ListContacts(ctx, users[1].ID)
+	if contacts[0].Note != "" {
+		t.Errorf("contact sees note %q, want none", contacts[0].Note)
+	}
+
+	// Empty note removes it
+	s.Contacts().SetNote(ctx, users[0].ID, users[1].ID, "")
+	contacts, _ = s.Contacts().ListContacts(ctx, users[0].ID)
+	if contacts[0].Note != "" {
+		t.Errorf("note after removal = %q, want empty", contacts[0].Note)
+	}
+
+	// Not a contact
+	if err := s.Contacts().SetNote(ctx, users[0].ID, users[2].ID, "note"); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for non-contact, got %v", err)
+	}
+	if err := s.Contacts().SetNote(ctx, users[0].ID, users[2].ID, ""); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound removing note on non-contact, got %v", err)
+	}
+}
+
+func TestContactRepository_CountContactsWithKeys(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 5)
+	a := users[0]
+
+	for _, other := range users[1:] {
+		req, _ := s.Contacts().CreateRequest(ctx, a.ID, other.ID)
+		must(t, s.Contacts().AcceptRequest(ctx, req.ID, other.ID))
+	}
+	// One contact cleared their key and one deleted their account
+	must(t, s.Users().SetPublicKey(ctx, users[2].ID, ""))
+	must(t, s.Users().SoftDelete(ctx, users[3].ID))
+
+	count, err := s.Contacts().CountContactsWithKeys(ctx, a.ID)
+	must(t, err)
+	if count != 2 {
+		t.Errorf("CountContactsWithKeys = %d, want 2", count)
+	}
+	summary, err := s.Contacts().Summary(ctx, a.ID)
+	must(t, err)
+	if summary.WithPublicKey != count {
+		t.Errorf("Summary.WithPublicKey = %d, want %d", summary.WithPublicKey, count)
+	}
+
+	// The key lookup is served by the partial index
+	rows, err := s.db.QueryContext(ctx, `
+		EXPLAIN QUERY PLAN SELECT COUNT(*) FROM contacts c JOIN users u ON u.id = c.contact_id
+		WHERE c.user_id = ? AND u.deleted_at IS NULL AND u.public_key > ''
+	`, a.ID)
+	must(t, err)
+	defer rows.Close()
+	var plan []string
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		must(t, rows.Scan(&id, &parent, &unused, &detail))
+		plan = append(plan, detail)
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "idx_users_with_key") {
+		t.Errorf("query plan doesn't use idx_users_with_key:\n%s", strings.Join(plan, "\n"))
+	}
+}
+
+func TestContactRepository_Nudge(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b := users[0], users[1]
+
+	req, _ := s.Contacts().CreateRequest(ctx, a.ID, b.ID)
+	s.Contacts().AcceptRequest(ctx, req.ID, b.ID)
+
+	nudged, err := s.Contacts().Nudge(ctx, a.ID, b.ID, time.Now().Add(-time.Minute))
+	if err != nil || !nudged {
+		t.Fatalf("Nudge = %v, %v; want true", nudged, err)
+	}
+
+	nudges, err := s.Contacts().ListNudges(ctx, b.ID)
+	must(t, err)
+	if len(nudges) != 1 || nudges[0].FromUserID != a.ID {
+		t.Fatalf("nudges = %+v, want one from a", nudges)
+	}
+	if nudges, _ := s.Contacts().ListNudges(ctx, a.ID); len(nudges) != 0 {
+		t.Errorf("nudger sees %d nudges, want 0", len(nudges))
+	}
+
+	// Nudged again within the interval
+	nudged, err = s.Contacts().Nudge(ctx, a.ID, b.ID, time.Now().Add(-time.Minute))
+	if err != nil || nudged {
+		t.Errorf("second Nudge = %v, %v; want false", nudged, err)
+	}
+	// ...and after it
+	nudged, err = s.Contacts().Nudge(ctx, a.ID, b.ID, time.Now())
+	if err != nil || !nudged {
+		t.Errorf("Nudge after interval = %v, %v; want true", nudged, err)
+	}
+
+	// Sharing with the nudger answers it
+	must(t, s.Locations().SetLocations(ctx, b.ID, []*store.EncryptedLocation{{ToUserID: a.ID, Blob: "ba"}}))
+	if nudges, _ := s.Contacts().ListNudges(ctx, b.ID); len(nudges) != 0 {
+		t.Errorf("nudges after sharing = %d, want 0", len(nudges))
+	}
+
+	// Not a contact
+	if _, err := s.Contacts().Nudge(ctx, a.ID, users[2].ID, time.Now()); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for non-contact, got %v", err)
+	}
+}
+
+func TestContactRepository_UpdatedAt(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	for _, u := range users[1:] {
+		req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, u.ID)
+		s.Contacts().AcceptRequest(ctx, req.ID, u.ID)
+	}
+
+	since := time.Now()
+
+	// A key change on one contact and a note on the other both count
+	if err := s.Users().SetPublicKey(ctx, users[1].ID, "new-key"); err != nil {
+		t.Fatalf("SetPublicKey failed: %v", err)
+	}
+	if err := s.Contacts().SetNote(ctx, users[0].ID, users[2].ID, "note"); err != nil {
+		t.Fatalf("SetNote failed: %v", err)
+	}
+
+	contacts, _ := s.Contacts().ListContacts(ctx, users[0].ID)
+	for _, c := range contacts {
+		if !c.UpdatedAt.After(since) {
+			t.Errorf("contact %s UpdatedAt = %v, want after %v", c.ContactID, c.UpdatedAt, since)
+		}
+	}
+
+	// Notes are private, so the other side's view is untouched
+	contacts, _ = s.Contacts().ListContacts(ctx, users[2].ID)
+	if contacts[0].UpdatedAt.After(since) {
+		t.Errorf("UpdatedAt = %v, want unchanged", contacts[0].UpdatedAt)
+	}
+}
+
 // =============================================================================
 // DeviceRepository Tests
 // =============================================================================
@@ -598,6 +1687,27 @@ func TestDeviceRepository_Revoke(t *testing.T) {
 	}
 }
 
+func TestDeviceRepository_Rename(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	device := &store.Device{UserID: users[0].ID, Name: "phone", Platform: "ios"}
+	must(t, s.Devices().Create(ctx, device))
+
+	if err := s.Devices().Rename(ctx, device.ID, users[1].ID, "Stolen"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Rename by another user = %v, want ErrNotFound", err)
+	}
+	if err := s.Devices().Rename(ctx, device.ID, users[0].ID, "Work iPhone"); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+
+	got, _ := s.Devices().GetByID(ctx, device.ID)
+	if got.Name != "Work iPhone" {
+		t.Errorf("name = %q, want %q", got.Name, "Work iPhone")
+	}
+}
+
 func TestDeviceRepository_UpdateLastSeen(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -698,6 +1808,196 @@ func TestLocationRepository_UpdateLocation(t *testing.T) {
 	}
 }
 
+func TestLocationRepository_DedupeIdenticalShares(t *testing.T) {
+	for _, dedupe := range []bool{true, false} {
+		s, err := New(":memory:", WithDedupeIdenticalShares(dedupe))
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer s.Close()
+		ctx := context.Background()
+		users := createTestUsers(t, s, 2)
+
+		share := func(blob string) time.Time {
+			t.Helper()
+			if err := s.Locations().SetLocations(ctx, users[0].ID, []*store.EncryptedLocation{
+				{ToUserID: users[1].ID, Blob: blob},
+			}); err != nil {
+				t.Fatalf("SetLocations failed: %v", err)
+			}
+			got, _ := s.Locations().GetLocationsForUser(ctx, users[1].ID)
+			return got[0].UpdatedAt
+		}
+
+		first := share("same")
+		time.Sleep(10 * time.Millisecond)
+		if again := share("same"); again.Equal(first) != dedupe {
+			t.Errorf("dedupe=%v: identical blob updated_at %v -> %v", dedupe, first, again)
+		}
+		time.Sleep(10 * time.Millisecond)
+		if changed := share("different"); !changed.After(first) {
+			t.Errorf("dedupe=%v: changed blob kept updated_at %v", dedupe, changed)
+		}
+	}
+}
+
+func TestContactRepository_ListContactsPage(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 5)
+
+	for _, u := range users[1:] {
+		req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, u.ID)
+		must(t, s.Contacts().AcceptRequest(ctx, req.ID, u.ID))
+	}
+	// Pin E so pages follow list order, not name order
+	first := 0
+	must(t, s.Contacts().SetSortOrder(ctx, users[0].ID, users[4].ID, &first))
+
+	count, err := s.Contacts().CountContacts(ctx, users[0].ID)
+	if err != nil || count != 4 {
+		t.Fatalf("CountContacts = %d, %v; want 4", count, err)
+	}
+
+	var got []string
+	for offset := 0; ; offset += 3 {
+		page, err := s.Contacts().ListContactsPage(ctx, users[0].ID, offset, 3)
+		if err != nil {
+			t.Fatalf("ListContactsPage failed: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, c := range page {
+			got = append(got, c.ContactID)
+		}
+	}
+	want := []string{users[4].ID, users[1].ID, users[2].ID, users[3].ID}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("paged contacts = %v, want %v", got, want)
+	}
+}
+
+func TestLocationRepository_Expiry(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	must(t, s.Locations().SetLocations(ctx, users[0].ID, []*store.EncryptedLocation{
+		{ToUserID: users[1].ID, Blob: "expired", ExpiresAt: &past},
+		{ToUserID: users[2].ID, Blob: "expiring", ExpiresAt: &future},
+	}))
+	must(t, s.Locations().SetLocations(ctx, users[2].ID, []*store.EncryptedLocation{
+		{ToUserID: users[1].ID, Blob: "forever"},
+	}))
+
+	got, err := s.Locations().GetLocationsForUser(ctx, users[1].ID)
+	if err != nil {
+		t.Fatalf("GetLocationsForUser failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Blob != "forever" || got[0].ExpiresAt != nil {
+		t.Errorf("locations = %+v, want only the share without expiry", got)
+	}
+	page, _ := s.Locations().GetLocationsForUserPage(ctx, users[1].ID, "", 10)
+	if len(page) != 1 {
+		t.Errorf("page has %d locations, want 1", len(page))
+	}
+	if n, err := s.Locations().CountLocationsForUser(ctx, users[1].ID); err != nil || n != 1 {
+		t.Errorf("CountLocationsForUser = %d, %v; want 1", n, err)
+	}
+	got, _ = s.Locations().GetLocationsForUser(ctx, users[2].ID)
+	if len(got) != 1 || got[0].ExpiresAt == nil || !got[0].ExpiresAt.Equal(future) {
+		t.Errorf("locations = %+v, want the share expiring at %v", got, future)
+	}
+
+	// Only the expired share is deleted
+	n, err := s.Locations().DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("deleted %d, want 1", n)
+	}
+	if got, _ := s.Locations().GetLocationsForUser(ctx, users[2].ID); len(got) != 1 {
+		t.Error("unexpired share was deleted")
+	}
+}
+
+func TestLocationRepository_HasIncoming(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	s.Contacts().AcceptRequest(ctx, req.ID, users[1].ID)
+
+	has, _, err := s.Locations().HasIncoming(ctx, users[1].ID)
+	if err != nil {
+		t.Fatalf("HasIncoming failed: %v", err)
+	}
+	if has {
+		t.Error("expected no incoming locations before any share")
+	}
+
+	s.Locations().SetLocations(ctx, users[0].ID, []*store.EncryptedLocation{
+		{ToUserID: users[1].ID, Blob: "blob"},
+	})
+	shared, _ := s.Locations().GetLocationsForUser(ctx, users[1].ID)
+
+	has, latest, err := s.Locations().HasIncoming(ctx, users[1].ID)
+	if err != nil {
+		t.Fatalf("HasIncoming failed: %v", err)
+	}
+	if !has {
+		t.Error("expected incoming locations after a share")
+	}
+	if !latest.Equal(shared[0].UpdatedAt) {
+		t.Errorf("latest = %v, want %v", latest, shared[0].UpdatedAt)
+	}
+
+	// Outgoing shares don't count
+	if has, _, _ := s.Locations().HasIncoming(ctx, users[0].ID); has {
+		t.Error("sharer shouldn't have incoming locations")
+	}
+}
+
+func TestLocationRepository_SetLocationsBestEffort(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+
+	// Second recipient doesn't exist, so its row violates the foreign key
+	locations := []*store.EncryptedLocation{
+		{ToUserID: users[1].ID, Blob: "for_user1"},
+		{ToUserID: "nonexistent", Blob: "for_nobody"},
+		{ToUserID: users[2].ID, Blob: "for_user2"},
+	}
+
+	results, err := s.Locations().SetLocationsBestEffort(ctx, users[0].ID, locations)
+	if err != nil {
+		t.Fatalf("SetLocationsBestEffort failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %d, want 3", len(results))
+	}
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("expected valid recipients to succeed, got %v and %v", results[0].Err, results[2].Err)
+	}
+	if results[1].Err == nil {
+		t.Error("expected invalid recipient to fail")
+	}
+
+	// The valid rows were written despite the failure
+	for _, u := range []*store.User{users[1], users[2]} {
+		got, _ := s.Locations().GetLocationsForUser(ctx, u.ID)
+		if len(got) != 1 {
+			t.Errorf("location count for %s = %d, want 1", u.Name, len(got))
+		}
+	}
+}
+
 func TestLocationRepository_DeleteLocationsBetween(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -732,6 +2032,48 @@ func TestLocationRepository_DeleteLocationsBetween(t *testing.T) {
 	}
 }
 
+func TestLocationRepository_Presence(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b, c := users[0], users[1], users[2]
+
+	for _, other := range []*store.User{b, c} {
+		req, _ := s.Contacts().CreateRequest(ctx, a.ID, other.ID)
+		s.Contacts().AcceptRequest(ctx, req.ID, other.ID)
+	}
+
+	must(t, s.Locations().SetPresence(ctx, a.ID, []*store.Presence{
+		{ToUserID: b.ID, Blob: "arrived-b"},
+		{ToUserID: c.ID, Blob: "arrived-c"},
+	}))
+	got, err := s.Locations().GetPresenceForUser(ctx, b.ID)
+	must(t, err)
+	if len(got) != 1 || got[0].Blob != "arrived-b" || got[0].FromUserID != a.ID {
+		t.Fatalf("b's presence = %+v, want arrived-b from a", got)
+	}
+
+	// A new status replaces the old one, including who it's shared with
+	must(t, s.Locations().SetPresence(ctx, a.ID, []*store.Presence{{ToUserID: b.ID, Blob: "left-b"}}))
+	if got, _ := s.Locations().GetPresenceForUser(ctx, b.ID); len(got) != 1 || got[0].Blob != "left-b" {
+		t.Errorf("b's presence after update = %+v, want left-b", got)
+	}
+	if got, _ := s.Locations().GetPresenceForUser(ctx, c.ID); len(got) != 0 {
+		t.Errorf("c's presence after update = %d, want 0", len(got))
+	}
+
+	// Presence goes with the contact
+	must(t, s.Contacts().RemoveContact(ctx, a.ID, b.ID))
+	if got, _ := s.Locations().GetPresenceForUser(ctx, b.ID); len(got) != 0 {
+		t.Errorf("presence after removing contact = %d, want 0", len(got))
+	}
+
+	// Only contacts can receive it
+	if err := s.Locations().SetPresence(ctx, a.ID, []*store.Presence{{ToUserID: b.ID, Blob: "x"}}); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound for non-contact, got %v", err)
+	}
+}
+
 // =============================================================================
 // SessionRepository Tests
 // =============================================================================
@@ -776,6 +2118,86 @@ func TestSessionRepository_GetByToken(t *testing.T) {
 	}
 }
 
+func TestSessionRepository_SetDevice(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 1)
+
+	session := &store.Session{
+		UserID:    users[0].ID,
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	s.Sessions().Create(ctx, session)
+
+	device := &store.Device{UserID: users[0].ID, Name: "Phone", Platform: "ios"}
+	s.Devices().Create(ctx, device)
+
+	if err := s.Sessions().SetDevice(ctx, session.Token, device.ID); err != nil {
+		t.Fatalf("SetDevice failed: %v", err)
+	}
+
+	got, _ := s.Sessions().GetByToken(ctx, session.Token)
+	if got.DeviceID != device.ID {
+		t.Errorf("DeviceID = %q, want %q", got.DeviceID, device.ID)
+	}
+
+	if err := s.Sessions().SetDevice(ctx, "nonexistent", device.ID); err != store.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSessionRepository_ListForDevice(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 1)
+
+	device := &store.Device{UserID: users[0].ID, Name: "Phone", Platform: "ios"}
+	s.Devices().Create(ctx, device)
+
+	now := time.Now()
+	for _, expires := range []time.Time{now.Add(time.Hour), now.Add(48 * time.Hour), now.Add(-time.Hour)} {
+		s.Sessions().Create(ctx, &store.Session{UserID: users[0].ID, DeviceID: device.ID, ExpiresAt: expires})
+	}
+	s.Sessions().Create(ctx, &store.Session{UserID: users[0].ID, ExpiresAt: now.Add(time.Hour)})
+
+	sessions, err := s.Sessions().ListForDevice(ctx, device.ID)
+	if err != nil {
+		t.Fatalf("ListForDevice failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("session count = %d, want 2 unexpired", len(sessions))
+	}
+	if !sessions[0].ExpiresAt.After(sessions[1].ExpiresAt) {
+		t.Errorf("sessions not ordered by latest expiry: %v, %v", sessions[0].ExpiresAt, sessions[1].ExpiresAt)
+	}
+}
+
+func TestSessionRepository_ListForUser(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	now := time.Now()
+	older := &store.Session{UserID: users[0].ID, CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}
+	newer := &store.Session{UserID: users[0].ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	for _, session := range []*store.Session{
+		older,
+		newer,
+		{UserID: users[0].ID, ExpiresAt: now.Add(-time.Minute)},
+		{UserID: users[1].ID, ExpiresAt: now.Add(time.Hour)},
+	} {
+		must(t, s.Sessions().Create(ctx, session))
+	}
+
+	sessions, err := s.Sessions().ListForUser(ctx, users[0].ID)
+	if err != nil {
+		t.Fatalf("ListForUser failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Token != newer.Token || sessions[1].Token != older.Token {
+		t.Errorf("sessions = %v, want the two unexpired ones, newest first", sessions)
+	}
+}
+
 func TestSessionRepository_Delete(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -839,12 +2261,16 @@ func TestSessionRepository_DeleteExpired(t *testing.T) {
 	}
 	s.Sessions().Create(ctx, valid)
 
-	if err := s.Sessions().DeleteExpired(ctx); err != nil {
+	n, err := s.Sessions().DeleteExpired(ctx)
+	if err != nil {
 		t.Fatalf("DeleteExpired failed: %v", err)
 	}
+	if n != 1 {
+		t.Errorf("DeleteExpired removed %d sessions, want 1", n)
+	}
 
 	// Expired should be gone
-	_, err := s.Sessions().GetByToken(ctx, expired.Token)
+	_, err = s.Sessions().GetByToken(ctx, expired.Token)
 	if err != store.ErrNotFound {
 		t.Error("expected expired session to be deleted")
 	}
@@ -855,3 +2281,220 @@ func TestSessionRepository_DeleteExpired(t *testing.T) {
 		t.Errorf("expected valid session to still exist, got %v", err)
 	}
 }
+
+func TestSessionRepository_RefreshTokens(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 1)
+
+	newToken := func(hash string, expiresIn time.Duration) *store.Session {
+		t.Helper()
+		session := &store.Session{UserID: users[0].ID, ExpiresAt: time.Now().Add(time.Hour)}
+		must(t, s.Sessions().Create(ctx, session))
+		must(t, s.Sessions().CreateRefreshToken(ctx, &store.RefreshToken{
+			TokenHash:    hash,
+			UserID:       users[0].ID,
+			SessionToken: session.Token,
+			ExpiresAt:    time.Now().Add(expiresIn),
+		}))
+		return session
+	}
+
+	// Binding the session binds its refresh token, and each use consumes it
+	session := newToken("used", time.Hour)
+	device := &store.Device{UserID: users[0].ID, Name: "Phone", Platform: "ios"}
+	must(t, s.Devices().Create(ctx, device))
+	must(t, s.Sessions().SetDevice(ctx, session.Token, device.ID))
+	got, err := s.Sessions().UseRefreshToken(ctx, "used")
+	if err != nil {
+		t.Fatalf("UseRefreshToken failed: %v", err)
+	}
+	if got.UserID != users[0].ID || got.SessionToken != session.Token || got.DeviceID != device.ID {
+		t.Errorf("UseRefreshToken = %+v", got)
+	}
+	if _, err := s.Sessions().UseRefreshToken(ctx, "used"); err != store.ErrNotFound {
+		t.Errorf("reusing a refresh token: err = %v, want ErrNotFound", err)
+	}
+
+	newToken("expired", -time.Minute)
+	if _, err := s.Sessions().UseRefreshToken(ctx, "expired"); err != store.ErrNotFound {
+		t.Errorf("using an expired refresh token: err = %v, want ErrNotFound", err)
+	}
+	if n, err := s.Sessions().DeleteExpired(ctx); err != nil || n != 1 {
+		t.Errorf("DeleteExpired = %d, %v; want 1", n, err)
+	}
+
+	// Ending the session, or deleting by device, invalidates the token
+	session = newToken("logged-out", time.Hour)
+	must(t, s.Sessions().Delete(ctx, session.Token))
+	if _, err := s.Sessions().UseRefreshToken(ctx, "logged-out"); err != store.ErrNotFound {
+		t.Errorf("refresh token survived its session: err = %v", err)
+	}
+
+	session = newToken("revoked", time.Hour)
+	must(t, s.Sessions().SetDevice(ctx, session.Token, device.ID))
+	must(t, s.Sessions().DeleteRefreshTokensForDevice(ctx, device.ID))
+	if _, err := s.Sessions().UseRefreshToken(ctx, "revoked"); err != store.ErrNotFound {
+		t.Errorf("refresh token survived its device: err = %v", err)
+	}
+}
+
+// =============================================================================
+// Transaction Tests
+// =============================================================================
+
+func TestWithTx_RollsBackOnError(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	s.Contacts().AcceptRequest(ctx, req.ID, users[1].ID)
+
+	failure := errors.New("location cleanup failed")
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		if err := tx.Contacts().RemoveContact(ctx, users[0].ID, users[1].ID); err != nil {
+			return err
+		}
+		// Repository transactions join the outer one
+		if err := tx.Locations().SetLocations(ctx, users[0].ID, []*store.EncryptedLocation{
+			{ToUserID: users[1].ID, Blob: "blob"},
+		}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if err != failure {
+		t.Fatalf("WithTx error = %v, want %v", err, failure)
+	}
+
+	areContacts, _ := s.Contacts().AreContacts(ctx, users[0].ID, users[1].ID)
+	if !areContacts {
+		t.Error("contact removal wasn't rolled back")
+	}
+	locations, _ := s.Locations().GetLocationsForUser(ctx, users[1].ID)
+	if len(locations) != 0 {
+		t.Error("nested SetLocations wasn't rolled back")
+	}
+}
+
+func TestWithTx_Commits(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	req, _ := s.Contacts().CreateRequest(ctx, users[0].ID, users[1].ID)
+	s.Contacts().AcceptRequest(ctx, req.ID, users[1].ID)
+
+	err := s.WithTx(ctx, func(tx store.Store) error {
+		return tx.Contacts().RemoveContact(ctx, users[0].ID, users[1].ID)
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	areContacts, _ := s.Contacts().AreContacts(ctx, users[0].ID, users[1].ID)
+	if areContacts {
+		t.Error("contact removal wasn't committed")
+	}
+}
+
+// =============================================================================
+// AuditRepository Tests
+// =============================================================================
+
+func TestBlockRepository(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 3)
+	a, b, c := users[0], users[1], users[2]
+
+	// Blocking deletes pending requests in either direction
+	s.Contacts().CreateRequest(ctx, b.ID, a.ID)
+	s.Contacts().CreateRequest(ctx, a.ID, c.ID)
+	must(t, s.Blocks().Block(ctx, a.ID, b.ID))
+	must(t, s.Blocks().Block(ctx, a.ID, b.ID)) // again is a no-op
+	if incoming, _ := s.Contacts().ListIncomingRequests(ctx, a.ID); len(incoming) != 0 {
+		t.Errorf("incoming after block = %d, want 0", len(incoming))
+	}
+	if outgoing, _ := s.Contacts().ListOutgoingRequests(ctx, a.ID); len(outgoing) != 1 {
+		t.Errorf("outgoing to unblocked user = %d, want 1", len(outgoing))
+	}
+
+	// Blocks are one-way
+	if blocked, err := s.Blocks().IsBlocked(ctx, a.ID, b.ID); err != nil || !blocked {
+		t.Errorf("IsBlocked(a, b) = %v, %v; want true", blocked, err)
+	}
+	if blocked, _ := s.Blocks().IsBlocked(ctx, b.ID, a.ID); blocked {
+		t.Error("IsBlocked(b, a) = true, want false")
+	}
+
+	list, err := s.Blocks().ListBlocked(ctx, a.ID)
+	must(t, err)
+	if len(list) != 1 || list[0].UserID != b.ID || list[0].Email != b.Email {
+		t.Errorf("ListBlocked = %+v, want b", list)
+	}
+
+	if err := s.Blocks().Block(ctx, a.ID, "no-such-user"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Block unknown user = %v, want ErrNotFound", err)
+	}
+
+	must(t, s.Blocks().Unblock(ctx, a.ID, b.ID))
+	if err := s.Blocks().Unblock(ctx, a.ID, b.ID); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Unblock twice = %v, want ErrNotFound", err)
+	}
+	if blocked, _ := s.Blocks().IsBlocked(ctx, a.ID, b.ID); blocked {
+		t.Error("still blocked after Unblock")
+	}
+}
+
+func TestAuditRepository(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 2)
+
+	first := &store.AuditEvent{UserID: users[0].ID, Action: store.AuditContactRemoved, TargetID: users[1].ID}
+	if err := s.Audit().Record(ctx, first); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if first.ID == 0 {
+		t.Error("expected ID to be set")
+	}
+	second := &store.AuditEvent{UserID: users[0].ID, Action: store.AuditContactRemoved, TargetID: "other"}
+	s.Audit().Record(ctx, second)
+
+	events, err := s.Audit().ListForUser(ctx, users[0].ID)
+	if err != nil {
+		t.Fatalf("ListForUser failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events))
+	}
+	if events[0].TargetID != "other" {
+		t.Errorf("newest target = %q, want %q", events[0].TargetID, "other")
+	}
+
+	events, _ = s.Audit().ListForUser(ctx, users[1].ID)
+	if len(events) != 0 {
+		t.Errorf("other user's events = %d, want 0", len(events))
+	}
+}
+
+func TestAuditRepository_CountSince(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	users := createTestUsers(t, s, 1)
+
+	now := time.Now()
+	s.Audit().Record(ctx, &store.AuditEvent{UserID: users[0].ID, Action: store.AuditIdentityBackupReplaced, CreatedAt: now.Add(-2 * time.Hour)})
+	s.Audit().Record(ctx, &store.AuditEvent{UserID: users[0].ID, Action: store.AuditIdentityBackupReplaced, CreatedAt: now})
+	s.Audit().Record(ctx, &store.AuditEvent{UserID: users[0].ID, Action: store.AuditContactRemoved, CreatedAt: now})
+
+	count, err := s.Audit().CountSince(ctx, users[0].ID, store.AuditIdentityBackupReplaced, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountSince failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+}
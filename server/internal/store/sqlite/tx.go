@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/whereish/server/internal/store"
+)
+
+// dbtx is the query interface shared by *sql.DB and *sql.Tx, so
+// repositories work the same inside and outside a transaction
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txn is a transaction started by beginTx
+type txn interface {
+	dbtx
+	Commit() error
+	Rollback() error
+}
+
+// beginTx starts a transaction on db. Inside a WithTx transaction it
+// joins the outer transaction, which decides whether to commit.
+func beginTx(ctx context.Context, db dbtx) (txn, error) {
+	if tx, ok := db.(*sql.Tx); ok {
+		return nestedTx{tx}, nil
+	}
+	return db.(*sql.DB).BeginTx(ctx, nil)
+}
+
+// nestedTx defers Commit and Rollback to the enclosing transaction
+type nestedTx struct {
+	*sql.Tx
+}
+
+func (nestedTx) Commit() error   { return nil }
+func (nestedTx) Rollback() error { return nil }
+
+// conn returns the transaction when inside WithTx, otherwise the database
+func (s *Store) conn() dbtx {
+	if s.tx != nil {
+		return s.tx
+	}
+	return s.db
+}
+
+// WithTx runs fn in a transaction, committing if it returns nil and rolling
+// back otherwise. Calls made inside an existing transaction join it.
+func (s *Store) WithTx(ctx context.Context, fn func(tx store.Store) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&Store{db: s.db, tx: tx, dedupe: s.dedupe, emails: s.emails, keyCleanup: s.keyCleanup}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
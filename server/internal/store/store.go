@@ -5,6 +5,7 @@ package store
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 )
 
@@ -23,6 +24,15 @@ type Store interface {
 	Devices() DeviceRepository
 	Locations() LocationRepository
 	Sessions() SessionRepository
+	Audit() AuditRepository
+	Blocks() BlockRepository
+
+	// WithTx runs fn in a transaction, committing if it returns nil and
+	// rolling back otherwise. Repositories from tx share the transaction.
+	WithTx(ctx context.Context, fn func(tx Store) error) error
+
+	// Ping verifies the database is reachable
+	Ping(ctx context.Context) error
 
 	// Close releases database resources
 	Close() error
@@ -30,22 +40,37 @@ type Store interface {
 
 // User represents a registered user
 type User struct {
-	ID        string
-	Email     string
-	GoogleID  string // nullable
-	Name      string
-	PublicKey string // Base64-encoded X25519 public key
-	CreatedAt time.Time
+	ID          string
+	Email       string
+	GoogleID    string // nullable
+	AppleID     string // nullable
+	Name        string
+	PublicKey   string // Base64-encoded X25519 public key
+	CreatedAt   time.Time
+	LastLoginAt *time.Time // nullable
 }
 
 // IdentityBackup stores the encrypted identity keypair
 type IdentityBackup struct {
-	Algorithm  string // e.g., "AES-256-GCM"
-	KDF        string // e.g., "PBKDF2-SHA256"
-	Iterations int
-	Salt       string // Base64
-	IV         string // Base64
-	Payload    string // Base64 ciphertext
+	Algorithm   string // e.g., "AES-256-GCM"
+	KDF         string // e.g., "PBKDF2-SHA256" or "argon2id"
+	Iterations  int    // PBKDF2 iterations or Argon2id time cost
+	Memory      int    // Argon2id memory in KiB, 0 for PBKDF2
+	Parallelism int    // Argon2id threads, 0 for PBKDF2
+	Salt        string // Base64
+	IV          string // Base64
+	Payload     string // Base64 ciphertext
+	Generation  int    // Incremented on every write, 0 if no backup exists
+}
+
+// IdentityBackupMeta holds the encryption parameters of an identity backup
+// without the key material or ciphertext
+type IdentityBackupMeta struct {
+	Algorithm   string
+	KDF         string
+	Iterations  int
+	Memory      int
+	Parallelism int
 }
 
 // UserData stores the encrypted user data blob
@@ -55,6 +80,139 @@ type UserData struct {
 	Blob      string // Base64 ciphertext
 }
 
+// StorageUsage is the number of bytes a user's data occupies, by category.
+// Sizes are of the stored (encoded) values, not the plaintext.
+type StorageUsage struct {
+	IdentityBackup int64
+	UserData       int64
+	Locations      int64 // Locations shared by the user
+}
+
+// Total returns the combined size of all categories
+func (u *StorageUsage) Total() int64 {
+	return u.IdentityBackup + u.UserData + u.Locations
+}
+
+// AccountDeletion counts the rows removed when an account is deleted
+type AccountDeletion struct {
+	Locations      int64 // shared by or with the user
+	Sessions       int64
+	RefreshTokens  int64
+	Devices        int64
+	ContactNotes   int64 // written by or about the user
+	Nudges         int64 // sent or received
+	Presence       int64 // shared by or with the user
+	Blocks         int64 // by or of the user
+	Contacts       int64 // both directions
+	Requests       int64 // sent or received
+	IdentityBackup int64
+	UserData       int64
+	Settings       int64
+	AuditEvents    int64
+	RecoveryCodes  int64
+}
+
+// Nudge is a contact asking the user to share their location
+type Nudge struct {
+	FromUserID string
+	ToUserID   string
+	CreatedAt  time.Time
+}
+
+// Pair states returned by ContactRepository.PairStatus
+const (
+	PairNone     = ""
+	PairContacts = "contacts"
+	PairPending  = "pending" // a request is pending in either direction
+)
+
+// ContactSummary counts a user's contacts, sharing and pending requests
+type ContactSummary struct {
+	Contacts        int
+	WithPublicKey   int
+	SharingTo       int // contacts the user shares a location with
+	SharingFrom     int // contacts sharing a location with the user
+	PendingIncoming int
+	PendingOutgoing int
+}
+
+// LoginAttempt records a single login attempt
+type LoginAttempt struct {
+	Email     string // empty when the attempt failed before an email was known
+	IP        string
+	Success   bool
+	CreatedAt time.Time
+}
+
+// InactiveAccount is a user who hasn't logged in for a while
+type InactiveAccount struct {
+	UserID     string
+	LastActive time.Time  // last login, or sign-up if they never logged in
+	FlaggedAt  *time.Time // nullable - set once flagged for deletion
+}
+
+// Audit actions
+const (
+	AuditContactRemoved         = "contact.removed"
+	AuditIdentityBackupReplaced = "identity_backup.replaced"
+	AuditAccountFlagged         = "account.flagged_inactive"
+	AuditAccountExpired         = "account.deleted_inactive"
+)
+
+// AuditEvent records a change made by a user
+type AuditEvent struct {
+	ID        int64
+	UserID    string // who made the change
+	Action    string // one of the Audit* constants
+	TargetID  string // what the change applied to, e.g. the contact
+	CreatedAt time.Time
+}
+
+// Setting keys stored in the user_settings table
+const (
+	SettingDiscoverable       = "discoverable"
+	SettingSharingEnabled     = "sharing_enabled"
+	SettingAutoAcceptRequests = "auto_accept_requests"
+	SettingLastKnownMode      = "last_known_mode"
+	SettingAcceptRequests     = "accept_requests"
+)
+
+// UserSettings holds a user's typed settings
+type UserSettings struct {
+	Discoverable       bool
+	SharingEnabled     bool
+	AutoAcceptRequests bool
+	LastKnownMode      bool
+	AcceptRequests     bool
+}
+
+// SettingsFromMap builds typed settings from stored key/value pairs,
+// using defaults for anything unset or unparseable
+func SettingsFromMap(values map[string]string) *UserSettings {
+	settings := &UserSettings{
+		Discoverable:       true,
+		SharingEnabled:     true,
+		AutoAcceptRequests: false,
+		LastKnownMode:      false,
+		AcceptRequests:     true,
+	}
+
+	boolSetting := func(key string, target *bool) {
+		if v, ok := values[key]; ok {
+			if b, err := strconv.ParseBool(v); err == nil {
+				*target = b
+			}
+		}
+	}
+	boolSetting(SettingDiscoverable, &settings.Discoverable)
+	boolSetting(SettingSharingEnabled, &settings.SharingEnabled)
+	boolSetting(SettingAutoAcceptRequests, &settings.AutoAcceptRequests)
+	boolSetting(SettingLastKnownMode, &settings.LastKnownMode)
+	boolSetting(SettingAcceptRequests, &settings.AcceptRequests)
+
+	return settings
+}
+
 // UserRepository handles user-related database operations
 type UserRepository interface {
 	// Create creates a new user
@@ -63,38 +221,117 @@ type UserRepository interface {
 	// GetByID retrieves a user by ID
 	GetByID(ctx context.Context, id string) (*User, error)
 
-	// GetByEmail retrieves a user by email
+	// GetByEmail retrieves a user by exact email, ignoring case. Sign in
+	// links accounts by it, so it must never match a different mailbox.
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
+	// FindByEmail retrieves the user an email reaches, matching address
+	// variants if the store normalizes emails. For finding people, such as
+	// contact request recipients; never for linking logins.
+	FindByEmail(ctx context.Context, email string) (*User, error)
+
 	// GetByGoogleID retrieves a user by Google OAuth ID
 	GetByGoogleID(ctx context.Context, googleID string) (*User, error)
 
+	// GetByAppleID retrieves a user by Sign in with Apple user ID
+	GetByAppleID(ctx context.Context, appleID string) (*User, error)
+
 	// Update updates a user's profile
 	Update(ctx context.Context, user *User) error
 
 	// Delete deletes a user and all associated data
 	Delete(ctx context.Context, id string) error
 
-	// SetPublicKey sets the user's public key
+	// SoftDelete marks a user deleted, ends their sessions and drops their
+	// recovery codes, keeping the row. Deleted users are left out of every lookup and listing, as if
+	// they didn't exist, and their email and Google and Apple IDs are
+	// released so signing in again creates a new account. Returns
+	// ErrNotFound if already deleted.
+	SoftDelete(ctx context.Context, id string) error
+
+	// DeleteAccount explicitly deletes everything belonging to a user, then
+	// the user, in one transaction. Unlike Delete it doesn't rely on the
+	// backend cascading, and reports how many rows it removed.
+	DeleteAccount(ctx context.Context, id string) (*AccountDeletion, error)
+
+	// SetPublicKey sets the user's public key. If the store is configured
+	// to, replacing or clearing an existing key also deletes the locations
+	// and presence the user has shared, which were encrypted under it.
 	SetPublicKey(ctx context.Context, userID, publicKey string) error
 
+	// TouchLastLogin records a successful login at the current time and
+	// clears any inactivity flag
+	TouchLastLogin(ctx context.Context, userID string) error
+
+	// ListInactive returns users not deleted who haven't been active since
+	// before the given time
+	ListInactive(ctx context.Context, before time.Time) ([]*InactiveAccount, error)
+
+	// FlagInactive marks a user for deletion as inactive, keeping an
+	// earlier flag if there is one
+	FlagInactive(ctx context.Context, userID string, at time.Time) error
+
+	// Login attempt operations
+	RecordLoginAttempt(ctx context.Context, attempt *LoginAttempt) error
+	// CountRecentFailures counts failed attempts matching the email or IP
+	// since the given time. A success resets only its own key: failures
+	// by email before the email's last success are ignored, and likewise
+	// for the IP.
+	CountRecentFailures(ctx context.Context, email, ip string, since time.Time) (int, error)
+	PruneLoginAttempts(ctx context.Context, before time.Time) error
+
+	// Recovery code operations. Codes are stored as hashes.
+	// SetRecoveryCodes replaces all of a user's recovery codes
+	SetRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+	// UseRecoveryCode consumes an unused code, returning its user's ID, or
+	// ErrNotFound if the code doesn't exist, was already used or belongs to
+	// a deleted user
+	UseRecoveryCode(ctx context.Context, hash string) (string, error)
+	// CountRecoveryCodes counts a user's unused recovery codes
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+
 	// Identity backup operations
 	GetIdentityBackup(ctx context.Context, userID string) (*IdentityBackup, error)
-	SetIdentityBackup(ctx context.Context, userID string, backup *IdentityBackup) error
+	// SetIdentityBackup creates the backup when expectedGeneration is 0, or
+	// replaces it only if the stored generation matches. Returns
+	// ErrVersionConflict otherwise.
+	SetIdentityBackup(ctx context.Context, userID string, backup *IdentityBackup, expectedGeneration int) error
+	GetIdentityBackupMeta(ctx context.Context, userID string) (*IdentityBackupMeta, error)
+	// Onboard registers the public key and creates the identity backup in
+	// one transaction, for a user who has neither. Returns
+	// ErrVersionConflict if either is already set.
+	Onboard(ctx context.Context, userID, publicKey string, backup *IdentityBackup) error
 
 	// User data operations
 	GetUserData(ctx context.Context, userID string) (*UserData, error)
+	// SetUserData creates the data when expectedVersion is 0, or replaces it
+	// only if the stored version matches. On ErrVersionConflict, data.Version
+	// is set to the stored version (0 if none).
 	SetUserData(ctx context.Context, userID string, data *UserData, expectedVersion int) error
+
+	// Settings operations (key/value)
+	GetSettings(ctx context.Context, userID string) (map[string]string, error)
+	SetSettings(ctx context.Context, userID string, values map[string]string) error
+
+	// UserStorageBytes reports how much storage the user's data occupies
+	UserStorageBytes(ctx context.Context, userID string) (*StorageUsage, error)
 }
 
-// ContactRequest represents a pending contact request
+// ContactRequest represents a contact request and its outcome
 type ContactRequest struct {
 	ID          string
 	RequesterID string
 	RecipientID string
-	Status      string // 'pending', 'accepted', 'declined'
+	Status      string // 'pending', 'accepted', 'declined', 'expired'
 	CreatedAt   time.Time
 	AcceptedAt  *time.Time // nullable
+	DeclinedAt  *time.Time // nullable
+	ExpiredAt   *time.Time // nullable
+
+	// The other user's name and email, from the viewer's side. Only the
+	// pending request lists fill these in.
+	Name  string
+	Email string
 }
 
 // Contact represents an accepted contact relationship
@@ -105,6 +342,9 @@ type Contact struct {
 	Email     string
 	PublicKey string
 	CreatedAt time.Time
+	SortOrder *int      // nullable - set when pinned
+	Note      string    // private to UserID, empty if none
+	UpdatedAt time.Time // last change to the contact's name, key, pin or note
 }
 
 // ContactRepository handles contact-related database operations
@@ -112,7 +352,37 @@ type ContactRepository interface {
 	// ListContacts returns all contacts for a user
 	ListContacts(ctx context.Context, userID string) ([]*Contact, error)
 
-	// RemoveContact removes a bidirectional contact relationship
+	// ListContactsPage returns up to limit contacts for a user in
+	// ListContacts order, skipping the first offset
+	ListContactsPage(ctx context.Context, userID string, offset, limit int) ([]*Contact, error)
+
+	// CountContacts counts a user's contacts
+	CountContacts(ctx context.Context, userID string) (int, error)
+
+	// Summary returns contact, sharing and pending request counts
+	Summary(ctx context.Context, userID string) (*ContactSummary, error)
+
+	// CountContactsWithKeys counts contacts who have published a public
+	// key, i.e. who can be shared with
+	CountContactsWithKeys(ctx context.Context, userID string) (int, error)
+
+	// SetSortOrder pins a contact in the user's list (nil unpins)
+	SetSortOrder(ctx context.Context, userID, contactID string, order *int) error
+
+	// SetNote sets the user's private note on a contact (empty removes it)
+	SetNote(ctx context.Context, userID, contactID, note string) error
+
+	// Nudge records the user asking a contact to share their location.
+	// Returns false without recording if the user already nudged them
+	// after notSince, and ErrNotFound if they aren't contacts.
+	Nudge(ctx context.Context, userID, contactID string, notSince time.Time) (bool, error)
+
+	// ListNudges returns nudges to the user that they haven't answered by
+	// sharing with the nudger, newest first
+	ListNudges(ctx context.Context, userID string) ([]*Nudge, error)
+
+	// RemoveContact removes a bidirectional contact relationship and the
+	// accepted request behind it, so either user can send a new one
 	RemoveContact(ctx context.Context, userID, contactID string) error
 
 	// CreateRequest creates a new contact request
@@ -127,17 +397,28 @@ type ContactRepository interface {
 	// ListOutgoingRequests returns pending requests sent by the user
 	ListOutgoingRequests(ctx context.Context, userID string) ([]*ContactRequest, error)
 
+	// ListRequestHistory returns requests sent or received by the user in
+	// any status, newest first. Pass the CreatedAt and ID of the last
+	// request seen to continue after it, or an empty beforeID to start.
+	ListRequestHistory(ctx context.Context, userID string, beforeCreatedAt time.Time, beforeID string, limit int) ([]*ContactRequest, error)
+
 	// AcceptRequest accepts a contact request (creates bidirectional contact)
 	AcceptRequest(ctx context.Context, requestID, userID string) error
 
 	// DeclineRequest declines a contact request
 	DeclineRequest(ctx context.Context, requestID, userID string) error
 
-	// CancelRequest cancels an outgoing contact request
+	// ExpireRequests marks requests still pending from before the given
+	// time as expired, returning how many were expired
+	ExpireRequests(ctx context.Context, before time.Time) (int, error)
+
+	// CancelRequest deletes an outgoing contact request that's pending or
+	// expired, freeing the pair for a new request
 	CancelRequest(ctx context.Context, requestID, userID string) error
 
-	// CheckExistingRequest checks if a request or contact already exists
-	CheckExistingRequest(ctx context.Context, requesterID, recipientID string) (bool, error)
+	// PairStatus reports whether two users are already contacts or have a
+	// request pending in either direction. Either blocks a new request.
+	PairStatus(ctx context.Context, userID, otherID string) (string, error)
 
 	// AreContacts checks if two users are contacts
 	AreContacts(ctx context.Context, userID, otherID string) (bool, error)
@@ -149,10 +430,19 @@ type Device struct {
 	UserID    string
 	Name      string
 	Platform  string // 'ios', 'android', 'web', 'cli'
+	UserAgent string // client-declared app and version, e.g. "Whereish iOS/2.3.1"
 	Token     string // device-specific auth token
 	CreatedAt time.Time
 	LastSeen  time.Time
 	RevokedAt *time.Time // nullable
+	// PausedUntil blocks the device until this time; nullable, and ignored
+	// once it has passed
+	PausedUntil *time.Time
+}
+
+// IsPaused reports whether the device is paused at the given time
+func (d *Device) IsPaused(now time.Time) bool {
+	return d.PausedUntil != nil && now.Before(*d.PausedUntil)
 }
 
 // DeviceRepository handles device-related database operations
@@ -172,8 +462,17 @@ type DeviceRepository interface {
 	// UpdateLastSeen updates the device's last seen timestamp
 	UpdateLastSeen(ctx context.Context, deviceID string) error
 
+	// Rename changes the name of one of the user's devices
+	Rename(ctx context.Context, deviceID, userID, name string) error
+
 	// Revoke marks a device as revoked
 	Revoke(ctx context.Context, deviceID, userID string) error
+
+	// Pause blocks an unrevoked device until the given time
+	Pause(ctx context.Context, deviceID, userID string, until time.Time) error
+
+	// Unpause ends an unrevoked device's pause
+	Unpause(ctx context.Context, deviceID, userID string) error
 }
 
 // EncryptedLocation represents an encrypted location shared between users
@@ -182,21 +481,73 @@ type EncryptedLocation struct {
 	ToUserID   string
 	Blob       string // Base64 NaCl box ciphertext
 	UpdatedAt  time.Time
+	Precision  string     // sender-declared Precision* label; empty is stored as exact
+	ExpiresAt  *time.Time // nil never expires
+}
+
+// Presence is a small encrypted check-in status, such as "arrived", shared
+// with one contact. It's kept apart from locations.
+type Presence struct {
+	FromUserID string
+	ToUserID   string
+	Blob       string // Base64 NaCl box ciphertext
+	UpdatedAt  time.Time
+}
+
+// Location precision labels. They're unverified hints from the sender.
+const (
+	PrecisionCountry = "country"
+	PrecisionCity    = "city"
+	PrecisionExact   = "exact"
+)
+
+// LocationResult reports whether a single location in a batch was written
+type LocationResult struct {
+	ToUserID string
+	Err      error
 }
 
 // LocationRepository handles location-related database operations
 type LocationRepository interface {
-	// GetLocationsForUser returns all locations shared TO a user
+	// GetLocationsForUser returns all unexpired locations shared TO a user
 	GetLocationsForUser(ctx context.Context, userID string) ([]*EncryptedLocation, error)
 
+	// GetLocationsForUserPage returns up to limit locations shared TO a user,
+	// ordered by sender, starting after the given sender ID ("" for the
+	// first page)
+	GetLocationsForUserPage(ctx context.Context, userID, afterFromUserID string, limit int) ([]*EncryptedLocation, error)
+
+	// CountLocationsForUser counts unexpired locations shared TO a user
+	CountLocationsForUser(ctx context.Context, userID string) (int, error)
+
+	// HasIncoming reports whether any location is shared TO a user, and
+	// when the most recent one was updated
+	HasIncoming(ctx context.Context, userID string) (bool, time.Time, error)
+
 	// SetLocations updates/creates locations shared FROM a user
 	SetLocations(ctx context.Context, fromUserID string, locations []*EncryptedLocation) error
 
+	// SetLocationsBestEffort writes each location independently, returning
+	// one result per location instead of failing the whole batch
+	SetLocationsBestEffort(ctx context.Context, fromUserID string, locations []*EncryptedLocation) ([]LocationResult, error)
+
 	// DeleteLocationsFromUser deletes all locations shared by a user
 	DeleteLocationsFromUser(ctx context.Context, userID string) error
 
 	// DeleteLocationsBetween deletes locations between two users
 	DeleteLocationsBetween(ctx context.Context, userID, contactID string) error
+
+	// DeleteExpired removes locations whose expiry has passed, returning
+	// how many were removed
+	DeleteExpired(ctx context.Context) (int64, error)
+
+	// SetPresence replaces everything a user's presence was shared with by
+	// the given statuses, one per recipient. Returns ErrNotFound if a
+	// recipient isn't a contact.
+	SetPresence(ctx context.Context, fromUserID string, statuses []*Presence) error
+
+	// GetPresenceForUser returns presence shared TO a user by their contacts
+	GetPresenceForUser(ctx context.Context, userID string) ([]*Presence, error)
 }
 
 // Session represents an authenticated session
@@ -208,6 +559,18 @@ type Session struct {
 	ExpiresAt time.Time
 }
 
+// RefreshToken lets a client replace its session without logging in
+// again. Only a hash of the token is stored. Each is used once: using it
+// issues a new session and a new refresh token.
+type RefreshToken struct {
+	TokenHash    string
+	UserID       string
+	SessionToken string // the session it was issued with
+	DeviceID     string // optional - follows the session's device
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
 // SessionRepository handles session-related database operations
 type SessionRepository interface {
 	// Create creates a new session
@@ -216,12 +579,72 @@ type SessionRepository interface {
 	// GetByToken retrieves a session by token
 	GetByToken(ctx context.Context, token string) (*Session, error)
 
-	// Delete deletes a session
+	// SetDevice binds a session, and refresh tokens issued with it, to a
+	// registered device
+	SetDevice(ctx context.Context, token, deviceID string) error
+
+	// ListForDevice returns the unexpired sessions bound to a device,
+	// latest expiry first
+	ListForDevice(ctx context.Context, deviceID string) ([]*Session, error)
+
+	// ListForUser returns a user's unexpired sessions, newest first
+	ListForUser(ctx context.Context, userID string) ([]*Session, error)
+
+	// Delete deletes a session and refresh tokens issued with it
 	Delete(ctx context.Context, token string) error
 
-	// DeleteForUser deletes all sessions for a user
+	// DeleteForUser deletes all sessions and refresh tokens for a user
 	DeleteForUser(ctx context.Context, userID string) error
 
-	// DeleteExpired removes expired sessions
-	DeleteExpired(ctx context.Context) error
+	// DeleteExpired removes expired sessions and refresh tokens, returning
+	// how many were removed
+	DeleteExpired(ctx context.Context) (int64, error)
+
+	// CreateRefreshToken stores a refresh token
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+
+	// UseRefreshToken deletes an unexpired refresh token by hash and
+	// returns it. Returns ErrNotFound if there's no such token, it has
+	// expired or it was already used.
+	UseRefreshToken(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// DeleteRefreshTokensForDevice deletes refresh tokens bound to a device
+	DeleteRefreshTokensForDevice(ctx context.Context, deviceID string) error
+}
+
+// BlockedUser is someone a user has blocked
+type BlockedUser struct {
+	UserID    string
+	Name      string
+	Email     string
+	CreatedAt time.Time
+}
+
+// BlockRepository tracks users who have blocked each other
+type BlockRepository interface {
+	// Block blocks targetID for userID and deletes pending requests
+	// between them. Blocking again is a no-op. Returns ErrNotFound if the
+	// target doesn't exist.
+	Block(ctx context.Context, userID, targetID string) error
+
+	// Unblock removes a block. Returns ErrNotFound if there was none.
+	Unblock(ctx context.Context, userID, targetID string) error
+
+	// IsBlocked reports whether userID has blocked targetID
+	IsBlocked(ctx context.Context, userID, targetID string) (bool, error)
+
+	// ListBlocked returns the users userID has blocked, most recent first
+	ListBlocked(ctx context.Context, userID string) ([]*BlockedUser, error)
+}
+
+// AuditRepository records user changes for later review
+type AuditRepository interface {
+	// Record stores an audit event
+	Record(ctx context.Context, event *AuditEvent) error
+
+	// ListForUser returns a user's audit events, newest first
+	ListForUser(ctx context.Context, userID string) ([]*AuditEvent, error)
+
+	// CountSince counts a user's events with the given action since a time
+	CountSince(ctx context.Context, userID, action string, since time.Time) (int, error)
 }
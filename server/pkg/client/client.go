@@ -4,26 +4,71 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// ErrNotFound is returned by lookup helpers when nothing matches
+var ErrNotFound = errors.New("not found")
+
+// Version is the client version sent to the server with each request
+const Version = "1.0.0"
+
 // WhereishClient is a high-level Whereish API client
 type WhereishClient struct {
 	baseURL    string
-	token      string
+	userAgent  string
 	httpClient *http.Client
+
+	auth         sync.Mutex // guards token and refreshToken
+	token        string
+	refreshToken string
+
+	// refreshing serializes refreshes, so concurrent requests that get a
+	// 401 use the refresh token once
+	refreshing sync.Mutex
+	onRefresh  func(token, refreshToken string)
+
+	retries        int
+	retryBaseDelay time.Duration
+	retryAll       bool
+
+	etags etagCache
 }
 
 // ClientConfig holds client configuration
 type ClientConfig struct {
-	BaseURL string
-	Token   string
-	Timeout time.Duration
+	BaseURL   string
+	Token     string
+	Timeout   time.Duration
+	UserAgent string // sent on API requests; the server records it on device registration
+
+	// RefreshToken renews the session when the server rejects Token
+	RefreshToken string
+	// OnRefresh is called with the new tokens after the session is
+	// refreshed, so they can be saved
+	OnRefresh func(token, refreshToken string)
+
+	// Retries is how many times a request is retried after a connection
+	// error or 5xx response (0 = never). Only GET, HEAD, OPTIONS and
+	// DELETE are retried unless RetryNonIdempotent is set.
+	Retries int
+	// RetryBaseDelay is the wait before the first retry, doubling for
+	// each one after (default 200ms)
+	RetryBaseDelay time.Duration
+	// RetryNonIdempotent also retries POST, PUT and PATCH, which may
+	// repeat their effects if the server acted before the failure
+	RetryNonIdempotent bool
 }
 
 // NewWhereishClient creates a new Whereish client
@@ -31,9 +76,19 @@ func NewWhereishClient(cfg ClientConfig) *WhereishClient {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 30 * time.Second
 	}
+	if cfg.RetryBaseDelay == 0 {
+		cfg.RetryBaseDelay = defaultRetryBaseDelay
+	}
 	return &WhereishClient{
-		baseURL: cfg.BaseURL,
-		token:   cfg.Token,
+		baseURL:      cfg.BaseURL,
+		token:        cfg.Token,
+		refreshToken: cfg.RefreshToken,
+		onRefresh:    cfg.OnRefresh,
+		userAgent:    cfg.UserAgent,
+
+		retries:        cfg.Retries,
+		retryBaseDelay: cfg.RetryBaseDelay,
+		retryAll:       cfg.RetryNonIdempotent,
 		httpClient: &http.Client{
 			Timeout: cfg.Timeout,
 		},
@@ -42,6 +97,8 @@ func NewWhereishClient(cfg ClientConfig) *WhereishClient {
 
 // SetToken sets the authentication token
 func (c *WhereishClient) SetToken(token string) {
+	c.auth.Lock()
+	defer c.auth.Unlock()
 	c.token = token
 }
 
@@ -52,7 +109,8 @@ func (c *WhereishClient) Health(ctx context.Context) (*HealthResponse, error) {
 		return nil, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	c.setClientHeaders(req)
+	resp, err := c.send(c.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +140,47 @@ func (c *WhereishClient) LoginWithGoogle(ctx context.Context, idToken string) (*
 		return nil, err
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	c.setClientHeaders(httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var login LoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return nil, err
+	}
+
+	// Store tokens for future requests
+	c.storeLogin(&login)
+	return &login, nil
+}
+
+// LoginWithApple authenticates with a Sign in with Apple identity token.
+// nonce is the one the app generated for the sign in; name is the user's
+// name from Apple's first authorization, or "" on later sign ins.
+func (c *WhereishClient) LoginWithApple(ctx context.Context, idToken, nonce, name string) (*LoginResponse, error) {
+	req := AppleLoginRequest{IdToken: idToken, Nonce: nonce}
+	if name != "" {
+		req.Name = &name
+	}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/auth/apple", body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	c.setClientHeaders(httpReq)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -98,20 +197,51 @@ func (c *WhereishClient) LoginWithGoogle(ctx context.Context, idToken string) (*
 		return nil, err
 	}
 
-	// Store token for future requests
-	c.token = login.Token
+	// Store tokens for future requests
+	c.storeLogin(&login)
 	return &login, nil
 }
 
-// DevLoginRequest is the request for dev login
-type DevLoginRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name,omitempty"`
+// LoginWithRecoveryCode authenticates with a one-time recovery code
+func (c *WhereishClient) LoginWithRecoveryCode(ctx context.Context, code string) (*LoginResponse, error) {
+	body, err := jsonBody(RecoveryLoginRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/auth/recovery", body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	c.setClientHeaders(httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var login LoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return nil, err
+	}
+
+	// Store tokens for future requests
+	c.storeLogin(&login)
+	return &login, nil
 }
 
 // DevLogin authenticates with email only (dev mode)
 func (c *WhereishClient) DevLogin(ctx context.Context, email, name string) (*LoginResponse, error) {
-	req := DevLoginRequest{Email: email, Name: name}
+	req := DevLoginRequest{Email: email}
+	if name != "" {
+		req.Name = &name
+	}
 	body, err := jsonBody(req)
 	if err != nil {
 		return nil, err
@@ -122,6 +252,7 @@ func (c *WhereishClient) DevLogin(ctx context.Context, email, name string) (*Log
 		return nil, err
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	c.setClientHeaders(httpReq)
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -138,8 +269,8 @@ func (c *WhereishClient) DevLogin(ctx context.Context, email, name string) (*Log
 		return nil, err
 	}
 
-	// Store token for future requests
-	c.token = login.Token
+	// Store tokens for future requests
+	c.storeLogin(&login)
 	return &login, nil
 }
 
@@ -154,13 +285,14 @@ func (c *WhereishClient) Logout(ctx context.Context) error {
 	if resp.StatusCode != http.StatusNoContent {
 		return c.parseError(resp)
 	}
-	c.token = ""
+	c.setTokens("", "")
 	return nil
 }
 
-// GetCurrentUser returns the current user
-func (c *WhereishClient) GetCurrentUser(ctx context.Context) (*User, error) {
-	resp, err := c.doAuth(ctx, "GET", "/me", nil)
+// GenerateRecoveryCodes replaces the account's recovery codes with a new
+// set. The codes can't be retrieved again.
+func (c *WhereishClient) GenerateRecoveryCodes(ctx context.Context) (*RecoveryCodes, error) {
+	resp, err := c.doAuth(ctx, "POST", "/auth/recovery-codes", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -170,16 +302,16 @@ func (c *WhereishClient) GetCurrentUser(ctx context.Context) (*User, error) {
 		return nil, c.parseError(resp)
 	}
 
-	var user User
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+	var codes RecoveryCodes
+	if err := json.NewDecoder(resp.Body).Decode(&codes); err != nil {
 		return nil, err
 	}
-	return &user, nil
+	return &codes, nil
 }
 
-// GetIdentityBackup retrieves the encrypted identity backup
-func (c *WhereishClient) GetIdentityBackup(ctx context.Context) (*IdentityBackup, error) {
-	resp, err := c.doAuth(ctx, "GET", "/identity/backup", nil)
+// GetRecoveryCodeStatus returns how many recovery codes are unused
+func (c *WhereishClient) GetRecoveryCodeStatus(ctx context.Context) (*RecoveryCodeStatus, error) {
+	resp, err := c.doAuth(ctx, "GET", "/auth/recovery-codes", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -189,55 +321,86 @@ func (c *WhereishClient) GetIdentityBackup(ctx context.Context) (*IdentityBackup
 		return nil, c.parseError(resp)
 	}
 
-	var backup IdentityBackup
-	if err := json.NewDecoder(resp.Body).Decode(&backup); err != nil {
+	var status RecoveryCodeStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
 		return nil, err
 	}
-	return &backup, nil
+	return &status, nil
 }
 
-// SetIdentityBackup stores the encrypted identity backup
-func (c *WhereishClient) SetIdentityBackup(ctx context.Context, backup *IdentityBackup) error {
-	body, err := jsonBody(backup)
+// ValidateToken checks that the client's token is still valid without
+// fetching the user. An invalid or expired token returns a 401 APIError.
+func (c *WhereishClient) ValidateToken(ctx context.Context) (*TokenValidation, error) {
+	resp, err := c.doAuth(ctx, "GET", "/auth/validate", nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	resp, err := c.doAuth(ctx, "PUT", "/identity/backup", body)
-	if err != nil {
-		return err
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		return c.parseError(resp)
+	var validation TokenValidation
+	if err := json.NewDecoder(resp.Body).Decode(&validation); err != nil {
+		return nil, err
 	}
-	return nil
+	return &validation, nil
 }
 
-// SetPublicKey registers the user's public key
-func (c *WhereishClient) SetPublicKey(ctx context.Context, publicKey string) error {
-	req := PublicKeyRequest{PublicKey: publicKey}
-	body, err := jsonBody(req)
+// Optional server features, as reported by Capabilities
+const (
+	FeatureEvents          = "events"
+	FeaturePresence        = "presence"
+	FeatureNudges          = "nudges"
+	FeatureOnboard         = "onboard"
+	FeatureTokenValidation = "token_validation"
+	FeatureBlocking        = "blocking"
+	FeatureSessions        = "sessions"
+	FeatureLocationStream  = "location_stream"
+	FeatureStorageQuota    = "storage_quota"
+	FeatureRequireDevice   = "require_device"
+	FeatureRefreshTokens   = "refresh_tokens"
+	FeatureAppleSignIn     = "apple_sign_in"
+)
+
+// Capabilities returns the optional features the server supports. A server
+// too old to report them supports none, so that isn't an error.
+func (c *WhereishClient) Capabilities(ctx context.Context) (*Capabilities, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/capabilities", nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resp, err := c.doAuth(ctx, "POST", "/identity/public-key", body)
+	c.setClientHeaders(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		return c.parseError(resp)
+	if resp.StatusCode == http.StatusNotFound {
+		return &Capabilities{Features: []string{}}, nil
 	}
-	return nil
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var caps Capabilities
+	if err := json.NewDecoder(resp.Body).Decode(&caps); err != nil {
+		return nil, err
+	}
+	return &caps, nil
 }
 
-// GetUserData retrieves the encrypted user data
-func (c *WhereishClient) GetUserData(ctx context.Context) (*UserData, error) {
-	resp, err := c.doAuth(ctx, "GET", "/user-data", nil)
+// Has reports whether the server supports a feature
+func (caps *Capabilities) Has(feature string) bool {
+	return slices.Contains(caps.Features, feature)
+}
+
+// GetCurrentUser returns the current user
+func (c *WhereishClient) GetCurrentUser(ctx context.Context) (*User, error) {
+	resp, err := c.doAuth(ctx, "GET", "/me", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -247,22 +410,21 @@ func (c *WhereishClient) GetUserData(ctx context.Context) (*UserData, error) {
 		return nil, c.parseError(resp)
 	}
 
-	var data UserData
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
 		return nil, err
 	}
-	return &data, nil
+	return &user, nil
 }
 
-// SetUserData updates the encrypted user data
-func (c *WhereishClient) SetUserData(ctx context.Context, version int, blob string) (*UserData, error) {
-	req := UserDataUpdate{Version: version, Blob: blob}
-	body, err := jsonBody(req)
+// UpdateProfile changes the user's display name, returning the updated user
+func (c *WhereishClient) UpdateProfile(ctx context.Context, name string) (*User, error) {
+	body, err := jsonBody(ProfileUpdate{Name: name})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.doAuth(ctx, "PUT", "/user-data", body)
+	resp, err := c.doAuth(ctx, "PATCH", "/me", body)
 	if err != nil {
 		return nil, err
 	}
@@ -272,16 +434,16 @@ func (c *WhereishClient) SetUserData(ctx context.Context, version int, blob stri
 		return nil, c.parseError(resp)
 	}
 
-	var data UserData
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
 		return nil, err
 	}
-	return &data, nil
+	return &user, nil
 }
 
-// ListContacts returns all contacts
-func (c *WhereishClient) ListContacts(ctx context.Context) (*ContactList, error) {
-	resp, err := c.doAuth(ctx, "GET", "/contacts", nil)
+// GetStorageUsage returns the user's storage usage and quota
+func (c *WhereishClient) GetStorageUsage(ctx context.Context) (*StorageUsage, error) {
+	resp, err := c.doAuth(ctx, "GET", "/me/usage", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -291,41 +453,40 @@ func (c *WhereishClient) ListContacts(ctx context.Context) (*ContactList, error)
 		return nil, c.parseError(resp)
 	}
 
-	var contacts ContactList
-	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+	var usage StorageUsage
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
 		return nil, err
 	}
-	return &contacts, nil
+	return &usage, nil
 }
 
-// SendContactRequest sends a contact request by email
-func (c *WhereishClient) SendContactRequest(ctx context.Context, email string) (*ContactRequest, error) {
-	req := ContactRequestCreate{Email: Email(email)}
-	body, err := jsonBody(req)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.doAuth(ctx, "POST", "/contacts/request", body)
+// GetSettings returns the user's settings
+func (c *WhereishClient) GetSettings(ctx context.Context) (*UserSettings, error) {
+	resp, err := c.doAuth(ctx, "GET", "/me/settings", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		return nil, c.parseError(resp)
 	}
 
-	var request ContactRequest
-	if err := json.NewDecoder(resp.Body).Decode(&request); err != nil {
+	var settings UserSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
 		return nil, err
 	}
-	return &request, nil
+	return &settings, nil
 }
 
-// ListContactRequests returns pending contact requests
-func (c *WhereishClient) ListContactRequests(ctx context.Context) (*ContactRequestList, error) {
-	resp, err := c.doAuth(ctx, "GET", "/contacts/requests", nil)
+// UpdateSettings updates the provided settings and returns the full set
+func (c *WhereishClient) UpdateSettings(ctx context.Context, update *UserSettingsUpdate) (*UserSettings, error) {
+	body, err := jsonBody(update)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuth(ctx, "PUT", "/me/settings", body)
 	if err != nil {
 		return nil, err
 	}
@@ -335,16 +496,36 @@ func (c *WhereishClient) ListContactRequests(ctx context.Context) (*ContactReque
 		return nil, c.parseError(resp)
 	}
 
-	var requests ContactRequestList
-	if err := json.NewDecoder(resp.Body).Decode(&requests); err != nil {
+	var settings UserSettings
+	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
 		return nil, err
 	}
-	return &requests, nil
+	return &settings, nil
 }
 
-// AcceptContactRequest accepts a contact request
-func (c *WhereishClient) AcceptContactRequest(ctx context.Context, requestID string) (*Contact, error) {
-	resp, err := c.doAuth(ctx, "POST", "/contacts/requests/"+requestID+"/accept", nil)
+// GetIdentityBackup retrieves the encrypted identity backup
+func (c *WhereishClient) GetIdentityBackup(ctx context.Context) (*IdentityBackup, error) {
+	var backup IdentityBackup
+	if err := c.getCacheable(ctx, "/identity/backup", false, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// GetIdentityBackupIfChanged retrieves the encrypted identity backup, or
+// returns ErrNotModified if it hasn't changed since this client last
+// retrieved it
+func (c *WhereishClient) GetIdentityBackupIfChanged(ctx context.Context) (*IdentityBackup, error) {
+	var backup IdentityBackup
+	if err := c.getCacheable(ctx, "/identity/backup", true, &backup); err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
+
+// GetIdentityBackupMeta retrieves the identity backup parameters without the ciphertext
+func (c *WhereishClient) GetIdentityBackupMeta(ctx context.Context) (*IdentityBackupMeta, error) {
+	resp, err := c.doAuth(ctx, "GET", "/identity/backup/meta", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -354,30 +535,21 @@ func (c *WhereishClient) AcceptContactRequest(ctx context.Context, requestID str
 		return nil, c.parseError(resp)
 	}
 
-	var contact Contact
-	if err := json.NewDecoder(resp.Body).Decode(&contact); err != nil {
+	var meta IdentityBackupMeta
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
 		return nil, err
 	}
-	return &contact, nil
+	return &meta, nil
 }
 
-// DeclineContactRequest declines a contact request
-func (c *WhereishClient) DeclineContactRequest(ctx context.Context, requestID string) error {
-	resp, err := c.doAuth(ctx, "POST", "/contacts/requests/"+requestID+"/decline", nil)
+// SetIdentityBackup stores the encrypted identity backup
+func (c *WhereishClient) SetIdentityBackup(ctx context.Context, backup *IdentityBackup) error {
+	body, err := jsonBody(backup)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return c.parseError(resp)
-	}
-	return nil
-}
 
-// CancelContactRequest cancels an outgoing contact request
-func (c *WhereishClient) CancelContactRequest(ctx context.Context, requestID string) error {
-	resp, err := c.doAuth(ctx, "DELETE", "/contacts/requests/"+requestID, nil)
+	resp, err := c.doAuth(ctx, "PUT", "/identity/backup", body)
 	if err != nil {
 		return err
 	}
@@ -389,9 +561,15 @@ func (c *WhereishClient) CancelContactRequest(ctx context.Context, requestID str
 	return nil
 }
 
-// RemoveContact removes a contact
-func (c *WhereishClient) RemoveContact(ctx context.Context, contactID string) error {
-	resp, err := c.doAuth(ctx, "DELETE", "/contacts/"+contactID, nil)
+// OverwriteIdentityBackup replaces the identity backup without checking its
+// generation. Devices still using the old backup's PIN or keys are locked out.
+func (c *WhereishClient) OverwriteIdentityBackup(ctx context.Context, backup *IdentityBackup) error {
+	body, err := jsonBody(backup)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "PUT", "/identity/backup?overwrite=true", body)
 	if err != nil {
 		return err
 	}
@@ -403,34 +581,36 @@ func (c *WhereishClient) RemoveContact(ctx context.Context, contactID string) er
 	return nil
 }
 
-// GetLocations retrieves encrypted locations from contacts
-func (c *WhereishClient) GetLocations(ctx context.Context) (*LocationList, error) {
-	resp, err := c.doAuth(ctx, "GET", "/locations", nil)
+// SetPublicKey registers the user's public key
+func (c *WhereishClient) SetPublicKey(ctx context.Context, publicKey string) error {
+	req := PublicKeyRequest{PublicKey: publicKey}
+	body, err := jsonBody(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
+	resp, err := c.doAuth(ctx, "POST", "/identity/public-key", body)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	var locations LocationList
-	if err := json.NewDecoder(resp.Body).Decode(&locations); err != nil {
-		return nil, err
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
 	}
-	return &locations, nil
+	return nil
 }
 
-// ShareLocations publishes encrypted locations to contacts
-func (c *WhereishClient) ShareLocations(ctx context.Context, locations []LocationShare) error {
-	req := LocationShareRequest{Locations: locations}
+// Onboard stores a new account's identity backup and public key in one
+// call. Either both are saved or neither is.
+func (c *WhereishClient) Onboard(ctx context.Context, publicKey string, backup *IdentityBackup) error {
+	req := OnboardRequest{PublicKey: publicKey, IdentityBackup: *backup}
 	body, err := jsonBody(req)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.doAuth(ctx, "POST", "/locations", body)
+	resp, err := c.doAuth(ctx, "POST", "/onboard", body)
 	if err != nil {
 		return err
 	}
@@ -442,26 +622,623 @@ func (c *WhereishClient) ShareLocations(ctx context.Context, locations []Locatio
 	return nil
 }
 
-// ListDevices returns all devices
-func (c *WhereishClient) ListDevices(ctx context.Context) (*DeviceList, error) {
-	resp, err := c.doAuth(ctx, "GET", "/devices", nil)
-	if err != nil {
+// GetUserData retrieves the encrypted user data
+func (c *WhereishClient) GetUserData(ctx context.Context) (*UserData, error) {
+	var data UserData
+	if err := c.getCacheable(ctx, "/user-data", false, &data); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
-	}
+	return &data, nil
+}
 
-	var devices DeviceList
-	if err := json.NewDecoder(resp.Body).Decode(&devices); err != nil {
+// GetUserDataIfChanged retrieves the encrypted user data, or returns
+// ErrNotModified if it hasn't changed since this client last retrieved it,
+// so a poller can skip decrypting it again
+func (c *WhereishClient) GetUserDataIfChanged(ctx context.Context) (*UserData, error) {
+	var data UserData
+	if err := c.getCacheable(ctx, "/user-data", true, &data); err != nil {
 		return nil, err
 	}
-	return &devices, nil
+	return &data, nil
 }
 
-// RegisterDevice registers a new device
+// SetUserData updates the encrypted user data
+func (c *WhereishClient) SetUserData(ctx context.Context, version int, blob string) (*UserData, error) {
+	req := UserDataUpdate{Version: version, Blob: blob}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuth(ctx, "PUT", "/user-data", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var data UserData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListContacts returns all contacts
+func (c *WhereishClient) ListContacts(ctx context.Context) (*ContactList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var contacts ContactList
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, err
+	}
+	return &contacts, nil
+}
+
+// ListContactsSince returns contacts whose name, key, pin or note changed
+// after the given time
+func (c *WhereishClient) ListContactsSince(ctx context.Context, since time.Time) (*ContactList, error) {
+	path := "/contacts?since=" + url.QueryEscape(since.Format(time.RFC3339Nano))
+	resp, err := c.doAuth(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var contacts ContactList
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, err
+	}
+	return &contacts, nil
+}
+
+// ListContactsPage returns one page of contacts, pinned first then by name,
+// skipping the first offset. Pass the previous page's NextOffset for the
+// next page. A limit of 0 uses the server default.
+func (c *WhereishClient) ListContactsPage(ctx context.Context, offset, limit int) (*ContactList, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(offset))
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := c.doAuth(ctx, "GET", "/contacts?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var contacts ContactList
+	if err := json.NewDecoder(resp.Body).Decode(&contacts); err != nil {
+		return nil, err
+	}
+	return &contacts, nil
+}
+
+// FindContactByEmail returns the contact with the given email, ignoring case
+// and surrounding whitespace. Returns ErrNotFound if no contact matches.
+func (c *WhereishClient) FindContactByEmail(ctx context.Context, email string) (*Contact, error) {
+	contacts, err := c.ListContacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	email = normalizeEmail(email)
+	for i := range contacts.Contacts {
+		if normalizeEmail(string(contacts.Contacts[i].Email)) == email {
+			return &contacts.Contacts[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// SetContactOrder pins a contact at the given position (nil unpins)
+func (c *WhereishClient) SetContactOrder(ctx context.Context, contactID string, order *int) error {
+	req := ContactOrderUpdate{SortOrder: order}
+	body, err := jsonBody(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "PUT", "/contacts/"+contactID+"/order", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// SetContactNote sets a private note on a contact (empty removes it)
+func (c *WhereishClient) SetContactNote(ctx context.Context, contactID, note string) error {
+	req := ContactNoteUpdate{Note: note}
+	body, err := jsonBody(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "PUT", "/contacts/"+contactID+"/note", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// CheckContact reports whether a contact request to the email can be sent
+func (c *WhereishClient) CheckContact(ctx context.Context, email string) (*ContactCheck, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts/check?email="+url.QueryEscape(email), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var check ContactCheck
+	if err := json.NewDecoder(resp.Body).Decode(&check); err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// GetContactSummary returns contact, sharing and pending request counts
+func (c *WhereishClient) GetContactSummary(ctx context.Context) (*ContactSummary, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts/summary", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var summary ContactSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// SendContactRequest sends a contact request by email
+func (c *WhereishClient) SendContactRequest(ctx context.Context, email string) (*ContactRequest, error) {
+	req := ContactRequestCreate{Email: Email(email)}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/contacts/request", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, c.parseError(resp)
+	}
+
+	var request ContactRequest
+	if err := json.NewDecoder(resp.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ListContactRequests returns pending contact requests
+func (c *WhereishClient) ListContactRequests(ctx context.Context) (*ContactRequestList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts/requests", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var requests ContactRequestList
+	if err := json.NewDecoder(resp.Body).Decode(&requests); err != nil {
+		return nil, err
+	}
+	return &requests, nil
+}
+
+// GetContactRequestHistory returns a page of all contact requests, newest
+// first, with their final status. Pass "" for the first page, then the
+// previous page's NextCursor. A limit of 0 uses the server default.
+func (c *WhereishClient) GetContactRequestHistory(ctx context.Context, cursor string, limit int) (*ContactRequestHistory, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	path := "/contacts/requests/history"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doAuth(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var history ContactRequestHistory
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// AcceptContactRequest accepts a contact request
+func (c *WhereishClient) AcceptContactRequest(ctx context.Context, requestID string) (*Contact, error) {
+	resp, err := c.doAuth(ctx, "POST", "/contacts/requests/"+requestID+"/accept", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var contact Contact
+	if err := json.NewDecoder(resp.Body).Decode(&contact); err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// DeclineContactRequest declines a contact request
+func (c *WhereishClient) DeclineContactRequest(ctx context.Context, requestID string) error {
+	resp, err := c.doAuth(ctx, "POST", "/contacts/requests/"+requestID+"/decline", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// CancelContactRequest cancels an outgoing contact request
+func (c *WhereishClient) CancelContactRequest(ctx context.Context, requestID string) error {
+	resp, err := c.doAuth(ctx, "DELETE", "/contacts/requests/"+requestID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// RemoveContact removes a contact
+func (c *WhereishClient) RemoveContact(ctx context.Context, contactID string) error {
+	resp, err := c.doAuth(ctx, "DELETE", "/contacts/"+contactID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// NudgeContact asks a contact to share their location
+func (c *WhereishClient) NudgeContact(ctx context.Context, contactID string) error {
+	resp, err := c.doAuth(ctx, "POST", "/contacts/"+contactID+"/nudge", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// ListNudges lists contacts waiting for the user to share their location
+func (c *WhereishClient) ListNudges(ctx context.Context) (*NudgeList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts/nudges", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var nudges NudgeList
+	if err := json.NewDecoder(resp.Body).Decode(&nudges); err != nil {
+		return nil, err
+	}
+	return &nudges, nil
+}
+
+// BlockUser blocks a user from sending or accepting contact requests
+func (c *WhereishClient) BlockUser(ctx context.Context, userID string) error {
+	body, err := jsonBody(BlockCreate{UserId: userID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/contacts/block", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// UnblockUser removes a block
+func (c *WhereishClient) UnblockUser(ctx context.Context, userID string) error {
+	resp, err := c.doAuth(ctx, "DELETE", "/contacts/block/"+userID, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// ListBlocked lists the users the user has blocked
+func (c *WhereishClient) ListBlocked(ctx context.Context) (*BlockedList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/contacts/blocked", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var blocked BlockedList
+	if err := json.NewDecoder(resp.Body).Decode(&blocked); err != nil {
+		return nil, err
+	}
+	return &blocked, nil
+}
+
+// GetPresence retrieves encrypted check-ins from contacts
+func (c *WhereishClient) GetPresence(ctx context.Context) (*PresenceList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/presence", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var presence PresenceList
+	if err := json.NewDecoder(resp.Body).Decode(&presence); err != nil {
+		return nil, err
+	}
+	return &presence, nil
+}
+
+// SetPresence replaces the user's check-in with the given per-contact blobs
+func (c *WhereishClient) SetPresence(ctx context.Context, statuses []PresenceShare) error {
+	body, err := jsonBody(PresenceUpdate{Statuses: statuses})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/presence", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// GetLocations retrieves encrypted locations from contacts
+func (c *WhereishClient) GetLocations(ctx context.Context) (*LocationList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/locations", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var locations LocationList
+	if err := json.NewDecoder(resp.Body).Decode(&locations); err != nil {
+		return nil, err
+	}
+	return &locations, nil
+}
+
+// GetLocationsPage returns a page of locations shared with the user, by
+// sender. Pass "" for the first page, then the previous page's NextCursor.
+// A limit of 0 gets 100 per page.
+func (c *WhereishClient) GetLocationsPage(ctx context.Context, cursor string, limit int) (*LocationList, error) {
+	// Always send a limit, since without one the server returns everything
+	if limit <= 0 {
+		limit = 100
+	}
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	resp, err := c.doAuth(ctx, "GET", "/locations?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var locations LocationList
+	if err := json.NewDecoder(resp.Body).Decode(&locations); err != nil {
+		return nil, err
+	}
+	return &locations, nil
+}
+
+// GetLocationSnapshot returns a page of all locations shared with the user.
+// Pass "" for the first page, then the previous page's NextCursor. A limit
+// of 0 uses the server default.
+func (c *WhereishClient) GetLocationSnapshot(ctx context.Context, cursor string, limit int) (*LocationSnapshot, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	path := "/locations/all"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.doAuth(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var snapshot LocationSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// ShareLocations publishes encrypted locations to contacts
+func (c *WhereishClient) ShareLocations(ctx context.Context, locations []LocationShare) error {
+	req := LocationShareRequest{Locations: locations}
+	body, err := jsonBody(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/locations", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// ShareLocationsPartial shares locations recipient by recipient, returning
+// which recipients were written instead of failing the whole batch
+func (c *WhereishClient) ShareLocationsPartial(ctx context.Context, locations []LocationShare) (*LocationShareResults, error) {
+	req := LocationShareRequest{Locations: locations}
+	body, err := jsonBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/locations?partial=true", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var result LocationShareResults
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDevices returns all devices
+func (c *WhereishClient) ListDevices(ctx context.Context) (*DeviceList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/devices", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var devices DeviceList
+	if err := json.NewDecoder(resp.Body).Decode(&devices); err != nil {
+		return nil, err
+	}
+	return &devices, nil
+}
+
+// RegisterDevice registers a new device
 func (c *WhereishClient) RegisterDevice(ctx context.Context, name, platform string) (*DeviceWithToken, error) {
 	req := DeviceCreate{Name: name, Platform: DeviceCreatePlatform(platform)}
 	body, err := jsonBody(req)
@@ -500,6 +1277,91 @@ func (c *WhereishClient) RevokeDevice(ctx context.Context, deviceID string) erro
 	return nil
 }
 
+// RenameDevice changes a device's name
+func (c *WhereishClient) RenameDevice(ctx context.Context, deviceID, name string) error {
+	body, err := jsonBody(DeviceUpdate{Name: name})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "PATCH", "/devices/"+deviceID, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// PauseDevice blocks a device until the given time
+func (c *WhereishClient) PauseDevice(ctx context.Context, deviceID string, until time.Time) error {
+	body, err := jsonBody(DevicePause{Until: until})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doAuth(ctx, "POST", "/devices/"+deviceID+"/pause", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// UnpauseDevice ends a device's pause
+func (c *WhereishClient) UnpauseDevice(ctx context.Context, deviceID string) error {
+	resp, err := c.doAuth(ctx, "POST", "/devices/"+deviceID+"/unpause", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
+// ListSessions returns the user's active sessions, with masked tokens
+func (c *WhereishClient) ListSessions(ctx context.Context) (*SessionList, error) {
+	resp, err := c.doAuth(ctx, "GET", "/sessions", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var sessions SessionList
+	if err := json.NewDecoder(resp.Body).Decode(&sessions); err != nil {
+		return nil, err
+	}
+	return &sessions, nil
+}
+
+// RevokeSession ends a session given its masked token from ListSessions
+func (c *WhereishClient) RevokeSession(ctx context.Context, token string) error {
+	resp, err := c.doAuth(ctx, "DELETE", "/sessions/"+url.PathEscape(token), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseError(resp)
+	}
+	return nil
+}
+
 // DeleteAccount permanently deletes the user account
 func (c *WhereishClient) DeleteAccount(ctx context.Context) error {
 	resp, err := c.doAuth(ctx, "DELETE", "/auth/account", nil)
@@ -514,21 +1376,33 @@ func (c *WhereishClient) DeleteAccount(ctx context.Context) error {
 	return nil
 }
 
-// doAuth performs an authenticated HTTP request
+// doAuth performs an authenticated HTTP request, refreshing the session
+// and retrying once if the token is rejected. Transient failures are
+// retried as configured by ClientConfig.Retries.
 func (c *WhereishClient) doAuth(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	body, err := replayable(body)
+	if err != nil {
+		return nil, err
+	}
 	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
-	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	c.setClientHeaders(req)
+
+	return c.sendAuth(c.httpClient, req)
+}
 
-	return c.httpClient.Do(req)
+// setClientHeaders identifies the client on a request
+func (c *WhereishClient) setClientHeaders(req *http.Request) {
+	req.Header.Set("X-Client-Version", Version)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 }
 
 // parseError parses an error response
@@ -565,6 +1439,11 @@ func (e *APIError) Error() string {
 // Email type alias for generated type
 type Email = openapi_types.Email
 
+// normalizeEmail lowercases and trims an email for comparison
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // jsonBody creates a JSON body reader
 func jsonBody(v interface{}) (io.Reader, error) {
 	data, err := json.Marshal(v)
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// testClient creates a client pointed at a test server using the given handler
+func testClient(t *testing.T, handler http.Handler) *WhereishClient {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	return NewWhereishClient(ClientConfig{BaseURL: ts.URL, Token: "test-token"})
+}
+
+// contactsHandler serves a fixed contact list at /contacts
+func contactsHandler(contacts ...Contact) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ContactList{Contacts: contacts})
+	})
+	return mux
+}
+
+// =============================================================================
+// Contact Lookup Tests
+// =============================================================================
+
+func TestClientSendsVersion(t *testing.T) {
+	var got string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-Client-Version")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ContactList{})
+	}))
+
+	if _, err := c.ListContacts(context.Background()); err != nil {
+		t.Fatalf("ListContacts failed: %v", err)
+	}
+	if got != Version {
+		t.Errorf("X-Client-Version = %q, want %q", got, Version)
+	}
+}
+
+func TestFindContactByEmail(t *testing.T) {
+	c := testClient(t, contactsHandler(
+		Contact{Id: "alice-id", Email: "alice@example.com", Name: "Alice", CreatedAt: time.Now()},
+		Contact{Id: "bob-id", Email: "bob@example.com", Name: "Bob", CreatedAt: time.Now()},
+	))
+
+	contact, err := c.FindContactByEmail(context.Background(), " Bob@Example.com ")
+	if err != nil {
+		t.Fatalf("FindContactByEmail failed: %v", err)
+	}
+	if contact.Id != "bob-id" {
+		t.Errorf("contact ID = %q, want %q", contact.Id, "bob-id")
+	}
+}
+
+func TestFindContactByEmail_NotFound(t *testing.T) {
+	c := testClient(t, contactsHandler(
+		Contact{Id: "alice-id", Email: "alice@example.com", Name: "Alice", CreatedAt: time.Now()},
+	))
+
+	_, err := c.FindContactByEmail(context.Background(), "carol@example.com")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// =============================================================================
+// Location Sharing Tests
+// =============================================================================
+
+// partialShareHandler accepts partial shares, rejecting recipients with the
+// error code given for them
+func partialShareHandler(rejected map[string]string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/locations", func(w http.ResponseWriter, r *http.Request) {
+		var req LocationShareRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var results LocationShareResults
+		for _, loc := range req.Locations {
+			result := LocationShareResult{ToUserId: loc.ToUserId, Ok: true}
+			if code, ok := rejected[loc.ToUserId]; ok {
+				result = LocationShareResult{ToUserId: loc.ToUserId, Error: &code}
+			}
+			results.Results = append(results.Results, result)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	})
+	return mux
+}
+
+// shareFixture returns a sender identity, a location, and a contact with a
+// valid key for the given ID
+func shareFixture(t *testing.T) (*crypto.Identity, *crypto.LocationData, func(id string) Contact) {
+	t.Helper()
+	sender, err := crypto.GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+	loc := &crypto.LocationData{Hierarchy: map[string]string{"city": "Seattle"}}
+	contact := func(id string) Contact {
+		recipient, err := crypto.GenerateIdentity()
+		if err != nil {
+			t.Fatalf("GenerateIdentity failed: %v", err)
+		}
+		return Contact{Id: id, Name: id, PublicKey: recipient.PublicKeyBase64()}
+	}
+	return sender, loc, contact
+}
+
+func TestShareLocationEncrypted_Skips(t *testing.T) {
+	c := testClient(t, partialShareHandler(map[string]string{
+		"carol-id": "internal_error",
+		"erin-id":  "not_a_contact",
+	}))
+	sender, loc, contact := shareFixture(t)
+
+	contacts := []Contact{
+		contact("alice-id"),
+		{Id: "bob-id", Name: "Bob"},
+		contact("carol-id"),
+		{Id: "dave-id", Name: "Dave", PublicKey: "not-a-key"},
+		contact("erin-id"),
+	}
+
+	report, err := c.ShareLocationEncrypted(context.Background(), sender, loc, contacts, nil)
+	if err != nil {
+		t.Fatalf("ShareLocationEncrypted failed: %v", err)
+	}
+
+	if len(report.Shared) != 1 || report.Shared[0].Id != "alice-id" {
+		t.Errorf("shared = %v, want alice-id only", report.Shared)
+	}
+
+	want := map[string]SkipReason{
+		"bob-id":   SkipNoPublicKey,
+		"carol-id": SkipRejected,
+		"dave-id":  SkipEncryptFailed,
+		"erin-id":  SkipNotContact,
+	}
+	if len(report.Skipped) != len(want) {
+		t.Fatalf("skipped = %d, want %d", len(report.Skipped), len(want))
+	}
+	for _, skipped := range report.Skipped {
+		if skipped.Reason != want[skipped.Contact.Id] {
+			t.Errorf("%s skip reason = %q, want %q", skipped.Contact.Id, skipped.Reason, want[skipped.Contact.Id])
+		}
+	}
+}
+
+func TestShareLocationEncrypted_RequireKey(t *testing.T) {
+	c := testClient(t, partialShareHandler(nil))
+	sender, loc, contact := shareFixture(t)
+
+	contacts := []Contact{contact("alice-id"), {Id: "bob-id", Name: "Bob"}}
+
+	_, err := c.ShareLocationEncrypted(context.Background(), sender, loc, contacts, &ShareOptions{SkipWithoutKey: false})
+	if !errors.Is(err, ErrMissingPublicKey) {
+		t.Errorf("expected ErrMissingPublicKey, got %v", err)
+	}
+}
+
+func TestShareLocationEncrypted_PerContact(t *testing.T) {
+	precisions := make(map[string]LocationPrecision)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/locations", func(w http.ResponseWriter, r *http.Request) {
+		var req LocationShareRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var results LocationShareResults
+		for _, loc := range req.Locations {
+			precisions[loc.ToUserId] = *loc.Precision
+			results.Results = append(results.Results, LocationShareResult{ToUserId: loc.ToUserId, Ok: true})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(results)
+	})
+	c := testClient(t, mux)
+	sender, loc, contact := shareFixture(t)
+
+	opts := ShareOptions{
+		SkipWithoutKey: true,
+		Precision:      Exact,
+		Contacts:       map[string]ContactShareOptions{"bob-id": {Levels: crypto.LevelsFrom("state"), Precision: Country}},
+	}
+	_, err := c.ShareLocationEncrypted(context.Background(), sender, loc, []Contact{contact("alice-id"), contact("bob-id")}, &opts)
+	if err != nil {
+		t.Fatalf("ShareLocationEncrypted failed: %v", err)
+	}
+	if precisions["alice-id"] != Exact || precisions["bob-id"] != Country {
+		t.Errorf("precisions = %v, want alice exact and bob country", precisions)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// ErrNotModified is returned by the IfChanged getters when the resource
+// hasn't changed since the client last retrieved it
+var ErrNotModified = errors.New("not modified")
+
+// etagCache remembers the ETag of the last response for each path
+type etagCache struct {
+	mu    sync.Mutex
+	etags map[string]string
+}
+
+func (e *etagCache) get(path string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.etags[path]
+}
+
+func (e *etagCache) set(path, etag string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.etags == nil {
+		e.etags = make(map[string]string)
+	}
+	if etag == "" {
+		delete(e.etags, path)
+		return
+	}
+	e.etags[path] = etag
+}
+
+// getCacheable GETs path into v and remembers the response's ETag. If
+// conditional is set, the remembered ETag is sent in If-None-Match and
+// ErrNotModified is returned when the server reports no change.
+func (c *WhereishClient) getCacheable(ctx context.Context, path string, conditional bool, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if etag := c.etags.get(path); conditional && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	c.setClientHeaders(req)
+
+	resp, err := c.sendAuth(c.httpClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return ErrNotModified
+	default:
+		return c.parseError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return err
+	}
+	c.etags.set(path, resp.Header.Get("ETag"))
+	return nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetUserDataIfChanged(t *testing.T) {
+	version := 1
+	var ifNoneMatch []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		etag := `"` + strconv.Itoa(version) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		blob := "blob"
+		json.NewEncoder(w).Encode(UserData{Version: version, Blob: &blob, UpdatedAt: time.Now()})
+	}))
+	ctx := context.Background()
+
+	// Nothing cached yet, so the first conditional get fetches the data
+	if data, err := c.GetUserDataIfChanged(ctx); err != nil || data.Version != 1 {
+		t.Fatalf("first GetUserDataIfChanged = %+v, %v", data, err)
+	}
+	if _, err := c.GetUserDataIfChanged(ctx); !errors.Is(err, ErrNotModified) {
+		t.Errorf("unchanged GetUserDataIfChanged error = %v, want ErrNotModified", err)
+	}
+	// Plain gets always return the data
+	if data, err := c.GetUserData(ctx); err != nil || data.Version != 1 {
+		t.Errorf("GetUserData = %+v, %v", data, err)
+	}
+
+	version = 2
+	if data, err := c.GetUserDataIfChanged(ctx); err != nil || data.Version != 2 {
+		t.Errorf("changed GetUserDataIfChanged = %+v, %v", data, err)
+	}
+
+	want := []string{"", `"1"`, "", `"1"`}
+	for i, h := range want {
+		if ifNoneMatch[i] != h {
+			t.Errorf("request %d If-None-Match = %q, want %q", i+1, ifNoneMatch[i], h)
+		}
+	}
+}
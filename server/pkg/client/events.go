@@ -0,0 +1,202 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Event is a server-sent event from the /events stream
+type Event struct {
+	ID   string
+	Type string // e.g. "contact_request.received", "location.shared"
+	Data string
+}
+
+// Decode unmarshals the event's JSON data into v
+func (e Event) Decode(v any) error {
+	return json.Unmarshal([]byte(e.Data), v)
+}
+
+// StreamEvents connects to the server event stream and calls fn for each
+// event until the server closes the stream or ctx is cancelled. Pass the ID
+// of the last event seen to resume after it. Returns the ID of the last
+// event received.
+func (c *WhereishClient) StreamEvents(ctx context.Context, lastEventID string, fn func(Event)) (string, error) {
+	resp, err := c.openEventStream(ctx, lastEventID)
+	if err != nil {
+		return lastEventID, err
+	}
+	defer resp.Body.Close()
+
+	return readEvents(resp.Body, lastEventID, fn)
+}
+
+// StreamLocations connects to the location stream and calls fn for each
+// location a contact shares with the user, until the server closes the
+// stream or ctx is cancelled. Each event's data decodes to an
+// EncryptedLocation. Returns the ID of the last event received, to resume
+// after it.
+func (c *WhereishClient) StreamLocations(ctx context.Context, lastEventID string, fn func(Event)) (string, error) {
+	resp, err := c.openStream(ctx, "/locations/stream", lastEventID)
+	if err != nil {
+		return lastEventID, err
+	}
+	defer resp.Body.Close()
+
+	return readEvents(resp.Body, lastEventID, fn)
+}
+
+// SubscribeOptions configures Subscribe
+type SubscribeOptions struct {
+	LastEventID string        // resume after this event
+	MinBackoff  time.Duration // first reconnect delay (default 1s)
+	MaxBackoff  time.Duration // longest reconnect delay (default 1m)
+	Buffer      int           // capacity of the returned channel
+}
+
+// Subscribe connects to the server event stream and delivers events on the
+// returned channel, reconnecting with exponential backoff when the stream
+// drops and resuming after the last event received. An event the server
+// replays after a reconnect is delivered once. The channel is closed when
+// ctx is cancelled or the server rejects the session. Errors connecting the
+// first time are returned rather than retried.
+func (c *WhereishClient) Subscribe(ctx context.Context, opts SubscribeOptions) (<-chan Event, error) {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Second
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(time.Minute, opts.MinBackoff)
+	}
+
+	resp, err := c.openEventStream(ctx, opts.LastEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event, opts.Buffer)
+	go c.subscribe(ctx, resp, opts, events)
+	return events, nil
+}
+
+// subscribe reads from resp, then from each reconnected stream, until ctx
+// is cancelled or the server rejects the session
+func (c *WhereishClient) subscribe(ctx context.Context, resp *http.Response, opts SubscribeOptions, events chan<- Event) {
+	defer close(events)
+
+	lastID := opts.LastEventID
+	backoff := opts.MinBackoff
+	for {
+		if resp != nil {
+			received := false
+			lastID, _ = readEvents(resp.Body, lastID, func(ev Event) {
+				received = true
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+				}
+			})
+			resp.Body.Close()
+			if received {
+				backoff = opts.MinBackoff
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, opts.MaxBackoff)
+
+		var err error
+		resp, err = c.openEventStream(ctx, lastID)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			return
+		}
+	}
+}
+
+// openEventStream starts a request for the event stream, resuming after
+// lastEventID if it's set
+func (c *WhereishClient) openEventStream(ctx context.Context, lastEventID string) (*http.Response, error) {
+	return c.openStream(ctx, "/events", lastEventID)
+}
+
+// openStream starts a request for the server-sent event stream at path
+func (c *WhereishClient) openStream(ctx context.Context, path, lastEventID string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	c.setClientHeaders(req)
+
+	// The stream is long-lived, so don't apply the client's request timeout
+	httpClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := c.sendAuth(httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.parseError(resp)
+	}
+	return resp, nil
+}
+
+// readEvents parses server-sent events from r, calling fn for each until r
+// ends. Events repeating lastEventID, as a server may send when resuming,
+// are skipped. Returns the ID of the last event received.
+func readEvents(r io.Reader, lastEventID string, fn func(Event)) (string, error) {
+	var event Event
+	var data []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the pending event
+		if line == "" {
+			if len(data) > 0 && (event.ID == "" || event.ID != lastEventID) {
+				event.Data = strings.Join(data, "\n")
+				if event.Type == "" {
+					event.Type = "message"
+				}
+				if event.ID != "" {
+					lastEventID = event.ID
+				}
+				fn(event)
+			}
+			event, data = Event{}, nil
+			continue
+		}
+
+		// Lines starting with a colon are comments (keepalives)
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			event.ID = value
+		case "event":
+			event.Type = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	return lastEventID, scanner.Err()
+}
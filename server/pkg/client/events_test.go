@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscribe_Resumes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var lastEventIDs []string
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		conn := len(lastEventIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch conn {
+		case 1:
+			// Two events, then the connection drops
+			fmt.Fprint(w, "id: 1\nevent: location.shared\ndata: {\"from\":\"alice\"}\n\n")
+			fmt.Fprint(w, "id: 2\nevent: location.shared\ndata: {\"from\":\"bob\"}\n\n")
+		default:
+			// Replay the last event seen before the ones after it
+			fmt.Fprint(w, ": keepalive\n\n")
+			for id := 2; id <= 3; id++ {
+				fmt.Fprintf(w, "id: %d\nevent: location.shared\ndata: {\"from\":\"carol\"}\n\n", id)
+			}
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+
+	events, err := c.Subscribe(ctx, SubscribeOptions{MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	var ids []string
+	for len(ids) < 3 {
+		select {
+		case ev := <-events:
+			ids = append(ids, ev.ID)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after events %v", ids)
+		}
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("event IDs = %v, want [1 2 3]", ids)
+	}
+
+	mu.Lock()
+	if len(lastEventIDs) != 2 || lastEventIDs[0] != "" || lastEventIDs[1] != "2" {
+		t.Errorf("Last-Event-ID headers = %q, want [\"\" \"2\"]", lastEventIDs)
+	}
+	mu.Unlock()
+
+	// Cancelling closes the channel
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("unexpected event after cancel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestSubscribe_Rejected(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"unauthorized","message":"Invalid session"}}`))
+	}))
+
+	_, err := c.Subscribe(context.Background(), SubscribeOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Subscribe error = %v, want 401", err)
+	}
+}
+
+func TestEventDecode(t *testing.T) {
+	var payload struct {
+		From string `json:"from"`
+	}
+	if err := (Event{Data: `{"from":"alice"}`}).Decode(&payload); err != nil || payload.From != "alice" {
+		t.Errorf("Decode = %q, %v", payload.From, err)
+	}
+}
+
+func TestStreamLocations(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/locations/stream") {
+			t.Errorf("path = %s, want the location stream", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 7\nevent: location\ndata: {\"fromUserId\":\"alice\",\"blob\":\"b\",\"precision\":\"city\",\"updatedAt\":\"2026-01-02T03:04:05Z\"}\n\n")
+	}))
+
+	var locs []EncryptedLocation
+	lastID, err := c.StreamLocations(context.Background(), "", func(ev Event) {
+		var loc EncryptedLocation
+		if err := ev.Decode(&loc); err != nil {
+			t.Errorf("Decode failed: %v", err)
+		}
+		locs = append(locs, loc)
+	})
+	if err != nil || lastID != "7" {
+		t.Fatalf("StreamLocations = %q, %v; want 7", lastID, err)
+	}
+	if len(locs) != 1 || locs[0].FromUserId != "alice" || locs[0].Precision != City {
+		t.Errorf("locations = %+v", locs)
+	}
+}
@@ -22,6 +22,15 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ContactCheckStatus.
+const (
+	AlreadyContact ContactCheckStatus = "already_contact"
+	Blocked        ContactCheckStatus = "blocked"
+	CanRequest     ContactCheckStatus = "can_request"
+	NotAccepting   ContactCheckStatus = "not_accepting"
+	RequestPending ContactCheckStatus = "request_pending"
+)
+
 // Defines values for ContactRequestDirection.
 const (
 	Incoming ContactRequestDirection = "incoming"
@@ -32,6 +41,7 @@ const (
 const (
 	Accepted ContactRequestStatus = "accepted"
 	Declined ContactRequestStatus = "declined"
+	Expired  ContactRequestStatus = "expired"
 	Pending  ContactRequestStatus = "pending"
 )
 
@@ -71,9 +81,68 @@ const (
 
 // Defines values for IdentityBackupKdf.
 const (
+	Argon2id     IdentityBackupKdf = "argon2id"
 	PBKDF2SHA256 IdentityBackupKdf = "PBKDF2-SHA256"
 )
 
+// Defines values for LocationPrecision.
+const (
+	City    LocationPrecision = "city"
+	Country LocationPrecision = "country"
+	Exact   LocationPrecision = "exact"
+)
+
+// Defines values for ReadinessResponseComponents.
+const (
+	Fail ReadinessResponseComponents = "fail"
+	Ok   ReadinessResponseComponents = "ok"
+)
+
+// Defines values for ReadinessResponseStatus.
+const (
+	Degraded ReadinessResponseStatus = "degraded"
+	Ready    ReadinessResponseStatus = "ready"
+)
+
+// AppleLoginRequest defines model for AppleLoginRequest.
+type AppleLoginRequest struct {
+	// IdToken Sign in with Apple identity token
+	IdToken string `json:"idToken"`
+
+	// Name User's name from Apple's authorization response, which is only
+	// included the first time the user signs in to the app
+	Name *string `json:"name,omitempty"`
+
+	// Nonce Nonce the app generated for this sign in. The app passes Apple
+	// its SHA-256 in hex and sends the nonce itself here; the token's
+	// nonce claim is not accepted.
+	Nonce string `json:"nonce"`
+}
+
+// BlockCreate defines model for BlockCreate.
+type BlockCreate struct {
+	// UserId User to block, from a contact or contact request
+	UserId string `json:"userId"`
+}
+
+// BlockedList defines model for BlockedList.
+type BlockedList struct {
+	Users []BlockedUser `json:"users"`
+}
+
+// BlockedUser defines model for BlockedUser.
+type BlockedUser struct {
+	BlockedAt time.Time           `json:"blockedAt"`
+	Email     openapi_types.Email `json:"email"`
+	Name      string              `json:"name"`
+	UserId    string              `json:"userId"`
+}
+
+// Capabilities defines model for Capabilities.
+type Capabilities struct {
+	Features []string `json:"features"`
+}
+
 // ConflictError defines model for ConflictError.
 type ConflictError struct {
 	// CurrentVersion Current version on server
@@ -96,24 +165,71 @@ type Contact struct {
 	// Name Contact's display name
 	Name string `json:"name"`
 
+	// Note Private note visible only to the owner; absent if none
+	Note *string `json:"note,omitempty"`
+
 	// PublicKey Base64-encoded X25519 public key for encrypting locations
 	PublicKey string `json:"publicKey"`
+
+	// SortOrder Pin position (lower first); absent if not pinned
+	SortOrder *int `json:"sortOrder,omitempty"`
+
+	// UpdatedAt When the contact's name, key, pin or note last changed
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ContactCheck defines model for ContactCheck.
+type ContactCheck struct {
+	// Status can_request: a request can be sent.
+	// already_contact: the user is already a contact.
+	// request_pending: a request between the users is pending in either direction.
+	// blocked: requests to this user are blocked.
+	// not_accepting: the user has turned off incoming requests.
+	Status ContactCheckStatus `json:"status"`
 }
 
+// ContactCheckStatus can_request: a request can be sent.
+// already_contact: the user is already a contact.
+// request_pending: a request between the users is pending in either direction.
+// blocked: requests to this user are blocked.
+// not_accepting: the user has turned off incoming requests.
+type ContactCheckStatus string
+
 // ContactList defines model for ContactList.
 type ContactList struct {
 	Contacts []Contact `json:"contacts"`
+
+	// NextOffset Offset of the next page; absent on the last page
+	NextOffset *int `json:"nextOffset,omitempty"`
+
+	// Total Total number of contacts; present when paging
+	Total *int `json:"total,omitempty"`
+}
+
+// ContactNoteUpdate defines model for ContactNoteUpdate.
+type ContactNoteUpdate struct {
+	// Note Note text; empty to remove
+	Note string `json:"note"`
+}
+
+// ContactOrderUpdate defines model for ContactOrderUpdate.
+type ContactOrderUpdate struct {
+	// SortOrder Pin position (lower first); null to unpin
+	SortOrder *int `json:"sortOrder"`
 }
 
 // ContactRequest defines model for ContactRequest.
 type ContactRequest struct {
-	CreatedAt time.Time `json:"createdAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	DeclinedAt *time.Time `json:"declinedAt,omitempty"`
 
 	// Direction Whether this is an incoming or outgoing request
 	Direction *ContactRequestDirection `json:"direction,omitempty"`
 
 	// Email Email of the other user
-	Email openapi_types.Email `json:"email"`
+	Email     openapi_types.Email `json:"email"`
+	ExpiredAt *time.Time          `json:"expiredAt,omitempty"`
 
 	// Id Request ID
 	Id string `json:"id"`
@@ -121,6 +237,9 @@ type ContactRequest struct {
 	// Name Name of the other user (if available)
 	Name   *string              `json:"name,omitempty"`
 	Status ContactRequestStatus `json:"status"`
+
+	// UserId ID of the other user
+	UserId *string `json:"userId,omitempty"`
 }
 
 // ContactRequestDirection Whether this is an incoming or outgoing request
@@ -135,15 +254,54 @@ type ContactRequestCreate struct {
 	Email openapi_types.Email `json:"email"`
 }
 
+// ContactRequestHistory defines model for ContactRequestHistory.
+type ContactRequestHistory struct {
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string          `json:"nextCursor,omitempty"`
+	Requests   []ContactRequest `json:"requests"`
+}
+
 // ContactRequestList defines model for ContactRequestList.
 type ContactRequestList struct {
 	Incoming []ContactRequest `json:"incoming"`
 	Outgoing []ContactRequest `json:"outgoing"`
 }
 
+// ContactSummary defines model for ContactSummary.
+type ContactSummary struct {
+	// Contacts Accepted contacts
+	Contacts int `json:"contacts"`
+
+	// PendingIncoming Pending requests received
+	PendingIncoming int `json:"pendingIncoming"`
+
+	// PendingOutgoing Pending requests sent
+	PendingOutgoing int `json:"pendingOutgoing"`
+
+	// SharingFrom Contacts who have shared a location with the user
+	SharingFrom int `json:"sharingFrom"`
+
+	// SharingTo Contacts the user has shared a location with
+	SharingTo int `json:"sharingTo"`
+
+	// WithPublicKey Contacts who have published a public key
+	WithPublicKey int `json:"withPublicKey"`
+}
+
+// DevLoginRequest defines model for DevLoginRequest.
+type DevLoginRequest struct {
+	// Email Email to sign in as
+	Email string `json:"email"`
+
+	// Name Name for a new user (defaults to the email's local part)
+	Name *string `json:"name,omitempty"`
+}
+
 // Device defines model for Device.
 type Device struct {
-	CreatedAt time.Time `json:"createdAt"`
+	// ActiveSessions Number of unexpired sessions bound to the device
+	ActiveSessions *int      `json:"activeSessions,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
 
 	// Id Device ID
 	Id string `json:"id"`
@@ -156,8 +314,17 @@ type Device struct {
 	LastSeen  time.Time `json:"lastSeen"`
 
 	// Name User-friendly device name
-	Name     string         `json:"name"`
-	Platform DevicePlatform `json:"platform"`
+	Name string `json:"name"`
+
+	// PausedUntil When the device's pause ends; absent if it isn't paused
+	PausedUntil *time.Time     `json:"pausedUntil,omitempty"`
+	Platform    DevicePlatform `json:"platform"`
+
+	// SessionExpiresAt When the device's last active session expires; absent without one
+	SessionExpiresAt *time.Time `json:"sessionExpiresAt,omitempty"`
+
+	// UserAgent App and version the device registered with
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DevicePlatform defines model for Device.Platform.
@@ -168,6 +335,9 @@ type DeviceCreate struct {
 	// Name User-friendly device name
 	Name     string               `json:"name"`
 	Platform DeviceCreatePlatform `json:"platform"`
+
+	// UserAgent App and version, e.g. "Whereish iOS/2.3.1". Defaults to the User-Agent header.
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DeviceCreatePlatform defines model for DeviceCreate.Platform.
@@ -178,9 +348,23 @@ type DeviceList struct {
 	Devices []Device `json:"devices"`
 }
 
+// DevicePause defines model for DevicePause.
+type DevicePause struct {
+	// Until When the pause ends; must be in the future
+	Until time.Time `json:"until"`
+}
+
+// DeviceUpdate defines model for DeviceUpdate.
+type DeviceUpdate struct {
+	// Name New device name
+	Name string `json:"name"`
+}
+
 // DeviceWithToken defines model for DeviceWithToken.
 type DeviceWithToken struct {
-	CreatedAt time.Time `json:"createdAt"`
+	// ActiveSessions Number of unexpired sessions bound to the device
+	ActiveSessions *int      `json:"activeSessions,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
 
 	// Id Device ID
 	Id string `json:"id"`
@@ -193,11 +377,21 @@ type DeviceWithToken struct {
 	LastSeen  time.Time `json:"lastSeen"`
 
 	// Name User-friendly device name
-	Name     string                  `json:"name"`
-	Platform DeviceWithTokenPlatform `json:"platform"`
+	Name string `json:"name"`
+
+	// PausedUntil When the device's pause ends; absent if it isn't paused
+	PausedUntil *time.Time              `json:"pausedUntil,omitempty"`
+	Platform    DeviceWithTokenPlatform `json:"platform"`
 
-	// Token Device token for API authentication
+	// SessionExpiresAt When the device's last active session expires; absent without one
+	SessionExpiresAt *time.Time `json:"sessionExpiresAt,omitempty"`
+
+	// Token Device token. Not sent with requests: the device is
+	// identified by the session bound to it.
 	Token string `json:"token"`
+
+	// UserAgent App and version the device registered with
+	UserAgent *string `json:"userAgent,omitempty"`
 }
 
 // DeviceWithTokenPlatform defines model for DeviceWithToken.Platform.
@@ -210,9 +404,18 @@ type EncryptedLocation struct {
 	// Contains location hierarchy, optional named location, and timestamp.
 	Blob string `json:"blob"`
 
+	// ExpiresAt When the share expires; absent if it doesn't
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// FromUserId User ID who shared this location
 	FromUserId string `json:"fromUserId"`
 
+	// Precision Sender-declared, unencrypted label for how precise the encrypted
+	// location is, so recipients can decide how to render it and senders
+	// can review what they share. It's a hint; the server can't check it
+	// against the blob. Defaults to exact.
+	Precision LocationPrecision `json:"precision"`
+
 	// UpdatedAt When the location was last updated
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -250,7 +453,12 @@ type IdentityBackup struct {
 	// Algorithm Encryption algorithm
 	Algorithm IdentityBackupAlgorithm `json:"algorithm"`
 
-	// Iterations KDF iterations
+	// Generation Current generation when read. When writing, the generation being
+	// replaced (omit or 0 to create).
+	Generation *int `json:"generation,omitempty"`
+
+	// Iterations PBKDF2 iterations (the accepted range is server-configurable), or
+	// the Argon2id time cost (1-64)
 	Iterations int `json:"iterations"`
 
 	// Iv Base64-encoded IV (12 bytes)
@@ -259,6 +467,12 @@ type IdentityBackup struct {
 	// Kdf Key derivation function
 	Kdf IdentityBackupKdf `json:"kdf"`
 
+	// Memory Argon2id memory in KiB, at least 8 per thread (argon2id only)
+	Memory *int `json:"memory,omitempty"`
+
+	// Parallelism Argon2id threads (argon2id only)
+	Parallelism *int `json:"parallelism,omitempty"`
+
 	// Payload Base64-encoded ciphertext of encrypted keypair
 	Payload string `json:"payload"`
 
@@ -272,16 +486,57 @@ type IdentityBackupAlgorithm string
 // IdentityBackupKdf Key derivation function
 type IdentityBackupKdf string
 
+// IdentityBackupMeta defines model for IdentityBackupMeta.
+type IdentityBackupMeta struct {
+	// Algorithm Encryption algorithm
+	Algorithm string `json:"algorithm"`
+
+	// Iterations KDF iterations, or the Argon2id time cost
+	Iterations int `json:"iterations"`
+
+	// Kdf Key derivation function
+	Kdf string `json:"kdf"`
+
+	// Memory Argon2id memory in KiB (argon2id only)
+	Memory *int `json:"memory,omitempty"`
+
+	// Parallelism Argon2id threads (argon2id only)
+	Parallelism *int `json:"parallelism,omitempty"`
+}
+
 // LocationList defines model for LocationList.
 type LocationList struct {
 	Locations []EncryptedLocation `json:"locations"`
+
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Total Total number of locations; present when paging
+	Total *int `json:"total,omitempty"`
 }
 
+// LocationPrecision Sender-declared, unencrypted label for how precise the encrypted
+// location is, so recipients can decide how to render it and senders
+// can review what they share. It's a hint; the server can't check it
+// against the blob. Defaults to exact.
+type LocationPrecision string
+
 // LocationShare defines model for LocationShare.
 type LocationShare struct {
 	// Blob Base64-encoded NaCl box ciphertext for this recipient
 	Blob string `json:"blob"`
 
+	// ExpiresAt When the share should disappear; must be in the future. From
+	// then on the recipient no longer sees it, and it is deleted soon
+	// after. Omit to keep the share until it's replaced.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Precision Sender-declared, unencrypted label for how precise the encrypted
+	// location is, so recipients can decide how to render it and senders
+	// can review what they share. It's a hint; the server can't check it
+	// against the blob. Defaults to exact.
+	Precision *LocationPrecision `json:"precision,omitempty"`
+
 	// ToUserId User ID to share location with
 	ToUserId string `json:"toUserId"`
 }
@@ -291,22 +546,197 @@ type LocationShareRequest struct {
 	Locations []LocationShare `json:"locations"`
 }
 
+// LocationShareResult defines model for LocationShareResult.
+type LocationShareResult struct {
+	// Error Error code when ok is false
+	Error *string `json:"error,omitempty"`
+
+	// Ok Whether the location was written for this recipient
+	Ok       bool   `json:"ok"`
+	ToUserId string `json:"toUserId"`
+}
+
+// LocationShareResults defines model for LocationShareResults.
+type LocationShareResults struct {
+	Results []LocationShareResult `json:"results"`
+}
+
+// LocationSnapshot defines model for LocationSnapshot.
+type LocationSnapshot struct {
+	Locations []EncryptedLocation `json:"locations"`
+
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// SnapshotAt When the snapshot was started; the same on every page
+	SnapshotAt time.Time `json:"snapshotAt"`
+}
+
 // LoginResponse defines model for LoginResponse.
 type LoginResponse struct {
 	// IsNewUser True if this is the user's first login
 	IsNewUser *bool `json:"isNewUser,omitempty"`
 
+	// RefreshToken Long-lived token for POST /auth/refresh, omitted if the server
+	// doesn't issue them
+	RefreshToken *string `json:"refreshToken,omitempty"`
+
 	// Token Session token for API authentication
 	Token string `json:"token"`
 	User  User   `json:"user"`
 }
 
+// Nudge defines model for Nudge.
+type Nudge struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// FromUserId Contact asking for a location
+	FromUserId string `json:"fromUserId"`
+}
+
+// NudgeList defines model for NudgeList.
+type NudgeList struct {
+	Nudges []Nudge `json:"nudges"`
+}
+
+// OnboardRequest defines model for OnboardRequest.
+type OnboardRequest struct {
+	IdentityBackup IdentityBackup `json:"identityBackup"`
+
+	// PublicKey Base64-encoded X25519 public key (32 bytes)
+	PublicKey string `json:"publicKey"`
+}
+
+// Presence defines model for Presence.
+type Presence struct {
+	// Blob Base64-encoded NaCl box ciphertext
+	Blob       string    `json:"blob"`
+	FromUserId string    `json:"fromUserId"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// PresenceList defines model for PresenceList.
+type PresenceList struct {
+	Presence []Presence `json:"presence"`
+}
+
+// PresenceShare defines model for PresenceShare.
+type PresenceShare struct {
+	// Blob Base64-encoded NaCl box ciphertext for this recipient
+	Blob     string `json:"blob"`
+	ToUserId string `json:"toUserId"`
+}
+
+// PresenceUpdate defines model for PresenceUpdate.
+type PresenceUpdate struct {
+	Statuses []PresenceShare `json:"statuses"`
+}
+
+// ProfileUpdate defines model for ProfileUpdate.
+type ProfileUpdate struct {
+	// Name New display name
+	Name string `json:"name"`
+}
+
 // PublicKeyRequest defines model for PublicKeyRequest.
 type PublicKeyRequest struct {
 	// PublicKey Base64-encoded X25519 public key (32 bytes)
 	PublicKey string `json:"publicKey"`
 }
 
+// ReadinessResponse defines model for ReadinessResponse.
+type ReadinessResponse struct {
+	// Components Status of each checked component
+	Components map[string]ReadinessResponseComponents `json:"components"`
+	Status     ReadinessResponseStatus                `json:"status"`
+}
+
+// ReadinessResponseComponents defines model for ReadinessResponse.Components.
+type ReadinessResponseComponents string
+
+// ReadinessResponseStatus defines model for ReadinessResponse.Status.
+type ReadinessResponseStatus string
+
+// RecoveryCodeStatus defines model for RecoveryCodeStatus.
+type RecoveryCodeStatus struct {
+	// Remaining Unused recovery codes
+	Remaining int `json:"remaining"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// Codes One-time recovery codes. Store them somewhere safe.
+	Codes []string `json:"codes"`
+}
+
+// RecoveryLoginRequest defines model for RecoveryLoginRequest.
+type RecoveryLoginRequest struct {
+	// Code Recovery code; case, spaces and dashes are ignored
+	Code string `json:"code"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RefreshResponse defines model for RefreshResponse.
+type RefreshResponse struct {
+	// ExpiresAt When the new session expires
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// RefreshToken Replaces the refresh token that was used
+	RefreshToken string `json:"refreshToken"`
+
+	// Token New session token
+	Token string `json:"token"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeviceId Device the session is bound to; absent if none
+	DeviceId *string `json:"deviceId,omitempty"`
+
+	// DeviceName Name of the bound device
+	DeviceName *string   `json:"deviceName,omitempty"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+
+	// IsCurrent Whether this is the session making the request
+	IsCurrent *bool `json:"isCurrent,omitempty"`
+
+	// Token Masked token, the first few characters followed by an ellipsis
+	Token string `json:"token"`
+}
+
+// SessionList defines model for SessionList.
+type SessionList struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// StorageUsage defines model for StorageUsage.
+type StorageUsage struct {
+	// IdentityBackup Bytes used by the identity backup
+	IdentityBackup int64 `json:"identityBackup"`
+
+	// Locations Bytes used by locations shared with contacts
+	Locations int64 `json:"locations"`
+
+	// Quota Per-user storage quota in bytes (0 means unlimited)
+	Quota int64 `json:"quota"`
+	Total int64 `json:"total"`
+
+	// UserData Bytes used by the encrypted user data blob
+	UserData int64 `json:"userData"`
+}
+
+// TokenValidation defines model for TokenValidation.
+type TokenValidation struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserId    string    `json:"userId"`
+}
+
 // User defines model for User.
 type User struct {
 	// CreatedAt Account creation timestamp
@@ -318,12 +748,21 @@ type User struct {
 	// HasIdentityBackup Whether user has stored an identity backup
 	HasIdentityBackup *bool `json:"hasIdentityBackup,omitempty"`
 
+	// HasIncomingLocations Whether anyone is sharing a location with the user (GET /me only)
+	HasIncomingLocations *bool `json:"hasIncomingLocations,omitempty"`
+
 	// HasUserData Whether user has stored encrypted user data
 	HasUserData *bool `json:"hasUserData,omitempty"`
 
 	// Id Unique user identifier
 	Id string `json:"id"`
 
+	// LastLoginAt Most recent successful login
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+
+	// LatestLocationAt When a location was most recently shared with the user (GET /me only)
+	LatestLocationAt *time.Time `json:"latestLocationAt,omitempty"`
+
 	// Name Display name
 	Name string `json:"name"`
 
@@ -354,6 +793,41 @@ type UserDataUpdate struct {
 	Version int `json:"version"`
 }
 
+// UserSettings defines model for UserSettings.
+type UserSettings struct {
+	// AcceptRequests Whether other users can send new contact requests
+	AcceptRequests bool `json:"acceptRequests"`
+
+	// AutoAcceptRequests Whether incoming contact requests are accepted as soon as they arrive
+	AutoAcceptRequests bool `json:"autoAcceptRequests"`
+
+	// Discoverable Whether other users can send contact requests by email
+	Discoverable bool `json:"discoverable"`
+
+	// LastKnownMode Whether contacts keep seeing the last shared location when sharing
+	// stops. When off, turning sharing off deletes the user's shared
+	// locations.
+	LastKnownMode bool `json:"lastKnownMode"`
+
+	// SharingEnabled Whether the user can share locations; when off, shares are rejected
+	SharingEnabled bool `json:"sharingEnabled"`
+}
+
+// UserSettingsUpdate defines model for UserSettingsUpdate.
+type UserSettingsUpdate struct {
+	AcceptRequests     *bool `json:"acceptRequests,omitempty"`
+	AutoAcceptRequests *bool `json:"autoAcceptRequests,omitempty"`
+	Discoverable       *bool `json:"discoverable,omitempty"`
+	LastKnownMode      *bool `json:"lastKnownMode,omitempty"`
+	SharingEnabled     *bool `json:"sharingEnabled,omitempty"`
+}
+
+// EventFrom defines model for EventFrom.
+type EventFrom = []string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
 // ContactId defines model for contactId.
 type ContactId = string
 
@@ -363,6 +837,12 @@ type DeviceId = string
 // RequestId defines model for requestId.
 type RequestId = string
 
+// SessionToken defines model for sessionToken.
+type SessionToken = string
+
+// UserId defines model for userId.
+type UserId = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
@@ -372,15 +852,129 @@ type NotFound = Error
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// ListContactsParams defines parameters for ListContacts.
+type ListContactsParams struct {
+	// Since Only return contacts updated after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Maximum contacts per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of contacts to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// CheckContactParams defines parameters for CheckContact.
+type CheckContactParams struct {
+	// Email Email of the user to check
+	Email string `form:"email" json:"email"`
+}
+
+// GetContactRequestHistoryParams defines parameters for GetContactRequestHistory.
+type GetContactRequestHistoryParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum requests per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// From Contact user IDs to receive events from; repeat for several (default all)
+	From *EventFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// LastEventID ID of the last event received, to resume after it
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetIdentityBackupParams defines parameters for GetIdentityBackup.
+type GetIdentityBackupParams struct {
+	// IfNoneMatch ETag from an earlier response, to get a 304 if unchanged
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// SetIdentityBackupParams defines parameters for SetIdentityBackup.
+type SetIdentityBackupParams struct {
+	// Overwrite Replace an existing backup without passing its generation
+	Overwrite *bool `form:"overwrite,omitempty" json:"overwrite,omitempty"`
+}
+
+// GetLocationsParams defines parameters for GetLocations.
+type GetLocationsParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum locations per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ShareLocationsParams defines parameters for ShareLocations.
+type ShareLocationsParams struct {
+	// Partial Write recipients independently and report per-recipient results
+	Partial *bool `form:"partial,omitempty" json:"partial,omitempty"`
+}
+
+// GetLocationSnapshotParams defines parameters for GetLocationSnapshot.
+type GetLocationSnapshotParams struct {
+	// Cursor Cursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum locations per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// StreamLocationsParams defines parameters for StreamLocations.
+type StreamLocationsParams struct {
+	// From Contact user IDs to receive events from; repeat for several (default all)
+	From *EventFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// LastEventID ID of the last event received, to resume after it
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetUserDataParams defines parameters for GetUserData.
+type GetUserDataParams struct {
+	// IfNoneMatch ETag from an earlier response, to get a 304 if unchanged
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// LoginWithAppleJSONRequestBody defines body for LoginWithApple for application/json ContentType.
+type LoginWithAppleJSONRequestBody = AppleLoginRequest
+
 // LoginWithGoogleJSONRequestBody defines body for LoginWithGoogle for application/json ContentType.
 type LoginWithGoogleJSONRequestBody = GoogleLoginRequest
 
+// LoginWithRecoveryCodeJSONRequestBody defines body for LoginWithRecoveryCode for application/json ContentType.
+type LoginWithRecoveryCodeJSONRequestBody = RecoveryLoginRequest
+
+// RefreshSessionJSONRequestBody defines body for RefreshSession for application/json ContentType.
+type RefreshSessionJSONRequestBody = RefreshRequest
+
+// BlockUserJSONRequestBody defines body for BlockUser for application/json ContentType.
+type BlockUserJSONRequestBody = BlockCreate
+
 // SendContactRequestJSONRequestBody defines body for SendContactRequest for application/json ContentType.
 type SendContactRequestJSONRequestBody = ContactRequestCreate
 
+// SetContactNoteJSONRequestBody defines body for SetContactNote for application/json ContentType.
+type SetContactNoteJSONRequestBody = ContactNoteUpdate
+
+// SetContactOrderJSONRequestBody defines body for SetContactOrder for application/json ContentType.
+type SetContactOrderJSONRequestBody = ContactOrderUpdate
+
+// DevLoginJSONRequestBody defines body for DevLogin for application/json ContentType.
+type DevLoginJSONRequestBody = DevLoginRequest
+
 // RegisterDeviceJSONRequestBody defines body for RegisterDevice for application/json ContentType.
 type RegisterDeviceJSONRequestBody = DeviceCreate
 
+// RenameDeviceJSONRequestBody defines body for RenameDevice for application/json ContentType.
+type RenameDeviceJSONRequestBody = DeviceUpdate
+
+// PauseDeviceJSONRequestBody defines body for PauseDevice for application/json ContentType.
+type PauseDeviceJSONRequestBody = DevicePause
+
 // SetIdentityBackupJSONRequestBody defines body for SetIdentityBackup for application/json ContentType.
 type SetIdentityBackupJSONRequestBody = IdentityBackup
 
@@ -390,6 +984,18 @@ type SetPublicKeyJSONRequestBody = PublicKeyRequest
 // ShareLocationsJSONRequestBody defines body for ShareLocations for application/json ContentType.
 type ShareLocationsJSONRequestBody = LocationShareRequest
 
+// UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
+type UpdateProfileJSONRequestBody = ProfileUpdate
+
+// UpdateSettingsJSONRequestBody defines body for UpdateSettings for application/json ContentType.
+type UpdateSettingsJSONRequestBody = UserSettingsUpdate
+
+// OnboardJSONRequestBody defines body for Onboard for application/json ContentType.
+type OnboardJSONRequestBody = OnboardRequest
+
+// SetPresenceJSONRequestBody defines body for SetPresence for application/json ContentType.
+type SetPresenceJSONRequestBody = PresenceUpdate
+
 // SetUserDataJSONRequestBody defines body for SetUserData for application/json ContentType.
 type SetUserDataJSONRequestBody = UserDataUpdate
 
@@ -469,6 +1075,11 @@ type ClientInterface interface {
 	// DeleteAccount request
 	DeleteAccount(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// LoginWithAppleWithBody request with any body
+	LoginWithAppleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LoginWithApple(ctx context.Context, body LoginWithAppleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// LoginWithGoogleWithBody request with any body
 	LoginWithGoogleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -477,8 +1088,47 @@ type ClientInterface interface {
 	// Logout request
 	Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// LoginWithRecoveryCodeWithBody request with any body
+	LoginWithRecoveryCodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LoginWithRecoveryCode(ctx context.Context, body LoginWithRecoveryCodeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRecoveryCodeStatus request
+	GetRecoveryCodeStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GenerateRecoveryCodes request
+	GenerateRecoveryCodes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RefreshSessionWithBody request with any body
+	RefreshSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RefreshSession(ctx context.Context, body RefreshSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ValidateToken request
+	ValidateToken(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCapabilities request
+	GetCapabilities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListContacts request
-	ListContacts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ListContacts(ctx context.Context, params *ListContactsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BlockUserWithBody request with any body
+	BlockUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BlockUser(ctx context.Context, body BlockUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnblockUser request
+	UnblockUser(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBlocked request
+	ListBlocked(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CheckContact request
+	CheckContact(ctx context.Context, params *CheckContactParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListNudges request
+	ListNudges(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SendContactRequestWithBody request with any body
 	SendContactRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -488,6 +1138,9 @@ type ClientInterface interface {
 	// ListContactRequests request
 	ListContactRequests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetContactRequestHistory request
+	GetContactRequestHistory(ctx context.Context, params *GetContactRequestHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// CancelContactRequest request
 	CancelContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -497,9 +1150,30 @@ type ClientInterface interface {
 	// DeclineContactRequest request
 	DeclineContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetContactSummary request
+	GetContactSummary(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// RemoveContact request
 	RemoveContact(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// SetContactNoteWithBody request with any body
+	SetContactNoteWithBody(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetContactNote(ctx context.Context, contactId ContactId, body SetContactNoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// NudgeContact request
+	NudgeContact(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetContactOrderWithBody request with any body
+	SetContactOrderWithBody(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetContactOrder(ctx context.Context, contactId ContactId, body SetContactOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DevLoginWithBody request with any body
+	DevLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DevLogin(ctx context.Context, body DevLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListDevices request
 	ListDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -511,16 +1185,35 @@ type ClientInterface interface {
 	// RevokeDevice request
 	RevokeDevice(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RenameDeviceWithBody request with any body
+	RenameDeviceWithBody(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RenameDevice(ctx context.Context, deviceId DeviceId, body RenameDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PauseDeviceWithBody request with any body
+	PauseDeviceWithBody(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PauseDevice(ctx context.Context, deviceId DeviceId, body PauseDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnpauseDevice request
+	UnpauseDevice(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamEvents request
+	StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetIdentityBackup request
-	GetIdentityBackup(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetIdentityBackup(ctx context.Context, params *GetIdentityBackupParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SetIdentityBackupWithBody request with any body
-	SetIdentityBackupWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	SetIdentityBackupWithBody(ctx context.Context, params *SetIdentityBackupParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetIdentityBackup(ctx context.Context, params *SetIdentityBackupParams, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	SetIdentityBackup(ctx context.Context, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// GetIdentityBackupMeta request
+	GetIdentityBackupMeta(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// SetPublicKeyWithBody request with any body
 	SetPublicKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -528,23 +1221,70 @@ type ClientInterface interface {
 	SetPublicKey(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetLocations request
-	GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetLocations(ctx context.Context, params *GetLocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ShareLocationsWithBody request with any body
-	ShareLocationsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	ShareLocationsWithBody(ctx context.Context, params *ShareLocationsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ShareLocations(ctx context.Context, params *ShareLocationsParams, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLocationSnapshot request
+	GetLocationSnapshot(ctx context.Context, params *GetLocationSnapshotParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	ShareLocations(ctx context.Context, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// StreamLocations request
+	StreamLocations(ctx context.Context, params *StreamLocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetCurrentUser request
 	GetCurrentUser(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// GetUserData request
-	GetUserData(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// UpdateProfileWithBody request with any body
+	UpdateProfileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// SetUserDataWithBody request with any body
-	SetUserDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+	UpdateProfile(ctx context.Context, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	SetUserData(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// GetSettings request
+	GetSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateSettingsWithBody request with any body
+	UpdateSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateSettings(ctx context.Context, body UpdateSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetStorageUsage request
+	GetStorageUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OnboardWithBody request with any body
+	OnboardWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Onboard(ctx context.Context, body OnboardJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPresence request
+	GetPresence(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPresenceWithBody request with any body
+	SetPresenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPresence(ctx context.Context, body SetPresenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadiness request
+	GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSessions request
+	ListSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeSession request
+	RevokeSession(ctx context.Context, token SessionToken, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUserData request
+	GetUserData(ctx context.Context, params *GetUserDataParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetUserDataWithBody request with any body
+	SetUserDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetUserData(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) DeleteAccount(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -559,6 +1299,30 @@ func (c *Client) DeleteAccount(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
+func (c *Client) LoginWithAppleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginWithAppleRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginWithApple(ctx context.Context, body LoginWithAppleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginWithAppleRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) LoginWithGoogleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewLoginWithGoogleRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -595,8 +1359,8 @@ func (c *Client) Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*ht
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListContacts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListContactsRequest(c.Server)
+func (c *Client) LoginWithRecoveryCodeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginWithRecoveryCodeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -607,8 +1371,8 @@ func (c *Client) ListContacts(ctx context.Context, reqEditors ...RequestEditorFn
 	return c.Client.Do(req)
 }
 
-func (c *Client) SendContactRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSendContactRequestRequestWithBody(c.Server, contentType, body)
+func (c *Client) LoginWithRecoveryCode(ctx context.Context, body LoginWithRecoveryCodeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginWithRecoveryCodeRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -619,8 +1383,8 @@ func (c *Client) SendContactRequestWithBody(ctx context.Context, contentType str
 	return c.Client.Do(req)
 }
 
-func (c *Client) SendContactRequest(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSendContactRequestRequest(c.Server, body)
+func (c *Client) GetRecoveryCodeStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRecoveryCodeStatusRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -631,8 +1395,8 @@ func (c *Client) SendContactRequest(ctx context.Context, body SendContactRequest
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListContactRequests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListContactRequestsRequest(c.Server)
+func (c *Client) GenerateRecoveryCodes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGenerateRecoveryCodesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -643,8 +1407,8 @@ func (c *Client) ListContactRequests(ctx context.Context, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
-func (c *Client) CancelContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCancelContactRequestRequest(c.Server, requestId)
+func (c *Client) RefreshSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshSessionRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -655,8 +1419,8 @@ func (c *Client) CancelContactRequest(ctx context.Context, requestId RequestId,
 	return c.Client.Do(req)
 }
 
-func (c *Client) AcceptContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewAcceptContactRequestRequest(c.Server, requestId)
+func (c *Client) RefreshSession(ctx context.Context, body RefreshSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRefreshSessionRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -667,8 +1431,8 @@ func (c *Client) AcceptContactRequest(ctx context.Context, requestId RequestId,
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeclineContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeclineContactRequestRequest(c.Server, requestId)
+func (c *Client) ValidateToken(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewValidateTokenRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -679,8 +1443,8 @@ func (c *Client) DeclineContactRequest(ctx context.Context, requestId RequestId,
 	return c.Client.Do(req)
 }
 
-func (c *Client) RemoveContact(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRemoveContactRequest(c.Server, contactId)
+func (c *Client) GetCapabilities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCapabilitiesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -691,8 +1455,8 @@ func (c *Client) RemoveContact(ctx context.Context, contactId ContactId, reqEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListDevicesRequest(c.Server)
+func (c *Client) ListContacts(ctx context.Context, params *ListContactsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListContactsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -703,8 +1467,8 @@ func (c *Client) ListDevices(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) RegisterDeviceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRegisterDeviceRequestWithBody(c.Server, contentType, body)
+func (c *Client) BlockUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBlockUserRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -715,8 +1479,8 @@ func (c *Client) RegisterDeviceWithBody(ctx context.Context, contentType string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) RegisterDevice(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRegisterDeviceRequest(c.Server, body)
+func (c *Client) BlockUser(ctx context.Context, body BlockUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBlockUserRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -727,8 +1491,8 @@ func (c *Client) RegisterDevice(ctx context.Context, body RegisterDeviceJSONRequ
 	return c.Client.Do(req)
 }
 
-func (c *Client) RevokeDevice(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRevokeDeviceRequest(c.Server, deviceId)
+func (c *Client) UnblockUser(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnblockUserRequest(c.Server, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -739,8 +1503,8 @@ func (c *Client) RevokeDevice(ctx context.Context, deviceId DeviceId, reqEditors
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetHealthRequest(c.Server)
+func (c *Client) ListBlocked(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBlockedRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -751,8 +1515,8 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetIdentityBackup(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetIdentityBackupRequest(c.Server)
+func (c *Client) CheckContact(ctx context.Context, params *CheckContactParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCheckContactRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -763,8 +1527,8 @@ func (c *Client) GetIdentityBackup(ctx context.Context, reqEditors ...RequestEdi
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetIdentityBackupWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetIdentityBackupRequestWithBody(c.Server, contentType, body)
+func (c *Client) ListNudges(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListNudgesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -775,8 +1539,8 @@ func (c *Client) SetIdentityBackupWithBody(ctx context.Context, contentType stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetIdentityBackup(ctx context.Context, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetIdentityBackupRequest(c.Server, body)
+func (c *Client) SendContactRequestWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendContactRequestRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -787,8 +1551,8 @@ func (c *Client) SetIdentityBackup(ctx context.Context, body SetIdentityBackupJS
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetPublicKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetPublicKeyRequestWithBody(c.Server, contentType, body)
+func (c *Client) SendContactRequest(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendContactRequestRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -799,8 +1563,8 @@ func (c *Client) SetPublicKeyWithBody(ctx context.Context, contentType string, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetPublicKey(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetPublicKeyRequest(c.Server, body)
+func (c *Client) ListContactRequests(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListContactRequestsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -811,8 +1575,8 @@ func (c *Client) SetPublicKey(ctx context.Context, body SetPublicKeyJSONRequestB
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetLocations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetLocationsRequest(c.Server)
+func (c *Client) GetContactRequestHistory(ctx context.Context, params *GetContactRequestHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetContactRequestHistoryRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -823,8 +1587,8 @@ func (c *Client) GetLocations(ctx context.Context, reqEditors ...RequestEditorFn
 	return c.Client.Do(req)
 }
 
-func (c *Client) ShareLocationsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewShareLocationsRequestWithBody(c.Server, contentType, body)
+func (c *Client) CancelContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelContactRequestRequest(c.Server, requestId)
 	if err != nil {
 		return nil, err
 	}
@@ -835,8 +1599,8 @@ func (c *Client) ShareLocationsWithBody(ctx context.Context, contentType string,
 	return c.Client.Do(req)
 }
 
-func (c *Client) ShareLocations(ctx context.Context, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewShareLocationsRequest(c.Server, body)
+func (c *Client) AcceptContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptContactRequestRequest(c.Server, requestId)
 	if err != nil {
 		return nil, err
 	}
@@ -847,8 +1611,8 @@ func (c *Client) ShareLocations(ctx context.Context, body ShareLocationsJSONRequ
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetCurrentUser(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetCurrentUserRequest(c.Server)
+func (c *Client) DeclineContactRequest(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeclineContactRequestRequest(c.Server, requestId)
 	if err != nil {
 		return nil, err
 	}
@@ -859,8 +1623,8 @@ func (c *Client) GetCurrentUser(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetUserData(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetUserDataRequest(c.Server)
+func (c *Client) GetContactSummary(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetContactSummaryRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -871,8 +1635,8 @@ func (c *Client) GetUserData(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetUserDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetUserDataRequestWithBody(c.Server, contentType, body)
+func (c *Client) RemoveContact(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveContactRequest(c.Server, contactId)
 	if err != nil {
 		return nil, err
 	}
@@ -883,8 +1647,8 @@ func (c *Client) SetUserDataWithBody(ctx context.Context, contentType string, bo
 	return c.Client.Do(req)
 }
 
-func (c *Client) SetUserData(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSetUserDataRequest(c.Server, body)
+func (c *Client) SetContactNoteWithBody(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetContactNoteRequestWithBody(c.Server, contactId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -895,468 +1659,580 @@ func (c *Client) SetUserData(ctx context.Context, body SetUserDataJSONRequestBod
 	return c.Client.Do(req)
 }
 
-// NewDeleteAccountRequest generates requests for DeleteAccount
-func NewDeleteAccountRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) SetContactNote(ctx context.Context, contactId ContactId, body SetContactNoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetContactNoteRequest(c.Server, contactId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/auth/account")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) NudgeContact(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewNudgeContactRequest(c.Server, contactId)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewLoginWithGoogleRequest calls the generic LoginWithGoogle builder with application/json body
-func NewLoginWithGoogleRequest(server string, body LoginWithGoogleJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) SetContactOrderWithBody(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetContactOrderRequestWithBody(c.Server, contactId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewLoginWithGoogleRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewLoginWithGoogleRequestWithBody generates requests for LoginWithGoogle with any type of body
-func NewLoginWithGoogleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) SetContactOrder(ctx context.Context, contactId ContactId, body SetContactOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetContactOrderRequest(c.Server, contactId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/auth/google")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) DevLoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDevLoginRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewLogoutRequest generates requests for Logout
-func NewLogoutRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DevLogin(ctx context.Context, body DevLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDevLoginRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/auth/logout")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ListDevices(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDevicesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewListContactsRequest generates requests for ListContacts
-func NewListContactsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) RegisterDeviceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterDeviceRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) RegisterDevice(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterDeviceRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewSendContactRequestRequest calls the generic SendContactRequest builder with application/json body
-func NewSendContactRequestRequest(server string, body SendContactRequestJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) RevokeDevice(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeDeviceRequest(c.Server, deviceId)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSendContactRequestRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewSendContactRequestRequestWithBody generates requests for SendContactRequest with any type of body
-func NewSendContactRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) RenameDeviceWithBody(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameDeviceRequestWithBody(c.Server, deviceId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/request")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) RenameDevice(ctx context.Context, deviceId DeviceId, body RenameDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRenameDeviceRequest(c.Server, deviceId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewListContactRequestsRequest generates requests for ListContactRequests
-func NewListContactRequestsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PauseDeviceWithBody(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPauseDeviceRequestWithBody(c.Server, deviceId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/requests")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PauseDevice(ctx context.Context, deviceId DeviceId, body PauseDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPauseDeviceRequest(c.Server, deviceId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewCancelContactRequestRequest generates requests for CancelContactRequest
-func NewCancelContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+func (c *Client) UnpauseDevice(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnpauseDeviceRequest(c.Server, deviceId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) StreamEvents(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamEventsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/requests/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewAcceptContactRequestRequest generates requests for AcceptContactRequest
-func NewAcceptContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+func (c *Client) GetIdentityBackup(ctx context.Context, params *GetIdentityBackupParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdentityBackupRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) SetIdentityBackupWithBody(ctx context.Context, params *SetIdentityBackupParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetIdentityBackupRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/requests/%s/accept", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) SetIdentityBackup(ctx context.Context, params *SetIdentityBackupParams, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetIdentityBackupRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewDeclineContactRequestRequest generates requests for DeclineContactRequest
-func NewDeclineContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+func (c *Client) GetIdentityBackupMeta(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdentityBackupMetaRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) SetPublicKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPublicKeyRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/requests/%s/decline", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) SetPublicKey(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPublicKeyRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewRemoveContactRequest generates requests for RemoveContact
-func NewRemoveContactRequest(server string, contactId ContactId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "contactId", runtime.ParamLocationPath, contactId)
+func (c *Client) GetLocations(ctx context.Context, params *GetLocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) ShareLocationsWithBody(ctx context.Context, params *ShareLocationsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShareLocationsRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/contacts/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ShareLocations(ctx context.Context, params *ShareLocationsParams, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewShareLocationsRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewListDevicesRequest generates requests for ListDevices
-func NewListDevicesRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetLocationSnapshot(ctx context.Context, params *GetLocationSnapshotParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLocationSnapshotRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/devices")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) StreamLocations(ctx context.Context, params *StreamLocationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamLocationsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewRegisterDeviceRequest calls the generic RegisterDevice builder with application/json body
-func NewRegisterDeviceRequest(server string, body RegisterDeviceJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetCurrentUser(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCurrentUserRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewRegisterDeviceRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewRegisterDeviceRequestWithBody generates requests for RegisterDevice with any type of body
-func NewRegisterDeviceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) UpdateProfileWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProfileRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/devices")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) UpdateProfile(ctx context.Context, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProfileRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewRevokeDeviceRequest generates requests for RevokeDevice
-func NewRevokeDeviceRequest(server string, deviceId DeviceId) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "deviceId", runtime.ParamLocationPath, deviceId)
+func (c *Client) GetSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSettingsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) UpdateSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSettingsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/devices/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) UpdateSettings(ctx context.Context, body UpdateSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSettingsRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetStorageUsage(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetStorageUsageRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) OnboardWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOnboardRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetIdentityBackupRequest generates requests for GetIdentityBackup
-func NewGetIdentityBackupRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) Onboard(ctx context.Context, body OnboardJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOnboardRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/identity/backup")
+func (c *Client) GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPISpecRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPresence(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPresenceRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPresenceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPresenceRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPresence(ctx context.Context, body SetPresenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPresenceRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSessions(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSessionsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeSession(ctx context.Context, token SessionToken, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeSessionRequest(c.Server, token)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUserData(ctx context.Context, params *GetUserDataParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUserDataRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetUserDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserDataRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetUserData(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserDataRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewDeleteAccountRequest generates requests for DeleteAccount
+func NewDeleteAccountRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/account")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1366,7 +2242,7 @@ func NewGetIdentityBackupRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1374,19 +2250,19 @@ func NewGetIdentityBackupRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSetIdentityBackupRequest calls the generic SetIdentityBackup builder with application/json body
-func NewSetIdentityBackupRequest(server string, body SetIdentityBackupJSONRequestBody) (*http.Request, error) {
+// NewLoginWithAppleRequest calls the generic LoginWithApple builder with application/json body
+func NewLoginWithAppleRequest(server string, body LoginWithAppleJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewSetIdentityBackupRequestWithBody(server, "application/json", bodyReader)
+	return NewLoginWithAppleRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewSetIdentityBackupRequestWithBody generates requests for SetIdentityBackup with any type of body
-func NewSetIdentityBackupRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewLoginWithAppleRequestWithBody generates requests for LoginWithApple with any type of body
+func NewLoginWithAppleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1394,7 +2270,7 @@ func NewSetIdentityBackupRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/identity/backup")
+	operationPath := fmt.Sprintf("/auth/apple")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1404,7 +2280,7 @@ func NewSetIdentityBackupRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1414,19 +2290,19 @@ func NewSetIdentityBackupRequestWithBody(server string, contentType string, body
 	return req, nil
 }
 
-// NewSetPublicKeyRequest calls the generic SetPublicKey builder with application/json body
-func NewSetPublicKeyRequest(server string, body SetPublicKeyJSONRequestBody) (*http.Request, error) {
+// NewLoginWithGoogleRequest calls the generic LoginWithGoogle builder with application/json body
+func NewLoginWithGoogleRequest(server string, body LoginWithGoogleJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewSetPublicKeyRequestWithBody(server, "application/json", bodyReader)
+	return NewLoginWithGoogleRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewSetPublicKeyRequestWithBody generates requests for SetPublicKey with any type of body
-func NewSetPublicKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewLoginWithGoogleRequestWithBody generates requests for LoginWithGoogle with any type of body
+func NewLoginWithGoogleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1434,7 +2310,7 @@ func NewSetPublicKeyRequestWithBody(server string, contentType string, body io.R
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/identity/public-key")
+	operationPath := fmt.Sprintf("/auth/google")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1454,8 +2330,8 @@ func NewSetPublicKeyRequestWithBody(server string, contentType string, body io.R
 	return req, nil
 }
 
-// NewGetLocationsRequest generates requests for GetLocations
-func NewGetLocationsRequest(server string) (*http.Request, error) {
+// NewLogoutRequest generates requests for Logout
+func NewLogoutRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1463,7 +2339,7 @@ func NewGetLocationsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/locations")
+	operationPath := fmt.Sprintf("/auth/logout")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1473,7 +2349,7 @@ func NewGetLocationsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1481,19 +2357,19 @@ func NewGetLocationsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewShareLocationsRequest calls the generic ShareLocations builder with application/json body
-func NewShareLocationsRequest(server string, body ShareLocationsJSONRequestBody) (*http.Request, error) {
+// NewLoginWithRecoveryCodeRequest calls the generic LoginWithRecoveryCode builder with application/json body
+func NewLoginWithRecoveryCodeRequest(server string, body LoginWithRecoveryCodeJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewShareLocationsRequestWithBody(server, "application/json", bodyReader)
+	return NewLoginWithRecoveryCodeRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewShareLocationsRequestWithBody generates requests for ShareLocations with any type of body
-func NewShareLocationsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewLoginWithRecoveryCodeRequestWithBody generates requests for LoginWithRecoveryCode with any type of body
+func NewLoginWithRecoveryCodeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1501,7 +2377,7 @@ func NewShareLocationsRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/locations")
+	operationPath := fmt.Sprintf("/auth/recovery")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1521,8 +2397,8 @@ func NewShareLocationsRequestWithBody(server string, contentType string, body io
 	return req, nil
 }
 
-// NewGetCurrentUserRequest generates requests for GetCurrentUser
-func NewGetCurrentUserRequest(server string) (*http.Request, error) {
+// NewGetRecoveryCodeStatusRequest generates requests for GetRecoveryCodeStatus
+func NewGetRecoveryCodeStatusRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1530,7 +2406,7 @@ func NewGetCurrentUserRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/me")
+	operationPath := fmt.Sprintf("/auth/recovery-codes")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1548,8 +2424,8 @@ func NewGetCurrentUserRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetUserDataRequest generates requests for GetUserData
-func NewGetUserDataRequest(server string) (*http.Request, error) {
+// NewGenerateRecoveryCodesRequest generates requests for GenerateRecoveryCodes
+func NewGenerateRecoveryCodesRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1557,7 +2433,7 @@ func NewGetUserDataRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/user-data")
+	operationPath := fmt.Sprintf("/auth/recovery-codes")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1567,7 +2443,7 @@ func NewGetUserDataRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1575,19 +2451,19 @@ func NewGetUserDataRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewSetUserDataRequest calls the generic SetUserData builder with application/json body
-func NewSetUserDataRequest(server string, body SetUserDataJSONRequestBody) (*http.Request, error) {
+// NewRefreshSessionRequest calls the generic RefreshSession builder with application/json body
+func NewRefreshSessionRequest(server string, body RefreshSessionJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewSetUserDataRequestWithBody(server, "application/json", bodyReader)
+	return NewRefreshSessionRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewSetUserDataRequestWithBody generates requests for SetUserData with any type of body
-func NewSetUserDataRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewRefreshSessionRequestWithBody generates requests for RefreshSession with any type of body
+func NewRefreshSessionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1595,7 +2471,7 @@ func NewSetUserDataRequestWithBody(server string, contentType string, body io.Re
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/user-data")
+	operationPath := fmt.Sprintf("/auth/refresh")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1605,7 +2481,7 @@ func NewSetUserDataRequestWithBody(server string, contentType string, body io.Re
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1615,977 +2491,5759 @@ func NewSetUserDataRequestWithBody(server string, contentType string, body io.Re
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewValidateTokenRequest generates requests for ValidateToken
+func NewValidateTokenRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/auth/validate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// DeleteAccountWithResponse request
-	DeleteAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error)
+// NewGetCapabilitiesRequest generates requests for GetCapabilities
+func NewGetCapabilitiesRequest(server string) (*http.Request, error) {
+	var err error
 
-	// LoginWithGoogleWithBodyWithResponse request with any body
-	LoginWithGoogleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	LoginWithGoogleWithResponse(ctx context.Context, body LoginWithGoogleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error)
+	operationPath := fmt.Sprintf("/capabilities")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// LogoutWithResponse request
-	LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// ListContactsWithResponse request
-	ListContactsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactsResponse, error)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	// SendContactRequestWithBodyWithResponse request with any body
-	SendContactRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error)
+	return req, nil
+}
 
-	SendContactRequestWithResponse(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error)
+// NewListContactsRequest generates requests for ListContacts
+func NewListContactsRequest(server string, params *ListContactsParams) (*http.Request, error) {
+	var err error
 
-	// ListContactRequestsWithResponse request
-	ListContactRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactRequestsResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// CancelContactRequestWithResponse request
-	CancelContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*CancelContactRequestResponse, error)
+	operationPath := fmt.Sprintf("/contacts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// AcceptContactRequestWithResponse request
-	AcceptContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*AcceptContactRequestResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// DeclineContactRequestWithResponse request
-	DeclineContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*DeclineContactRequestResponse, error)
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	// RemoveContactWithResponse request
-	RemoveContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*RemoveContactResponse, error)
+		if params.Since != nil {
 
-	// ListDevicesWithResponse request
-	ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// RegisterDeviceWithBodyWithResponse request with any body
-	RegisterDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error)
+		}
 
-	RegisterDeviceWithResponse(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error)
+		if params.Limit != nil {
 
-	// RevokeDeviceWithResponse request
-	RevokeDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*RevokeDeviceResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+		}
 
-	// GetIdentityBackupWithResponse request
-	GetIdentityBackupWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentityBackupResponse, error)
+		if params.Offset != nil {
 
-	// SetIdentityBackupWithBodyWithResponse request with any body
-	SetIdentityBackupWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	SetIdentityBackupWithResponse(ctx context.Context, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error)
+		}
 
-	// SetPublicKeyWithBodyWithResponse request with any body
-	SetPublicKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error)
+		queryURL.RawQuery = queryValues.Encode()
+	}
 
-	SetPublicKeyWithResponse(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetLocationsWithResponse request
-	GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error)
+	return req, nil
+}
 
-	// ShareLocationsWithBodyWithResponse request with any body
-	ShareLocationsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error)
+// NewBlockUserRequest calls the generic BlockUser builder with application/json body
+func NewBlockUserRequest(server string, body BlockUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBlockUserRequestWithBody(server, "application/json", bodyReader)
+}
 
-	ShareLocationsWithResponse(ctx context.Context, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error)
+// NewBlockUserRequestWithBody generates requests for BlockUser with any type of body
+func NewBlockUserRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// GetCurrentUserWithResponse request
-	GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetUserDataWithResponse request
-	GetUserDataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserDataResponse, error)
+	operationPath := fmt.Sprintf("/contacts/block")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// SetUserDataWithBodyWithResponse request with any body
-	SetUserDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	SetUserDataWithResponse(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error)
-}
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-type DeleteAccountResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteAccountResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewUnblockUserRequest generates requests for UnblockUser
+func NewUnblockUserRequest(server string, userId UserId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "userId", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteAccountResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type LoginWithGoogleResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *LoginResponse
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-}
+	operationPath := fmt.Sprintf("/contacts/block/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r LoginWithGoogleResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r LoginWithGoogleResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type LogoutResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r LogoutResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// NewListBlockedRequest generates requests for ListBlocked
+func NewListBlockedRequest(server string) (*http.Request, error) {
+	var err error
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r LogoutResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type ListContactsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ContactList
-	JSON401      *Unauthorized
-}
+	operationPath := fmt.Sprintf("/contacts/blocked")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r ListContactsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListContactsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type SendContactRequestResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *ContactRequest
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-	JSON404      *Error
-	JSON409      *Error
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r SendContactRequestResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewCheckContactRequest generates requests for CheckContact
+func NewCheckContactRequest(server string, params *CheckContactParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SendContactRequestResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/contacts/check")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type ListContactRequestsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ContactRequestList
-	JSON401      *Unauthorized
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r ListContactRequestsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "email", runtime.ParamLocationQuery, params.Email); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListContactRequestsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type CancelContactRequestResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
-	JSON404      *NotFound
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r CancelContactRequestResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewListNudgesRequest generates requests for ListNudges
+func NewListNudgesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CancelContactRequestResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/contacts/nudges")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type AcceptContactRequestResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Contact
-	JSON401      *Unauthorized
-	JSON404      *NotFound
-}
-
-// Status returns HTTPResponse.Status
-func (r AcceptContactRequestResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r AcceptContactRequestResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type DeclineContactRequestResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
-	JSON404      *NotFound
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r DeclineContactRequestResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewSendContactRequestRequest calls the generic SendContactRequest builder with application/json body
+func NewSendContactRequestRequest(server string, body SendContactRequestJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	bodyReader = bytes.NewReader(buf)
+	return NewSendContactRequestRequestWithBody(server, "application/json", bodyReader)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeclineContactRequestResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewSendContactRequestRequestWithBody generates requests for SendContactRequest with any type of body
+func NewSendContactRequestRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type RemoveContactResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
-	JSON404      *NotFound
-}
+	operationPath := fmt.Sprintf("/contacts/request")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r RemoveContactResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RemoveContactResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type ListDevicesResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *DeviceList
-	JSON401      *Unauthorized
-}
+	req.Header.Add("Content-Type", contentType)
 
-// Status returns HTTPResponse.Status
-func (r ListDevicesResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListDevicesResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewListContactRequestsRequest generates requests for ListContactRequests
+func NewListContactRequestsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type RegisterDeviceResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *DeviceWithToken
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-}
+	operationPath := fmt.Sprintf("/contacts/requests")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// Status returns HTTPResponse.Status
-func (r RegisterDeviceResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RegisterDeviceResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type RevokeDeviceResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON401      *Unauthorized
-	JSON404      *NotFound
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r RevokeDeviceResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+// NewGetContactRequestHistoryRequest generates requests for GetContactRequestHistory
+func NewGetContactRequestHistoryRequest(server string, params *GetContactRequestHistoryParams) (*http.Request, error) {
+	var err error
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RevokeDeviceResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
-
-type GetHealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *HealthResponse
-}
 
-// Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	operationPath := fmt.Sprintf("/contacts/requests/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetIdentityBackupResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *IdentityBackup
-	JSON401      *Unauthorized
-	JSON404      *Error
-}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-// Status returns HTTPResponse.Status
-func (r GetIdentityBackupResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+		if params.Cursor != nil {
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetIdentityBackupResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-type SetIdentityBackupResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-}
+		}
 
-// Status returns HTTPResponse.Status
-func (r SetIdentityBackupResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+		if params.Limit != nil {
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SetIdentityBackupResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-type SetPublicKeyResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-}
+		}
 
-// Status returns HTTPResponse.Status
-func (r SetPublicKeyResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SetPublicKeyResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return 0
-}
 
-type GetLocationsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *LocationList
-	JSON401      *Unauthorized
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
+// NewCancelContactRequestRequest generates requests for CancelContactRequest
+func NewCancelContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/requests/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcceptContactRequestRequest generates requests for AcceptContactRequest
+func NewAcceptContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/requests/%s/accept", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeclineContactRequestRequest generates requests for DeclineContactRequest
+func NewDeclineContactRequestRequest(server string, requestId RequestId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "requestId", runtime.ParamLocationPath, requestId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/requests/%s/decline", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetContactSummaryRequest generates requests for GetContactSummary
+func NewGetContactSummaryRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/summary")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveContactRequest generates requests for RemoveContact
+func NewRemoveContactRequest(server string, contactId ContactId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "contactId", runtime.ParamLocationPath, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetContactNoteRequest calls the generic SetContactNote builder with application/json body
+func NewSetContactNoteRequest(server string, contactId ContactId, body SetContactNoteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetContactNoteRequestWithBody(server, contactId, "application/json", bodyReader)
+}
+
+// NewSetContactNoteRequestWithBody generates requests for SetContactNote with any type of body
+func NewSetContactNoteRequestWithBody(server string, contactId ContactId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "contactId", runtime.ParamLocationPath, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/%s/note", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewNudgeContactRequest generates requests for NudgeContact
+func NewNudgeContactRequest(server string, contactId ContactId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "contactId", runtime.ParamLocationPath, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/%s/nudge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetContactOrderRequest calls the generic SetContactOrder builder with application/json body
+func NewSetContactOrderRequest(server string, contactId ContactId, body SetContactOrderJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetContactOrderRequestWithBody(server, contactId, "application/json", bodyReader)
+}
+
+// NewSetContactOrderRequestWithBody generates requests for SetContactOrder with any type of body
+func NewSetContactOrderRequestWithBody(server string, contactId ContactId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "contactId", runtime.ParamLocationPath, contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contacts/%s/order", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDevLoginRequest calls the generic DevLogin builder with application/json body
+func NewDevLoginRequest(server string, body DevLoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDevLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewDevLoginRequestWithBody generates requests for DevLogin with any type of body
+func NewDevLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/dev/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListDevicesRequest generates requests for ListDevices
+func NewListDevicesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterDeviceRequest calls the generic RegisterDevice builder with application/json body
+func NewRegisterDeviceRequest(server string, body RegisterDeviceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterDeviceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterDeviceRequestWithBody generates requests for RegisterDevice with any type of body
+func NewRegisterDeviceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeDeviceRequest generates requests for RevokeDevice
+func NewRevokeDeviceRequest(server string, deviceId DeviceId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "deviceId", runtime.ParamLocationPath, deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRenameDeviceRequest calls the generic RenameDevice builder with application/json body
+func NewRenameDeviceRequest(server string, deviceId DeviceId, body RenameDeviceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRenameDeviceRequestWithBody(server, deviceId, "application/json", bodyReader)
+}
+
+// NewRenameDeviceRequestWithBody generates requests for RenameDevice with any type of body
+func NewRenameDeviceRequestWithBody(server string, deviceId DeviceId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "deviceId", runtime.ParamLocationPath, deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPauseDeviceRequest calls the generic PauseDevice builder with application/json body
+func NewPauseDeviceRequest(server string, deviceId DeviceId, body PauseDeviceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPauseDeviceRequestWithBody(server, deviceId, "application/json", bodyReader)
+}
+
+// NewPauseDeviceRequestWithBody generates requests for PauseDevice with any type of body
+func NewPauseDeviceRequestWithBody(server string, deviceId DeviceId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "deviceId", runtime.ParamLocationPath, deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices/%s/pause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnpauseDeviceRequest generates requests for UnpauseDevice
+func NewUnpauseDeviceRequest(server string, deviceId DeviceId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "deviceId", runtime.ParamLocationPath, deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/devices/%s/unpause", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamEventsRequest generates requests for StreamEvents
+func NewStreamEventsRequest(server string, params *StreamEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdentityBackupRequest generates requests for GetIdentityBackup
+func NewGetIdentityBackupRequest(server string, params *GetIdentityBackupParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identity/backup")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewSetIdentityBackupRequest calls the generic SetIdentityBackup builder with application/json body
+func NewSetIdentityBackupRequest(server string, params *SetIdentityBackupParams, body SetIdentityBackupJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetIdentityBackupRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewSetIdentityBackupRequestWithBody generates requests for SetIdentityBackup with any type of body
+func NewSetIdentityBackupRequestWithBody(server string, params *SetIdentityBackupParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identity/backup")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Overwrite != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "overwrite", runtime.ParamLocationQuery, *params.Overwrite); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetIdentityBackupMetaRequest generates requests for GetIdentityBackupMeta
+func NewGetIdentityBackupMetaRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identity/backup/meta")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPublicKeyRequest calls the generic SetPublicKey builder with application/json body
+func NewSetPublicKeyRequest(server string, body SetPublicKeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPublicKeyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetPublicKeyRequestWithBody generates requests for SetPublicKey with any type of body
+func NewSetPublicKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/identity/public-key")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLocationsRequest generates requests for GetLocations
+func NewGetLocationsRequest(server string, params *GetLocationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewShareLocationsRequest calls the generic ShareLocations builder with application/json body
+func NewShareLocationsRequest(server string, params *ShareLocationsParams, body ShareLocationsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewShareLocationsRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewShareLocationsRequestWithBody generates requests for ShareLocations with any type of body
+func NewShareLocationsRequestWithBody(server string, params *ShareLocationsParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Partial != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "partial", runtime.ParamLocationQuery, *params.Partial); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetLocationSnapshotRequest generates requests for GetLocationSnapshot
+func NewGetLocationSnapshotRequest(server string, params *GetLocationSnapshotParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/all")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamLocationsRequest generates requests for StreamLocations
+func NewStreamLocationsRequest(server string, params *StreamLocationsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/locations/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.LastEventID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetCurrentUserRequest generates requests for GetCurrentUser
+func NewGetCurrentUserRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateProfileRequest calls the generic UpdateProfile builder with application/json body
+func NewUpdateProfileRequest(server string, body UpdateProfileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateProfileRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateProfileRequestWithBody generates requests for UpdateProfile with any type of body
+func NewUpdateProfileRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSettingsRequest generates requests for GetSettings
+func NewGetSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateSettingsRequest calls the generic UpdateSettings builder with application/json body
+func NewUpdateSettingsRequest(server string, body UpdateSettingsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateSettingsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateSettingsRequestWithBody generates requests for UpdateSettings with any type of body
+func NewUpdateSettingsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/settings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetStorageUsageRequest generates requests for GetStorageUsage
+func NewGetStorageUsageRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/me/usage")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOnboardRequest calls the generic Onboard builder with application/json body
+func NewOnboardRequest(server string, body OnboardJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewOnboardRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewOnboardRequestWithBody generates requests for Onboard with any type of body
+func NewOnboardRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/onboard")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOpenAPISpecRequest generates requests for GetOpenAPISpec
+func NewGetOpenAPISpecRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPresenceRequest generates requests for GetPresence
+func NewGetPresenceRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/presence")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPresenceRequest calls the generic SetPresence builder with application/json body
+func NewSetPresenceRequest(server string, body SetPresenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPresenceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetPresenceRequestWithBody generates requests for SetPresence with any type of body
+func NewSetPresenceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/presence")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetReadinessRequest generates requests for GetReadiness
+func NewGetReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/ready")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSessionsRequest generates requests for ListSessions
+func NewListSessionsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRevokeSessionRequest generates requests for RevokeSession
+func NewRevokeSessionRequest(server string, token SessionToken) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationPath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sessions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetUserDataRequest generates requests for GetUserData
+func NewGetUserDataRequest(server string, params *GetUserDataParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user-data")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewSetUserDataRequest calls the generic SetUserData builder with application/json body
+func NewSetUserDataRequest(server string, body SetUserDataJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetUserDataRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetUserDataRequestWithBody generates requests for SetUserData with any type of body
+func NewSetUserDataRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user-data")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// DeleteAccountWithResponse request
+	DeleteAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error)
+
+	// LoginWithAppleWithBodyWithResponse request with any body
+	LoginWithAppleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithAppleResponse, error)
+
+	LoginWithAppleWithResponse(ctx context.Context, body LoginWithAppleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithAppleResponse, error)
+
+	// LoginWithGoogleWithBodyWithResponse request with any body
+	LoginWithGoogleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error)
+
+	LoginWithGoogleWithResponse(ctx context.Context, body LoginWithGoogleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error)
+
+	// LogoutWithResponse request
+	LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error)
+
+	// LoginWithRecoveryCodeWithBodyWithResponse request with any body
+	LoginWithRecoveryCodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithRecoveryCodeResponse, error)
+
+	LoginWithRecoveryCodeWithResponse(ctx context.Context, body LoginWithRecoveryCodeJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithRecoveryCodeResponse, error)
+
+	// GetRecoveryCodeStatusWithResponse request
+	GetRecoveryCodeStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRecoveryCodeStatusResponse, error)
+
+	// GenerateRecoveryCodesWithResponse request
+	GenerateRecoveryCodesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GenerateRecoveryCodesResponse, error)
+
+	// RefreshSessionWithBodyWithResponse request with any body
+	RefreshSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshSessionResponse, error)
+
+	RefreshSessionWithResponse(ctx context.Context, body RefreshSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*RefreshSessionResponse, error)
+
+	// ValidateTokenWithResponse request
+	ValidateTokenWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ValidateTokenResponse, error)
+
+	// GetCapabilitiesWithResponse request
+	GetCapabilitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCapabilitiesResponse, error)
+
+	// ListContactsWithResponse request
+	ListContactsWithResponse(ctx context.Context, params *ListContactsParams, reqEditors ...RequestEditorFn) (*ListContactsResponse, error)
+
+	// BlockUserWithBodyWithResponse request with any body
+	BlockUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BlockUserResponse, error)
+
+	BlockUserWithResponse(ctx context.Context, body BlockUserJSONRequestBody, reqEditors ...RequestEditorFn) (*BlockUserResponse, error)
+
+	// UnblockUserWithResponse request
+	UnblockUserWithResponse(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*UnblockUserResponse, error)
+
+	// ListBlockedWithResponse request
+	ListBlockedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBlockedResponse, error)
+
+	// CheckContactWithResponse request
+	CheckContactWithResponse(ctx context.Context, params *CheckContactParams, reqEditors ...RequestEditorFn) (*CheckContactResponse, error)
+
+	// ListNudgesWithResponse request
+	ListNudgesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListNudgesResponse, error)
+
+	// SendContactRequestWithBodyWithResponse request with any body
+	SendContactRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error)
+
+	SendContactRequestWithResponse(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error)
+
+	// ListContactRequestsWithResponse request
+	ListContactRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactRequestsResponse, error)
+
+	// GetContactRequestHistoryWithResponse request
+	GetContactRequestHistoryWithResponse(ctx context.Context, params *GetContactRequestHistoryParams, reqEditors ...RequestEditorFn) (*GetContactRequestHistoryResponse, error)
+
+	// CancelContactRequestWithResponse request
+	CancelContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*CancelContactRequestResponse, error)
+
+	// AcceptContactRequestWithResponse request
+	AcceptContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*AcceptContactRequestResponse, error)
+
+	// DeclineContactRequestWithResponse request
+	DeclineContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*DeclineContactRequestResponse, error)
+
+	// GetContactSummaryWithResponse request
+	GetContactSummaryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetContactSummaryResponse, error)
+
+	// RemoveContactWithResponse request
+	RemoveContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*RemoveContactResponse, error)
+
+	// SetContactNoteWithBodyWithResponse request with any body
+	SetContactNoteWithBodyWithResponse(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetContactNoteResponse, error)
+
+	SetContactNoteWithResponse(ctx context.Context, contactId ContactId, body SetContactNoteJSONRequestBody, reqEditors ...RequestEditorFn) (*SetContactNoteResponse, error)
+
+	// NudgeContactWithResponse request
+	NudgeContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*NudgeContactResponse, error)
+
+	// SetContactOrderWithBodyWithResponse request with any body
+	SetContactOrderWithBodyWithResponse(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetContactOrderResponse, error)
+
+	SetContactOrderWithResponse(ctx context.Context, contactId ContactId, body SetContactOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*SetContactOrderResponse, error)
+
+	// DevLoginWithBodyWithResponse request with any body
+	DevLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DevLoginResponse, error)
+
+	DevLoginWithResponse(ctx context.Context, body DevLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*DevLoginResponse, error)
+
+	// ListDevicesWithResponse request
+	ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error)
+
+	// RegisterDeviceWithBodyWithResponse request with any body
+	RegisterDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error)
+
+	RegisterDeviceWithResponse(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error)
+
+	// RevokeDeviceWithResponse request
+	RevokeDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*RevokeDeviceResponse, error)
+
+	// RenameDeviceWithBodyWithResponse request with any body
+	RenameDeviceWithBodyWithResponse(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameDeviceResponse, error)
+
+	RenameDeviceWithResponse(ctx context.Context, deviceId DeviceId, body RenameDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameDeviceResponse, error)
+
+	// PauseDeviceWithBodyWithResponse request with any body
+	PauseDeviceWithBodyWithResponse(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PauseDeviceResponse, error)
+
+	PauseDeviceWithResponse(ctx context.Context, deviceId DeviceId, body PauseDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*PauseDeviceResponse, error)
+
+	// UnpauseDeviceWithResponse request
+	UnpauseDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*UnpauseDeviceResponse, error)
+
+	// StreamEventsWithResponse request
+	StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetIdentityBackupWithResponse request
+	GetIdentityBackupWithResponse(ctx context.Context, params *GetIdentityBackupParams, reqEditors ...RequestEditorFn) (*GetIdentityBackupResponse, error)
+
+	// SetIdentityBackupWithBodyWithResponse request with any body
+	SetIdentityBackupWithBodyWithResponse(ctx context.Context, params *SetIdentityBackupParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error)
+
+	SetIdentityBackupWithResponse(ctx context.Context, params *SetIdentityBackupParams, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error)
+
+	// GetIdentityBackupMetaWithResponse request
+	GetIdentityBackupMetaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentityBackupMetaResponse, error)
+
+	// SetPublicKeyWithBodyWithResponse request with any body
+	SetPublicKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error)
+
+	SetPublicKeyWithResponse(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error)
+
+	// GetLocationsWithResponse request
+	GetLocationsWithResponse(ctx context.Context, params *GetLocationsParams, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error)
+
+	// ShareLocationsWithBodyWithResponse request with any body
+	ShareLocationsWithBodyWithResponse(ctx context.Context, params *ShareLocationsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error)
+
+	ShareLocationsWithResponse(ctx context.Context, params *ShareLocationsParams, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error)
+
+	// GetLocationSnapshotWithResponse request
+	GetLocationSnapshotWithResponse(ctx context.Context, params *GetLocationSnapshotParams, reqEditors ...RequestEditorFn) (*GetLocationSnapshotResponse, error)
+
+	// StreamLocationsWithResponse request
+	StreamLocationsWithResponse(ctx context.Context, params *StreamLocationsParams, reqEditors ...RequestEditorFn) (*StreamLocationsResponse, error)
+
+	// GetCurrentUserWithResponse request
+	GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error)
+
+	// UpdateProfileWithBodyWithResponse request with any body
+	UpdateProfileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error)
+
+	UpdateProfileWithResponse(ctx context.Context, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error)
+
+	// GetSettingsWithResponse request
+	GetSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSettingsResponse, error)
+
+	// UpdateSettingsWithBodyWithResponse request with any body
+	UpdateSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSettingsResponse, error)
+
+	UpdateSettingsWithResponse(ctx context.Context, body UpdateSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSettingsResponse, error)
+
+	// GetStorageUsageWithResponse request
+	GetStorageUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStorageUsageResponse, error)
+
+	// OnboardWithBodyWithResponse request with any body
+	OnboardWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OnboardResponse, error)
+
+	OnboardWithResponse(ctx context.Context, body OnboardJSONRequestBody, reqEditors ...RequestEditorFn) (*OnboardResponse, error)
+
+	// GetOpenAPISpecWithResponse request
+	GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error)
+
+	// GetPresenceWithResponse request
+	GetPresenceWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPresenceResponse, error)
+
+	// SetPresenceWithBodyWithResponse request with any body
+	SetPresenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPresenceResponse, error)
+
+	SetPresenceWithResponse(ctx context.Context, body SetPresenceJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPresenceResponse, error)
+
+	// GetReadinessWithResponse request
+	GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error)
+
+	// ListSessionsWithResponse request
+	ListSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSessionsResponse, error)
+
+	// RevokeSessionWithResponse request
+	RevokeSessionWithResponse(ctx context.Context, token SessionToken, reqEditors ...RequestEditorFn) (*RevokeSessionResponse, error)
+
+	// GetUserDataWithResponse request
+	GetUserDataWithResponse(ctx context.Context, params *GetUserDataParams, reqEditors ...RequestEditorFn) (*GetUserDataResponse, error)
+
+	// SetUserDataWithBodyWithResponse request with any body
+	SetUserDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error)
+
+	SetUserDataWithResponse(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error)
+}
+
+type DeleteAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginWithAppleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LoginResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginWithAppleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginWithAppleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginWithGoogleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LoginResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginWithGoogleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginWithGoogleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LogoutResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r LogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginWithRecoveryCodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LoginResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginWithRecoveryCodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginWithRecoveryCodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRecoveryCodeStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RecoveryCodeStatus
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRecoveryCodeStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRecoveryCodeStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GenerateRecoveryCodesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RecoveryCodes
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GenerateRecoveryCodesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GenerateRecoveryCodesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RefreshSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RefreshResponse
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r RefreshSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RefreshSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ValidateTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TokenValidation
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ValidateTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ValidateTokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCapabilitiesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Capabilities
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCapabilitiesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCapabilitiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListContactsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListContactsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListContactsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BlockUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r BlockUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BlockUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnblockUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r UnblockUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnblockUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBlockedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BlockedList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBlockedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBlockedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CheckContactResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactCheck
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r CheckContactResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CheckContactResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListNudgesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NudgeList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListNudgesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListNudgesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SendContactRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ContactRequest
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SendContactRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SendContactRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListContactRequestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactRequestList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListContactRequestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListContactRequestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetContactRequestHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactRequestHistory
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetContactRequestHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetContactRequestHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelContactRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelContactRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelContactRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcceptContactRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Contact
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r AcceptContactRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptContactRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeclineContactRequestResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r DeclineContactRequestResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeclineContactRequestResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetContactSummaryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContactSummary
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetContactSummaryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetContactSummaryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveContactResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveContactResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveContactResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetContactNoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r SetContactNoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetContactNoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type NudgeContactResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r NudgeContactResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r NudgeContactResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetContactOrderResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r SetContactOrderResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetContactOrderResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DevLoginResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LoginResponse
+	JSON400      *BadRequest
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r DevLoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DevLoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDevicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeviceList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDevicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDevicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *DeviceWithToken
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RenameDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RenameDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RenameDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PauseDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r PauseDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PauseDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnpauseDeviceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r UnpauseDeviceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnpauseDeviceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdentityBackupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IdentityBackup
+	JSON401      *Unauthorized
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdentityBackupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdentityBackupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetIdentityBackupResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *ConflictError
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetIdentityBackupResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetIdentityBackupResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdentityBackupMetaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IdentityBackupMeta
+	JSON401      *Unauthorized
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdentityBackupMetaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdentityBackupMetaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPublicKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPublicKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPublicKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LocationList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
 func (r GetLocationsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
-	return http.StatusText(0)
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ShareLocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LocationShareResults
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Error
+	JSON409      *Error
+	JSON413      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ShareLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ShareLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLocationSnapshotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LocationSnapshot
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLocationSnapshotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLocationSnapshotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamLocationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamLocationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamLocationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCurrentUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCurrentUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCurrentUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserSettings
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserSettings
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetStorageUsageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StorageUsage
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetStorageUsageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetStorageUsageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type OnboardResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r OnboardResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r OnboardResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPISpecResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPISpecResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPISpecResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPresenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PresenceList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPresenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPresenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPresenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPresenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPresenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadinessResponse
+	JSON503      *ReadinessResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSessionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SessionList
+	JSON401      *Unauthorized
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSessionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSessionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON404      *NotFound
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserData
+	JSON401      *Unauthorized
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetUserDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *UserData
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON409      *ConflictError
+}
+
+// Status returns HTTPResponse.Status
+func (r SetUserDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetUserDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// DeleteAccountWithResponse request returning *DeleteAccountResponse
+func (c *ClientWithResponses) DeleteAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error) {
+	rsp, err := c.DeleteAccount(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAccountResponse(rsp)
+}
+
+// LoginWithAppleWithBodyWithResponse request with arbitrary body returning *LoginWithAppleResponse
+func (c *ClientWithResponses) LoginWithAppleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithAppleResponse, error) {
+	rsp, err := c.LoginWithAppleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithAppleResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithAppleWithResponse(ctx context.Context, body LoginWithAppleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithAppleResponse, error) {
+	rsp, err := c.LoginWithApple(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithAppleResponse(rsp)
+}
+
+// LoginWithGoogleWithBodyWithResponse request with arbitrary body returning *LoginWithGoogleResponse
+func (c *ClientWithResponses) LoginWithGoogleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error) {
+	rsp, err := c.LoginWithGoogleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithGoogleResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithGoogleWithResponse(ctx context.Context, body LoginWithGoogleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error) {
+	rsp, err := c.LoginWithGoogle(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithGoogleResponse(rsp)
+}
+
+// LogoutWithResponse request returning *LogoutResponse
+func (c *ClientWithResponses) LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error) {
+	rsp, err := c.Logout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogoutResponse(rsp)
+}
+
+// LoginWithRecoveryCodeWithBodyWithResponse request with arbitrary body returning *LoginWithRecoveryCodeResponse
+func (c *ClientWithResponses) LoginWithRecoveryCodeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithRecoveryCodeResponse, error) {
+	rsp, err := c.LoginWithRecoveryCodeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithRecoveryCodeResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithRecoveryCodeWithResponse(ctx context.Context, body LoginWithRecoveryCodeJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithRecoveryCodeResponse, error) {
+	rsp, err := c.LoginWithRecoveryCode(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginWithRecoveryCodeResponse(rsp)
+}
+
+// GetRecoveryCodeStatusWithResponse request returning *GetRecoveryCodeStatusResponse
+func (c *ClientWithResponses) GetRecoveryCodeStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRecoveryCodeStatusResponse, error) {
+	rsp, err := c.GetRecoveryCodeStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRecoveryCodeStatusResponse(rsp)
+}
+
+// GenerateRecoveryCodesWithResponse request returning *GenerateRecoveryCodesResponse
+func (c *ClientWithResponses) GenerateRecoveryCodesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GenerateRecoveryCodesResponse, error) {
+	rsp, err := c.GenerateRecoveryCodes(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGenerateRecoveryCodesResponse(rsp)
+}
+
+// RefreshSessionWithBodyWithResponse request with arbitrary body returning *RefreshSessionResponse
+func (c *ClientWithResponses) RefreshSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RefreshSessionResponse, error) {
+	rsp, err := c.RefreshSessionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshSessionResponse(rsp)
+}
+
+func (c *ClientWithResponses) RefreshSessionWithResponse(ctx context.Context, body RefreshSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*RefreshSessionResponse, error) {
+	rsp, err := c.RefreshSession(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRefreshSessionResponse(rsp)
+}
+
+// ValidateTokenWithResponse request returning *ValidateTokenResponse
+func (c *ClientWithResponses) ValidateTokenWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ValidateTokenResponse, error) {
+	rsp, err := c.ValidateToken(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseValidateTokenResponse(rsp)
+}
+
+// GetCapabilitiesWithResponse request returning *GetCapabilitiesResponse
+func (c *ClientWithResponses) GetCapabilitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCapabilitiesResponse, error) {
+	rsp, err := c.GetCapabilities(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCapabilitiesResponse(rsp)
+}
+
+// ListContactsWithResponse request returning *ListContactsResponse
+func (c *ClientWithResponses) ListContactsWithResponse(ctx context.Context, params *ListContactsParams, reqEditors ...RequestEditorFn) (*ListContactsResponse, error) {
+	rsp, err := c.ListContacts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListContactsResponse(rsp)
+}
+
+// BlockUserWithBodyWithResponse request with arbitrary body returning *BlockUserResponse
+func (c *ClientWithResponses) BlockUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BlockUserResponse, error) {
+	rsp, err := c.BlockUserWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) BlockUserWithResponse(ctx context.Context, body BlockUserJSONRequestBody, reqEditors ...RequestEditorFn) (*BlockUserResponse, error) {
+	rsp, err := c.BlockUser(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBlockUserResponse(rsp)
+}
+
+// UnblockUserWithResponse request returning *UnblockUserResponse
+func (c *ClientWithResponses) UnblockUserWithResponse(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*UnblockUserResponse, error) {
+	rsp, err := c.UnblockUser(ctx, userId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnblockUserResponse(rsp)
+}
+
+// ListBlockedWithResponse request returning *ListBlockedResponse
+func (c *ClientWithResponses) ListBlockedWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBlockedResponse, error) {
+	rsp, err := c.ListBlocked(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBlockedResponse(rsp)
+}
+
+// CheckContactWithResponse request returning *CheckContactResponse
+func (c *ClientWithResponses) CheckContactWithResponse(ctx context.Context, params *CheckContactParams, reqEditors ...RequestEditorFn) (*CheckContactResponse, error) {
+	rsp, err := c.CheckContact(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCheckContactResponse(rsp)
+}
+
+// ListNudgesWithResponse request returning *ListNudgesResponse
+func (c *ClientWithResponses) ListNudgesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListNudgesResponse, error) {
+	rsp, err := c.ListNudges(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListNudgesResponse(rsp)
+}
+
+// SendContactRequestWithBodyWithResponse request with arbitrary body returning *SendContactRequestResponse
+func (c *ClientWithResponses) SendContactRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error) {
+	rsp, err := c.SendContactRequestWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendContactRequestResponse(rsp)
+}
+
+func (c *ClientWithResponses) SendContactRequestWithResponse(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error) {
+	rsp, err := c.SendContactRequest(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendContactRequestResponse(rsp)
+}
+
+// ListContactRequestsWithResponse request returning *ListContactRequestsResponse
+func (c *ClientWithResponses) ListContactRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactRequestsResponse, error) {
+	rsp, err := c.ListContactRequests(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListContactRequestsResponse(rsp)
+}
+
+// GetContactRequestHistoryWithResponse request returning *GetContactRequestHistoryResponse
+func (c *ClientWithResponses) GetContactRequestHistoryWithResponse(ctx context.Context, params *GetContactRequestHistoryParams, reqEditors ...RequestEditorFn) (*GetContactRequestHistoryResponse, error) {
+	rsp, err := c.GetContactRequestHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetContactRequestHistoryResponse(rsp)
+}
+
+// CancelContactRequestWithResponse request returning *CancelContactRequestResponse
+func (c *ClientWithResponses) CancelContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*CancelContactRequestResponse, error) {
+	rsp, err := c.CancelContactRequest(ctx, requestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelContactRequestResponse(rsp)
+}
+
+// AcceptContactRequestWithResponse request returning *AcceptContactRequestResponse
+func (c *ClientWithResponses) AcceptContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*AcceptContactRequestResponse, error) {
+	rsp, err := c.AcceptContactRequest(ctx, requestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptContactRequestResponse(rsp)
+}
+
+// DeclineContactRequestWithResponse request returning *DeclineContactRequestResponse
+func (c *ClientWithResponses) DeclineContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*DeclineContactRequestResponse, error) {
+	rsp, err := c.DeclineContactRequest(ctx, requestId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeclineContactRequestResponse(rsp)
+}
+
+// GetContactSummaryWithResponse request returning *GetContactSummaryResponse
+func (c *ClientWithResponses) GetContactSummaryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetContactSummaryResponse, error) {
+	rsp, err := c.GetContactSummary(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetContactSummaryResponse(rsp)
+}
+
+// RemoveContactWithResponse request returning *RemoveContactResponse
+func (c *ClientWithResponses) RemoveContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*RemoveContactResponse, error) {
+	rsp, err := c.RemoveContact(ctx, contactId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveContactResponse(rsp)
+}
+
+// SetContactNoteWithBodyWithResponse request with arbitrary body returning *SetContactNoteResponse
+func (c *ClientWithResponses) SetContactNoteWithBodyWithResponse(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetContactNoteResponse, error) {
+	rsp, err := c.SetContactNoteWithBody(ctx, contactId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetContactNoteResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetContactNoteWithResponse(ctx context.Context, contactId ContactId, body SetContactNoteJSONRequestBody, reqEditors ...RequestEditorFn) (*SetContactNoteResponse, error) {
+	rsp, err := c.SetContactNote(ctx, contactId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetContactNoteResponse(rsp)
+}
+
+// NudgeContactWithResponse request returning *NudgeContactResponse
+func (c *ClientWithResponses) NudgeContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*NudgeContactResponse, error) {
+	rsp, err := c.NudgeContact(ctx, contactId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNudgeContactResponse(rsp)
+}
+
+// SetContactOrderWithBodyWithResponse request with arbitrary body returning *SetContactOrderResponse
+func (c *ClientWithResponses) SetContactOrderWithBodyWithResponse(ctx context.Context, contactId ContactId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetContactOrderResponse, error) {
+	rsp, err := c.SetContactOrderWithBody(ctx, contactId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetContactOrderResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetContactOrderWithResponse(ctx context.Context, contactId ContactId, body SetContactOrderJSONRequestBody, reqEditors ...RequestEditorFn) (*SetContactOrderResponse, error) {
+	rsp, err := c.SetContactOrder(ctx, contactId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetContactOrderResponse(rsp)
+}
+
+// DevLoginWithBodyWithResponse request with arbitrary body returning *DevLoginResponse
+func (c *ClientWithResponses) DevLoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DevLoginResponse, error) {
+	rsp, err := c.DevLoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDevLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) DevLoginWithResponse(ctx context.Context, body DevLoginJSONRequestBody, reqEditors ...RequestEditorFn) (*DevLoginResponse, error) {
+	rsp, err := c.DevLogin(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDevLoginResponse(rsp)
+}
+
+// ListDevicesWithResponse request returning *ListDevicesResponse
+func (c *ClientWithResponses) ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error) {
+	rsp, err := c.ListDevices(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDevicesResponse(rsp)
+}
+
+// RegisterDeviceWithBodyWithResponse request with arbitrary body returning *RegisterDeviceResponse
+func (c *ClientWithResponses) RegisterDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error) {
+	rsp, err := c.RegisterDeviceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterDeviceResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterDeviceWithResponse(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error) {
+	rsp, err := c.RegisterDevice(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterDeviceResponse(rsp)
+}
+
+// RevokeDeviceWithResponse request returning *RevokeDeviceResponse
+func (c *ClientWithResponses) RevokeDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*RevokeDeviceResponse, error) {
+	rsp, err := c.RevokeDevice(ctx, deviceId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeDeviceResponse(rsp)
+}
+
+// RenameDeviceWithBodyWithResponse request with arbitrary body returning *RenameDeviceResponse
+func (c *ClientWithResponses) RenameDeviceWithBodyWithResponse(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RenameDeviceResponse, error) {
+	rsp, err := c.RenameDeviceWithBody(ctx, deviceId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameDeviceResponse(rsp)
+}
+
+func (c *ClientWithResponses) RenameDeviceWithResponse(ctx context.Context, deviceId DeviceId, body RenameDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RenameDeviceResponse, error) {
+	rsp, err := c.RenameDevice(ctx, deviceId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRenameDeviceResponse(rsp)
+}
+
+// PauseDeviceWithBodyWithResponse request with arbitrary body returning *PauseDeviceResponse
+func (c *ClientWithResponses) PauseDeviceWithBodyWithResponse(ctx context.Context, deviceId DeviceId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PauseDeviceResponse, error) {
+	rsp, err := c.PauseDeviceWithBody(ctx, deviceId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePauseDeviceResponse(rsp)
+}
+
+func (c *ClientWithResponses) PauseDeviceWithResponse(ctx context.Context, deviceId DeviceId, body PauseDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*PauseDeviceResponse, error) {
+	rsp, err := c.PauseDevice(ctx, deviceId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePauseDeviceResponse(rsp)
+}
+
+// UnpauseDeviceWithResponse request returning *UnpauseDeviceResponse
+func (c *ClientWithResponses) UnpauseDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*UnpauseDeviceResponse, error) {
+	rsp, err := c.UnpauseDevice(ctx, deviceId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnpauseDeviceResponse(rsp)
+}
+
+// StreamEventsWithResponse request returning *StreamEventsResponse
+func (c *ClientWithResponses) StreamEventsWithResponse(ctx context.Context, params *StreamEventsParams, reqEditors ...RequestEditorFn) (*StreamEventsResponse, error) {
+	rsp, err := c.StreamEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamEventsResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetIdentityBackupWithResponse request returning *GetIdentityBackupResponse
+func (c *ClientWithResponses) GetIdentityBackupWithResponse(ctx context.Context, params *GetIdentityBackupParams, reqEditors ...RequestEditorFn) (*GetIdentityBackupResponse, error) {
+	rsp, err := c.GetIdentityBackup(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdentityBackupResponse(rsp)
+}
+
+// SetIdentityBackupWithBodyWithResponse request with arbitrary body returning *SetIdentityBackupResponse
+func (c *ClientWithResponses) SetIdentityBackupWithBodyWithResponse(ctx context.Context, params *SetIdentityBackupParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error) {
+	rsp, err := c.SetIdentityBackupWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetIdentityBackupResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetIdentityBackupWithResponse(ctx context.Context, params *SetIdentityBackupParams, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error) {
+	rsp, err := c.SetIdentityBackup(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetIdentityBackupResponse(rsp)
+}
+
+// GetIdentityBackupMetaWithResponse request returning *GetIdentityBackupMetaResponse
+func (c *ClientWithResponses) GetIdentityBackupMetaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentityBackupMetaResponse, error) {
+	rsp, err := c.GetIdentityBackupMeta(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdentityBackupMetaResponse(rsp)
+}
+
+// SetPublicKeyWithBodyWithResponse request with arbitrary body returning *SetPublicKeyResponse
+func (c *ClientWithResponses) SetPublicKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error) {
+	rsp, err := c.SetPublicKeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPublicKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPublicKeyWithResponse(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error) {
+	rsp, err := c.SetPublicKey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPublicKeyResponse(rsp)
+}
+
+// GetLocationsWithResponse request returning *GetLocationsResponse
+func (c *ClientWithResponses) GetLocationsWithResponse(ctx context.Context, params *GetLocationsParams, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error) {
+	rsp, err := c.GetLocations(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationsResponse(rsp)
+}
+
+// ShareLocationsWithBodyWithResponse request with arbitrary body returning *ShareLocationsResponse
+func (c *ClientWithResponses) ShareLocationsWithBodyWithResponse(ctx context.Context, params *ShareLocationsParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error) {
+	rsp, err := c.ShareLocationsWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShareLocationsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ShareLocationsWithResponse(ctx context.Context, params *ShareLocationsParams, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error) {
+	rsp, err := c.ShareLocations(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseShareLocationsResponse(rsp)
+}
+
+// GetLocationSnapshotWithResponse request returning *GetLocationSnapshotResponse
+func (c *ClientWithResponses) GetLocationSnapshotWithResponse(ctx context.Context, params *GetLocationSnapshotParams, reqEditors ...RequestEditorFn) (*GetLocationSnapshotResponse, error) {
+	rsp, err := c.GetLocationSnapshot(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLocationSnapshotResponse(rsp)
+}
+
+// StreamLocationsWithResponse request returning *StreamLocationsResponse
+func (c *ClientWithResponses) StreamLocationsWithResponse(ctx context.Context, params *StreamLocationsParams, reqEditors ...RequestEditorFn) (*StreamLocationsResponse, error) {
+	rsp, err := c.StreamLocations(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamLocationsResponse(rsp)
+}
+
+// GetCurrentUserWithResponse request returning *GetCurrentUserResponse
+func (c *ClientWithResponses) GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error) {
+	rsp, err := c.GetCurrentUser(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCurrentUserResponse(rsp)
+}
+
+// UpdateProfileWithBodyWithResponse request with arbitrary body returning *UpdateProfileResponse
+func (c *ClientWithResponses) UpdateProfileWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error) {
+	rsp, err := c.UpdateProfileWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProfileResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateProfileWithResponse(ctx context.Context, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error) {
+	rsp, err := c.UpdateProfile(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProfileResponse(rsp)
+}
+
+// GetSettingsWithResponse request returning *GetSettingsResponse
+func (c *ClientWithResponses) GetSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSettingsResponse, error) {
+	rsp, err := c.GetSettings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSettingsResponse(rsp)
+}
+
+// UpdateSettingsWithBodyWithResponse request with arbitrary body returning *UpdateSettingsResponse
+func (c *ClientWithResponses) UpdateSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSettingsResponse, error) {
+	rsp, err := c.UpdateSettingsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSettingsResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateSettingsWithResponse(ctx context.Context, body UpdateSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSettingsResponse, error) {
+	rsp, err := c.UpdateSettings(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSettingsResponse(rsp)
+}
+
+// GetStorageUsageWithResponse request returning *GetStorageUsageResponse
+func (c *ClientWithResponses) GetStorageUsageWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetStorageUsageResponse, error) {
+	rsp, err := c.GetStorageUsage(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetStorageUsageResponse(rsp)
+}
+
+// OnboardWithBodyWithResponse request with arbitrary body returning *OnboardResponse
+func (c *ClientWithResponses) OnboardWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*OnboardResponse, error) {
+	rsp, err := c.OnboardWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardResponse(rsp)
+}
+
+func (c *ClientWithResponses) OnboardWithResponse(ctx context.Context, body OnboardJSONRequestBody, reqEditors ...RequestEditorFn) (*OnboardResponse, error) {
+	rsp, err := c.Onboard(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOnboardResponse(rsp)
+}
+
+// GetOpenAPISpecWithResponse request returning *GetOpenAPISpecResponse
+func (c *ClientWithResponses) GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error) {
+	rsp, err := c.GetOpenAPISpec(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPISpecResponse(rsp)
+}
+
+// GetPresenceWithResponse request returning *GetPresenceResponse
+func (c *ClientWithResponses) GetPresenceWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetPresenceResponse, error) {
+	rsp, err := c.GetPresence(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPresenceResponse(rsp)
+}
+
+// SetPresenceWithBodyWithResponse request with arbitrary body returning *SetPresenceResponse
+func (c *ClientWithResponses) SetPresenceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPresenceResponse, error) {
+	rsp, err := c.SetPresenceWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPresenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPresenceWithResponse(ctx context.Context, body SetPresenceJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPresenceResponse, error) {
+	rsp, err := c.SetPresence(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPresenceResponse(rsp)
+}
+
+// GetReadinessWithResponse request returning *GetReadinessResponse
+func (c *ClientWithResponses) GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error) {
+	rsp, err := c.GetReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadinessResponse(rsp)
+}
+
+// ListSessionsWithResponse request returning *ListSessionsResponse
+func (c *ClientWithResponses) ListSessionsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSessionsResponse, error) {
+	rsp, err := c.ListSessions(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSessionsResponse(rsp)
+}
+
+// RevokeSessionWithResponse request returning *RevokeSessionResponse
+func (c *ClientWithResponses) RevokeSessionWithResponse(ctx context.Context, token SessionToken, reqEditors ...RequestEditorFn) (*RevokeSessionResponse, error) {
+	rsp, err := c.RevokeSession(ctx, token, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeSessionResponse(rsp)
+}
+
+// GetUserDataWithResponse request returning *GetUserDataResponse
+func (c *ClientWithResponses) GetUserDataWithResponse(ctx context.Context, params *GetUserDataParams, reqEditors ...RequestEditorFn) (*GetUserDataResponse, error) {
+	rsp, err := c.GetUserData(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUserDataResponse(rsp)
+}
+
+// SetUserDataWithBodyWithResponse request with arbitrary body returning *SetUserDataResponse
+func (c *ClientWithResponses) SetUserDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error) {
+	rsp, err := c.SetUserDataWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserDataResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetUserDataWithResponse(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error) {
+	rsp, err := c.SetUserData(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserDataResponse(rsp)
+}
+
+// ParseDeleteAccountResponse parses an HTTP response from a DeleteAccountWithResponse call
+func ParseDeleteAccountResponse(rsp *http.Response) (*DeleteAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginWithAppleResponse parses an HTTP response from a LoginWithAppleWithResponse call
+func ParseLoginWithAppleResponse(rsp *http.Response) (*LoginWithAppleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginWithAppleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LoginResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginWithGoogleResponse parses an HTTP response from a LoginWithGoogleWithResponse call
+func ParseLoginWithGoogleResponse(rsp *http.Response) (*LoginWithGoogleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginWithGoogleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LoginResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLogoutResponse parses an HTTP response from a LogoutWithResponse call
+func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginWithRecoveryCodeResponse parses an HTTP response from a LoginWithRecoveryCodeWithResponse call
+func ParseLoginWithRecoveryCodeResponse(rsp *http.Response) (*LoginWithRecoveryCodeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginWithRecoveryCodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LoginResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRecoveryCodeStatusResponse parses an HTTP response from a GetRecoveryCodeStatusWithResponse call
+func ParseGetRecoveryCodeStatusResponse(rsp *http.Response) (*GetRecoveryCodeStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRecoveryCodeStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RecoveryCodeStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGenerateRecoveryCodesResponse parses an HTTP response from a GenerateRecoveryCodesWithResponse call
+func ParseGenerateRecoveryCodesResponse(rsp *http.Response) (*GenerateRecoveryCodesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GenerateRecoveryCodesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RecoveryCodes
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRefreshSessionResponse parses an HTTP response from a RefreshSessionWithResponse call
+func ParseRefreshSessionResponse(rsp *http.Response) (*RefreshSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RefreshSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RefreshResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseValidateTokenResponse parses an HTTP response from a ValidateTokenWithResponse call
+func ParseValidateTokenResponse(rsp *http.Response) (*ValidateTokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ValidateTokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TokenValidation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCapabilitiesResponse parses an HTTP response from a GetCapabilitiesWithResponse call
+func ParseGetCapabilitiesResponse(rsp *http.Response) (*GetCapabilitiesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCapabilitiesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Capabilities
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListContactsResponse parses an HTTP response from a ListContactsWithResponse call
+func ParseListContactsResponse(rsp *http.Response) (*ListContactsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListContactsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBlockUserResponse parses an HTTP response from a BlockUserWithResponse call
+func ParseBlockUserResponse(rsp *http.Response) (*BlockUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BlockUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnblockUserResponse parses an HTTP response from a UnblockUserWithResponse call
+func ParseUnblockUserResponse(rsp *http.Response) (*UnblockUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnblockUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListBlockedResponse parses an HTTP response from a ListBlockedWithResponse call
+func ParseListBlockedResponse(rsp *http.Response) (*ListBlockedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBlockedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BlockedList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCheckContactResponse parses an HTTP response from a CheckContactWithResponse call
+func ParseCheckContactResponse(rsp *http.Response) (*CheckContactResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CheckContactResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactCheck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListNudgesResponse parses an HTTP response from a ListNudgesWithResponse call
+func ParseListNudgesResponse(rsp *http.Response) (*ListNudgesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListNudgesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NudgeList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSendContactRequestResponse parses an HTTP response from a SendContactRequestWithResponse call
+func ParseSendContactRequestResponse(rsp *http.Response) (*SendContactRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SendContactRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ContactRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListContactRequestsResponse parses an HTTP response from a ListContactRequestsWithResponse call
+func ParseListContactRequestsResponse(rsp *http.Response) (*ListContactRequestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListContactRequestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactRequestList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetContactRequestHistoryResponse parses an HTTP response from a GetContactRequestHistoryWithResponse call
+func ParseGetContactRequestHistoryResponse(rsp *http.Response) (*GetContactRequestHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetContactRequestHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactRequestHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetLocationsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseCancelContactRequestResponse parses an HTTP response from a CancelContactRequestWithResponse call
+func ParseCancelContactRequestResponse(rsp *http.Response) (*CancelContactRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &CancelContactRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-type ShareLocationsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
+// ParseAcceptContactRequestResponse parses an HTTP response from a AcceptContactRequestWithResponse call
+func ParseAcceptContactRequestResponse(rsp *http.Response) (*AcceptContactRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcceptContactRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Contact
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ShareLocationsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseDeclineContactRequestResponse parses an HTTP response from a DeclineContactRequestWithResponse call
+func ParseDeclineContactRequestResponse(rsp *http.Response) (*DeclineContactRequestResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &DeclineContactRequestResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ShareLocationsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseGetContactSummaryResponse parses an HTTP response from a GetContactSummaryWithResponse call
+func ParseGetContactSummaryResponse(rsp *http.Response) (*GetContactSummaryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &GetContactSummaryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContactSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	}
+
+	return response, nil
 }
 
-type GetCurrentUserResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *User
-	JSON401      *Unauthorized
+// ParseRemoveContactResponse parses an HTTP response from a RemoveContactWithResponse call
+func ParseRemoveContactResponse(rsp *http.Response) (*RemoveContactResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveContactResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetCurrentUserResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseSetContactNoteResponse parses an HTTP response from a SetContactNoteWithResponse call
+func ParseSetContactNoteResponse(rsp *http.Response) (*SetContactNoteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &SetContactNoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetCurrentUserResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseNudgeContactResponse parses an HTTP response from a NudgeContactWithResponse call
+func ParseNudgeContactResponse(rsp *http.Response) (*NudgeContactResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &NudgeContactResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	}
-	return 0
-}
 
-type GetUserDataResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *UserData
-	JSON401      *Unauthorized
-	JSON404      *Error
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetUserDataResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseSetContactOrderResponse parses an HTTP response from a SetContactOrderWithResponse call
+func ParseSetContactOrderResponse(rsp *http.Response) (*SetContactOrderResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetUserDataResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &SetContactOrderResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type SetUserDataResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *UserData
-	JSON400      *BadRequest
-	JSON401      *Unauthorized
-	JSON409      *ConflictError
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-// Status returns HTTPResponse.Status
-func (r SetUserDataResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SetUserDataResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// DeleteAccountWithResponse request returning *DeleteAccountResponse
-func (c *ClientWithResponses) DeleteAccountWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error) {
-	rsp, err := c.DeleteAccount(ctx, reqEditors...)
+// ParseDevLoginResponse parses an HTTP response from a DevLoginWithResponse call
+func ParseDevLoginResponse(rsp *http.Response) (*DevLoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseDeleteAccountResponse(rsp)
-}
 
-// LoginWithGoogleWithBodyWithResponse request with arbitrary body returning *LoginWithGoogleResponse
-func (c *ClientWithResponses) LoginWithGoogleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error) {
-	rsp, err := c.LoginWithGoogleWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DevLoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseLoginWithGoogleResponse(rsp)
-}
 
-func (c *ClientWithResponses) LoginWithGoogleWithResponse(ctx context.Context, body LoginWithGoogleJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginWithGoogleResponse, error) {
-	rsp, err := c.LoginWithGoogle(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LoginResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseLoginWithGoogleResponse(rsp)
+
+	return response, nil
 }
 
-// LogoutWithResponse request returning *LogoutResponse
-func (c *ClientWithResponses) LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error) {
-	rsp, err := c.Logout(ctx, reqEditors...)
+// ParseListDevicesResponse parses an HTTP response from a ListDevicesWithResponse call
+func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseLogoutResponse(rsp)
-}
 
-// ListContactsWithResponse request returning *ListContactsResponse
-func (c *ClientWithResponses) ListContactsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactsResponse, error) {
-	rsp, err := c.ListContacts(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ListDevicesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseListContactsResponse(rsp)
-}
 
-// SendContactRequestWithBodyWithResponse request with arbitrary body returning *SendContactRequestResponse
-func (c *ClientWithResponses) SendContactRequestWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error) {
-	rsp, err := c.SendContactRequestWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeviceList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	}
-	return ParseSendContactRequestResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) SendContactRequestWithResponse(ctx context.Context, body SendContactRequestJSONRequestBody, reqEditors ...RequestEditorFn) (*SendContactRequestResponse, error) {
-	rsp, err := c.SendContactRequest(ctx, body, reqEditors...)
+// ParseRegisterDeviceResponse parses an HTTP response from a RegisterDeviceWithResponse call
+func ParseRegisterDeviceResponse(rsp *http.Response) (*RegisterDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseSendContactRequestResponse(rsp)
-}
 
-// ListContactRequestsWithResponse request returning *ListContactRequestsResponse
-func (c *ClientWithResponses) ListContactRequestsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListContactRequestsResponse, error) {
-	rsp, err := c.ListContactRequests(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &RegisterDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseListContactRequestsResponse(rsp)
-}
 
-// CancelContactRequestWithResponse request returning *CancelContactRequestResponse
-func (c *ClientWithResponses) CancelContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*CancelContactRequestResponse, error) {
-	rsp, err := c.CancelContactRequest(ctx, requestId, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest DeviceWithToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	}
-	return ParseCancelContactRequestResponse(rsp)
+
+	return response, nil
 }
 
-// AcceptContactRequestWithResponse request returning *AcceptContactRequestResponse
-func (c *ClientWithResponses) AcceptContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*AcceptContactRequestResponse, error) {
-	rsp, err := c.AcceptContactRequest(ctx, requestId, reqEditors...)
+// ParseRevokeDeviceResponse parses an HTTP response from a RevokeDeviceWithResponse call
+func ParseRevokeDeviceResponse(rsp *http.Response) (*RevokeDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseAcceptContactRequestResponse(rsp)
-}
 
-// DeclineContactRequestWithResponse request returning *DeclineContactRequestResponse
-func (c *ClientWithResponses) DeclineContactRequestWithResponse(ctx context.Context, requestId RequestId, reqEditors ...RequestEditorFn) (*DeclineContactRequestResponse, error) {
-	rsp, err := c.DeclineContactRequest(ctx, requestId, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &RevokeDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseDeclineContactRequestResponse(rsp)
-}
 
-// RemoveContactWithResponse request returning *RemoveContactResponse
-func (c *ClientWithResponses) RemoveContactWithResponse(ctx context.Context, contactId ContactId, reqEditors ...RequestEditorFn) (*RemoveContactResponse, error) {
-	rsp, err := c.RemoveContact(ctx, contactId, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseRemoveContactResponse(rsp)
+
+	return response, nil
 }
 
-// ListDevicesWithResponse request returning *ListDevicesResponse
-func (c *ClientWithResponses) ListDevicesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListDevicesResponse, error) {
-	rsp, err := c.ListDevices(ctx, reqEditors...)
+// ParseRenameDeviceResponse parses an HTTP response from a RenameDeviceWithResponse call
+func ParseRenameDeviceResponse(rsp *http.Response) (*RenameDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListDevicesResponse(rsp)
-}
 
-// RegisterDeviceWithBodyWithResponse request with arbitrary body returning *RegisterDeviceResponse
-func (c *ClientWithResponses) RegisterDeviceWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error) {
-	rsp, err := c.RegisterDeviceWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &RenameDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseRegisterDeviceResponse(rsp)
-}
 
-func (c *ClientWithResponses) RegisterDeviceWithResponse(ctx context.Context, body RegisterDeviceJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterDeviceResponse, error) {
-	rsp, err := c.RegisterDevice(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseRegisterDeviceResponse(rsp)
+
+	return response, nil
 }
 
-// RevokeDeviceWithResponse request returning *RevokeDeviceResponse
-func (c *ClientWithResponses) RevokeDeviceWithResponse(ctx context.Context, deviceId DeviceId, reqEditors ...RequestEditorFn) (*RevokeDeviceResponse, error) {
-	rsp, err := c.RevokeDevice(ctx, deviceId, reqEditors...)
+// ParsePauseDeviceResponse parses an HTTP response from a PauseDeviceWithResponse call
+func ParsePauseDeviceResponse(rsp *http.Response) (*PauseDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseRevokeDeviceResponse(rsp)
-}
 
-// GetHealthWithResponse request returning *GetHealthResponse
-func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
-	rsp, err := c.GetHealth(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PauseDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetHealthResponse(rsp)
-}
 
-// GetIdentityBackupWithResponse request returning *GetIdentityBackupResponse
-func (c *ClientWithResponses) GetIdentityBackupWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetIdentityBackupResponse, error) {
-	rsp, err := c.GetIdentityBackup(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseGetIdentityBackupResponse(rsp)
+
+	return response, nil
 }
 
-// SetIdentityBackupWithBodyWithResponse request with arbitrary body returning *SetIdentityBackupResponse
-func (c *ClientWithResponses) SetIdentityBackupWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error) {
-	rsp, err := c.SetIdentityBackupWithBody(ctx, contentType, body, reqEditors...)
+// ParseUnpauseDeviceResponse parses an HTTP response from a UnpauseDeviceWithResponse call
+func ParseUnpauseDeviceResponse(rsp *http.Response) (*UnpauseDeviceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetIdentityBackupResponse(rsp)
-}
 
-func (c *ClientWithResponses) SetIdentityBackupWithResponse(ctx context.Context, body SetIdentityBackupJSONRequestBody, reqEditors ...RequestEditorFn) (*SetIdentityBackupResponse, error) {
-	rsp, err := c.SetIdentityBackup(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &UnpauseDeviceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSetIdentityBackupResponse(rsp)
-}
 
-// SetPublicKeyWithBodyWithResponse request with arbitrary body returning *SetPublicKeyResponse
-func (c *ClientWithResponses) SetPublicKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error) {
-	rsp, err := c.SetPublicKeyWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
-	return ParseSetPublicKeyResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) SetPublicKeyWithResponse(ctx context.Context, body SetPublicKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPublicKeyResponse, error) {
-	rsp, err := c.SetPublicKey(ctx, body, reqEditors...)
+// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
+func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetPublicKeyResponse(rsp)
-}
 
-// GetLocationsWithResponse request returning *GetLocationsResponse
-func (c *ClientWithResponses) GetLocationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLocationsResponse, error) {
-	rsp, err := c.GetLocations(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &StreamEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetLocationsResponse(rsp)
-}
 
-// ShareLocationsWithBodyWithResponse request with arbitrary body returning *ShareLocationsResponse
-func (c *ClientWithResponses) ShareLocationsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error) {
-	rsp, err := c.ShareLocationsWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
 	}
-	return ParseShareLocationsResponse(rsp)
+
+	return response, nil
 }
 
-func (c *ClientWithResponses) ShareLocationsWithResponse(ctx context.Context, body ShareLocationsJSONRequestBody, reqEditors ...RequestEditorFn) (*ShareLocationsResponse, error) {
-	rsp, err := c.ShareLocations(ctx, body, reqEditors...)
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseShareLocationsResponse(rsp)
-}
 
-// GetCurrentUserWithResponse request returning *GetCurrentUserResponse
-func (c *ClientWithResponses) GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error) {
-	rsp, err := c.GetCurrentUser(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetCurrentUserResponse(rsp)
-}
 
-// GetUserDataWithResponse request returning *GetUserDataResponse
-func (c *ClientWithResponses) GetUserDataWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetUserDataResponse, error) {
-	rsp, err := c.GetUserData(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	}
-	return ParseGetUserDataResponse(rsp)
+
+	return response, nil
 }
 
-// SetUserDataWithBodyWithResponse request with arbitrary body returning *SetUserDataResponse
-func (c *ClientWithResponses) SetUserDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error) {
-	rsp, err := c.SetUserDataWithBody(ctx, contentType, body, reqEditors...)
+// ParseGetIdentityBackupResponse parses an HTTP response from a GetIdentityBackupWithResponse call
+func ParseGetIdentityBackupResponse(rsp *http.Response) (*GetIdentityBackupResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseSetUserDataResponse(rsp)
-}
 
-func (c *ClientWithResponses) SetUserDataWithResponse(ctx context.Context, body SetUserDataJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserDataResponse, error) {
-	rsp, err := c.SetUserData(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetIdentityBackupResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseSetUserDataResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IdentityBackup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseDeleteAccountResponse parses an HTTP response from a DeleteAccountWithResponse call
-func ParseDeleteAccountResponse(rsp *http.Response) (*DeleteAccountResponse, error) {
+// ParseSetIdentityBackupResponse parses an HTTP response from a SetIdentityBackupWithResponse call
+func ParseSetIdentityBackupResponse(rsp *http.Response) (*SetIdentityBackupResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteAccountResponse{
+	response := &SetIdentityBackupResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ConflictError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON429 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseLoginWithGoogleResponse parses an HTTP response from a LoginWithGoogleWithResponse call
-func ParseLoginWithGoogleResponse(rsp *http.Response) (*LoginWithGoogleResponse, error) {
+// ParseGetIdentityBackupMetaResponse parses an HTTP response from a GetIdentityBackupMetaWithResponse call
+func ParseGetIdentityBackupMetaResponse(rsp *http.Response) (*GetIdentityBackupMetaResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &LoginWithGoogleResponse{
+	response := &GetIdentityBackupMetaResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest LoginResponse
+		var dest IdentityBackupMeta
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseLogoutResponse parses an HTTP response from a LogoutWithResponse call
-func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
+// ParseSetPublicKeyResponse parses an HTTP response from a SetPublicKeyWithResponse call
+func ParseSetPublicKeyResponse(rsp *http.Response) (*SetPublicKeyResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &LogoutResponse{
+	response := &SetPublicKeyResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2598,22 +8256,22 @@ func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
 	return response, nil
 }
 
-// ParseListContactsResponse parses an HTTP response from a ListContactsWithResponse call
-func ParseListContactsResponse(rsp *http.Response) (*ListContactsResponse, error) {
+// ParseGetLocationsResponse parses an HTTP response from a GetLocationsWithResponse call
+func ParseGetLocationsResponse(rsp *http.Response) (*GetLocationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListContactsResponse{
+	response := &GetLocationsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ContactList
+		var dest LocationList
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2631,26 +8289,26 @@ func ParseListContactsResponse(rsp *http.Response) (*ListContactsResponse, error
 	return response, nil
 }
 
-// ParseSendContactRequestResponse parses an HTTP response from a SendContactRequestWithResponse call
-func ParseSendContactRequestResponse(rsp *http.Response) (*SendContactRequestResponse, error) {
+// ParseShareLocationsResponse parses an HTTP response from a ShareLocationsWithResponse call
+func ParseShareLocationsResponse(rsp *http.Response) (*ShareLocationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SendContactRequestResponse{
+	response := &ShareLocationsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest ContactRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LocationShareResults
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest BadRequest
@@ -2666,12 +8324,12 @@ func ParseSendContactRequestResponse(rsp *http.Response) (*SendContactRequestRes
 		}
 		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON403 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest Error
@@ -2680,32 +8338,46 @@ func ParseSendContactRequestResponse(rsp *http.Response) (*SendContactRequestRes
 		}
 		response.JSON409 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 413:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON413 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseListContactRequestsResponse parses an HTTP response from a ListContactRequestsWithResponse call
-func ParseListContactRequestsResponse(rsp *http.Response) (*ListContactRequestsResponse, error) {
+// ParseGetLocationSnapshotResponse parses an HTTP response from a GetLocationSnapshotWithResponse call
+func ParseGetLocationSnapshotResponse(rsp *http.Response) (*GetLocationSnapshotResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListContactRequestsResponse{
+	response := &GetLocationSnapshotResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ContactRequestList
+		var dest LocationSnapshot
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2718,55 +8390,55 @@ func ParseListContactRequestsResponse(rsp *http.Response) (*ListContactRequestsR
 	return response, nil
 }
 
-// ParseCancelContactRequestResponse parses an HTTP response from a CancelContactRequestWithResponse call
-func ParseCancelContactRequestResponse(rsp *http.Response) (*CancelContactRequestResponse, error) {
+// ParseStreamLocationsResponse parses an HTTP response from a StreamLocationsWithResponse call
+func ParseStreamLocationsResponse(rsp *http.Response) (*StreamLocationsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CancelContactRequestResponse{
+	response := &StreamLocationsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest NotFound
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON401 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseAcceptContactRequestResponse parses an HTTP response from a AcceptContactRequestWithResponse call
-func ParseAcceptContactRequestResponse(rsp *http.Response) (*AcceptContactRequestResponse, error) {
+// ParseGetCurrentUserResponse parses an HTTP response from a GetCurrentUserWithResponse call
+func ParseGetCurrentUserResponse(rsp *http.Response) (*GetCurrentUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &AcceptContactRequestResponse{
+	response := &GetCurrentUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Contact
+		var dest User
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2779,105 +8451,112 @@ func ParseAcceptContactRequestResponse(rsp *http.Response) (*AcceptContactReques
 		}
 		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest NotFound
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseDeclineContactRequestResponse parses an HTTP response from a DeclineContactRequestWithResponse call
-func ParseDeclineContactRequestResponse(rsp *http.Response) (*DeclineContactRequestResponse, error) {
+// ParseUpdateProfileResponse parses an HTTP response from a UpdateProfileWithResponse call
+func ParseUpdateProfileResponse(rsp *http.Response) (*UpdateProfileResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeclineContactRequestResponse{
+	response := &UpdateProfileResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest NotFound
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseRemoveContactResponse parses an HTTP response from a RemoveContactWithResponse call
-func ParseRemoveContactResponse(rsp *http.Response) (*RemoveContactResponse, error) {
+// ParseGetSettingsResponse parses an HTTP response from a GetSettingsWithResponse call
+func ParseGetSettingsResponse(rsp *http.Response) (*GetSettingsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RemoveContactResponse{
+	response := &GetSettingsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest UserSettings
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest NotFound
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON401 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseListDevicesResponse parses an HTTP response from a ListDevicesWithResponse call
-func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error) {
+// ParseUpdateSettingsResponse parses an HTTP response from a UpdateSettingsWithResponse call
+func ParseUpdateSettingsResponse(rsp *http.Response) (*UpdateSettingsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListDevicesResponse{
+	response := &UpdateSettingsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest DeviceList
+		var dest UserSettings
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2890,33 +8569,26 @@ func ParseListDevicesResponse(rsp *http.Response) (*ListDevicesResponse, error)
 	return response, nil
 }
 
-// ParseRegisterDeviceResponse parses an HTTP response from a RegisterDeviceWithResponse call
-func ParseRegisterDeviceResponse(rsp *http.Response) (*RegisterDeviceResponse, error) {
+// ParseGetStorageUsageResponse parses an HTTP response from a GetStorageUsageWithResponse call
+func ParseGetStorageUsageResponse(rsp *http.Response) (*GetStorageUsageResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RegisterDeviceResponse{
+	response := &GetStorageUsageResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest DeviceWithToken
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StorageUsage
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
@@ -2930,20 +8602,27 @@ func ParseRegisterDeviceResponse(rsp *http.Response) (*RegisterDeviceResponse, e
 	return response, nil
 }
 
-// ParseRevokeDeviceResponse parses an HTTP response from a RevokeDeviceWithResponse call
-func ParseRevokeDeviceResponse(rsp *http.Response) (*RevokeDeviceResponse, error) {
+// ParseOnboardResponse parses an HTTP response from a OnboardWithResponse call
+func ParseOnboardResponse(rsp *http.Response) (*OnboardResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RevokeDeviceResponse{
+	response := &OnboardResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2951,34 +8630,34 @@ func ParseRevokeDeviceResponse(rsp *http.Response) (*RevokeDeviceResponse, error
 		}
 		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest NotFound
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON404 = &dest
+		response.JSON409 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParseGetOpenAPISpecResponse parses an HTTP response from a GetOpenAPISpecWithResponse call
+func ParseGetOpenAPISpecResponse(rsp *http.Response) (*GetOpenAPISpecResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &GetOpenAPISpecResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest HealthResponse
+		var dest map[string]interface{}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -2989,22 +8668,22 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	return response, nil
 }
 
-// ParseGetIdentityBackupResponse parses an HTTP response from a GetIdentityBackupWithResponse call
-func ParseGetIdentityBackupResponse(rsp *http.Response) (*GetIdentityBackupResponse, error) {
+// ParseGetPresenceResponse parses an HTTP response from a GetPresenceWithResponse call
+func ParseGetPresenceResponse(rsp *http.Response) (*GetPresenceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetIdentityBackupResponse{
+	response := &GetPresenceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest IdentityBackup
+		var dest PresenceList
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3017,27 +8696,20 @@ func ParseGetIdentityBackupResponse(rsp *http.Response) (*GetIdentityBackupRespo
 		}
 		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseSetIdentityBackupResponse parses an HTTP response from a SetIdentityBackupWithResponse call
-func ParseSetIdentityBackupResponse(rsp *http.Response) (*SetIdentityBackupResponse, error) {
+// ParseSetPresenceResponse parses an HTTP response from a SetPresenceWithResponse call
+func ParseSetPresenceResponse(rsp *http.Response) (*SetPresenceResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SetIdentityBackupResponse{
+	response := &SetPresenceResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -3057,60 +8729,67 @@ func ParseSetIdentityBackupResponse(rsp *http.Response) (*SetIdentityBackupRespo
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseSetPublicKeyResponse parses an HTTP response from a SetPublicKeyWithResponse call
-func ParseSetPublicKeyResponse(rsp *http.Response) (*SetPublicKeyResponse, error) {
+// ParseGetReadinessResponse parses an HTTP response from a GetReadinessWithResponse call
+func ParseGetReadinessResponse(rsp *http.Response) (*GetReadinessResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &SetPublicKeyResponse{
+	response := &GetReadinessResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest BadRequest
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadinessResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ReadinessResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON503 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseGetLocationsResponse parses an HTTP response from a GetLocationsWithResponse call
-func ParseGetLocationsResponse(rsp *http.Response) (*GetLocationsResponse, error) {
+// ParseListSessionsResponse parses an HTTP response from a ListSessionsWithResponse call
+func ParseListSessionsResponse(rsp *http.Response) (*ListSessionsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetLocationsResponse{
+	response := &ListSessionsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest LocationList
+		var dest SessionList
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3128,15 +8807,15 @@ func ParseGetLocationsResponse(rsp *http.Response) (*GetLocationsResponse, error
 	return response, nil
 }
 
-// ParseShareLocationsResponse parses an HTTP response from a ShareLocationsWithResponse call
-func ParseShareLocationsResponse(rsp *http.Response) (*ShareLocationsResponse, error) {
+// ParseRevokeSessionResponse parses an HTTP response from a RevokeSessionWithResponse call
+func ParseRevokeSessionResponse(rsp *http.Response) (*RevokeSessionResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ShareLocationsResponse{
+	response := &RevokeSessionResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -3156,38 +8835,12 @@ func ParseShareLocationsResponse(rsp *http.Response) (*ShareLocationsResponse, e
 		}
 		response.JSON401 = &dest
 
-	}
-
-	return response, nil
-}
-
-// ParseGetCurrentUserResponse parses an HTTP response from a GetCurrentUserWithResponse call
-func ParseGetCurrentUserResponse(rsp *http.Response) (*GetCurrentUserResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
-
-	response := &GetCurrentUserResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
-
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest User
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON404 = &dest
 
 	}
 
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// AllContacts iterates over all the user's contacts, fetching pageSize at a
+// time (0 uses the server default). Contacts added or removed while paging
+// may be skipped or repeated. Iteration stops after the first error, which
+// is yielded with a zero Contact.
+func (c *WhereishClient) AllContacts(ctx context.Context, pageSize int) iter.Seq2[Contact, error] {
+	return func(yield func(Contact, error) bool) {
+		offset := 0
+		for {
+			page, err := c.ListContactsPage(ctx, offset, pageSize)
+			if err != nil {
+				yield(Contact{}, err)
+				return
+			}
+			for _, contact := range page.Contacts {
+				if !yield(contact, nil) {
+					return
+				}
+			}
+			// Servers that don't page return everything with no NextOffset
+			if page.NextOffset == nil || *page.NextOffset <= offset {
+				return
+			}
+			offset = *page.NextOffset
+		}
+	}
+}
+
+// AllLocations iterates over all locations shared with the user, fetching
+// pageSize at a time (0 gets 100). Iteration stops after the
+// first error, which is yielded with a zero EncryptedLocation.
+func (c *WhereishClient) AllLocations(ctx context.Context, pageSize int) iter.Seq2[EncryptedLocation, error] {
+	return func(yield func(EncryptedLocation, error) bool) {
+		cursor := ""
+		for {
+			page, err := c.GetLocationsPage(ctx, cursor, pageSize)
+			if err != nil {
+				yield(EncryptedLocation{}, err)
+				return
+			}
+			for _, loc := range page.Locations {
+				if !yield(loc, nil) {
+					return
+				}
+			}
+			if page.NextCursor == nil {
+				return
+			}
+			cursor = *page.NextCursor
+		}
+	}
+}
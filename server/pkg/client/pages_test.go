@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestAllContacts(t *testing.T) {
+	var contacts []Contact
+	for i := 0; i < 5; i++ {
+		contacts = append(contacts, Contact{Id: fmt.Sprintf("c%d", i), Email: Email(fmt.Sprintf("c%d@example.com", i))})
+	}
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		total := len(contacts)
+		page := ContactList{Contacts: contacts[offset:min(offset+limit, total)], Total: &total}
+		if next := offset + limit; next < len(contacts) {
+			page.NextOffset = &next
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+
+	var ids []string
+	for contact, err := range c.AllContacts(context.Background(), 2) {
+		if err != nil {
+			t.Fatalf("AllContacts failed: %v", err)
+		}
+		ids = append(ids, contact.Id)
+	}
+	if fmt.Sprint(ids) != "[c0 c1 c2 c3 c4]" {
+		t.Errorf("contacts = %v", ids)
+	}
+
+	// Stopping early doesn't fetch further pages
+	for range c.AllContacts(context.Background(), 2) {
+		break
+	}
+}
+
+func TestAllContacts_ServerWithoutPaging(t *testing.T) {
+	c := testClient(t, contactsHandler(
+		Contact{Id: "a", Email: "a@example.com"},
+		Contact{Id: "b", Email: "b@example.com"},
+		Contact{Id: "c", Email: "c@example.com"},
+	))
+
+	n := 0
+	for _, err := range c.AllContacts(context.Background(), 2) {
+		if err != nil {
+			t.Fatalf("AllContacts failed: %v", err)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("got %d contacts, want 3", n)
+	}
+}
+
+func TestAllLocations(t *testing.T) {
+	c := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("limit = %q, want the default 100", r.URL.Query().Get("limit"))
+		}
+		next := "next"
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(LocationList{Locations: []EncryptedLocation{{FromUserId: "a"}}, NextCursor: &next})
+		case "next":
+			json.NewEncoder(w).Encode(LocationList{Locations: []EncryptedLocation{{FromUserId: "b"}}})
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+
+	var from []string
+	for loc, err := range c.AllLocations(context.Background(), 0) {
+		if err != nil {
+			t.Fatalf("AllLocations failed: %v", err)
+		}
+		from = append(from, loc.FromUserId)
+	}
+	if fmt.Sprint(from) != "[a b]" {
+		t.Errorf("locations from %v, want [a b]", from)
+	}
+}
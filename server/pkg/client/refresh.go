@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrNoRefreshToken is returned by Refresh when the client has no refresh
+// token, either because it hasn't logged in or the server doesn't issue them
+var ErrNoRefreshToken = errors.New("no refresh token")
+
+// SetRefreshToken sets the refresh token used to renew the session
+func (c *WhereishClient) SetRefreshToken(refreshToken string) {
+	c.auth.Lock()
+	defer c.auth.Unlock()
+	c.refreshToken = refreshToken
+}
+
+// RefreshToken returns the current refresh token, which changes each time
+// the session is refreshed
+func (c *WhereishClient) RefreshToken() string {
+	c.auth.Lock()
+	defer c.auth.Unlock()
+	return c.refreshToken
+}
+
+// Refresh exchanges the refresh token for a new session. Both tokens are
+// replaced and passed to the OnRefresh callback, if any.
+func (c *WhereishClient) Refresh(ctx context.Context) (*RefreshResponse, error) {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+	return c.refresh(ctx)
+}
+
+// refresh does the work of Refresh; callers hold c.refreshing
+func (c *WhereishClient) refresh(ctx context.Context) (*RefreshResponse, error) {
+	refreshToken := c.RefreshToken()
+	if refreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	body, err := jsonBody(RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/auth/refresh", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.setClientHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	var refreshed RefreshResponse
+	if err := json.NewDecoder(resp.Body).Decode(&refreshed); err != nil {
+		return nil, err
+	}
+	c.setTokens(refreshed.Token, refreshed.RefreshToken)
+	if c.onRefresh != nil {
+		c.onRefresh(refreshed.Token, refreshed.RefreshToken)
+	}
+	return &refreshed, nil
+}
+
+// accessToken returns the current session token
+func (c *WhereishClient) accessToken() string {
+	c.auth.Lock()
+	defer c.auth.Unlock()
+	return c.token
+}
+
+// setTokens replaces the session and refresh tokens together
+func (c *WhereishClient) setTokens(token, refreshToken string) {
+	c.auth.Lock()
+	defer c.auth.Unlock()
+	c.token = token
+	c.refreshToken = refreshToken
+}
+
+// storeLogin keeps the tokens from a login. A server that doesn't issue
+// refresh tokens leaves the client without one.
+func (c *WhereishClient) storeLogin(login *LoginResponse) {
+	refreshToken := ""
+	if login.RefreshToken != nil {
+		refreshToken = *login.RefreshToken
+	}
+	c.setTokens(login.Token, refreshToken)
+}
+
+// sendAuth sends req with the session token. If the server rejects the
+// token and the client holds a refresh token, the session is refreshed and
+// the request retried once. A request whose body can't be replayed isn't
+// retried.
+func (c *WhereishClient) sendAuth(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	token := c.accessToken()
+	setBearer(req, token)
+	resp, err := c.send(httpClient, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.RefreshToken() == "" {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	if !c.refreshAfter(req.Context(), token) {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	setBearer(retry, c.accessToken())
+	return c.send(httpClient, retry)
+}
+
+// refreshAfter refreshes the session after staleToken was rejected, unless
+// another request already has. Reports whether there's a new token to
+// retry with.
+func (c *WhereishClient) refreshAfter(ctx context.Context, staleToken string) bool {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+	if c.accessToken() != staleToken {
+		return true
+	}
+	_, err := c.refresh(ctx)
+	return err == nil
+}
+
+// setBearer sets or clears a request's Authorization header
+func setBearer(req *http.Request, token string) {
+	if token == "" {
+		req.Header.Del("Authorization")
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// replayable buffers a request body so sendAuth can resend it
+func replayable(body io.Reader) (io.Reader, error) {
+	if body == nil {
+		return nil, nil
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// refreshHandler accepts session "token-N" for the latest N and rotates
+// "refresh-N" into "token-N+1" and "refresh-N+1". Profile updates echo the
+// name sent.
+type refreshHandler struct {
+	generation int
+}
+
+func (h *refreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/auth/refresh" {
+		var req RefreshRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.RefreshToken != "refresh-"+strconv.Itoa(h.generation) {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":{"code":"invalid_refresh_token","message":"Invalid refresh token"}}`)
+			return
+		}
+		h.generation++
+		json.NewEncoder(w).Encode(RefreshResponse{
+			Token:        "token-" + strconv.Itoa(h.generation),
+			RefreshToken: "refresh-" + strconv.Itoa(h.generation),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer token-"+strconv.Itoa(h.generation) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var update ProfileUpdate
+	json.NewDecoder(r.Body).Decode(&update)
+	json.NewEncoder(w).Encode(User{Id: "user-1", Name: update.Name, Email: "test@example.com"})
+}
+
+func TestAutoRefresh(t *testing.T) {
+	h := &refreshHandler{}
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+
+	var saved []string
+	c := NewWhereishClient(ClientConfig{
+		BaseURL:      ts.URL,
+		Token:        "expired",
+		RefreshToken: "refresh-0",
+		OnRefresh: func(token, refreshToken string) {
+			saved = append(saved, token, refreshToken)
+		},
+	})
+	ctx := context.Background()
+
+	// The rejected request is retried with the new token, body and all
+	user, err := c.UpdateProfile(ctx, "Alice")
+	if err != nil {
+		t.Fatalf("UpdateProfile failed: %v", err)
+	}
+	if user.Name != "Alice" {
+		t.Errorf("retried request sent name %q, want Alice", user.Name)
+	}
+	if len(saved) != 2 || saved[0] != "token-1" || saved[1] != "refresh-1" {
+		t.Errorf("OnRefresh got %v", saved)
+	}
+	if c.RefreshToken() != "refresh-1" {
+		t.Errorf("RefreshToken = %q, want refresh-1", c.RefreshToken())
+	}
+
+	// A refresh token the server rejects leaves the original 401
+	c.SetToken("expired")
+	c.SetRefreshToken("stolen")
+	var apiErr *APIError
+	if _, err := c.GetCurrentUser(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GetCurrentUser with a bad refresh token: err = %v, want 401", err)
+	}
+	if h.generation != 1 {
+		t.Errorf("refreshed %d times, want 1", h.generation)
+	}
+
+	// Without a refresh token there's nothing to try
+	c.SetRefreshToken("")
+	if _, err := c.Refresh(ctx); !errors.Is(err, ErrNoRefreshToken) {
+		t.Errorf("Refresh without a token: err = %v, want ErrNoRefreshToken", err)
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	maxRetryDelay         = 10 * time.Second
+)
+
+// send performs req, retrying up to c.retries times with exponential
+// backoff when the connection fails or the server returns a 5xx. Only
+// idempotent methods are retried unless the client opted in to retrying
+// everything. A retry that wouldn't start before the request's deadline
+// isn't attempted; the last response or error is returned instead.
+func (c *WhereishClient) send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	if c.retries <= 0 || !c.retryable(req) {
+		return httpClient.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := httpClient.Do(req)
+		if attempt == c.retries || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		next := req.Clone(ctx)
+		if req.GetBody != nil {
+			if next.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		req = next
+	}
+}
+
+// retryable reports whether req may be sent more than once
+func (c *WhereishClient) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	}
+	return c.retryAll
+}
+
+// shouldRetry reports whether a failed attempt is worth repeating: the
+// connection failed, other than by the caller giving up, or the server or
+// a proxy in front of it had a problem
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= 500
+}
+
+// backoff returns how long to wait before retry attempt+1: the base delay
+// doubled per attempt, with jitter so clients that failed together don't
+// retry together. A Retry-After from the server is honored if longer.
+func (c *WhereishClient) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := min(c.retryBaseDelay<<min(attempt, 16), maxRetryDelay)
+	delay = delay/2 + rand.N(delay/2+1)
+
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			delay = max(delay, min(time.Duration(secs)*time.Second, maxRetryDelay))
+		}
+	}
+	return delay
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// flakyHandler fails the first failures requests with 502, then serves a
+// contact list or echoes a contact request back
+type flakyHandler struct {
+	failures int
+	requests int
+	bodies   []string
+}
+
+func (h *flakyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.requests++
+	var req ContactRequestCreate
+	json.NewDecoder(r.Body).Decode(&req)
+	h.bodies = append(h.bodies, string(req.Email))
+	if h.requests <= h.failures {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(ContactRequest{Id: "req-1", Email: req.Email, Status: "pending", CreatedAt: time.Now()})
+		return
+	}
+	json.NewEncoder(w).Encode(ContactList{Contacts: []Contact{}})
+}
+
+func retryClient(t *testing.T, h http.Handler, cfg ClientConfig) *WhereishClient {
+	t.Helper()
+	ts := httptest.NewServer(h)
+	t.Cleanup(ts.Close)
+	cfg.BaseURL = ts.URL
+	cfg.Token = "test-token"
+	if cfg.RetryBaseDelay == 0 {
+		cfg.RetryBaseDelay = time.Millisecond
+	}
+	return NewWhereishClient(cfg)
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	// Idempotent requests are retried until they succeed...
+	h := &flakyHandler{failures: 2}
+	c := retryClient(t, h, ClientConfig{Retries: 3})
+	if _, err := c.ListContacts(ctx); err != nil {
+		t.Errorf("ListContacts failed: %v", err)
+	}
+	if h.requests != 3 {
+		t.Errorf("requests = %d, want 3", h.requests)
+	}
+
+	// ...or run out of retries
+	h = &flakyHandler{failures: 5}
+	c = retryClient(t, h, ClientConfig{Retries: 2})
+	var apiErr *APIError
+	if _, err := c.ListContacts(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("ListContacts error = %v, want 502", err)
+	}
+	if h.requests != 3 {
+		t.Errorf("requests = %d, want 3", h.requests)
+	}
+
+	// POSTs aren't retried unless opted in, then are with the same body
+	h = &flakyHandler{failures: 1}
+	c = retryClient(t, h, ClientConfig{Retries: 3})
+	if _, err := c.SendContactRequest(ctx, "bob@example.com"); err == nil {
+		t.Error("SendContactRequest succeeded without retrying")
+	}
+	h = &flakyHandler{failures: 1}
+	c = retryClient(t, h, ClientConfig{Retries: 3, RetryNonIdempotent: true})
+	if _, err := c.SendContactRequest(ctx, "bob@example.com"); err != nil {
+		t.Errorf("SendContactRequest with retries failed: %v", err)
+	}
+	if len(h.bodies) != 2 || h.bodies[1] != "bob@example.com" {
+		t.Errorf("request bodies = %q, want the email twice", h.bodies)
+	}
+}
+
+func TestRetry_Deadline(t *testing.T) {
+	h := &flakyHandler{failures: 5}
+	c := retryClient(t, h, ClientConfig{Retries: 5, RetryBaseDelay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.ListContacts(ctx); err == nil {
+		t.Error("ListContacts succeeded, want the 502")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second/2 {
+		t.Errorf("took %s; retries should stop at the deadline", elapsed)
+	}
+	if h.requests != 1 {
+		t.Errorf("requests = %d, want 1", h.requests)
+	}
+}
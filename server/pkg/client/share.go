@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/whereish/server/pkg/crypto"
+)
+
+// ErrMissingPublicKey is returned when a contact can't be shared with
+// because they haven't published a public key
+var ErrMissingPublicKey = errors.New("contact has no public key")
+
+// SkipReason explains why a contact didn't receive a location share
+type SkipReason string
+
+const (
+	SkipNoPublicKey   SkipReason = "no_public_key"  // contact hasn't published a key
+	SkipEncryptFailed SkipReason = "encrypt_failed" // contact's key couldn't be used
+	SkipRejected      SkipReason = "rejected"       // server refused this recipient
+	SkipNotContact    SkipReason = "not_a_contact"  // recipient removed the sender as a contact
+)
+
+// SkippedContact is a contact that didn't receive a location share
+type SkippedContact struct {
+	Contact Contact
+	Reason  SkipReason
+	Err     error // underlying error, nil for SkipNoPublicKey
+}
+
+// ShareReport lists who received a location share and who was skipped
+type ShareReport struct {
+	Shared  []Contact
+	Skipped []SkippedContact
+}
+
+// ShareOptions controls ShareLocationEncrypted
+type ShareOptions struct {
+	// SkipWithoutKey skips contacts without a public key. When false, a
+	// missing key fails the share before anything is uploaded.
+	SkipWithoutKey bool
+
+	// Precision labels the shares so recipients know how precise they are
+	// without decrypting them. Empty leaves it to the server default, exact.
+	Precision LocationPrecision
+
+	// TTL makes the shares expire after this long. Zero keeps them until
+	// they're replaced.
+	TTL time.Duration
+
+	// Contacts overrides sharing for particular contacts, keyed by contact
+	// ID, to share less precisely with some of them
+	Contacts map[string]ContactShareOptions
+}
+
+// ContactShareOptions controls sharing with one contact
+type ContactShareOptions struct {
+	// Levels are the hierarchy levels the contact may see; see
+	// crypto.LocationData.Restrict. Empty shares the whole location.
+	Levels []string
+
+	// Precision labels this contact's share, overriding
+	// ShareOptions.Precision when set
+	Precision LocationPrecision
+}
+
+// DefaultShareOptions returns the options used when none are given
+func DefaultShareOptions() ShareOptions {
+	return ShareOptions{SkipWithoutKey: true}
+}
+
+// ShareLocationEncrypted encrypts the location to each contact and uploads
+// it, reporting which contacts were skipped and why. A nil opts uses
+// DefaultShareOptions.
+func (c *WhereishClient) ShareLocationEncrypted(ctx context.Context, identity *crypto.Identity, loc *crypto.LocationData, contacts []Contact, opts *ShareOptions) (*ShareReport, error) {
+	if opts == nil {
+		defaults := DefaultShareOptions()
+		opts = &defaults
+	}
+
+	var expiresAt *time.Time
+	if opts.TTL > 0 {
+		t := time.Now().Add(opts.TTL)
+		expiresAt = &t
+	}
+
+	report := &ShareReport{}
+	var shares []LocationShare
+	pending := make(map[string]Contact)
+	for _, contact := range contacts {
+		if contact.PublicKey == "" {
+			if !opts.SkipWithoutKey {
+				return nil, fmt.Errorf("%s: %w", contact.Name, ErrMissingPublicKey)
+			}
+			report.Skipped = append(report.Skipped, SkippedContact{Contact: contact, Reason: SkipNoPublicKey})
+			continue
+		}
+
+		override := opts.Contacts[contact.Id]
+		encrypted, err := crypto.EncryptLocation(loc, identity, contact.PublicKey, override.Levels...)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedContact{Contact: contact, Reason: SkipEncryptFailed, Err: err})
+			continue
+		}
+
+		share := LocationShare{ToUserId: contact.Id, Blob: encrypted, ExpiresAt: expiresAt}
+		precision := opts.Precision
+		if override.Precision != "" {
+			precision = override.Precision
+		}
+		if precision != "" {
+			share.Precision = &precision
+		}
+		shares = append(shares, share)
+		pending[contact.Id] = contact
+	}
+
+	if len(shares) == 0 {
+		return report, nil
+	}
+
+	results, err := c.ShareLocationsPartial(ctx, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results.Results {
+		contact, ok := pending[result.ToUserId]
+		if !ok {
+			continue
+		}
+		if result.Ok {
+			report.Shared = append(report.Shared, contact)
+			continue
+		}
+		code := "unknown"
+		if result.Error != nil {
+			code = *result.Error
+		}
+		reason := SkipRejected
+		if code == string(SkipNotContact) {
+			reason = SkipNotContact
+		}
+		report.Skipped = append(report.Skipped, SkippedContact{
+			Contact: contact,
+			Reason:  reason,
+			Err:     errors.New(code),
+		})
+	}
+
+	return report, nil
+}
@@ -11,8 +11,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
 	"golang.org/x/crypto/pbkdf2"
 )
 
@@ -23,25 +27,52 @@ const (
 	KeySize          = 32 // AES-256
 	NonceSize        = 12 // AES-GCM nonce
 
+	// Argon2id parameters for PIN-derived key (memory in KiB)
+	Argon2Time        = 3
+	Argon2Memory      = 64 * 1024
+	Argon2Parallelism = 4
+
+	// Identity backup key derivation functions
+	KDFPBKDF2   = "PBKDF2-SHA256"
+	KDFArgon2id = "argon2id"
+
 	// NaCl key sizes
 	PublicKeySize  = 32
 	PrivateKeySize = 32
+
+	// User data encryption schemes
+	UserDataSchemeBox       = "box"       // NaCl box to self (identity keypair)
+	UserDataSchemeSecretbox = "secretbox" // NaCl secretbox with a symmetric key
+)
+
+// Limits on the Argon2id parameters a backup can ask for, so a crafted
+// backup can't exhaust memory or stall decryption
+const (
+	maxArgon2Time   = 64
+	maxArgon2Memory = 1024 * 1024
 )
 
+// secretboxPrefix marks secretbox user data. Box-encrypted user data is
+// plain base64 and predates the envelope, so it has no prefix.
+const secretboxPrefix = UserDataSchemeSecretbox + ":"
+
 // Identity holds the user's X25519 keypair
 type Identity struct {
 	PublicKey  [PublicKeySize]byte
 	PrivateKey [PrivateKeySize]byte
 }
 
-// IdentityBackup is the encrypted identity structure
+// IdentityBackup is the encrypted identity structure. For Argon2id,
+// Iterations is the time cost.
 type IdentityBackup struct {
-	Algorithm  string `json:"algorithm"`
-	KDF        string `json:"kdf"`
-	Iterations int    `json:"iterations"`
-	Salt       string `json:"salt"`       // Base64
-	IV         string `json:"iv"`         // Base64
-	Payload    string `json:"payload"`    // Base64 ciphertext
+	Algorithm   string `json:"algorithm"`
+	KDF         string `json:"kdf"`
+	Iterations  int    `json:"iterations"`
+	Memory      int    `json:"memory,omitempty"`      // Argon2id memory in KiB
+	Parallelism int    `json:"parallelism,omitempty"` // Argon2id threads
+	Salt        string `json:"salt"`                  // Base64
+	IV          string `json:"iv"`                    // Base64
+	Payload     string `json:"payload"`               // Base64 ciphertext
 }
 
 // identityPayload is the decrypted identity structure
@@ -72,16 +103,38 @@ func (id *Identity) PrivateKeyBase64() string {
 	return base64.StdEncoding.EncodeToString(id.PrivateKey[:])
 }
 
-// EncryptIdentity encrypts the identity with a PIN-derived key
+// EncryptIdentity encrypts the identity with a PBKDF2 PIN-derived key
 func EncryptIdentity(identity *Identity, pin string) (*IdentityBackup, error) {
+	return EncryptIdentityKDF(identity, pin, KDFPBKDF2)
+}
+
+// EncryptIdentityKDF encrypts the identity with a key derived from the PIN
+// by kdf, KDFPBKDF2 or KDFArgon2id, using this package's default parameters
+func EncryptIdentityKDF(identity *Identity, pin, kdf string) (*IdentityBackup, error) {
+	backup := &IdentityBackup{Algorithm: "AES-256-GCM", KDF: kdf}
+	switch kdf {
+	case KDFPBKDF2:
+		backup.Iterations = PBKDF2Iterations
+	case KDFArgon2id:
+		backup.Iterations = Argon2Time
+		backup.Memory = Argon2Memory
+		backup.Parallelism = Argon2Parallelism
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q", kdf)
+	}
+
 	// Generate random salt
 	salt := make([]byte, SaltSize)
 	if _, err := rand.Read(salt); err != nil {
 		return nil, fmt.Errorf("generate salt: %w", err)
 	}
 
-	// Derive key from PIN using PBKDF2
-	key := pbkdf2.Key([]byte(pin), salt, PBKDF2Iterations, KeySize, sha256.New)
+	// Derive key from PIN
+	key, err := deriveBackupKey(backup, pin, salt)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(key)
 
 	// Create AES-GCM cipher
 	block, err := aes.NewCipher(key)
@@ -113,14 +166,44 @@ func EncryptIdentity(identity *Identity, pin string) (*IdentityBackup, error) {
 	// Encrypt
 	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
 
-	return &IdentityBackup{
-		Algorithm:  "AES-256-GCM",
-		KDF:        "PBKDF2-SHA256",
-		Iterations: PBKDF2Iterations,
-		Salt:       base64.StdEncoding.EncodeToString(salt),
-		IV:         base64.StdEncoding.EncodeToString(nonce),
-		Payload:    base64.StdEncoding.EncodeToString(ciphertext),
-	}, nil
+	backup.Salt = base64.StdEncoding.EncodeToString(salt)
+	backup.IV = base64.StdEncoding.EncodeToString(nonce)
+	backup.Payload = base64.StdEncoding.EncodeToString(ciphertext)
+	return backup, nil
+}
+
+// deriveBackupKey derives an identity backup's key from the PIN with the
+// backup's KDF. Backups with no KDF predate the field and use PBKDF2.
+func deriveBackupKey(backup *IdentityBackup, pin string, salt []byte) ([]byte, error) {
+	switch backup.KDF {
+	case KDFPBKDF2, "":
+		iterations := backup.Iterations
+		if iterations == 0 {
+			iterations = PBKDF2Iterations
+		}
+		return pbkdf2.Key([]byte(pin), salt, iterations, KeySize, sha256.New), nil
+	case KDFArgon2id:
+		if err := ValidateArgon2Params(backup.Iterations, backup.Memory, backup.Parallelism); err != nil {
+			return nil, err
+		}
+		return argon2.IDKey([]byte(pin), salt, uint32(backup.Iterations), uint32(backup.Memory), uint8(backup.Parallelism), KeySize), nil
+	}
+	return nil, fmt.Errorf("unsupported KDF %q", backup.KDF)
+}
+
+// ValidateArgon2Params checks Argon2id parameters against the limits
+// backups may ask for. memory is in KiB.
+func ValidateArgon2Params(timeCost, memory, parallelism int) error {
+	if timeCost < 1 || timeCost > maxArgon2Time {
+		return fmt.Errorf("argon2id time %d out of range", timeCost)
+	}
+	if parallelism < 1 || parallelism > 255 {
+		return fmt.Errorf("argon2id parallelism %d out of range", parallelism)
+	}
+	if memory < 8*parallelism || memory > maxArgon2Memory {
+		return fmt.Errorf("argon2id memory %d KiB out of range", memory)
+	}
+	return nil
 }
 
 // DecryptIdentity decrypts the identity backup with a PIN
@@ -142,11 +225,11 @@ func DecryptIdentity(backup *IdentityBackup, pin string) (*Identity, error) {
 	}
 
 	// Derive key from PIN
-	iterations := backup.Iterations
-	if iterations == 0 {
-		iterations = PBKDF2Iterations
+	key, err := deriveBackupKey(backup, pin, salt)
+	if err != nil {
+		return nil, err
 	}
-	key := pbkdf2.Key([]byte(pin), salt, iterations, KeySize, sha256.New)
+	defer wipe(key)
 
 	// Create AES-GCM cipher
 	block, err := aes.NewCipher(key)
@@ -193,16 +276,113 @@ func DecryptIdentity(backup *IdentityBackup, pin string) (*Identity, error) {
 	return identity, nil
 }
 
+// VerifyPIN reports whether pin decrypts the identity backup. It runs the
+// same key derivation and authenticated decryption as DecryptIdentity, but
+// wipes the plaintext and key and returns only the result, so callers can
+// check a PIN before a destructive change without handling key material.
+// GCM checks the tag in constant time; a wrong PIN and a right one do the
+// same work up to that point.
+func VerifyPIN(backup *IdentityBackup, pin string) bool {
+	salt, saltErr := base64.StdEncoding.DecodeString(backup.Salt)
+	nonce, nonceErr := base64.StdEncoding.DecodeString(backup.IV)
+	ciphertext, payloadErr := base64.StdEncoding.DecodeString(backup.Payload)
+	if saltErr != nil || nonceErr != nil || payloadErr != nil || len(nonce) != NonceSize {
+		return false
+	}
+
+	key, err := deriveBackupKey(backup, pin, salt)
+	if err != nil {
+		return false
+	}
+	defer wipe(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return false
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return false
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	wipe(plaintext)
+	return err == nil
+}
+
+// wipe zeroes b
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// HierarchyLevels lists location hierarchy keys from most to least specific,
+// matching the levels used by the web client
+var HierarchyLevels = []string{
+	"address", "street", "neighborhood", "city", "county",
+	"state", "country", "continent", "planet",
+}
+
+// Coordinates is an optional precise position within a location share
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
 // LocationData is the plaintext location structure
 type LocationData struct {
 	Hierarchy     map[string]string `json:"hierarchy"`
 	NamedLocation string            `json:"namedLocation,omitempty"`
+	Coordinates   *Coordinates      `json:"coordinates,omitempty"`
 	Timestamp     string            `json:"timestamp"`
 }
 
+// LevelsFrom returns level and every coarser hierarchy level, the levels to
+// allow when sharing no more precisely than level. Returns nil for a level
+// not in HierarchyLevels.
+func LevelsFrom(level string) []string {
+	for i, l := range HierarchyLevels {
+		if l == level {
+			return HierarchyLevels[i:]
+		}
+	}
+	return nil
+}
+
+// Restrict returns a copy of the location with only the allowed hierarchy
+// levels. Coordinates and the named location can pin down more than any
+// level, so they're kept only if the most specific level is allowed.
+func (d *LocationData) Restrict(allowed []string) *LocationData {
+	keep := make(map[string]bool)
+	for _, level := range allowed {
+		keep[level] = true
+	}
+
+	restricted := &LocationData{
+		Hierarchy: make(map[string]string),
+		Timestamp: d.Timestamp,
+	}
+	for level, value := range d.Hierarchy {
+		if keep[level] {
+			restricted.Hierarchy[level] = value
+		}
+	}
+	if keep[HierarchyLevels[0]] {
+		restricted.NamedLocation = d.NamedLocation
+		restricted.Coordinates = d.Coordinates
+	}
+	return restricted
+}
+
 // EncryptLocation encrypts location data using NaCl box
-// sender is your identity, recipientPubKey is base64-encoded
-func EncryptLocation(data *LocationData, sender *Identity, recipientPubKeyB64 string) (string, error) {
+// sender is your identity, recipientPubKey is base64-encoded. If allowed
+// levels are given, the location is restricted to them before encrypting.
+func EncryptLocation(data *LocationData, sender *Identity, recipientPubKeyB64 string, allowed ...string) (string, error) {
+	if len(allowed) > 0 {
+		data = data.Restrict(allowed)
+	}
+
 	// Decode recipient public key
 	recipientPubKeyBytes, err := base64.StdEncoding.DecodeString(recipientPubKeyB64)
 	if err != nil {
@@ -279,6 +459,28 @@ func DecryptLocation(encryptedB64 string, recipient *Identity, senderPubKeyB64 s
 	return &data, nil
 }
 
+// ErrTimestampOutOfRange is returned for location timestamps too far from now
+var ErrTimestampOutOfRange = errors.New("location timestamp out of range")
+
+// ValidateLocationTimestamp checks that a decrypted location's timestamp is
+// valid RFC 3339 and within maxSkew of the current time. The timestamp is
+// set by the sender, so a failure means a clock or client bug, or tampering.
+func ValidateLocationTimestamp(data *LocationData, maxSkew time.Duration) error {
+	ts, err := time.Parse(time.RFC3339, data.Timestamp)
+	if err != nil {
+		return fmt.Errorf("parse timestamp: %w", err)
+	}
+
+	skew := time.Since(ts)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return fmt.Errorf("%w: %s", ErrTimestampOutOfRange, data.Timestamp)
+	}
+	return nil
+}
+
 // EncryptUserData encrypts user data using NaCl box to self
 func EncryptUserData(data []byte, identity *Identity) (string, error) {
 	// Generate random nonce
@@ -295,6 +497,10 @@ func EncryptUserData(data []byte, identity *Identity) (string, error) {
 
 // DecryptUserData decrypts user data encrypted with NaCl box to self
 func DecryptUserData(encryptedB64 string, identity *Identity) ([]byte, error) {
+	if UserDataScheme(encryptedB64) != UserDataSchemeBox {
+		return nil, errors.New("user data is not box-encrypted")
+	}
+
 	// Decode encrypted data
 	encrypted, err := base64.StdEncoding.DecodeString(encryptedB64)
 	if err != nil {
@@ -317,3 +523,100 @@ func DecryptUserData(encryptedB64 string, identity *Identity) ([]byte, error) {
 
 	return plaintext, nil
 }
+
+// UserDataScheme returns the scheme an encrypted user data blob uses
+func UserDataScheme(encrypted string) string {
+	if strings.HasPrefix(encrypted, secretboxPrefix) {
+		return UserDataSchemeSecretbox
+	}
+	return UserDataSchemeBox
+}
+
+// DeriveUserDataKey derives a symmetric user data key from a PIN
+func DeriveUserDataKey(pin string, salt []byte) *[KeySize]byte {
+	var key [KeySize]byte
+	copy(key[:], pbkdf2.Key([]byte(pin), salt, PBKDF2Iterations, KeySize, sha256.New))
+	return &key
+}
+
+// UserDataKeySalt returns the salt for a user's PIN-derived user data key.
+// It is derived from the user ID so it stays fixed across PIN changes and
+// differs from the identity backup salt.
+func UserDataKeySalt(userID string) []byte {
+	sum := sha256.Sum256([]byte("whereish user data:" + userID))
+	return sum[:SaltSize]
+}
+
+// EncryptUserDataSecretbox encrypts user data using NaCl secretbox, so it
+// can be re-encrypted under a new key without rotating the identity
+func EncryptUserDataSecretbox(data []byte, key *[KeySize]byte) (string, error) {
+	// Generate random nonce
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	encrypted := secretbox.Seal(nonce[:], data, &nonce, key)
+
+	return secretboxPrefix + base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// DecryptUserDataSecretbox decrypts user data encrypted with NaCl secretbox
+func DecryptUserDataSecretbox(encrypted string, key *[KeySize]byte) ([]byte, error) {
+	if UserDataScheme(encrypted) != UserDataSchemeSecretbox {
+		return nil, errors.New("user data is not secretbox-encrypted")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, secretboxPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("decode encrypted data: %w", err)
+	}
+
+	if len(raw) < 24 {
+		return nil, errors.New("encrypted data too short")
+	}
+
+	// Extract nonce (first 24 bytes)
+	var nonce [24]byte
+	copy(nonce[:], raw[:24])
+
+	plaintext, ok := secretbox.Open(nil, raw[24:], &nonce, key)
+	if !ok {
+		return nil, errors.New("decryption failed")
+	}
+
+	return plaintext, nil
+}
+
+// SelfTest verifies that key generation and NaCl box encryption work,
+// e.g. that the system entropy source is available. It does not exercise
+// PBKDF2 since that is deliberately slow.
+func SelfTest() error {
+	alice, err := GenerateIdentity()
+	if err != nil {
+		return err
+	}
+	bob, err := GenerateIdentity()
+	if err != nil {
+		return err
+	}
+
+	data := &LocationData{
+		Hierarchy: map[string]string{"city": "selftest"},
+		Timestamp: "1970-01-01T00:00:00Z",
+	}
+	encrypted, err := EncryptLocation(data, alice, bob.PublicKeyBase64())
+	if err != nil {
+		return err
+	}
+
+	decrypted, err := DecryptLocation(encrypted, bob, alice.PublicKeyBase64())
+	if err != nil {
+		return err
+	}
+	if decrypted.Hierarchy["city"] != "selftest" {
+		return errors.New("self-test round trip mismatch")
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserDataSecretbox_RoundTrip(t *testing.T) {
+	key := DeriveUserDataKey("1234", []byte("0123456789abcdef"))
+	data := []byte(`{"places":["home","work"]}`)
+
+	encrypted, err := EncryptUserDataSecretbox(data, key)
+	if err != nil {
+		t.Fatalf("EncryptUserDataSecretbox failed: %v", err)
+	}
+	if scheme := UserDataScheme(encrypted); scheme != UserDataSchemeSecretbox {
+		t.Errorf("scheme = %q, want %q", scheme, UserDataSchemeSecretbox)
+	}
+
+	// The same PIN and salt derive the same key
+	decrypted, err := DecryptUserDataSecretbox(encrypted, DeriveUserDataKey("1234", []byte("0123456789abcdef")))
+	if err != nil {
+		t.Fatalf("DecryptUserDataSecretbox failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Errorf("decrypted = %q, want %q", decrypted, data)
+	}
+}
+
+func TestUserDataSecretbox_WrongKey(t *testing.T) {
+	encrypted, err := EncryptUserDataSecretbox([]byte("secret"), DeriveUserDataKey("1234", []byte("salt")))
+	if err != nil {
+		t.Fatalf("EncryptUserDataSecretbox failed: %v", err)
+	}
+
+	if _, err := DecryptUserDataSecretbox(encrypted, DeriveUserDataKey("4321", []byte("salt"))); err == nil {
+		t.Error("expected decryption with the wrong key to fail")
+	}
+}
+
+func TestUserDataScheme_Box(t *testing.T) {
+	identity, err := GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+
+	encrypted, err := EncryptUserData([]byte("secret"), identity)
+	if err != nil {
+		t.Fatalf("EncryptUserData failed: %v", err)
+	}
+	if scheme := UserDataScheme(encrypted); scheme != UserDataSchemeBox {
+		t.Errorf("scheme = %q, want %q", scheme, UserDataSchemeBox)
+	}
+
+	// Each decrypt path rejects the other scheme
+	if _, err := DecryptUserDataSecretbox(encrypted, DeriveUserDataKey("1234", []byte("salt"))); err == nil {
+		t.Error("expected secretbox decrypt of box data to fail")
+	}
+	sb, _ := EncryptUserDataSecretbox([]byte("secret"), DeriveUserDataKey("1234", []byte("salt")))
+	if _, err := DecryptUserData(sb, identity); err == nil {
+		t.Error("expected box decrypt of secretbox data to fail")
+	}
+}
+
+func TestValidateLocationTimestamp(t *testing.T) {
+	now := &LocationData{Timestamp: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)}
+	if err := ValidateLocationTimestamp(now, time.Hour); err != nil {
+		t.Errorf("near-now timestamp flagged: %v", err)
+	}
+
+	future := &LocationData{Timestamp: time.Now().AddDate(1, 0, 0).UTC().Format(time.RFC3339)}
+	if err := ValidateLocationTimestamp(future, time.Hour); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("expected ErrTimestampOutOfRange for far-future timestamp, got %v", err)
+	}
+
+	past := &LocationData{Timestamp: time.Now().AddDate(-1, 0, 0).UTC().Format(time.RFC3339)}
+	if err := ValidateLocationTimestamp(past, time.Hour); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("expected ErrTimestampOutOfRange for far-past timestamp, got %v", err)
+	}
+
+	if err := ValidateLocationTimestamp(&LocationData{Timestamp: "yesterday"}, time.Hour); err == nil {
+		t.Error("expected error for unparseable timestamp")
+	}
+}
+
+func TestVerifyPIN(t *testing.T) {
+	identity, _ := GenerateIdentity()
+	backup, err := EncryptIdentity(identity, "1234")
+	if err != nil {
+		t.Fatalf("EncryptIdentity failed: %v", err)
+	}
+	original := *backup
+
+	if !VerifyPIN(backup, "1234") {
+		t.Error("correct PIN rejected")
+	}
+	if VerifyPIN(backup, "0000") {
+		t.Error("wrong PIN accepted")
+	}
+	if *backup != original {
+		t.Error("VerifyPIN modified the backup")
+	}
+
+	tampered := *backup
+	tampered.Payload = "AAAA" + backup.Payload[4:]
+	if VerifyPIN(&tampered, "1234") {
+		t.Error("tampered payload accepted")
+	}
+	malformed := *backup
+	malformed.IV = "not base64!"
+	if VerifyPIN(&malformed, "1234") {
+		t.Error("malformed IV accepted")
+	}
+}
+
+func TestIdentityBackup_KDFs(t *testing.T) {
+	identity, err := GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+
+	for _, kdf := range []string{KDFPBKDF2, KDFArgon2id} {
+		backup, err := EncryptIdentityKDF(identity, "1234", kdf)
+		if err != nil {
+			t.Fatalf("%s: EncryptIdentityKDF failed: %v", kdf, err)
+		}
+		if backup.KDF != kdf {
+			t.Errorf("%s: backup KDF = %q", kdf, backup.KDF)
+		}
+
+		got, err := DecryptIdentity(backup, "1234")
+		if err != nil {
+			t.Fatalf("%s: DecryptIdentity failed: %v", kdf, err)
+		}
+		if got.PrivateKey != identity.PrivateKey {
+			t.Errorf("%s: decrypted identity doesn't match", kdf)
+		}
+		if _, err := DecryptIdentity(backup, "0000"); err == nil {
+			t.Errorf("%s: wrong PIN decrypted the backup", kdf)
+		}
+		if !VerifyPIN(backup, "1234") {
+			t.Errorf("%s: correct PIN rejected", kdf)
+		}
+	}
+
+	backup, _ := EncryptIdentityKDF(identity, "1234", KDFArgon2id)
+	if backup.Memory != Argon2Memory || backup.Parallelism != Argon2Parallelism || backup.Iterations != Argon2Time {
+		t.Errorf("argon2id parameters = %d/%d/%d, want the defaults", backup.Iterations, backup.Memory, backup.Parallelism)
+	}
+	backup.Memory = maxArgon2Memory + 1
+	if _, err := DecryptIdentity(backup, "1234"); err == nil {
+		t.Error("DecryptIdentity accepted argon2id memory above the limit")
+	}
+
+	if _, err := EncryptIdentityKDF(identity, "1234", "scrypt"); err == nil {
+		t.Error("EncryptIdentityKDF accepted an unknown KDF")
+	}
+}
+
+func TestIdentityFile_RoundTrip(t *testing.T) {
+	identity, err := GenerateIdentity()
+	if err != nil {
+		t.Fatalf("GenerateIdentity failed: %v", err)
+	}
+
+	data, err := ExportIdentityFile(identity, "1234")
+	if err != nil {
+		t.Fatalf("ExportIdentityFile failed: %v", err)
+	}
+
+	got, err := ImportIdentityFile(data, "1234")
+	if err != nil {
+		t.Fatalf("ImportIdentityFile failed: %v", err)
+	}
+	if got.PublicKey != identity.PublicKey || got.PrivateKey != identity.PrivateKey {
+		t.Error("imported identity doesn't match exported one")
+	}
+}
+
+func TestIdentityFile_WrongPIN(t *testing.T) {
+	identity, _ := GenerateIdentity()
+	data, _ := ExportIdentityFile(identity, "1234")
+
+	_, err := ImportIdentityFile(data, "0000")
+	if err == nil {
+		t.Fatal("expected error for wrong PIN")
+	}
+	if errors.Is(err, ErrInvalidIdentityFile) {
+		t.Errorf("wrong PIN reported as invalid file: %v", err)
+	}
+}
+
+func TestIdentityFile_Corrupted(t *testing.T) {
+	identity, _ := GenerateIdentity()
+	data, _ := ExportIdentityFile(identity, "1234")
+
+	var fields map[string]any
+	json.Unmarshal(data, &fields)
+	withField := func(key string, value any) []byte {
+		copied := make(map[string]any, len(fields))
+		for k, v := range fields {
+			copied[k] = v
+		}
+		copied[key] = value
+		out, _ := json.Marshal(copied)
+		return out
+	}
+
+	tests := map[string][]byte{
+		"truncated":       data[:len(data)/2],
+		"not json":        []byte("hello"),
+		"wrong format":    withField("format", "something-else"),
+		"unknown kdf":     withField("kdf", "scrypt"),
+		"huge iterations": withField("iterations", 1<<40),
+		"missing payload": withField("payload", ""),
+		"server backup":   withField("format", nil),
+	}
+	for name, corrupted := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ImportIdentityFile(corrupted, "1234")
+			if !errors.Is(err, ErrInvalidIdentityFile) {
+				t.Errorf("err = %v, want ErrInvalidIdentityFile", err)
+			}
+		})
+	}
+
+	// Tampered ciphertext is caught by decryption
+	tampered := withField("payload", "AAAA"+fields["payload"].(string)[4:])
+	if _, err := ImportIdentityFile(tampered, "1234"); err == nil {
+		t.Error("expected error for tampered payload")
+	}
+}
+
+func TestEncryptLocation_Restrict(t *testing.T) {
+	alice, _ := GenerateIdentity()
+	bob, _ := GenerateIdentity()
+	data := &LocationData{
+		Hierarchy:     map[string]string{"street": "Main St", "city": "Seattle", "country": "USA"},
+		NamedLocation: "Home",
+		Coordinates:   &Coordinates{Latitude: 47.6, Longitude: -122.3},
+		Timestamp:     "2025-01-01T00:00:00Z",
+	}
+
+	encrypted, err := EncryptLocation(data, alice, bob.PublicKeyBase64(), LevelsFrom("city")...)
+	if err != nil {
+		t.Fatalf("EncryptLocation failed: %v", err)
+	}
+	got, err := DecryptLocation(encrypted, bob, alice.PublicKeyBase64())
+	if err != nil {
+		t.Fatalf("DecryptLocation failed: %v", err)
+	}
+	if len(got.Hierarchy) != 2 || got.Hierarchy["city"] != "Seattle" || got.Hierarchy["country"] != "USA" {
+		t.Errorf("hierarchy = %v, want city and country only", got.Hierarchy)
+	}
+	if got.NamedLocation != "" || got.Coordinates != nil {
+		t.Errorf("named location %q and coordinates %v should be stripped", got.NamedLocation, got.Coordinates)
+	}
+	if got.Timestamp != data.Timestamp {
+		t.Errorf("timestamp = %q, want %q", got.Timestamp, data.Timestamp)
+	}
+	if data.Hierarchy["street"] != "Main St" {
+		t.Error("EncryptLocation modified the caller's location")
+	}
+
+	// No levels shares everything, as does allowing the most specific level
+	for _, allowed := range [][]string{nil, LevelsFrom("address")} {
+		encrypted, _ := EncryptLocation(data, alice, bob.PublicKeyBase64(), allowed...)
+		got, _ := DecryptLocation(encrypted, bob, alice.PublicKeyBase64())
+		if len(got.Hierarchy) != 3 || got.NamedLocation != "Home" || got.Coordinates == nil {
+			t.Errorf("allowed %v: got %+v, want the whole location", allowed, got)
+		}
+	}
+
+	if LevelsFrom("galaxy") != nil {
+		t.Error("LevelsFrom accepted an unknown level")
+	}
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// IdentityFileFormat marks an exported identity file
+const IdentityFileFormat = "whereish-identity"
+
+// maxFileIterations bounds the KDF work an imported file can ask for, so a
+// crafted file can't stall the import
+const maxFileIterations = 10000000
+
+// ErrInvalidIdentityFile is returned when data isn't an identity file this
+// version can read
+var ErrInvalidIdentityFile = errors.New("not a valid Whereish identity file")
+
+// identityFile is the on-disk form of an exported identity: the same
+// PIN-encrypted backup stored on the server, tagged with its format
+type identityFile struct {
+	Format string `json:"format"`
+	IdentityBackup
+}
+
+// ExportIdentityFile encrypts the identity with a PIN into a self-describing
+// JSON file that can be moved between devices without the server
+func ExportIdentityFile(identity *Identity, pin string) ([]byte, error) {
+	backup, err := EncryptIdentity(identity, pin)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(identityFile{Format: IdentityFileFormat, IdentityBackup: *backup}, "", "  ")
+}
+
+// ImportIdentityFile decrypts a file written by ExportIdentityFile. Malformed
+// files return ErrInvalidIdentityFile; a wrong PIN or tampered ciphertext
+// fails decryption.
+func ImportIdentityFile(data []byte, pin string) (*Identity, error) {
+	var file identityFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIdentityFile, err)
+	}
+	if file.Format != IdentityFileFormat {
+		return nil, fmt.Errorf("%w: missing %q format marker", ErrInvalidIdentityFile, IdentityFileFormat)
+	}
+	if file.Algorithm != "AES-256-GCM" || (file.KDF != KDFPBKDF2 && file.KDF != KDFArgon2id) {
+		return nil, fmt.Errorf("%w: unsupported encryption %s/%s", ErrInvalidIdentityFile, file.Algorithm, file.KDF)
+	}
+	if file.Iterations < 1 || file.Iterations > maxFileIterations {
+		return nil, fmt.Errorf("%w: iterations %d out of range", ErrInvalidIdentityFile, file.Iterations)
+	}
+	if file.KDF == KDFArgon2id && (file.Iterations > maxArgon2Time || file.Memory > maxArgon2Memory) {
+		return nil, fmt.Errorf("%w: argon2id parameters out of range", ErrInvalidIdentityFile)
+	}
+	if file.Salt == "" || file.IV == "" || file.Payload == "" {
+		return nil, fmt.Errorf("%w: missing encrypted fields", ErrInvalidIdentityFile)
+	}
+
+	return DecryptIdentity(&file.IdentityBackup, pin)
+}